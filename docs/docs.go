@@ -41,21 +41,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/bookmarks": {
+        "/v1/admin/users": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of bookmarks for the authenticated user",
+                "description": "Get a paginated list of the users, the most recently registered first.\nq searches the username, display name and email, ignoring case.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bookmark"
+                    "Admin"
                 ],
-                "summary": "List bookmarks",
+                "summary": "List users",
                 "parameters": [
                     {
                         "type": "integer",
@@ -68,13 +68,31 @@ const docTemplate = `{
                         "description": "Items per page (default 10)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only disabled (true) or only enabled (false) accounts",
+                        "name": "disabled",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/bookmark.listBookmarksResponse"
+                            "$ref": "#/definitions/admin.listUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
                         }
                     },
                     "401": {
@@ -83,6 +101,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Message"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -90,40 +114,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/admin/users/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new bookmark with a description and target URL. Returns the created bookmark with its short code.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a user by its ID, with the number of bookmarks it owns outside the trash",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bookmark"
+                    "Admin"
                 ],
-                "summary": "Create a new bookmark",
+                "summary": "Get a user",
                 "parameters": [
                     {
-                        "description": "Bookmark details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/bookmark.createBookmarkInput"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Bookmark"
+                            "$ref": "#/definitions/model.UserOverview"
                         }
                     },
                     "400": {
@@ -138,6 +159,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Message"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -145,47 +178,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/bookmarks/{id}": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing bookmark's description and URL. Only the bookmark owner can update it.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Permanently remove a user together with its bookmarks. This cannot be undone.\nAdministrators cannot delete their own account, nor the last enabled administrator.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bookmark"
+                    "Admin"
                 ],
-                "summary": "Update a bookmark",
+                "summary": "Delete a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bookmark ID (UUID)",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated bookmark details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/bookmark.updateBookmarkInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -202,8 +221,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Message"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
                     "404": {
-                        "description": "Bookmark not found",
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "409": {
+                        "description": "Own account or last enabled administrator",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -215,25 +246,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/admin/users/{id}/disable": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an existing bookmark. Only the bookmark owner can delete it.",
+                "description": "Disable the account of a user: logging in is refused and every access and refresh token of the user is revoked.\nAdministrators cannot disable their own account, nor the last enabled administrator.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bookmark"
+                    "Admin"
                 ],
-                "summary": "Delete a bookmark",
+                "summary": "Disable a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bookmark ID (UUID)",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -241,7 +274,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -258,40 +291,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Message"
                         }
                     },
-                    "404": {
-                        "description": "Bookmark not found",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/gen-pass": {
-            "get": {
-                "description": "Generates a cryptographically secure random password",
-                "produces": [
-                    "text/plain"
-                ],
-                "tags": [
-                    "password"
-                ],
-                "summary": "Generate a random password",
-                "responses": {
-                    "200": {
-                        "description": "Generated password",
+                    },
+                    "409": {
+                        "description": "Own account or last enabled administrator",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -299,84 +318,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/links/shorten": {
+        "/v1/admin/users/{id}/enable": {
             "post": {
-                "description": "Generate a short code for the provided URL",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Enable the account of a disabled user, who can log in again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "URL"
+                    "Admin"
                 ],
-                "summary": "Shorten URL",
+                "summary": "Enable a user",
                 "parameters": [
                     {
-                        "description": "URL shorten request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/url.urlShortenRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/url.urlShortenResponse"
+                            "$ref": "#/definitions/response.Message"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/links/{code}": {
-            "get": {
-                "description": "Retrieve the original URL for a short code and redirect the client",
-                "tags": [
-                    "URL"
-                ],
-                "summary": "Redirect to original URL",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "abc1234",
-                        "description": "Short code",
-                        "name": "code",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "302": {
-                        "description": "Redirects to the original URL"
                     },
-                    "400": {
-                        "description": "Bad Request - wrong format",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -384,30 +382,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/self/info": {
-            "get": {
+        "/v1/admin/users/{id}/password/reset": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the authenticated user's profile information",
+                "description": "Invalidate the password of a user, revoke every token of the user, personal access tokens included, and email a password reset link",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
+                ],
+                "summary": "Force a password reset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.profileResBody"
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
                         }
                     },
                     "401": {
-                        "description": "Invalid or missing token",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -419,56 +444,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/admin/users/{id}/tokens/revoke": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the authenticated user's profile information (display name and/or email)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Revoke every access, refresh and personal access token issued to a user, e.g. after the account was compromised",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Update user profile",
+                "summary": "Revoke every token of a user",
                 "parameters": [
                     {
-                        "description": "Update profile request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.updateSelfInfoReqBody"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
                     },
                     "400": {
-                        "description": "No data provided for update",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
                     },
                     "401": {
-                        "description": "Invalid or missing token",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
                     },
                     "404": {
-                        "description": "User does not exist",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -482,27 +510,237 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/login": {
-            "post": {
-                "description": "Authenticate a user with username and password, returns a JWT token",
-                "consumes": [
-                    "application/json"
+        "/v1/auth/oidc/callback": {
+            "get": {
+                "description": "Redeem the authorization code the OpenID Connect provider redirected with, and return an access token and a refresh token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Complete the login with the identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "State of the login",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Error reported by the provider",
+                        "name": "error",
+                        "in": "query"
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/oidclogin.callbackResBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or invalid login state",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Login refused or failed at the identity provider",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Account is disabled or email address is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "409": {
+                        "description": "Account linked to another identity",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/oidc/login": {
+            "get": {
+                "description": "Redirect to the OpenID Connect provider of the company to sign in. The provider then redirects to GET /v1/auth/oidc/callback",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "User"
                 ],
-                "summary": "Login a user",
+                "summary": "Login with the identity provider",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the identity provider"
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "502": {
+                        "description": "Identity provider unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of bookmarks for the authenticated user.\nLinks are checked periodically; status narrows the list down by the outcome of the last check:\nbroken (unreachable or error status), ok, redirected (permanently moved) or unchecked.\nPinned bookmarks are listed first. Archived bookmarks are only listed with archived=true.\nThe response carries an ETag; send it back in If-None-Match to get 304 Not Modified while the page is unchanged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "List bookmarks",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "body",
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "broken",
+                            "ok",
+                            "redirected",
+                            "unchecked"
+                        ],
+                        "type": "string",
+                        "description": "Link status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only favorites (true) or only non-favorites (false)",
+                        "name": "favorite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only pinned (true) or only unpinned (false) bookmarks",
+                        "name": "pinned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "List archived bookmarks instead of active ones",
+                        "name": "archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread (true) or only read (false) bookmarks",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched page",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.listBookmarksResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Tag of the returned page"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Page not modified"
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new bookmark with a description and target URL. Returns the created bookmark with its short code.\nURLs are compared in normalized form. With on_duplicate=reject (default) an already bookmarked URL is refused\nwith the ID of the existing bookmark, merge returns the existing bookmark instead, and allow creates a duplicate.\nThe page is fetched in the background to fill in its title, meta description, favicon and canonical URL when no description is given.\nA code can be chosen instead of the generated one. It shares the redirect namespace with short links and cannot be a reserved word.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Create a new bookmark",
+                "parameters": [
+                    {
+                        "description": "Bookmark details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.loginInputBody"
+                            "$ref": "#/definitions/bookmark.createBookmarkInput"
                         }
                     }
                 ],
@@ -510,11 +748,92 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.loginResBody"
+                            "$ref": "#/definitions/model.Bookmark"
                         }
                     },
                     "400": {
-                        "description": "Invalid username or password",
+                        "description": "Invalid input or reserved code",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Bookmark quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "409": {
+                        "description": "URL already bookmarked, or code already taken",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.duplicateBookmarkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete, tag, untag, move or archive many bookmarks in a single transaction.\nBookmarks are selected by ids or by a filter using the same criteria as GET /v1/bookmarks.\nThe filter only covers link status and the favorite, pinned, archived and unread flags;\ntags, folders and text cannot be selected on, list the bookmarks and send their ids instead.\nAn empty filter is refused: send {\"archived\": false} to act on every active bookmark.\nWhen ids are given, every one of them must be owned by the user, otherwise nothing is changed.\naffected is the number of bookmarks changed, or of tags added or removed for tag and untag.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Bulk operation",
+                "parameters": [
+                    {
+                        "description": "Action and selection",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.bulkInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.bulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
@@ -528,229 +847,3804 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/register": {
-            "post": {
-                "description": "Register a new user with the provided information",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Register a new user",
-                "parameters": [
-                    {
-                        "description": "User registration details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.registerInputBody"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/user.registerResBody"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.Message"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/response.Message"
-                        }
-                    }
+        "/v1/bookmarks/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the groups of bookmarks of the authenticated user that point to the same normalized URL.\nURLs are normalized by lowercasing the scheme and host and by dropping default ports, fragments,\ntracking parameters (utm_*, fbclid, ...) and trailing slashes. Bookmarks in a group are ordered oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "List duplicate bookmarks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.listDuplicatesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download all bookmarks of the authenticated user, grouped by folder.\nThe default html format is a Netscape bookmark file that every browser can import.\nThe json format is an array of bookmarks; the csv format has the columns\nurl, description, folder, tags (comma-separated), code and created_at.",
+                "produces": [
+                    "text/html",
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Export bookmarks",
+                "parameters": [
+                    {
+                        "enum": [
+                            "html",
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "File format (default html)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bookmark file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import bookmarks from a Netscape bookmark file, the HTML format every browser exports.\nFolders are kept as folder paths or turned into tags. URLs that are already bookmarked\nare skipped, overwritten or imported again depending on the duplicates mode.\nLinks that are not http(s) URLs (e.g. bookmarklets) are counted as invalid.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Import bookmarks",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Netscape bookmark file (max 10 MiB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "folder",
+                            "tag"
+                        ],
+                        "type": "string",
+                        "description": "How folders are stored (default folder)",
+                        "name": "folder_mode",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "overwrite",
+                            "allow"
+                        ],
+                        "type": "string",
+                        "description": "How already bookmarked URLs are handled (default skip)",
+                        "name": "duplicates",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.importBookmarksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Bookmark quota exceeded, the entries imported before are kept",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/shared": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the bookmarks other users shared with the authenticated user,\ndirectly or through a shared tag or folder, most recently created first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "List bookmarks shared with me",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/share.listBookmarksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of soft-deleted bookmarks for the authenticated user, most recently deleted first.\nTrashed bookmarks are removed permanently once the retention period has passed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "List trashed bookmarks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.listTrashResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single bookmark by its ID. The owner can read it, and so can the users it was shared with,\ndirectly or through a shared tag or folder. Other users get a 404 as if the bookmark did not exist.\nThe ETag of the response identifies the version of the bookmark. Send it in If-Match on updates\nto detect concurrent edits, or in If-None-Match to get 304 Not Modified while it is unchanged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Get a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched version",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the bookmark"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Bookmark not modified"
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing bookmark's description and URL.\nThe owner can update it, and so can the users it was shared with as editors, directly or through a shared tag or folder.\nOther users, including viewers of the share, get a 404 as if the bookmark did not exist.\nSend the ETag of the bookmark in If-Match to only update it if nobody else modified it in the meantime.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Update a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated bookmark details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.updateBookmarkInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the bookmark must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "412": {
+                        "description": "Bookmark has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an existing bookmark to the trash. Only the bookmark owner can delete it.\nTrashed bookmarks can be restored until the retention period has passed.\nSend the ETag of the bookmark in If-Match to only delete it if nobody else modified it in the meantime.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Delete a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the bookmark must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "412": {
+                        "description": "Bookmark has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply an RFC 7396 JSON Merge Patch to a bookmark. Only the supplied fields change; a null description or notes clears them.\nThe owner can update it, and so can the users it was shared with as editors, directly or through a shared tag or folder.\nOther users, including viewers of the share, get a 404 as if the bookmark did not exist.\nSend the ETag of the bookmark in If-Match to only update it if nobody else modified it in the meantime.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Partially update a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.patchBookmarkInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the bookmark must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated bookmark"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "412": {
+                        "description": "Bookmark has been modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/accept-redirect": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the URL of a bookmark with the final_url found by the last link check,\nwhich is only set when the URL permanently redirects (301 or 308). Only the bookmark owner can accept it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Accept a redirect",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "409": {
+                        "description": "Bookmark has no permanent redirect to accept",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Flip the archived state of a bookmark. Archived bookmarks are hidden from\nGET /v1/bookmarks unless archived=true is given. Only the bookmark owner can change it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Toggle archived",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/code": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the short code of a bookmark with a newly generated one. The previous code stops redirecting.\nOnly the bookmark owner can change it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Regenerate bookmark code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/favorite": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Flip the is_favorite state of a bookmark. Only the bookmark owner can change it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Toggle favorite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the revisions of a bookmark, most recent first. Every edit of the description or URL\nrecords the values it replaced, who made the edit (changed_by) and when.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "List bookmark history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.listHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the Markdown notes of a bookmark together with their HTML rendering.\nRaw HTML in the notes is dropped and only http(s) and mailto links are rendered,\nso the HTML can be embedded in a page as-is. Notes are edited with PATCH /v1/bookmarks/{id}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Get bookmark notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.RenderedNotes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/pin": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Flip the pinned state of a bookmark. Pinned bookmarks are listed before all others.\nOnly the bookmark owner can change it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Toggle pinned",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set read_at on a bookmark, taking it off the reading list. A bookmark that already is\nread keeps its original read time. Only the bookmark owner can change it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Mark as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a soft-deleted bookmark from the trash. Only the bookmark owner can restore it.\nRestored bookmarks count towards the bookmark quota again, so restoring is refused once the quota is reached.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Restore a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Bookmark quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found in trash",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/revert/{rev}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore the description and URL recorded by a revision, undoing that edit and all later ones.\nThe revert is recorded as a new revision, so it can be reverted as well.\nOnly the bookmark owner and editors it was shared with can revert it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Revert a bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark or revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/snapshot": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Serve the latest successful snapshot of a bookmark as HTML. The document is sent with a\nsandboxing Content-Security-Policy, so it can be opened directly in a browser.\nWhile the first capture is pending the snapshot state is returned with status 202.\nAnyone who may read the bookmark may read its snapshot.",
+                "produces": [
+                    "text/html",
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Get the snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot HTML",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Capture pending",
+                        "schema": {
+                            "$ref": "#/definitions/model.BookmarkSnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark or snapshot not found, or capture failed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "503": {
+                        "description": "Snapshots disabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archive the page a bookmark points to, as HTML with its stylesheets inlined. The page is\ncaptured in the background: the snapshot is pending until GET /v1/bookmarks/{id}/snapshot\nserves it. A previous snapshot keeps being served until the new capture succeeds.\nOnly the bookmark owner can request a snapshot, within their storage quota.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Request a snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.BookmarkSnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "503": {
+                        "description": "Snapshots disabled or queue full",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}/unread": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear read_at on a bookmark, so that it is listed with unread=true again.\nOnly the bookmark owner can change it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookmark"
+                ],
+                "summary": "Mark as unread",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bookmark"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/gen-pass": {
+            "get": {
+                "description": "Generates a cryptographically secure random password",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "password"
+                ],
+                "summary": "Generate a random password",
+                "responses": {
+                    "200": {
+                        "description": "Generated password",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/links/shorten": {
+            "post": {
+                "description": "Generate a short code for the provided URL.\nAnonymous callers are welcome; links created with a bearer token count against the user's daily quota.\nWhen QUOTA_ANONYMOUS_SHORT_LINKS_PER_DAY is set, links created without a token count against a daily quota\nshared by all anonymous callers of the address the request reaches the server from.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "URL"
+                ],
+                "summary": "Shorten URL",
+                "parameters": [
+                    {
+                        "description": "URL shorten request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.urlShortenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.urlShortenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Daily short link quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the quota starts over"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/links/{code}": {
+            "get": {
+                "description": "Retrieve the original URL for a short code and redirect the client",
+                "tags": [
+                    "URL"
+                ],
+                "summary": "Redirect to original URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "abc1234",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirects to the original URL"
+                    },
+                    "400": {
+                        "description": "Bad Request - wrong format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/shares/{token}": {
+            "get": {
+                "description": "Get a paginated list of the bookmarks behind a public share link. No authentication is required.\nOnly the URL, description, code, title, tags and folder of the bookmarks are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Open a public link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/share.listPublicBookmarksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/info": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.profileResBody"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the authenticated user's profile information (display name and/or email)\nWhen email verification is enabled, a new email is returned as pending_email by GET /v1/self/info and a verification link is sent to it; it replaces the current email once confirmed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "Update profile request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.updateSelfInfoReqBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "No data provided for update",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the authenticated user's password. Every existing session is logged out, personal access tokens are revoked and new tokens are returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.changePasswordReqBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.loginResBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or wrong current password",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/quota": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the limits of the authenticated user on bookmarks, daily short links and snapshot storage, with the current usage. A limit of 0 means unlimited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user quota",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/quota.quotaResBody"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the number of bookmarks of the authenticated user outside the trash, the most bookmarked domains, the bookmarks per tag and per month created (UTC), and the bookmarks whose short code was followed most.\nThe statistics are cached for a short while.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get bookmark statistics",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Number of domains and clicked bookmarks listed (default 10)",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stats.statsResBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the personal access tokens of the authenticated user with their scopes, expiry and last use,\nmost recent first. The tokens themselves cannot be read again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access Token"
+                ],
+                "summary": "List my personal access tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/accesstoken.listTokensResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a named token for scripts and integrations, sent as \"Authorization: Bearer \u003ctoken\u003e\".\nThe token is only returned in this response; store it right away. It can call the bookmark endpoints\n(bookmarks:read, bookmarks:write) and the link shortener (links:write), depending on its scopes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access Token"
+                ],
+                "summary": "Create a personal access token",
+                "parameters": [
+                    {
+                        "description": "Token details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/accesstoken.createTokenInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PersonalAccessToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a personal access token. Requests made with it are rejected right away.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access Token"
+                ],
+                "summary": "Revoke a personal access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the shares and public links created by the authenticated user, most recent first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "List my shares",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/share.listSharesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give another user access to a bookmark, or to every bookmark carrying a tag or living in a folder (including subfolders).\nViewers can read the bookmarks, editors can also update them. Bookmarks added to a shared tag or folder later are shared as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Share with a user",
+                "parameters": [
+                    {
+                        "description": "Share details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/share.createShareInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/share.shareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "User or bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "409": {
+                        "description": "Already shared with this user",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shares/links": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a read-only link to a bookmark, a tag or a folder. Anyone holding the returned token\ncan list the bookmarks at /v1/public/shares/{token} without logging in, until the share is deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Create a public link",
+                "parameters": [
+                    {
+                        "description": "Link details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/share.createPublicLinkInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/share.shareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shares/received": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the bookmarks, tags and folders other users shared with the authenticated user, most recent first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "List shares received",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/share.listSharesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shares/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a share or public link. The grantee, or anyone holding the link, immediately loses access.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Delete a share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/login": {
+            "post": {
+                "description": "Authenticate a user with username and password, returns an access token and a refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Login a user",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.loginInputBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.loginResBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Account is disabled or email address is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the access token used for the request and, when given, the refresh token of the same login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/user.logoutInputBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/password/forgot": {
+            "post": {
+                "description": "Email a single-use, time-limited password reset link to the account with the given address. The response does not tell whether such an account exists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Email address of the account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/passwordreset.forgotPasswordReqBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/password/reset": {
+            "post": {
+                "description": "Set a new password with the token sent by POST /v1/users/password/forgot. The token can be used once, every existing session is logged out and personal access tokens are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/passwordreset.resetPasswordReqBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or invalid reset token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/register": {
+            "post": {
+                "description": "Register a new user with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.registerInputBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.registerResBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/token/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new (rotated) refresh token. Reusing a refresh token revokes all tokens of the login it belongs to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh the access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.refreshTokenInputBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.loginResBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/verify": {
+            "get": {
+                "description": "Confirm the email address a verification link was sent to, at registration or after an email change. The token can be used once",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or invalid verification token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/verify/resend": {
+            "post": {
+                "description": "Email a new verification link to the account with the given address if it is not verified yet. The response does not tell whether such an account exists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "Email address of the account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/emailverification.resendVerificationReqBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "accesstoken.createTokenInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "When the token stops working (RFC 3339); omit for a token that does not expire",
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "name": {
+                    "description": "A label telling the token apart from the other tokens of the user",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Nightly backup"
+                },
+                "scopes": {
+                    "description": "What the token may do: bookmarks:read, bookmarks:write or links:write",
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "bookmarks:read"
+                    ]
+                }
+            }
+        },
+        "accesstoken.listTokensResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PersonalAccessToken"
+                    }
+                }
+            }
+        },
+        "admin.listUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/pagination.Metadata"
+                }
+            }
+        },
+        "bookmark.DuplicateGroup": {
+            "type": "object",
+            "properties": {
+                "bookmarks": {
+                    "description": "Bookmarks are the duplicates, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Bookmark"
+                    }
+                },
+                "normalized_url": {
+                    "description": "NormalizedURL is the URL shared by the bookmarks, in normalized form",
+                    "type": "string"
+                }
+            }
+        },
+        "bookmark.RenderedNotes": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "description": "HTML is the sanitized rendering of the notes",
+                    "type": "string"
+                },
+                "markdown": {
+                    "description": "Markdown is the notes as written by the user",
+                    "type": "string"
+                }
+            }
+        },
+        "bookmark.bulkFilterInput": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "Archived bookmarks (true) instead of the active ones",
+                    "type": "boolean"
+                },
+                "favorite": {
+                    "description": "Only favorites (true) or only non-favorites (false)",
+                    "type": "boolean"
+                },
+                "pinned": {
+                    "description": "Only pinned (true) or only unpinned (false) bookmarks",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "Link status: broken, ok, redirected or unchecked",
+                    "type": "string",
+                    "enum": [
+                        "broken",
+                        "ok",
+                        "redirected",
+                        "unchecked"
+                    ],
+                    "example": "broken"
+                },
+                "unread": {
+                    "description": "Only unread (true) or only read (false) bookmarks",
+                    "type": "boolean"
+                }
+            }
+        },
+        "bookmark.bulkInput": {
+            "type": "object",
+            "required": [
+                "action",
+                "tags"
+            ],
+            "properties": {
+                "action": {
+                    "description": "What to do with the bookmarks: delete, tag, untag, move or archive",
+                    "type": "string",
+                    "enum": [
+                        "delete",
+                        "tag",
+                        "untag",
+                        "move",
+                        "archive"
+                    ],
+                    "example": "tag"
+                },
+                "filter": {
+                    "description": "Selects the bookmarks to act on instead of ids; at least one field must be set",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bookmark.bulkFilterInput"
+                        }
+                    ]
+                },
+                "folder": {
+                    "description": "Destination folder of move, empty for the root",
+                    "type": "string",
+                    "maxLength": 512,
+                    "example": "Work/Archive"
+                },
+                "ids": {
+                    "description": "IDs of the bookmarks to act on; required unless filter is given",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "description": "Tags to add or remove; required for tag and untag",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang"
+                    ]
+                }
+            }
+        },
+        "bookmark.bulkResponse": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "description": "Number of rows changed: bookmarks, or tag assignments for tag and untag",
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "bookmark.createBookmarkInput": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Short code of the bookmark. When empty, a code is generated.",
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 4,
+                    "example": "golang"
+                },
+                "description": {
+                    "description": "Description of the bookmark. When empty, it is filled in with the page title in the background.",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Your description here"
+                },
+                "on_duplicate": {
+                    "description": "What to do when the URL is already bookmarked: reject (default), merge or allow",
+                    "type": "string",
+                    "enum": [
+                        "reject",
+                        "merge",
+                        "allow"
+                    ],
+                    "example": "reject"
+                },
+                "url": {
+                    "description": "URL to be shortened",
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com"
+                }
+            }
+        },
+        "bookmark.duplicateBookmarkResponse": {
+            "type": "object",
+            "properties": {
+                "existing_id": {
+                    "type": "string",
+                    "example": "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "URL is already bookmarked"
+                }
+            }
+        },
+        "bookmark.importBookmarksResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "invalid": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "bookmark.listBookmarksResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Bookmark"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/pagination.Metadata"
+                }
+            }
+        },
+        "bookmark.listDuplicatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bookmark.DuplicateGroup"
+                    }
+                }
+            }
+        },
+        "bookmark.listHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BookmarkRevision"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/pagination.Metadata"
+                }
+            }
+        },
+        "bookmark.listTrashResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bookmark.trashedBookmark"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/pagination.Metadata"
+                }
+            }
+        },
+        "bookmark.patchBookmarkInput": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description of the bookmark, null clears it",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Google"
+                },
+                "id": {
+                    "description": "ID is the bookmark identifier from the URL path",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "Markdown notes, null clears them",
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "Read the *second* half first"
+                },
+                "url": {
+                    "description": "URL to be shortened, cannot be null or empty",
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://www.google.com"
+                }
+            }
+        },
+        "bookmark.trashedBookmark": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
+                "check_error": {
+                    "type": "string"
+                },
+                "click_count": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "favicon_url": {
+                    "type": "string"
+                },
+                "final_url": {
+                    "type": "string"
+                },
+                "folder": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_favorite": {
+                    "type": "boolean"
+                },
+                "last_checked_at": {
+                    "type": "string"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pinned": {
+                    "type": "boolean"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "reading_time": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "bookmark.updateBookmarkInput": {
+            "type": "object",
+            "required": [
+                "id",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description of the bookmark",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Google"
+                },
+                "id": {
+                    "description": "ID is the bookmark identifier from the URL path",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL to be shortened",
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://www.google.com"
+                }
+            }
+        },
+        "emailverification.resendVerificationReqBody": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email is the current address of the account.",
+                    "type": "string"
+                }
+            }
+        },
+        "healthcheck.pingErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "instance_id": {
+                    "type": "string",
+                    "example": "instance-123"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "bookmark_service"
+                }
+            }
+        },
+        "healthcheck.pingResponse": {
+            "type": "object",
+            "properties": {
+                "instance_id": {
+                    "type": "string",
+                    "example": "instance-123"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "bookmark_service"
+                }
+            }
+        },
+        "model.Bookmark": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
+                "check_error": {
+                    "type": "string"
+                },
+                "click_count": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "favicon_url": {
+                    "type": "string"
+                },
+                "final_url": {
+                    "type": "string"
+                },
+                "folder": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_favorite": {
+                    "type": "boolean"
+                },
+                "last_checked_at": {
+                    "type": "string"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pinned": {
+                    "type": "boolean"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "reading_time": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BookmarkRevision": {
+            "type": "object",
+            "properties": {
+                "bookmark_id": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BookmarkSnapshot": {
+            "type": "object",
+            "properties": {
+                "bookmark_id": {
+                    "type": "string"
+                },
+                "captured_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.SnapshotStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BookmarkStats": {
+            "type": "object",
+            "properties": {
+                "domains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DomainCount"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MonthCount"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TagCount"
+                    }
+                },
+                "top_clicked": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CodeClicks"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CodeClicks": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DomainCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "domain": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MonthCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PersonalAccessToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Quota": {
+            "type": "object",
+            "properties": {
+                "limits": {
+                    "$ref": "#/definitions/model.QuotaLimits"
+                },
+                "short_links_reset_at": {
+                    "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/model.QuotaUsage"
+                }
+            }
+        },
+        "model.QuotaLimits": {
+            "type": "object",
+            "properties": {
+                "bookmarks": {
+                    "type": "integer"
+                },
+                "short_links_per_day": {
+                    "type": "integer"
+                },
+                "snapshot_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.QuotaUsage": {
+            "type": "object",
+            "properties": {
+                "bookmarks": {
+                    "type": "integer"
+                },
+                "short_links_today": {
+                    "type": "integer"
+                },
+                "snapshot_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SharePermission": {
+            "type": "string",
+            "enum": [
+                "viewer",
+                "editor"
+            ],
+            "x-enum-varnames": [
+                "SharePermissionViewer",
+                "SharePermissionEditor"
+            ]
+        },
+        "model.ShareTargetType": {
+            "type": "string",
+            "enum": [
+                "bookmark",
+                "tag",
+                "folder"
+            ],
+            "x-enum-varnames": [
+                "ShareTargetBookmark",
+                "ShareTargetTag",
+                "ShareTargetFolder"
+            ]
+        },
+        "model.SnapshotStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "ready",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SnapshotPending",
+                "SnapshotReady",
+                "SnapshotFailed"
+            ]
+        },
+        "model.TagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pending_email": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserOverview": {
+            "type": "object",
+            "properties": {
+                "bookmark_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pending_email": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "oidclogin.callbackResBody": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data is the short-lived JWT access token.",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "ExpiresIn is the lifetime of the access token in seconds.",
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "description": "RefreshToken is the opaque token exchanged for a new pair at /v1/users/token/refresh.",
+                    "type": "string"
+                }
+            }
+        },
+        "pagination.Metadata": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "first_page": {
+                    "type": "integer"
+                },
+                "last_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "bookmark.createBookmarkInput": {
+        },
+        "passwordreset.forgotPasswordReqBody": {
             "type": "object",
             "required": [
-                "url"
+                "email"
             ],
             "properties": {
-                "description": {
-                    "description": "Description of the bookmark",
+                "email": {
+                    "description": "Email is the address of the account whose password was forgotten.",
+                    "type": "string"
+                }
+            }
+        },
+        "passwordreset.resetPasswordReqBody": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "NewPassword must satisfy the same rules as at registration.",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "Your description here"
+                    "maxLength": 20,
+                    "minLength": 8
                 },
-                "url": {
-                    "description": "URL to be shortened",
-                    "type": "string",
-                    "maxLength": 2048,
-                    "example": "https://example.com"
+                "token": {
+                    "description": "Token is the reset token received by email.",
+                    "type": "string"
                 }
             }
         },
-        "bookmark.listBookmarksResponse": {
+        "quota.quotaResBody": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Bookmark"
-                    }
+                    "$ref": "#/definitions/model.Quota"
+                }
+            }
+        },
+        "response.Message": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "description": "Details contains a list of specific error messages or additional information, if any.\nIf empty, this field is omitted from the JSON response."
                 },
-                "metadata": {
-                    "$ref": "#/definitions/pagination.Metadata"
+                "message": {
+                    "description": "Message is a brief summary of the response (e.g., \"Input error\").",
+                    "type": "string"
                 }
             }
         },
-        "bookmark.updateBookmarkInput": {
+        "share.createPublicLinkInput": {
             "type": "object",
             "required": [
-                "id",
-                "url"
+                "target",
+                "target_type"
             ],
             "properties": {
-                "description": {
-                    "description": "Description of the bookmark",
+                "target": {
+                    "description": "The bookmark ID, tag name or folder path",
                     "type": "string",
-                    "maxLength": 255,
-                    "example": "Google"
+                    "maxLength": 512,
+                    "example": "golang"
                 },
-                "id": {
-                    "description": "ID is the bookmark identifier from the URL path",
-                    "type": "string"
-                },
-                "url": {
-                    "description": "URL to be shortened",
+                "target_type": {
+                    "description": "What is shared: bookmark, tag or folder",
                     "type": "string",
-                    "maxLength": 2048,
-                    "example": "https://www.google.com"
+                    "enum": [
+                        "bookmark",
+                        "tag",
+                        "folder"
+                    ],
+                    "example": "tag"
                 }
             }
         },
-        "healthcheck.pingErrorResponse": {
+        "share.createShareInput": {
             "type": "object",
+            "required": [
+                "permission",
+                "target",
+                "target_type",
+                "username"
+            ],
             "properties": {
-                "error": {
-                    "type": "string"
+                "permission": {
+                    "description": "What the user may do with the bookmarks: viewer or editor",
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "editor"
+                    ],
+                    "example": "viewer"
                 },
-                "instance_id": {
+                "target": {
+                    "description": "The bookmark ID, tag name or folder path",
                     "type": "string",
-                    "example": "instance-123"
+                    "maxLength": 512,
+                    "example": "Work/Projects"
                 },
-                "message": {
+                "target_type": {
+                    "description": "What is shared: bookmark, tag or folder",
                     "type": "string",
-                    "example": "OK"
+                    "enum": [
+                        "bookmark",
+                        "tag",
+                        "folder"
+                    ],
+                    "example": "folder"
                 },
-                "service_name": {
+                "username": {
+                    "description": "Username of the user to share with",
                     "type": "string",
-                    "example": "bookmark_service"
+                    "example": "jane"
                 }
             }
         },
-        "healthcheck.pingResponse": {
+        "share.listBookmarksResponse": {
             "type": "object",
             "properties": {
-                "instance_id": {
-                    "type": "string",
-                    "example": "instance-123"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Bookmark"
+                    }
                 },
-                "message": {
-                    "type": "string",
-                    "example": "OK"
+                "metadata": {
+                    "$ref": "#/definitions/pagination.Metadata"
+                }
+            }
+        },
+        "share.listPublicBookmarksResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/share.publicBookmarkResponse"
+                    }
                 },
-                "service_name": {
-                    "type": "string",
-                    "example": "bookmark_service"
+                "metadata": {
+                    "$ref": "#/definitions/pagination.Metadata"
                 }
             }
         },
-        "model.Bookmark": {
+        "share.listSharesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/share.shareResponse"
+                    }
+                }
+            }
+        },
+        "share.publicBookmarkResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
-                },
                 "description": {
                     "type": "string"
                 },
-                "id": {
+                "folder": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "url": {
+                "title": {
                     "type": "string"
                 },
-                "user_id": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "model.User": {
+        "share.shareResponse": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "display_name": {
+                "grantee_id": {
                     "type": "string"
                 },
-                "email": {
+                "grantee_username": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "owner_id": {
                     "type": "string"
                 },
-                "username": {
+                "owner_username": {
                     "type": "string"
-                }
-            }
-        },
-        "pagination.Metadata": {
-            "type": "object",
-            "properties": {
-                "current_page": {
-                    "type": "integer"
                 },
-                "first_page": {
-                    "type": "integer"
+                "permission": {
+                    "$ref": "#/definitions/model.SharePermission"
                 },
-                "last_page": {
-                    "type": "integer"
+                "target": {
+                    "type": "string"
                 },
-                "page_size": {
-                    "type": "integer"
+                "target_type": {
+                    "$ref": "#/definitions/model.ShareTargetType"
                 },
-                "total_records": {
-                    "type": "integer"
+                "token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "response.Message": {
+        "stats.statsResBody": {
             "type": "object",
             "properties": {
-                "details": {
-                    "description": "Details contains a list of specific error messages or additional information, if any.\nIf empty, this field is omitted from the JSON response."
-                },
-                "message": {
-                    "description": "Message is a brief summary of the response (e.g., \"Input error\").",
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/model.BookmarkStats"
                 }
             }
         },
@@ -790,6 +4684,25 @@ const docTemplate = `{
                 }
             }
         },
+        "user.changePasswordReqBody": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "description": "CurrentPassword is checked against the stored password before anything changes.",
+                    "type": "string"
+                },
+                "new_password": {
+                    "description": "NewPassword must satisfy the same rules as at registration.",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 8
+                }
+            }
+        },
         "user.loginInputBody": {
             "type": "object",
             "required": [
@@ -812,11 +4725,28 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "data": {
-                    "description": "Token is the JWT authentication token.",
+                    "description": "Data is the short-lived JWT access token.",
                     "type": "string"
                 },
+                "expires_in": {
+                    "description": "ExpiresIn is the lifetime of the access token in seconds.",
+                    "type": "integer"
+                },
                 "message": {
                     "type": "string"
+                },
+                "refresh_token": {
+                    "description": "RefreshToken is the opaque token exchanged for a new pair at /v1/users/token/refresh.",
+                    "type": "string"
+                }
+            }
+        },
+        "user.logoutInputBody": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "RefreshToken is the refresh token of the same login; when given, it is revoked too.",
+                    "type": "string"
                 }
             }
         },
@@ -828,6 +4758,18 @@ const docTemplate = `{
                 }
             }
         },
+        "user.refreshTokenInputBody": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "RefreshToken is the refresh token returned by the last login or refresh.",
+                    "type": "string"
+                }
+            }
+        },
         "user.registerInputBody": {
             "type": "object",
             "required": [
@@ -4,30 +4,65 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/docs"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/api/middleware"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/accesstoken"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/admin"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/emailverification"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/healthcheck"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/oidclogin"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/password"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/passwordreset"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/share"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/stats"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/url"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/user"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	accessTokenRepo "github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken"
 	bookmarkRepo "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	quotaRepo "github.com/HadesHo3820/ebvn-golang-course/internal/repository/quota"
+	shareRepo "github.com/HadesHo3820/ebvn-golang-course/internal/repository/share"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/service"
+	accessTokenSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken"
+	adminSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/admin"
 	bookmarkSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	verificationSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification"
+	oidcLoginSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/oidclogin"
+	passwordResetSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/passwordreset"
+	quotaSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	shareSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/share"
+	statsSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/stats"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/blobstore"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/linkcheck"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netguard"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagemeta"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/scheduler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/snapshot"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"gorm.io/gorm"
 )
 
+// snapshotCleanupBatchSize is the number of orphaned snapshots deleted at most per cleanup run.
+const snapshotCleanupBatchSize = 100
+
 // Engine defines the interface for the API server.
 // It abstracts the server implementation, allowing for easier testing
 // and potential swapping of the underlying HTTP framework.
@@ -48,6 +83,14 @@ type api struct {
 	passwordHashing utils.PasswordHashing
 	jwtGen          jwtutils.JWTGenerator
 	jwtValidator    jwtutils.JWTValidator
+	pageFetcher     pagemeta.Fetcher
+	linkCheckClient *http.Client
+	capturer        snapshot.Capturer
+	snapshotStore   blobstore.Store
+	mailer          mailer.Mailer
+	jobs            []scheduler.Job
+	tokenSvc        token.Service
+	accessTokenSvc  accessTokenSvc.Service
 }
 
 type EngineOpts struct {
@@ -59,6 +102,16 @@ type EngineOpts struct {
 	PasswordHashing utils.PasswordHashing
 	JwtGen          jwtutils.JWTGenerator
 	JwtValidator    jwtutils.JWTValidator
+	// PageFetcher is optional; when nil a guarded HTTP fetcher is built from the config
+	PageFetcher pagemeta.Fetcher
+	// LinkCheckClient is optional; when nil a client refusing non-public addresses is built from the config
+	LinkCheckClient *http.Client
+	// SnapshotCapturer is optional; when nil a guarded HTTP capturer is built from the config
+	SnapshotCapturer snapshot.Capturer
+	// SnapshotStore is optional; when nil snapshots are stored in the SnapshotDir directory
+	SnapshotStore blobstore.Store
+	// Mailer is optional; when nil the driver selected by MailDriver is built from the config
+	Mailer mailer.Mailer
 }
 
 // New creates and initializes a new API server.
@@ -74,15 +127,23 @@ func New(opts *EngineOpts) Engine {
 		db:              opts.SqlDB,
 		jwtGen:          opts.JwtGen,
 		jwtValidator:    opts.JwtValidator,
+		pageFetcher:     opts.PageFetcher,
+		linkCheckClient: opts.LinkCheckClient,
+		capturer:        opts.SnapshotCapturer,
+		snapshotStore:   opts.SnapshotStore,
+		mailer:          opts.Mailer,
 	}
 	a.RegisterEP()
 	return a
 }
 
-// Start begins listening for HTTP requests.
+// Start launches the background jobs and begins listening for HTTP requests.
 // By default, Gin listens on port 8080.
 // Returns an error if the server fails to start.
 func (a *api) Start() error {
+	for _, job := range a.jobs {
+		go job.Start(context.Background())
+	}
 	return a.app.Run(fmt.Sprintf(":%s", a.cfg.AppPort))
 }
 
@@ -96,11 +157,19 @@ func (a *api) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // It serves as a container for dependency-injected handler instances,
 // making it easier to manage and pass handlers to route registration.
 type handlers struct {
-	healthCheckHandler healthcheck.HealthCheckHandler // Handles health check endpoints
-	passwordHandler    password.PasswordHandler       // Handles password generation endpoints
-	urlShortenHandler  url.UrlHandler                 // Handles URL shortening endpoints
-	userHandler        user.UserHandler               // Handles user management endpoints
-	bookmarkHandler    bookmark.Handler               // Handles bookmark endpoints
+	healthCheckHandler   healthcheck.HealthCheckHandler // Handles health check endpoints
+	passwordHandler      password.PasswordHandler       // Handles password generation endpoints
+	urlShortenHandler    url.UrlHandler                 // Handles URL shortening endpoints
+	userHandler          user.UserHandler               // Handles user management endpoints
+	bookmarkHandler      bookmark.Handler               // Handles bookmark endpoints
+	shareHandler         share.Handler                  // Handles sharing endpoints
+	quotaHandler         quota.Handler                  // Handles quota endpoints
+	statsHandler         stats.Handler                  // Handles statistics endpoints
+	adminHandler         admin.Handler                  // Handles administration endpoints
+	passwordResetHandler passwordreset.Handler          // Handles forgotten password endpoints
+	verificationHandler  emailverification.Handler      // Handles email verification endpoints
+	accessTokenHandler   accesstoken.Handler            // Handles personal access token endpoints
+	oidcLoginHandler     oidclogin.Handler              // Handles identity provider login endpoints, nil when disabled
 }
 
 // initHandlers initializes all handlers with their required dependencies.
@@ -109,6 +178,11 @@ type handlers struct {
 //  2. Creating service instances with injected repositories (domain layer)
 //  3. Creating handler instances with injected services (adapter layer)
 //
+// Background jobs that share these services (e.g. the trash purge, the
+// bookmark enrichment and snapshot workers and the link checker) are registered on the api here as well and launched by Start.
+// So are the token services, which the authentication middleware needs to reject revoked tokens
+// and to resolve personal access tokens.
+//
 // This method centralizes dependency injection, making it easier to:
 //   - Understand the dependency graph of the application
 //   - Test handlers with mock dependencies
@@ -120,31 +194,224 @@ func (a *api) initHandlers() *handlers {
 	healthCheckRepo := repository.NewRedisHealthChecker(a.redisClient)
 	healthSvc := service.NewHealthCheck(a.cfg.ServiceName, a.cfg.InstanceID, healthCheckRepo)
 
-	// Create URL shortening service with Redis storage
+	// Create quota service enforcing the per-user limits, with overrides in PostgreSQL
+	// and the daily short link counts of users and anonymous addresses in Redis
 	urlRepo := repository.NewUrlStorage(a.redisClient)
-	urlSvc := service.NewShortenUrl(urlRepo, a.keyGen)
+	bookmarkRepo := bookmarkRepo.NewRepository(a.db)
+	quotaSvc := quotaSvc.NewService(quotaRepo.NewRepository(a.db), bookmarkRepo, urlRepo, model.QuotaLimits{
+		Bookmarks:        a.cfg.QuotaMaxBookmarks,
+		ShortLinksPerDay: a.cfg.QuotaShortLinksPerDay,
+		SnapshotBytes:    a.cfg.SnapshotQuotaBytes,
+	}, a.cfg.QuotaAnonymousShortLinksPerDay)
+
+	// Create URL shortening service with Redis storage
+	urlSvc := service.NewShortenUrl(urlRepo, a.keyGen, quotaSvc, bookmarkRepo)
 
 	// Create password service (stateless, no repository needed)
 	passSvc := service.NewPassword()
 
 	// Create user service with PostgreSQL repository
 	userRepo := repository.NewUser(a.db)
-	userSvc := service.NewUser(userRepo, a.jwtGen, a.passwordHashing)
+	a.tokenSvc = token.NewService(a.jwtGen, repository.NewRefreshTokenStore(a.redisClient),
+		repository.NewTokenRevocationStore(a.redisClient), a.keyGen, token.Config{
+			AccessTTL:  a.cfg.AccessTokenTTL,
+			RefreshTTL: a.cfg.RefreshTokenTTL,
+		})
+	mailer := a.newMailer()
+	verificationSvc := verificationSvc.NewService(userRepo, repository.NewEmailVerificationStore(a.redisClient),
+		a.keyGen, mailer, verificationSvc.Config{
+			TTL:       a.cfg.EmailVerificationTTL,
+			VerifyURL: a.cfg.EmailVerificationURL,
+		})
+	accessTokens := accessTokenRepo.NewRepository(a.db)
+	userSvc := service.NewUser(userRepo, a.tokenSvc, accessTokens, a.passwordHashing,
+		service.WithEmailVerification(verificationSvc, a.cfg.RequireVerifiedEmail))
+
+	// Grant the admin role to the configured first administrator, creating the user if needed
+	if a.cfg.BootstrapAdminUsername != "" {
+		err := userSvc.BootstrapAdmin(context.Background(), a.cfg.BootstrapAdminUsername,
+			a.cfg.BootstrapAdminEmail, a.cfg.BootstrapAdminPassword)
+		if err != nil {
+			log.Error().Err(err).Str("username", a.cfg.BootstrapAdminUsername).Msg("Cannot bootstrap admin user")
+		}
+	}
+
+	// Create password reset service, emailing single-use links
+	resetSvc := passwordResetSvc.NewService(userRepo, repository.NewPasswordResetStore(a.redisClient), a.tokenSvc,
+		accessTokens, a.keyGen, a.passwordHashing, mailer, passwordResetSvc.Config{
+			TTL:      a.cfg.PasswordResetTTL,
+			ResetURL: a.cfg.PasswordResetURL,
+		})
+
+	// Create login service of the OpenID Connect provider, when one is configured
+	var oidcLoginHandler oidclogin.Handler
+	if a.cfg.OIDCIssuerURL != "" {
+		provider := oidc.NewProvider(oidc.Config{
+			IssuerURL:    a.cfg.OIDCIssuerURL,
+			ClientID:     a.cfg.OIDCClientID,
+			ClientSecret: a.cfg.OIDCClientSecret,
+			RedirectURL:  a.cfg.OIDCRedirectURL,
+			Scopes:       a.cfg.OIDCScopes,
+		})
+		loginSvc := oidcLoginSvc.NewService(provider, repository.NewOIDCLoginStore(a.redisClient), userRepo,
+			a.tokenSvc, accessTokens, a.keyGen, oidcLoginSvc.Config{StateTTL: a.cfg.OIDCStateTTL})
+		oidcLoginHandler = oidclogin.NewHandler(loginSvc)
+	}
 
 	// Init bookmark handler
-	bookmarkRepo := bookmarkRepo.NewRepository(a.db)
-	bookmarkSvc := bookmarkSvc.NewBookmarkSvc(bookmarkRepo, a.keyGen)
+	bookmarkOpts := []bookmarkSvc.Option{
+		bookmarkSvc.WithQuotas(quotaSvc),
+		bookmarkSvc.WithCodeLength(a.cfg.BookmarkCodeLength),
+		bookmarkSvc.WithShortLinks(urlRepo),
+	}
+	if a.cfg.EnrichWorkers > 0 {
+		// Enrich new bookmarks with the metadata of the page they point to
+		fetcher := a.pageFetcher
+		if fetcher == nil {
+			fetcher = pagemeta.NewFetcher(pagemeta.Options{
+				Timeout:     a.cfg.EnrichFetchTimeout,
+				MaxBodySize: a.cfg.EnrichMaxBodyBytes,
+			})
+		}
+		enricher := bookmarkSvc.NewEnricher(bookmarkRepo, fetcher, a.cfg.EnrichWorkers, a.cfg.EnrichQueueSize)
+		bookmarkOpts = append(bookmarkOpts, bookmarkSvc.WithEnrichmentQueue(enricher))
+		a.jobs = append(a.jobs, enricher)
+	}
+	if a.cfg.LinkCheckInterval > 0 {
+		// Periodically check bookmark URLs for broken links and permanent redirects
+		client := a.linkCheckClient
+		if client == nil {
+			client = &http.Client{
+				Transport: netguard.NewTransport(a.cfg.LinkCheckTimeout, false),
+				Timeout:   a.cfg.LinkCheckTimeout,
+			}
+		}
+		checker := linkcheck.NewChecker(client, linkcheck.Options{
+			Concurrency:  a.cfg.LinkCheckConcurrency,
+			HostInterval: a.cfg.LinkCheckHostInterval,
+		})
+		bookmarkOpts = append(bookmarkOpts, bookmarkSvc.WithLinkChecker(checker))
+	}
+	if a.cfg.SnapshotWorkers > 0 {
+		// Archive bookmarked pages on request, within a storage quota per user
+		store := a.snapshotStore
+		if store == nil {
+			var err error
+			if store, err = blobstore.NewLocalStore(a.cfg.SnapshotDir); err != nil {
+				log.Error().Err(err).Str("dir", a.cfg.SnapshotDir).Msg("Cannot open snapshot store, snapshots disabled")
+			}
+		}
+		if store != nil {
+			capturer := a.capturer
+			if capturer == nil {
+				capturer = snapshot.NewCapturer(snapshot.Options{
+					Timeout:     a.cfg.SnapshotFetchTimeout,
+					MaxPageSize: a.cfg.SnapshotMaxPageBytes,
+				})
+			}
+			snapshotter := bookmarkSvc.NewSnapshotter(bookmarkRepo, capturer, store, quotaSvc, a.cfg.SnapshotWorkers, a.cfg.SnapshotQueueSize)
+			bookmarkOpts = append(bookmarkOpts, bookmarkSvc.WithSnapshots(snapshotter, store))
+			a.jobs = append(a.jobs, snapshotter)
+		}
+	}
+	bookmarkSvc := bookmarkSvc.NewBookmarkSvc(bookmarkRepo, a.keyGen, bookmarkOpts...)
 	bookmarkHandler := bookmark.NewHandler(bookmarkSvc)
 
+	// Register the link check job, started together with the server
+	if a.cfg.LinkCheckInterval > 0 {
+		a.jobs = append(a.jobs, scheduler.NewPeriodic("link-check", a.cfg.LinkCheckInterval, func(ctx context.Context) error {
+			checked, err := bookmarkSvc.CheckLinks(ctx, a.cfg.LinkCheckStaleAfter, a.cfg.LinkCheckBatchSize)
+			if err != nil {
+				return err
+			}
+			log.Info().Int("bookmarks", checked).Msg("Checked bookmark links")
+			return nil
+		}))
+	}
+
+	// Register the snapshot cleanup job, which deletes the snapshots of purged bookmarks
+	if a.cfg.SnapshotWorkers > 0 && a.cfg.SnapshotCleanupInterval > 0 {
+		a.jobs = append(a.jobs, scheduler.NewPeriodic("snapshot-cleanup", a.cfg.SnapshotCleanupInterval, func(ctx context.Context) error {
+			deleted, err := bookmarkSvc.PurgeSnapshots(ctx, snapshotCleanupBatchSize)
+			if err != nil {
+				return err
+			}
+			log.Info().Int("snapshots", deleted).Msg("Deleted snapshots of purged bookmarks")
+			return nil
+		}))
+	}
+
+	// Register the trash retention job, started together with the server
+	if a.cfg.TrashRetentionDays > 0 {
+		retention := time.Duration(a.cfg.TrashRetentionDays) * 24 * time.Hour
+		a.jobs = append(a.jobs, scheduler.NewPeriodic("trash-purge", a.cfg.TrashPurgeInterval, func(ctx context.Context) error {
+			bookmarks, err := bookmarkSvc.PurgeTrash(ctx, retention)
+			if err != nil {
+				return err
+			}
+			users, err := userSvc.PurgeDeletedUsers(ctx, retention)
+			if err != nil {
+				return err
+			}
+			log.Info().Int64("bookmarks", bookmarks).Int64("users", users).Msg("Purged trash")
+			return nil
+		}))
+	}
+
+	// Create personal access token service, storing the hashes of the tokens in PostgreSQL
+	a.accessTokenSvc = accessTokenSvc.NewService(accessTokens, a.keyGen)
+
+	// Create user management service of the administration API
+	adminSvc := adminSvc.NewService(userRepo, bookmarkRepo, a.tokenSvc, resetSvc)
+
+	// Init share handler
+	shareRepo := shareRepo.NewRepository(a.db)
+	shareSvc := shareSvc.NewService(shareRepo, bookmarkRepo, userRepo, a.keyGen)
+
+	// Create statistics service aggregating in PostgreSQL and caching in Redis
+	statsSvc := statsSvc.NewService(bookmarkRepo, repository.NewStatsCache(a.redisClient), a.cfg.StatsCacheTTL)
+
 	return &handlers{
-		healthCheckHandler: healthcheck.NewHealthCheckHandler(healthSvc),
-		passwordHandler:    password.NewPasswordHandler(passSvc),
-		urlShortenHandler:  url.NewUrlHandler(urlSvc),
-		userHandler:        user.NewUserHandler(userSvc),
-		bookmarkHandler:    bookmarkHandler,
+		healthCheckHandler:   healthcheck.NewHealthCheckHandler(healthSvc),
+		passwordHandler:      password.NewPasswordHandler(passSvc),
+		urlShortenHandler:    url.NewUrlHandler(urlSvc),
+		userHandler:          user.NewUserHandler(userSvc),
+		bookmarkHandler:      bookmarkHandler,
+		shareHandler:         share.NewHandler(shareSvc),
+		quotaHandler:         quota.NewHandler(quotaSvc),
+		statsHandler:         stats.NewHandler(statsSvc),
+		adminHandler:         admin.NewHandler(userSvc, adminSvc),
+		passwordResetHandler: passwordreset.NewHandler(resetSvc),
+		verificationHandler:  emailverification.NewHandler(verificationSvc),
+		accessTokenHandler:   accesstoken.NewHandler(a.accessTokenSvc),
+		oidcLoginHandler:     oidcLoginHandler,
 	}
 }
 
+// newMailer returns the mailer given in the options, or builds the one selected by the config.
+// A misconfigured driver falls back to logging the emails, so that the server still starts.
+func (a *api) newMailer() mailer.Mailer {
+	if a.mailer != nil {
+		return a.mailer
+	}
+	m, err := mailer.New(mailer.Config{
+		Driver: a.cfg.MailDriver,
+		From:   a.cfg.MailFrom,
+		Dir:    a.cfg.MailDir,
+		SMTP: mailer.SMTPConfig{
+			Host:     a.cfg.SMTPHost,
+			Port:     a.cfg.SMTPPort,
+			Username: a.cfg.SMTPUsername,
+			Password: a.cfg.SMTPPassword,
+		},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("driver", a.cfg.MailDriver).Msg("Cannot create mailer, emails are only logged")
+		return mailer.NewLogMailer()
+	}
+	return m
+}
+
 // RegisterEP sets up all API endpoints and their handlers.
 // It performs dependency injection by:
 //  1. Creating service instances (business logic layer)
@@ -166,13 +433,16 @@ func (a *api) RegisterEP() {
 	// All routes registered under this group will be prefixed with "/v1",
 	// allowing for future API versions (e.g., "/v2") without breaking existing clients.
 	// The curly braces are purely for visual grouping and have no effect on scope.
+	jwtMiddleware := middleware.NewJWTAuth(a.jwtValidator, a.tokenSvc, a.accessTokenSvc)
+
 	v1PublicRoutes := a.app.Group("/v1")
 	{
 		// GET /v1/gen-pass - Generates a random password
 		v1PublicRoutes.GET("/gen-pass", allHandlers.passwordHandler.GenPass)
 
-		// POST /v1/links/shorten - Creates a shortened URL code for the provided URL
-		v1PublicRoutes.POST("/links/shorten", allHandlers.urlShortenHandler.ShortenUrl)
+		// POST /v1/links/shorten - Creates a shortened URL code for the provided URL,
+		// counted against the daily quota of the user when called with a token
+		v1PublicRoutes.POST("/links/shorten", jwtMiddleware.OptionalTokenAuth(model.ScopeLinksWrite), allHandlers.urlShortenHandler.ShortenUrl)
 
 		// GET /v1/links/redirect/{code} - Redirects to the original URL for the provided short code
 		v1PublicRoutes.GET("/links/redirect/:code", allHandlers.urlShortenHandler.GetUrl)
@@ -180,11 +450,35 @@ func (a *api) RegisterEP() {
 		// POST /v1/users/register - Registers a new user
 		v1PublicRoutes.POST("/users/register", allHandlers.userHandler.Register)
 
-		// POST /v1/users/login - Logs in a user and returns a JWT token
+		// POST /v1/users/login - Logs in a user and returns an access and a refresh token
 		v1PublicRoutes.POST("/users/login", allHandlers.userHandler.Login)
-	}
 
-	jwtMiddleware := middleware.NewJWTAuth(a.jwtValidator)
+		// POST /v1/users/token/refresh - Exchanges a refresh token for a new token pair
+		v1PublicRoutes.POST("/users/token/refresh", allHandlers.userHandler.RefreshToken)
+
+		// POST /v1/users/password/forgot - Emails a password reset link, without telling whether the account exists
+		v1PublicRoutes.POST("/users/password/forgot", allHandlers.passwordResetHandler.ForgotPassword)
+
+		// POST /v1/users/password/reset - Sets a new password with an emailed reset token
+		v1PublicRoutes.POST("/users/password/reset", allHandlers.passwordResetHandler.ResetPassword)
+
+		// GET /v1/users/verify - Confirms an email address with an emailed verification token
+		v1PublicRoutes.GET("/users/verify", allHandlers.verificationHandler.Verify)
+
+		// POST /v1/users/verify/resend - Emails a new verification link, without telling whether the account exists
+		v1PublicRoutes.POST("/users/verify/resend", allHandlers.verificationHandler.ResendVerification)
+
+		// GET /v1/public/shares/:token - Lists the bookmarks behind a public share link
+		v1PublicRoutes.GET("/public/shares/:token", allHandlers.shareHandler.GetPublicBookmarks)
+
+		if allHandlers.oidcLoginHandler != nil {
+			// GET /v1/auth/oidc/login - Redirects to the OpenID Connect provider to sign in
+			v1PublicRoutes.GET("/auth/oidc/login", allHandlers.oidcLoginHandler.Login)
+
+			// GET /v1/auth/oidc/callback - Signs in the user the provider redirects back, returning an access and a refresh token
+			v1PublicRoutes.GET("/auth/oidc/callback", allHandlers.oidcLoginHandler.Callback)
+		}
+	}
 
 	v1PrivateRoutes := a.app.Group("/v1")
 	v1PrivateRoutes.Use(jwtMiddleware.JWTAuth())
@@ -192,20 +486,155 @@ func (a *api) RegisterEP() {
 		// GET /v1/self/info - Gets the authenticated user's profile information
 		v1PrivateRoutes.GET("/self/info", allHandlers.userHandler.GetSelfInfo)
 
+		// POST /v1/users/logout - Revokes the access token of the request and, when given, its refresh token
+		v1PrivateRoutes.POST("/users/logout", allHandlers.userHandler.Logout)
+
 		// PUT /v1/self/info - Updates the authenticated user's profile information
 		v1PrivateRoutes.PUT("/self/info", allHandlers.userHandler.UpdateSelfInfo)
 
-		// POST /v1/bookmark - Creates a new bookmark
-		v1PrivateRoutes.POST("/bookmarks", allHandlers.bookmarkHandler.CreateBookmark)
+		// PUT /v1/self/password - Changes the password of the authenticated user and revokes their other tokens
+		v1PrivateRoutes.PUT("/self/password", allHandlers.userHandler.ChangePassword)
+
+		// GET /v1/self/quota - Gets the limits of the authenticated user with their current usage
+		v1PrivateRoutes.GET("/self/quota", allHandlers.quotaHandler.GetSelfQuota)
+
+		// GET /v1/self/stats - Gets the bookmark statistics of the authenticated user
+		v1PrivateRoutes.GET("/self/stats", allHandlers.statsHandler.GetSelfStats)
 
+		// POST /v1/self/tokens - Creates a personal access token, returned once
+		v1PrivateRoutes.POST("/self/tokens", allHandlers.accessTokenHandler.CreateToken)
+
+		// GET /v1/self/tokens - Lists the personal access tokens of the authenticated user with their last use
+		v1PrivateRoutes.GET("/self/tokens", allHandlers.accessTokenHandler.GetTokens)
+
+		// DELETE /v1/self/tokens/:id - Revokes a personal access token
+		v1PrivateRoutes.DELETE("/self/tokens/:id", allHandlers.accessTokenHandler.DeleteToken)
+
+		// POST /v1/shares - Share a bookmark, tag or folder with another user
+		v1PrivateRoutes.POST("/shares", allHandlers.shareHandler.CreateShare)
+
+		// POST /v1/shares/links - Create a public read-only link
+		v1PrivateRoutes.POST("/shares/links", allHandlers.shareHandler.CreatePublicLink)
+
+		// GET /v1/shares - List the shares created by the user
+		v1PrivateRoutes.GET("/shares", allHandlers.shareHandler.GetShares)
+
+		// GET /v1/shares/received - List the shares granted to the user
+		v1PrivateRoutes.GET("/shares/received", allHandlers.shareHandler.GetReceivedShares)
+
+		// DELETE /v1/shares/:id - Revoke a share or public link
+		v1PrivateRoutes.DELETE("/shares/:id", allHandlers.shareHandler.DeleteShare)
+	}
+
+	// The bookmark API is also open to personal access tokens, which need the bookmarks:read scope
+	// to read bookmarks and the bookmarks:write scope to change them.
+	v1BookmarkReadRoutes := a.app.Group("/v1")
+	v1BookmarkReadRoutes.Use(jwtMiddleware.TokenAuth(model.ScopeBookmarksRead))
+	{
 		// GET /v1/bookmarks - List bookmarks
-		v1PrivateRoutes.GET("/bookmarks", allHandlers.bookmarkHandler.GetBookmarks)
+		v1BookmarkReadRoutes.GET("/bookmarks", allHandlers.bookmarkHandler.GetBookmarks)
+
+		// GET /v1/bookmarks/export - Download all bookmarks as a file
+		v1BookmarkReadRoutes.GET("/bookmarks/export", allHandlers.bookmarkHandler.ExportBookmarks)
+
+		// GET /v1/bookmarks/duplicates - List groups of bookmarks pointing to the same page
+		v1BookmarkReadRoutes.GET("/bookmarks/duplicates", allHandlers.bookmarkHandler.GetDuplicates)
+
+		// GET /v1/bookmarks/shared - List bookmarks other users shared with the user
+		v1BookmarkReadRoutes.GET("/bookmarks/shared", allHandlers.shareHandler.GetSharedBookmarks)
+
+		// GET /v1/bookmarks/trash - List trashed bookmarks
+		v1BookmarkReadRoutes.GET("/bookmarks/trash", allHandlers.bookmarkHandler.GetTrash)
+
+		// GET /v1/bookmarks/:id - Get a single bookmark
+		v1BookmarkReadRoutes.GET("/bookmarks/:id", allHandlers.bookmarkHandler.GetBookmark)
+
+		// GET /v1/bookmarks/:id/history - List the revisions of a bookmark
+		v1BookmarkReadRoutes.GET("/bookmarks/:id/history", allHandlers.bookmarkHandler.GetHistory)
+
+		// GET /v1/bookmarks/:id/notes - Get the notes of a bookmark rendered to HTML
+		v1BookmarkReadRoutes.GET("/bookmarks/:id/notes", allHandlers.bookmarkHandler.GetNotes)
+
+		// GET /v1/bookmarks/:id/snapshot - Serve the archived copy of the page
+		v1BookmarkReadRoutes.GET("/bookmarks/:id/snapshot", allHandlers.bookmarkHandler.GetSnapshot)
+	}
+
+	v1BookmarkWriteRoutes := a.app.Group("/v1")
+	v1BookmarkWriteRoutes.Use(jwtMiddleware.TokenAuth(model.ScopeBookmarksWrite))
+	{
+		// POST /v1/bookmark - Creates a new bookmark
+		v1BookmarkWriteRoutes.POST("/bookmarks", allHandlers.bookmarkHandler.CreateBookmark)
+
+		// POST /v1/bookmarks/bulk - Delete, tag, untag, move or archive many bookmarks at once
+		v1BookmarkWriteRoutes.POST("/bookmarks/bulk", allHandlers.bookmarkHandler.BulkUpdate)
+
+		// POST /v1/bookmarks/import - Import bookmarks from a browser export file
+		v1BookmarkWriteRoutes.POST("/bookmarks/import", allHandlers.bookmarkHandler.ImportBookmarks)
 
 		// PUT /v1/bookmarks/:id - Update a bookmark
-		v1PrivateRoutes.PUT("/bookmarks/:id", allHandlers.bookmarkHandler.UpdateBookmark)
+		v1BookmarkWriteRoutes.PUT("/bookmarks/:id", allHandlers.bookmarkHandler.UpdateBookmark)
+
+		// PATCH /v1/bookmarks/:id - Partially update a bookmark (JSON Merge Patch)
+		v1BookmarkWriteRoutes.PATCH("/bookmarks/:id", allHandlers.bookmarkHandler.PatchBookmark)
+
+		// DELETE /v1/bookmarks/:id - Move a bookmark to the trash
+		v1BookmarkWriteRoutes.DELETE("/bookmarks/:id", allHandlers.bookmarkHandler.DeleteBookmark)
+
+		// POST /v1/bookmarks/:id/restore - Restore a bookmark from the trash
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/restore", allHandlers.bookmarkHandler.RestoreBookmark)
+
+		// POST /v1/bookmarks/:id/accept-redirect - Replace the URL with its permanent redirect target
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/accept-redirect", allHandlers.bookmarkHandler.AcceptRedirect)
+
+		// POST /v1/bookmarks/:id/code - Give a bookmark a new generated short code
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/code", allHandlers.bookmarkHandler.RegenerateCode)
+
+		// POST /v1/bookmarks/:id/revert/:rev - Restore a bookmark to a revision
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/revert/:rev", allHandlers.bookmarkHandler.RevertBookmark)
+
+		// POST /v1/bookmarks/:id/read - Mark a bookmark as read
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/read", allHandlers.bookmarkHandler.MarkRead)
+
+		// POST /v1/bookmarks/:id/unread - Put a bookmark back on the reading list
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/unread", allHandlers.bookmarkHandler.MarkUnread)
+
+		// POST /v1/bookmarks/:id/snapshot - Archive the page a bookmark points to
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/snapshot", allHandlers.bookmarkHandler.RequestSnapshot)
+
+		// POST /v1/bookmarks/:id/favorite - Toggle the favorite state of a bookmark
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/favorite", allHandlers.bookmarkHandler.ToggleFavorite)
+
+		// POST /v1/bookmarks/:id/pin - Toggle the pinned state of a bookmark
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/pin", allHandlers.bookmarkHandler.TogglePinned)
+
+		// POST /v1/bookmarks/:id/archive - Toggle the archived state of a bookmark
+		v1BookmarkWriteRoutes.POST("/bookmarks/:id/archive", allHandlers.bookmarkHandler.ToggleArchived)
+	}
+
+	// v1AdminRoutes holds the administration API, reserved to signed-in users with the admin role.
+	v1AdminRoutes := a.app.Group("/v1/admin")
+	v1AdminRoutes.Use(jwtMiddleware.JWTAuth(), middleware.RequireRole(model.RoleAdmin))
+	{
+		// GET /v1/admin/users - Lists and searches the users
+		v1AdminRoutes.GET("/users", allHandlers.adminHandler.ListUsers)
+
+		// GET /v1/admin/users/:id - Gets a user with its bookmark count
+		v1AdminRoutes.GET("/users/:id", allHandlers.adminHandler.GetUser)
+
+		// DELETE /v1/admin/users/:id - Permanently removes a user and its bookmarks
+		v1AdminRoutes.DELETE("/users/:id", allHandlers.adminHandler.DeleteUser)
+
+		// POST /v1/admin/users/:id/disable - Keeps a user from signing in and revokes its tokens
+		v1AdminRoutes.POST("/users/:id/disable", allHandlers.adminHandler.DisableUser)
+
+		// POST /v1/admin/users/:id/enable - Lets a disabled user sign in again
+		v1AdminRoutes.POST("/users/:id/enable", allHandlers.adminHandler.EnableUser)
+
+		// POST /v1/admin/users/:id/password/reset - Invalidates the password of a user and emails a reset link
+		v1AdminRoutes.POST("/users/:id/password/reset", allHandlers.adminHandler.ForcePasswordReset)
 
-		// DELETE /v1/bookmarks/:id - Delete a bookmark
-		v1PrivateRoutes.DELETE("/bookmarks/:id", allHandlers.bookmarkHandler.DeleteBookmark)
+		// POST /v1/admin/users/:id/tokens/revoke - Revokes every access and refresh token of a user
+		v1AdminRoutes.POST("/users/:id/tokens/revoke", allHandlers.adminHandler.RevokeUserTokens)
 	}
 
 	// Configure Swagger host dynamically at runtime.
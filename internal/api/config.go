@@ -1,6 +1,10 @@
 package api
 
 import (
+	"fmt"
+	"time"
+
+	bookmarkSvc "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -9,6 +13,98 @@ type Config struct {
 	ServiceName string `default:"bookmark-api" envconfig:"SERVICE_NAME"`
 	InstanceID  string `default:"" envconfig:"INSTANCE_ID"`
 	AppHostName string `default:"localhost:8080" envconfig:"APP_HOSTNAME"`
+
+	// TrashRetentionDays is how long soft-deleted rows are kept before being purged.
+	// A value of 0 keeps them forever.
+	TrashRetentionDays int           `default:"30" envconfig:"TRASH_RETENTION_DAYS"`
+	TrashPurgeInterval time.Duration `default:"1h" envconfig:"TRASH_PURGE_INTERVAL"`
+
+	// EnrichWorkers is the number of pages fetched concurrently to enrich new bookmarks.
+	// A value of 0 disables enrichment.
+	EnrichWorkers      int           `default:"2" envconfig:"ENRICH_WORKERS"`
+	EnrichQueueSize    int           `default:"100" envconfig:"ENRICH_QUEUE_SIZE"`
+	EnrichFetchTimeout time.Duration `default:"10s" envconfig:"ENRICH_FETCH_TIMEOUT"`
+	EnrichMaxBodyBytes int64         `default:"1048576" envconfig:"ENRICH_MAX_BODY_BYTES"`
+
+	// LinkCheckInterval is how often a batch of bookmark URLs is checked for broken links.
+	// A value of 0 disables link checking.
+	LinkCheckInterval     time.Duration `default:"1h" envconfig:"LINK_CHECK_INTERVAL"`
+	LinkCheckStaleAfter   time.Duration `default:"24h" envconfig:"LINK_CHECK_STALE_AFTER"`
+	LinkCheckBatchSize    int           `default:"100" envconfig:"LINK_CHECK_BATCH_SIZE"`
+	LinkCheckConcurrency  int           `default:"4" envconfig:"LINK_CHECK_CONCURRENCY"`
+	LinkCheckHostInterval time.Duration `default:"1s" envconfig:"LINK_CHECK_HOST_INTERVAL"`
+	LinkCheckTimeout      time.Duration `default:"10s" envconfig:"LINK_CHECK_TIMEOUT"`
+
+	// SnapshotWorkers is the number of pages captured concurrently for offline snapshots.
+	// A value of 0 disables snapshots.
+	SnapshotWorkers         int           `default:"1" envconfig:"SNAPSHOT_WORKERS"`
+	SnapshotQueueSize       int           `default:"50" envconfig:"SNAPSHOT_QUEUE_SIZE"`
+	SnapshotDir             string        `default:"data/snapshots" envconfig:"SNAPSHOT_DIR"`
+	SnapshotFetchTimeout    time.Duration `default:"30s" envconfig:"SNAPSHOT_FETCH_TIMEOUT"`
+	SnapshotMaxPageBytes    int64         `default:"5242880" envconfig:"SNAPSHOT_MAX_PAGE_BYTES"`
+	SnapshotCleanupInterval time.Duration `default:"1h" envconfig:"SNAPSHOT_CLEANUP_INTERVAL"`
+
+	// Default limits of each user, overridable per user in the user_quotas table.
+	// A value of 0 lifts the limit.
+	QuotaMaxBookmarks     int64 `default:"10000" envconfig:"QUOTA_MAX_BOOKMARKS"`
+	QuotaShortLinksPerDay int64 `default:"200" envconfig:"QUOTA_SHORT_LINKS_PER_DAY"`
+	SnapshotQuotaBytes    int64 `default:"104857600" envconfig:"SNAPSHOT_QUOTA_BYTES"`
+
+	// QuotaAnonymousShortLinksPerDay limits the short links created without a token,
+	// counted per client address. It is off by default (0): behind a reverse proxy every
+	// anonymous caller has the address of the proxy, and one client would lock out the others.
+	QuotaAnonymousShortLinksPerDay int64 `default:"0" envconfig:"QUOTA_ANONYMOUS_SHORT_LINKS_PER_DAY"`
+
+	// BookmarkCodeLength is the length of the generated bookmark codes, at most bookmark.MaxCodeLength.
+	// Codes chosen by the caller may have another length.
+	BookmarkCodeLength int `default:"9" envconfig:"BOOKMARK_CODE_LENGTH"`
+
+	// StatsCacheTTL is how long the bookmark statistics of a user are cached in Redis.
+	// A value of 0 disables the cache.
+	StatsCacheTTL time.Duration `default:"1m" envconfig:"STATS_CACHE_TTL"`
+
+	// AccessTokenTTL is the lifetime of the JWT access tokens.
+	// RefreshTokenTTL is how long a login can be kept alive by refreshing its tokens.
+	AccessTokenTTL  time.Duration `default:"15m" envconfig:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL time.Duration `default:"720h" envconfig:"REFRESH_TOKEN_TTL"`
+
+	// BootstrapAdminUsername, when set, names the user granted the admin role at startup, so that
+	// a fresh deployment has a first administrator. A missing user is created from the email and
+	// password; an existing one is promoted and its password left untouched.
+	BootstrapAdminUsername string `default:"" envconfig:"BOOTSTRAP_ADMIN_USERNAME"`
+	BootstrapAdminEmail    string `default:"" envconfig:"BOOTSTRAP_ADMIN_EMAIL"`
+	BootstrapAdminPassword string `default:"" envconfig:"BOOTSTRAP_ADMIN_PASSWORD"`
+
+	// MailDriver selects how emails are sent: "smtp", "file" (written to MailDir) or "log".
+	MailDriver   string `default:"log" envconfig:"MAIL_DRIVER"`
+	MailFrom     string `default:"no-reply@localhost" envconfig:"MAIL_FROM"`
+	MailDir      string `default:"data/mail" envconfig:"MAIL_DIR"`
+	SMTPHost     string `default:"" envconfig:"SMTP_HOST"`
+	SMTPPort     int    `default:"587" envconfig:"SMTP_PORT"`
+	SMTPUsername string `default:"" envconfig:"SMTP_USERNAME"`
+	SMTPPassword string `default:"" envconfig:"SMTP_PASSWORD"`
+
+	// PasswordResetTTL is how long an emailed password reset link can be used.
+	// PasswordResetURL is the page the link points to; the token is added as the "token" query parameter.
+	PasswordResetTTL time.Duration `default:"1h" envconfig:"PASSWORD_RESET_TTL"`
+	PasswordResetURL string        `default:"http://localhost:8080/reset-password" envconfig:"PASSWORD_RESET_URL"`
+
+	// EmailVerificationTTL is how long an emailed verification link can be used.
+	// EmailVerificationURL is where the link points to; the token is added as the "token" query parameter.
+	// RequireVerifiedEmail refuses the login of users who did not verify their email address yet.
+	EmailVerificationTTL time.Duration `default:"24h" envconfig:"EMAIL_VERIFICATION_TTL"`
+	EmailVerificationURL string        `default:"http://localhost:8080/v1/users/verify" envconfig:"EMAIL_VERIFICATION_URL"`
+	RequireVerifiedEmail bool          `default:"false" envconfig:"REQUIRE_VERIFIED_EMAIL"`
+
+	// OIDCIssuerURL, when set, enables the login through the OpenID Connect provider with this issuer.
+	// OIDCRedirectURL is the URL of GET /v1/auth/oidc/callback as registered at the provider.
+	// OIDCStateTTL is how long users have to sign in at the provider.
+	OIDCIssuerURL    string        `default:"" envconfig:"OIDC_ISSUER_URL"`
+	OIDCClientID     string        `default:"" envconfig:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string        `default:"" envconfig:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL  string        `default:"http://localhost:8080/v1/auth/oidc/callback" envconfig:"OIDC_REDIRECT_URL"`
+	OIDCScopes       []string      `default:"openid,email,profile" envconfig:"OIDC_SCOPES"`
+	OIDCStateTTL     time.Duration `default:"10m" envconfig:"OIDC_STATE_TTL"`
 }
 
 func NewConfig() (*Config, error) {
@@ -17,5 +113,8 @@ func NewConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cfg.BookmarkCodeLength > bookmarkSvc.MaxCodeLength {
+		return nil, fmt.Errorf("BOOKMARK_CODE_LENGTH must be at most %d", bookmarkSvc.MaxCodeLength)
+	}
 	return cfg, nil
 }
@@ -4,11 +4,17 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
 )
 
 // JWTAuth defines the interface for JWT-based authentication middleware.
@@ -19,29 +25,49 @@ type JWTAuth interface {
 	// It extracts the token from the Authorization header, validates it,
 	// and stores the claims in the Gin context for downstream handlers.
 	JWTAuth() gin.HandlerFunc
+
+	// OptionalJWTAuth returns a Gin middleware handler for routes open to anonymous callers.
+	// Requests without an Authorization header pass through without claims; requests
+	// with one must carry a valid token, exactly like with JWTAuth.
+	OptionalJWTAuth() gin.HandlerFunc
+
+	// TokenAuth returns a Gin middleware handler that accepts, besides JWTs, personal access
+	// tokens granted the given scope. Their claims hold the user ID in "sub" and their scopes.
+	TokenAuth(scope string) gin.HandlerFunc
+
+	// OptionalTokenAuth is OptionalJWTAuth accepting personal access tokens like TokenAuth.
+	OptionalTokenAuth(scope string) gin.HandlerFunc
 }
 
 // jwtAuth is the concrete implementation of the JWTAuth interface.
-// It uses a JWTValidator to verify token signatures and expiration.
+// It uses a JWTValidator to verify token signatures and expiration,
+// the token service to reject tokens revoked before they expire,
+// and the access token service to resolve personal access tokens.
 type jwtAuth struct {
 	jwtValidator jwtutils.JWTValidator
+	tokens       token.Service
+	accessTokens accesstoken.Service
 }
 
 // NewJWTAuth creates a new JWTAuth middleware instance.
 //
 // Parameters:
 //   - jwtValidator: The validator used to verify JWT tokens
+//   - tokens: The token service checking whether a valid token was revoked
+//   - accessTokens: The service resolving personal access tokens
 //
 // Returns:
 //   - JWTAuth: A new middleware instance ready to be used with Gin routes
 //
 // Example:
 //
-//	jwtMiddleware := middleware.NewJWTAuth(jwtValidator)
+//	jwtMiddleware := middleware.NewJWTAuth(jwtValidator, tokenSvc, accessTokenSvc)
 //	router.Use(jwtMiddleware.JWTAuth())
-func NewJWTAuth(jwtValidator jwtutils.JWTValidator) JWTAuth {
+func NewJWTAuth(jwtValidator jwtutils.JWTValidator, tokens token.Service, accessTokens accesstoken.Service) JWTAuth {
 	return &jwtAuth{
 		jwtValidator: jwtValidator,
+		tokens:       tokens,
+		accessTokens: accessTokens,
 	}
 }
 
@@ -51,11 +77,14 @@ func NewJWTAuth(jwtValidator jwtutils.JWTValidator) JWTAuth {
 //  1. Extracts the Authorization header from the request
 //  2. Validates the header format (must be "Bearer <token>")
 //  3. Validates the JWT token using the configured validator
-//  4. Stores the token claims in the Gin context under the key "claims"
-//  5. Calls the next handler in the chain if validation succeeds
+//  4. Checks that the token was not revoked (logout or revocation of every token of the user)
+//  5. Stores the token claims in the Gin context under the key "claims"
+//  6. Calls the next handler in the chain if validation succeeds
 //
 // On failure, the middleware aborts the request with HTTP 401 Unauthorized
-// and returns a JSON error response.
+// and returns a JSON error response. If the revocation check itself fails,
+// the request is aborted with HTTP 500 rather than let through. Personal access
+// tokens are rejected with HTTP 403 Forbidden; routes open to them use TokenAuth.
 //
 // Downstream handlers can access the claims using:
 //
@@ -66,33 +95,144 @@ func NewJWTAuth(jwtValidator jwtutils.JWTValidator) JWTAuth {
 //	}
 func (j *jwtAuth) JWTAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Extract the Authorization header from the request
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+		tokenString, ok := bearerToken(c)
+		if !ok {
 			return
 		}
 
-		// Parse the header to extract the Bearer token
-		// Expected format: "Bearer <token>"
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		// Personal access tokens are only accepted on the routes using TokenAuth
+		if strings.HasPrefix(tokenString, accesstoken.TokenPrefix) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Personal access tokens are not accepted on this endpoint"})
 			return
 		}
-		tokenString := parts[1]
 
-		// Validate the token signature, expiration, and claims
-		tokenClaims, err := j.jwtValidator.ValidateToken(tokenString)
-		if err != nil {
+		j.authenticateJWT(c, tokenString)
+	}
+}
+
+// TokenAuth returns a Gin middleware handler function that authenticates the request either
+// with a JWT, exactly like JWTAuth, or with a personal access token. JWTs grant every scope,
+// while personal access tokens must have been granted scope.
+//
+// The claims stored for a personal access token hold:
+//   - "sub": The ID of the user the token acts for
+//   - "scopes": The scopes of the token
+//   - "token_id": The ID of the token
+//
+// They carry no roles, so personal access tokens never pass RequireRole.
+//
+// Unknown or expired tokens, and tokens of disabled users, are rejected with HTTP 401
+// Unauthorized; tokens lacking the scope with HTTP 403 Forbidden.
+func (j *jwtAuth) TokenAuth(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, ok := bearerToken(c)
+		if !ok {
+			return
+		}
+
+		if !strings.HasPrefix(tokenString, accesstoken.TokenPrefix) {
+			j.authenticateJWT(c, tokenString)
+			return
+		}
+
+		pat, err := j.accessTokens.Authenticate(c, tokenString)
+		if errors.Is(err, accesstoken.ErrInvalidToken) {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			return
 		}
+		if err != nil {
+			log.Error().Err(err).Msg("failed to authenticate personal access token")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.InternalErrResponse)
+			return
+		}
 
-		// Store claims in context for downstream handlers to access
-		c.Set("claims", tokenClaims)
+		if !pat.Scopes.Has(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Token lacks the " + scope + " scope"})
+			return
+		}
 
-		// Continue to the next handler in the chain
+		c.Set("claims", jwt.MapClaims{
+			"sub":      pat.UserID,
+			"scopes":   []string(pat.Scopes),
+			"token_id": pat.ID,
+		})
 		c.Next()
 	}
 }
+
+// bearerToken extracts the token from the Authorization header of the request.
+// When the header is missing or malformed, it aborts the request with HTTP 401 Unauthorized
+// and returns false.
+func bearerToken(c *gin.Context) (string, bool) {
+	// Extract the Authorization header from the request
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+		return "", false
+	}
+
+	// Parse the header to extract the Bearer token
+	// Expected format: "Bearer <token>"
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		return "", false
+	}
+	return parts[1], true
+}
+
+// authenticateJWT validates a JWT, stores its claims and calls the next handler,
+// or aborts the request when the token is invalid or revoked.
+func (j *jwtAuth) authenticateJWT(c *gin.Context, tokenString string) {
+	// Validate the token signature, expiration, and claims
+	tokenClaims, err := j.jwtValidator.ValidateToken(tokenString)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return
+	}
+
+	// Reject tokens revoked before their expiry
+	err = j.tokens.Validate(c, tokenClaims)
+	if errors.Is(err, token.ErrTokenRevoked) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("failed to check token revocation")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	// Store claims in context for downstream handlers to access
+	c.Set("claims", tokenClaims)
+
+	// Continue to the next handler in the chain
+	c.Next()
+}
+
+// OptionalJWTAuth returns a Gin middleware handler function that authenticates the request
+// only when it carries an Authorization header. Anonymous requests reach the next handler
+// without claims, so handlers can tell them apart with utils.GetUIDFromRequest.
+//
+// A present but malformed or invalid header is rejected with HTTP 401 Unauthorized rather
+// than treated as anonymous, so that clients notice an expired token.
+func (j *jwtAuth) OptionalJWTAuth() gin.HandlerFunc {
+	return optional(j.JWTAuth())
+}
+
+// OptionalTokenAuth returns a Gin middleware handler function that authenticates the request
+// like TokenAuth only when it carries an Authorization header, letting anonymous requests through.
+func (j *jwtAuth) OptionalTokenAuth(scope string) gin.HandlerFunc {
+	return optional(j.TokenAuth(scope))
+}
+
+// optional runs authenticate only on requests carrying an Authorization header.
+func optional(authenticate gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		authenticate(c)
+	}
+}
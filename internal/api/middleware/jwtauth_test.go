@@ -7,10 +7,17 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken"
+	accessTokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	tokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/token/mocks"
 	jwtMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // TestJWTAuth tests the JWTAuth middleware handler.
@@ -18,6 +25,8 @@ import (
 //   - Missing Authorization header
 //   - Invalid header format (not "Bearer <token>")
 //   - Invalid token (validation failure)
+//   - Revoked token and failing revocation check
+//   - Personal access token (not accepted)
 //   - Valid token (claims stored in context)
 func TestJWTAuth(t *testing.T) {
 	t.Parallel()
@@ -33,6 +42,8 @@ func TestJWTAuth(t *testing.T) {
 
 		// Mock setup
 		setupMock func(*jwtMocks.JWTValidator)
+		// Token service setup, for tokens passing validation
+		setupTokens func(*tokenMocks.Service)
 
 		// Expected response
 		expectedStatus int
@@ -74,6 +85,16 @@ func TestJWTAuth(t *testing.T) {
 			},
 			expectClaims: false,
 		},
+		{
+			name:       "error - personal access token",
+			authHeader: "Bearer bpat_0123456789",
+			// No mock setup needed - middleware rejects before token validation
+			setupMock:      func(m *jwtMocks.JWTValidator) {},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"error": "Personal access tokens are not accepted on this endpoint",
+			},
+		},
 		{
 			name:       "error - token validation fails",
 			authHeader: "Bearer invalid.jwt.token",
@@ -97,10 +118,44 @@ func TestJWTAuth(t *testing.T) {
 						"exp": float64(9999999999),
 					}, nil)
 			},
+			setupTokens: func(m *tokenMocks.Service) {
+				m.On("Validate", mock.Anything, mock.Anything).Return(nil)
+			},
 			expectedStatus: http.StatusOK,
 			expectedBody:   nil, // Handler will set its own response
 			expectClaims:   true,
 		},
+		{
+			name:       "error - revoked token",
+			authHeader: "Bearer valid.jwt.token",
+			setupMock: func(m *jwtMocks.JWTValidator) {
+				m.On("ValidateToken", "valid.jwt.token").
+					Return(jwt.MapClaims{"sub": "user-123", "jti": "jti-1"}, nil)
+			},
+			setupTokens: func(m *tokenMocks.Service) {
+				m.On("Validate", mock.Anything, jwt.MapClaims{"sub": "user-123", "jti": "jti-1"}).
+					Return(token.ErrTokenRevoked)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"error": "Token has been revoked",
+			},
+		},
+		{
+			name:       "error - revocation check fails",
+			authHeader: "Bearer valid.jwt.token",
+			setupMock: func(m *jwtMocks.JWTValidator) {
+				m.On("ValidateToken", "valid.jwt.token").
+					Return(jwt.MapClaims{"sub": "user-123"}, nil)
+			},
+			setupTokens: func(m *tokenMocks.Service) {
+				m.On("Validate", mock.Anything, mock.Anything).Return(errors.New("redis error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -116,9 +171,13 @@ func TestJWTAuth(t *testing.T) {
 			// Setup mock validator
 			mockValidator := jwtMocks.NewJWTValidator(t)
 			tc.setupMock(mockValidator)
+			mockTokens := tokenMocks.NewService(t)
+			if tc.setupTokens != nil {
+				tc.setupTokens(mockTokens)
+			}
 
 			// Create middleware
-			middleware := NewJWTAuth(mockValidator)
+			middleware := NewJWTAuth(mockValidator, mockTokens, accessTokenMocks.NewService(t))
 
 			// Variable to capture claims from context
 			var capturedClaims any
@@ -160,12 +219,208 @@ func TestJWTAuth(t *testing.T) {
 	}
 }
 
+// TestOptionalJWTAuth tests the OptionalJWTAuth middleware handler:
+// anonymous requests pass without claims, while a present header must be valid.
+func TestOptionalJWTAuth(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		authHeader     string
+		setupMock      func(*jwtMocks.JWTValidator)
+		validates      bool
+		expectedStatus int
+		expectClaims   bool
+	}{
+		{
+			name:           "success - anonymous request",
+			authHeader:     "",
+			setupMock:      func(m *jwtMocks.JWTValidator) {},
+			expectedStatus: http.StatusOK,
+			expectClaims:   false,
+		},
+		{
+			name:       "success - valid token",
+			authHeader: "Bearer valid.jwt.token",
+			setupMock: func(m *jwtMocks.JWTValidator) {
+				m.On("ValidateToken", "valid.jwt.token").
+					Return(jwt.MapClaims{"sub": "user-123"}, nil)
+			},
+			validates:      true,
+			expectedStatus: http.StatusOK,
+			expectClaims:   true,
+		},
+		{
+			name:       "error - invalid token is not treated as anonymous",
+			authHeader: "Bearer invalid.jwt.token",
+			setupMock: func(m *jwtMocks.JWTValidator) {
+				m.On("ValidateToken", "invalid.jwt.token").
+					Return(nil, errors.New("token expired"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectClaims:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(rec)
+
+			mockValidator := jwtMocks.NewJWTValidator(t)
+			tc.setupMock(mockValidator)
+
+			mockTokens := tokenMocks.NewService(t)
+			if tc.validates {
+				mockTokens.On("Validate", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			var claimsExist bool
+			router.GET("/test", NewJWTAuth(mockValidator, mockTokens, accessTokenMocks.NewService(t)).OptionalJWTAuth(), func(c *gin.Context) {
+				_, claimsExist = c.Get("claims")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectClaims, claimsExist)
+		})
+	}
+}
+
+// TestTokenAuth tests the TokenAuth middleware handler:
+// JWTs pass whatever the scope, while personal access tokens need the scope of the route.
+func TestTokenAuth(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	const patToken = "bpat_0123456789"
+
+	testCases := []struct {
+		name           string
+		authHeader     string
+		setupMock      func(*jwtMocks.JWTValidator, *tokenMocks.Service, *accessTokenMocks.Service)
+		expectedStatus int
+		expectedBody   map[string]any
+		expectedClaims jwt.MapClaims
+	}{
+		{
+			name:       "success - JWT",
+			authHeader: "Bearer valid.jwt.token",
+			setupMock: func(v *jwtMocks.JWTValidator, tokens *tokenMocks.Service, _ *accessTokenMocks.Service) {
+				v.On("ValidateToken", "valid.jwt.token").Return(jwt.MapClaims{"sub": "user-123"}, nil)
+				tokens.On("Validate", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedClaims: jwt.MapClaims{"sub": "user-123"},
+		},
+		{
+			name:       "success - personal access token with the scope",
+			authHeader: "Bearer " + patToken,
+			setupMock: func(_ *jwtMocks.JWTValidator, _ *tokenMocks.Service, pats *accessTokenMocks.Service) {
+				pats.On("Authenticate", mock.Anything, patToken).Return(&model.PersonalAccessToken{
+					Base:   model.Base{ID: "token-123"},
+					UserID: "user-123",
+					Scopes: model.Scopes{model.ScopeBookmarksRead, model.ScopeBookmarksWrite},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedClaims: jwt.MapClaims{
+				"sub":      "user-123",
+				"scopes":   []string{model.ScopeBookmarksRead, model.ScopeBookmarksWrite},
+				"token_id": "token-123",
+			},
+		},
+		{
+			name:       "error - personal access token without the scope",
+			authHeader: "Bearer " + patToken,
+			setupMock: func(_ *jwtMocks.JWTValidator, _ *tokenMocks.Service, pats *accessTokenMocks.Service) {
+				pats.On("Authenticate", mock.Anything, patToken).Return(&model.PersonalAccessToken{
+					UserID: "user-123",
+					Scopes: model.Scopes{model.ScopeLinksWrite},
+				}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   map[string]any{"error": "Token lacks the bookmarks:read scope"},
+		},
+		{
+			name:       "error - invalid personal access token",
+			authHeader: "Bearer " + patToken,
+			setupMock: func(_ *jwtMocks.JWTValidator, _ *tokenMocks.Service, pats *accessTokenMocks.Service) {
+				pats.On("Authenticate", mock.Anything, patToken).Return(nil, accesstoken.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"error": "Invalid token"},
+		},
+		{
+			name:       "error - personal access token lookup fails",
+			authHeader: "Bearer " + patToken,
+			setupMock: func(_ *jwtMocks.JWTValidator, _ *tokenMocks.Service, pats *accessTokenMocks.Service) {
+				pats.On("Authenticate", mock.Anything, patToken).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+		{
+			name:           "error - missing Authorization header",
+			setupMock:      func(*jwtMocks.JWTValidator, *tokenMocks.Service, *accessTokenMocks.Service) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"error": "Authorization header is required"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(rec)
+
+			mockValidator := jwtMocks.NewJWTValidator(t)
+			mockTokens := tokenMocks.NewService(t)
+			mockAccessTokens := accessTokenMocks.NewService(t)
+			tc.setupMock(mockValidator, mockTokens, mockAccessTokens)
+
+			var capturedClaims any
+			middleware := NewJWTAuth(mockValidator, mockTokens, mockAccessTokens)
+			router.GET("/test", middleware.TokenAuth(model.ScopeBookmarksRead), func(c *gin.Context) {
+				capturedClaims, _ = c.Get("claims")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				assert.JSONEq(t, mustMarshal(tc.expectedBody), rec.Body.String())
+			}
+			if tc.expectedClaims != nil {
+				assert.Equal(t, tc.expectedClaims, capturedClaims)
+			}
+		})
+	}
+}
+
 // TestNewJWTAuth tests the JWTAuth constructor.
 func TestNewJWTAuth(t *testing.T) {
 	t.Parallel()
 
 	mockValidator := jwtMocks.NewJWTValidator(t)
-	middleware := NewJWTAuth(mockValidator)
+	middleware := NewJWTAuth(mockValidator, tokenMocks.NewService(t), accessTokenMocks.NewService(t))
 
 	assert.NotNil(t, middleware, "Expected middleware to be created")
 	assert.IsType(t, &jwtAuth{}, middleware, "Expected *jwtAuth type")
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RequireRole returns a Gin middleware handler that lets through only callers holding at least
+// one of the given roles. It must run after JWTAuth, which stores the claims of the access token
+// under the "claims" key; the roles are read from the "roles" claim embedded at login.
+//
+// Requests without claims are rejected with HTTP 401 Unauthorized, and callers lacking
+// every one of the roles with HTTP 403 Forbidden.
+//
+// Example:
+//
+//	adminRoutes.Use(jwtMiddleware.JWTAuth(), middleware.RequireRole(model.RoleAdmin))
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get("claims")
+		claims, ok := value.(jwt.MapClaims)
+		if !exists || !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication is required"})
+			return
+		}
+
+		granted := claimRoles(claims)
+		for _, role := range roles {
+			if granted[role] {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	}
+}
+
+// claimRoles returns the set of roles in the "roles" claim. Decoded tokens hold them as []any,
+// while claims built in-process may hold a []string.
+func claimRoles(claims jwt.MapClaims) map[string]bool {
+	granted := map[string]bool{}
+	switch roles := claims["roles"].(type) {
+	case []string:
+		for _, role := range roles {
+			granted[role] = true
+		}
+	case []any:
+		for _, role := range roles {
+			if s, ok := role.(string); ok {
+				granted[s] = true
+			}
+		}
+	}
+	return granted
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequireRole tests the RequireRole middleware handler:
+// only callers whose claims hold one of the required roles pass.
+func TestRequireRole(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		claims         jwt.MapClaims
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:           "success - role from a decoded token",
+			claims:         jwt.MapClaims{"sub": "user-123", "roles": []any{"user", "admin"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "success - role from in-process claims",
+			claims:         jwt.MapClaims{"sub": "user-123", "roles": []string{"moderator"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - missing role",
+			claims:         jwt.MapClaims{"sub": "user-123", "roles": []any{"user"}},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   map[string]any{"error": "Insufficient permissions"},
+		},
+		{
+			name:           "error - token without roles",
+			claims:         jwt.MapClaims{"sub": "user-123"},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   map[string]any{"error": "Insufficient permissions"},
+		},
+		{
+			name:           "error - unauthenticated request",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"error": "Authentication is required"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(rec)
+			router.GET("/test", func(c *gin.Context) {
+				if tc.claims != nil {
+					c.Set("claims", tc.claims)
+				}
+				c.Next()
+			}, RequireRole("admin", "moderator"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				assert.JSONEq(t, mustMarshal(tc.expectedBody), rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package accesstoken
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type createTokenInput struct {
+	// A label telling the token apart from the other tokens of the user
+	Name string `json:"name" example:"Nightly backup" validate:"required,lte=100"`
+	// What the token may do: bookmarks:read, bookmarks:write or links:write
+	Scopes []string `json:"scopes" example:"bookmarks:read" validate:"required,min=1,unique,dive,oneof=bookmarks:read bookmarks:write links:write"`
+	// When the token stops working (RFC 3339); omit for a token that does not expire
+	ExpiresAt *time.Time `json:"expires_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// CreateToken creates a personal access token for the authenticated user.
+//
+// @Summary      Create a personal access token
+// @Description  Create a named token for scripts and integrations, sent as "Authorization: Bearer <token>".
+// @Description  The token is only returned in this response; store it right away. It can call the bookmark endpoints
+// @Description  (bookmarks:read, bookmarks:write) and the link shortener (links:write), depending on its scopes.
+// @Tags         Access Token
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      createTokenInput  true  "Token details"
+// @Success      200      {object}  model.PersonalAccessToken
+// @Failure      400      {object}  response.Message "Invalid input"
+// @Failure      401      {object}  response.Message "Unauthorized"
+// @Failure      500      {object}  response.Message "Internal server error"
+// @Router       /v1/self/tokens [post]
+func (h *accessTokenHandler) CreateToken(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[createTokenInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.CreateToken(c, uid, input.Name, model.Scopes(input.Scopes), input.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, accesstoken.ErrExpiryInPast) {
+			c.JSON(http.StatusBadRequest, &response.Message{Message: "Expiry must be in the future"})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to create personal access token")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
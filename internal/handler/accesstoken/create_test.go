@@ -0,0 +1,160 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+)
+
+const (
+	testUserID  = "test-user-id"
+	testTokenID = "550e8400-e29b-41d4-a716-446655440000"
+	testToken   = "bpat_AbCdEfGhIjKlMnOpQrStUvWxYz0123456789ab"
+)
+
+var testTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestAccessTokenHandler_CreateToken(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		requestBody    any
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - token returned once",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{
+				"name":       "CI",
+				"scopes":     []string{"bookmarks:read", "links:write"},
+				"expires_at": "2026-01-01T00:00:00Z",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreateToken", ctx, testUserID, "CI", model.Scopes{model.ScopeBookmarksRead, model.ScopeLinksWrite},
+					mock.MatchedBy(func(at *time.Time) bool { return at != nil && at.Equal(expiresAt) })).
+					Return(&model.PersonalAccessToken{
+						Base:      model.Base{ID: testTokenID, CreatedAt: testTime, UpdatedAt: testTime},
+						UserID:    testUserID,
+						Name:      "CI",
+						TokenHash: "hash",
+						Scopes:    model.Scopes{model.ScopeBookmarksRead, model.ScopeLinksWrite},
+						ExpiresAt: &expiresAt,
+						Token:     testToken,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":           testTokenID,
+				"created_at":   "2025-01-01T00:00:00Z",
+				"updated_at":   "2025-01-01T00:00:00Z",
+				"name":         "CI",
+				"scopes":       []any{"bookmarks:read", "links:write"},
+				"expires_at":   "2026-01-01T00:00:00Z",
+				"last_used_at": nil,
+				"token":        testToken,
+			},
+		},
+		{
+			name:        "error - missing JWT claims",
+			jwtClaims:   nil,
+			requestBody: map[string]any{"name": "CI", "scopes": []string{"bookmarks:read"}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"message": "Invalid token"},
+		},
+		{
+			name:        "error - unknown scope",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"name": "CI", "scopes": []string{"admin"}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Scopes[0] is invalid (oneof)"},
+			},
+		},
+		{
+			name:        "error - no scopes",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"name": "CI", "scopes": []string{}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Scopes is invalid (min)"},
+			},
+		},
+		{
+			name:      "error - expiry in the past",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{
+				"name":       "CI",
+				"scopes":     []string{"bookmarks:read"},
+				"expires_at": "2020-01-01T00:00:00Z",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreateToken", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, accesstoken.ErrExpiryInPast)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]any{"message": "Expiry must be in the future"},
+		},
+		{
+			name:        "error - service failure",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"name": "CI", "scopes": []string{"bookmarks:read"}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreateToken", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("service error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/self/tokens").
+				WithJWTClaims(tc.jwtClaims).
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.CreateToken(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package accesstoken
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type deleteTokenInput struct {
+	// ID is the token identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// DeleteToken revokes a personal access token of the authenticated user.
+//
+// @Summary      Revoke a personal access token
+// @Description  Revoke a personal access token. Requests made with it are rejected right away.
+// @Tags         Access Token
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Token ID (UUID)"
+// @Success      200  {object}  response.Message "Success"
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Token not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/self/tokens/{id} [delete]
+func (h *accessTokenHandler) DeleteToken(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[deleteTokenInput](c)
+	if err != nil {
+		return
+	}
+
+	err = h.svc.DeleteToken(c, input.ID, uid)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Token not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("token_id", input.ID).Msg("Failed to delete personal access token")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response.Message{
+		Message: "Success",
+	})
+}
@@ -0,0 +1,25 @@
+package accesstoken
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for personal access token HTTP handlers.
+type Handler interface {
+	// CreateToken handles creating a personal access token.
+	CreateToken(c *gin.Context)
+	// GetTokens retrieves the personal access tokens of the user.
+	GetTokens(c *gin.Context)
+	// DeleteToken handles revoking a personal access token.
+	DeleteToken(c *gin.Context)
+}
+
+type accessTokenHandler struct {
+	svc accesstoken.Service
+}
+
+// NewHandler creates a new instance of the personal access token handler.
+func NewHandler(svc accesstoken.Service) Handler {
+	return &accessTokenHandler{svc: svc}
+}
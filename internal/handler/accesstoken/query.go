@@ -0,0 +1,48 @@
+package accesstoken
+
+import (
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// listTokensResponse is the response body of the token listing endpoint
+type listTokensResponse struct {
+	Data []*model.PersonalAccessToken `json:"data"`
+}
+
+// GetTokens lists the personal access tokens of the authenticated user.
+//
+// @Summary      List my personal access tokens
+// @Description  Get the personal access tokens of the authenticated user with their scopes, expiry and last use,
+// @Description  most recent first. The tokens themselves cannot be read again.
+// @Tags         Access Token
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  listTokensResponse
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/self/tokens [get]
+func (h *accessTokenHandler) GetTokens(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	tokens, err := h.svc.GetTokens(c, uid)
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to list personal access tokens")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, listTokensResponse{Data: tokens})
+}
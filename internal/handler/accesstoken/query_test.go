@@ -0,0 +1,92 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/accesstoken/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+)
+
+func TestAccessTokenHandler_GetTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - tokens without their hash",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetTokens", ctx, testUserID).Return([]*model.PersonalAccessToken{{
+					Base:       model.Base{ID: testTokenID, CreatedAt: testTime, UpdatedAt: testTime},
+					UserID:     testUserID,
+					Name:       "CI",
+					TokenHash:  "hash",
+					Scopes:     model.Scopes{model.ScopeBookmarksWrite},
+					LastUsedAt: &testTime,
+				}}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{map[string]any{
+					"id":           testTokenID,
+					"created_at":   "2025-01-01T00:00:00Z",
+					"updated_at":   "2025-01-01T00:00:00Z",
+					"name":         "CI",
+					"scopes":       []any{"bookmarks:write"},
+					"expires_at":   nil,
+					"last_used_at": "2025-01-01T00:00:00Z",
+				}},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"message": "Invalid token"},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetTokens", ctx, testUserID).Return(nil, errors.New("service error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/self/tokens").
+				WithJWTClaims(tc.jwtClaims)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetTokens(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/admin"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// DisableUser keeps a user from signing in. Every token issued to the user stops working.
+//
+// @Summary Disable a user
+// @Description Disable the account of a user: logging in is refused and every access and refresh token of the user is revoked.
+// @Description Administrators cannot disable their own account, nor the last enabled administrator.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Unauthorized"
+// @Failure 403 {object} response.Message "Insufficient permissions"
+// @Failure 404 {object} response.Message "User not found"
+// @Failure 409 {object} response.Message "Own account or last enabled administrator"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/admin/users/{id}/disable [post]
+func (h *adminHandler) DisableUser(c *gin.Context) {
+	h.setUserDisabled(c, true, "User disabled")
+}
+
+// EnableUser lets a disabled user sign in again.
+//
+// @Summary Enable a user
+// @Description Enable the account of a disabled user, who can log in again
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Unauthorized"
+// @Failure 403 {object} response.Message "Insufficient permissions"
+// @Failure 404 {object} response.Message "User not found"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/admin/users/{id}/enable [post]
+func (h *adminHandler) EnableUser(c *gin.Context) {
+	h.setUserDisabled(c, false, "User enabled")
+}
+
+// setUserDisabled disables or enables the user of the request and answers with message.
+func (h *adminHandler) setUserDisabled(c *gin.Context, disabled bool, message string) {
+	input, err := utils.BindInputFromRequest[userInput](c)
+	if err != nil {
+		return
+	}
+	if disabled && isCaller(c, input.ID) {
+		c.JSON(http.StatusConflict, response.Message{Message: ownAccountMessage})
+		return
+	}
+
+	err = h.adminSvc.SetUserDisabled(c, input.ID, disabled)
+	switch {
+	case errors.Is(err, dbutils.ErrNotFoundType):
+		c.JSON(http.StatusNotFound, response.Message{Message: "User not found"})
+		return
+	case errors.Is(err, admin.ErrLastAdmin):
+		c.JSON(http.StatusConflict, response.Message{Message: lastAdminMessage})
+		return
+	case err != nil:
+		log.Error().Err(err).Str("userID", input.ID).Bool("disabled", disabled).Msg("SetUserDisabled err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: message})
+}
+
+// Messages of the conflicts refusing to disable or delete an administrator.
+const (
+	ownAccountMessage = "You cannot disable or delete your own account"
+	lastAdminMessage  = "Cannot disable or delete the last enabled administrator"
+)
+
+// isCaller reports whether userID is the ID of the administrator making the request.
+func isCaller(c *gin.Context, userID string) bool {
+	callerID, err := utils.GetUIDFromRequest(c)
+	return err == nil && callerID == userID
+}
+
+// ForcePasswordReset makes a user choose a new password: the current one stops working,
+// the tokens of the user are revoked and a reset link is emailed to the user.
+//
+// @Summary Force a password reset
+// @Description Invalidate the password of a user, revoke every token of the user, personal access tokens included, and email a password reset link
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Unauthorized"
+// @Failure 403 {object} response.Message "Insufficient permissions"
+// @Failure 404 {object} response.Message "User not found"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/admin/users/{id}/password/reset [post]
+func (h *adminHandler) ForcePasswordReset(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[userInput](c)
+	if err != nil {
+		return
+	}
+
+	err = h.adminSvc.ForcePasswordReset(c, input.ID)
+	switch {
+	case errors.Is(err, dbutils.ErrNotFoundType):
+		c.JSON(http.StatusNotFound, response.Message{Message: "User not found"})
+		return
+	case err != nil:
+		log.Error().Err(err).Str("userID", input.ID).Msg("ForcePasswordReset err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: "Password reset email sent"})
+}
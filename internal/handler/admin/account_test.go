@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/admin"
+	adminMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/admin/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminHandler_SetUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		disable        bool
+		callerID       string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *adminMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:    "success - user disabled",
+			disable: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, testUserID, true).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "User disabled",
+			},
+		},
+		{
+			name:    "success - user enabled",
+			disable: false,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, testUserID, false).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "User enabled",
+			},
+		},
+		{
+			name:     "success - administrator enables own account",
+			disable:  false,
+			callerID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, testUserID, false).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "User enabled",
+			},
+		},
+		{
+			name:     "error - own account",
+			disable:  true,
+			callerID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				return adminMocks.NewService(t)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "You cannot disable or delete your own account",
+			},
+		},
+		{
+			name:    "error - last enabled administrator",
+			disable: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, testUserID, true).Return(admin.ErrLastAdmin)
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "Cannot disable or delete the last enabled administrator",
+			},
+		},
+		{
+			name:    "error - user not found",
+			disable: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, testUserID, true).Return(dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "User not found",
+			},
+		},
+		{
+			name:    "error - internal server error",
+			disable: false,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, testUserID, false).Return(assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/admin/users/"+testUserID+"/disable").
+				WithURIParams(map[string]string{"id": testUserID})
+			if tc.callerID != "" {
+				testCtx.WithJWTClaims(jwt.MapClaims{"sub": tc.callerID})
+			}
+
+			handler := NewHandler(mocks.NewUser(t), tc.setupMockSvc(t, testCtx.Ctx))
+
+			if tc.disable {
+				handler.DisableUser(testCtx.Ctx)
+			} else {
+				handler.EnableUser(testCtx.Ctx)
+			}
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestAdminHandler_ForcePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		userID         string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *adminMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:   "success - reset link sent",
+			userID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("ForcePasswordReset", ctx, testUserID).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Password reset email sent",
+			},
+		},
+		{
+			name:   "error - user not found",
+			userID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("ForcePasswordReset", ctx, testUserID).Return(dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "User not found",
+			},
+		},
+		{
+			name:   "error - invalid user ID",
+			userID: "not-a-uuid",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				return adminMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/admin/users/"+tc.userID+"/password/reset").
+				WithURIParams(map[string]string{"id": tc.userID})
+
+			handler := NewHandler(mocks.NewUser(t), tc.setupMockSvc(t, testCtx.Ctx))
+
+			handler.ForcePasswordReset(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Package admin provides the HTTP handlers of the administration API.
+package admin
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/admin"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for administration HTTP handlers.
+type Handler interface {
+	// RevokeUserTokens revokes every access, refresh and personal access token of a user.
+	RevokeUserTokens(c *gin.Context)
+	// ListUsers lists and searches the users.
+	ListUsers(c *gin.Context)
+	// GetUser returns a user with its bookmark count.
+	GetUser(c *gin.Context)
+	// DeleteUser permanently removes a user and its bookmarks.
+	DeleteUser(c *gin.Context)
+	// DisableUser keeps a user from signing in.
+	DisableUser(c *gin.Context)
+	// EnableUser lets a disabled user sign in again.
+	EnableUser(c *gin.Context)
+	// ForcePasswordReset makes a user choose a new password.
+	ForcePasswordReset(c *gin.Context)
+}
+
+type adminHandler struct {
+	userSvc  service.User
+	adminSvc admin.Service
+}
+
+// NewHandler creates a new instance of the administration handler.
+func NewHandler(userSvc service.User, adminSvc admin.Service) Handler {
+	return &adminHandler{userSvc: userSvc, adminSvc: adminSvc}
+}
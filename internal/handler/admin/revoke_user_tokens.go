@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// revokeUserTokensInput represents the URI parameters for revoking the tokens of a user.
+type revokeUserTokensInput struct {
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// RevokeUserTokens logs a user out everywhere: every access token, refresh token and
+// personal access token issued to the user so far stops working.
+//
+// @Summary Revoke every token of a user
+// @Description Revoke every access, refresh and personal access token issued to a user, e.g. after the account was compromised
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Unauthorized"
+// @Failure 403 {object} response.Message "Insufficient permissions"
+// @Failure 404 {object} response.Message "User not found"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/admin/users/{id}/tokens/revoke [post]
+func (h *adminHandler) RevokeUserTokens(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[revokeUserTokensInput](c)
+	if err != nil {
+		return
+	}
+
+	err = h.userSvc.RevokeAllTokens(c, input.ID)
+	switch {
+	case errors.Is(err, dbutils.ErrNotFoundType):
+		c.JSON(http.StatusNotFound, response.Message{Message: "User not found"})
+		return
+	case err != nil:
+		log.Error().Err(err).Str("userID", input.ID).Msg("RevokeUserTokens err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: "Tokens revoked"})
+}
@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	adminMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/admin/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+func TestAdminHandler_RevokeUserTokens(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		userID         string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.User
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:   "success - tokens revoked",
+			userID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("RevokeAllTokens", ctx, testUserID).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Tokens revoked",
+			},
+		},
+		{
+			name:   "error - user not found",
+			userID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("RevokeAllTokens", ctx, testUserID).Return(dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "User not found",
+			},
+		},
+		{
+			name:   "error - invalid user ID",
+			userID: "not-a-uuid",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name:   "error - internal server error",
+			userID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("RevokeAllTokens", ctx, testUserID).Return(assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/admin/users/"+tc.userID+"/tokens/revoke").
+				WithURIParams(map[string]string{"id": tc.userID})
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock, adminMocks.NewService(t))
+
+			handler.RevokeUserTokens(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
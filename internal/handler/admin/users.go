@@ -0,0 +1,153 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/admin"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// listUsersResponse is a helper struct for Swagger documentation
+type listUsersResponse struct {
+	Data     []*model.User       `json:"data"`
+	Metadata pagination.Metadata `json:"metadata"`
+}
+
+type listUsersInput struct {
+	pagination.Request
+	// Query is matched against the username, display name and email
+	Query string `form:"q" validate:"max=255"`
+	// Disabled selects disabled (true) or enabled (false) accounts
+	Disabled *bool `form:"disabled"`
+}
+
+// userInput represents the URI parameters of the endpoints acting on a user.
+type userInput struct {
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// ListUsers returns a paginated list of users.
+//
+// @Summary List users
+// @Description Get a paginated list of the users, the most recently registered first.
+// @Description q searches the username, display name and email, ignoring case.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 10)"
+// @Param q query string false "Search text"
+// @Param disabled query bool false "Only disabled (true) or only enabled (false) accounts"
+// @Success 200 {object} listUsersResponse
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Unauthorized"
+// @Failure 403 {object} response.Message "Insufficient permissions"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/admin/users [get]
+func (h *adminHandler) ListUsers(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[listUsersInput](c)
+	if err != nil {
+		return
+	}
+
+	filter := &model.UserFilter{Query: input.Query, Disabled: input.Disabled}
+	res, err := h.adminSvc.ListUsers(c, filter, &input.Request)
+	if err != nil {
+		log.Error().Err(err).Msg("ListUsers err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, listUsersResponse{
+		Data:     res.Data,
+		Metadata: res.Metadata,
+	})
+}
+
+// GetUser returns a user with the number of bookmarks it owns.
+//
+// @Summary Get a user
+// @Description Get a user by its ID, with the number of bookmarks it owns outside the trash
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} model.UserOverview
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Unauthorized"
+// @Failure 403 {object} response.Message "Insufficient permissions"
+// @Failure 404 {object} response.Message "User not found"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/admin/users/{id} [get]
+func (h *adminHandler) GetUser(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[userInput](c)
+	if err != nil {
+		return
+	}
+
+	user, err := h.adminSvc.GetUser(c, input.ID)
+	switch {
+	case errors.Is(err, dbutils.ErrNotFoundType):
+		c.JSON(http.StatusNotFound, response.Message{Message: "User not found"})
+		return
+	case err != nil:
+		log.Error().Err(err).Str("userID", input.ID).Msg("GetUser err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser permanently removes a user. Its bookmarks are removed with it, and every token
+// issued to it stops working.
+//
+// @Summary Delete a user
+// @Description Permanently remove a user together with its bookmarks. This cannot be undone.
+// @Description Administrators cannot delete their own account, nor the last enabled administrator.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Unauthorized"
+// @Failure 403 {object} response.Message "Insufficient permissions"
+// @Failure 404 {object} response.Message "User not found"
+// @Failure 409 {object} response.Message "Own account or last enabled administrator"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/admin/users/{id} [delete]
+func (h *adminHandler) DeleteUser(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[userInput](c)
+	if err != nil {
+		return
+	}
+
+	if isCaller(c, input.ID) {
+		c.JSON(http.StatusConflict, response.Message{Message: ownAccountMessage})
+		return
+	}
+
+	err = h.adminSvc.DeleteUser(c, input.ID)
+	switch {
+	case errors.Is(err, dbutils.ErrNotFoundType):
+		c.JSON(http.StatusNotFound, response.Message{Message: "User not found"})
+		return
+	case errors.Is(err, admin.ErrLastAdmin):
+		c.JSON(http.StatusConflict, response.Message{Message: lastAdminMessage})
+		return
+	case err != nil:
+		log.Error().Err(err).Str("userID", input.ID).Msg("DeleteUser err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: "User deleted"})
+}
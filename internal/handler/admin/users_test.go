@@ -0,0 +1,290 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/admin"
+	adminMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/admin/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testUser() model.User {
+	return model.User{
+		Base:        model.Base{ID: testUserID, CreatedAt: testTime, UpdatedAt: testTime},
+		Username:    "john",
+		DisplayName: "John",
+		Email:       "john@example.com",
+		Roles:       model.Roles{model.RoleUser},
+	}
+}
+
+func testUserJSON() map[string]any {
+	return map[string]any{
+		"id":                testUserID,
+		"username":          "john",
+		"display_name":      "John",
+		"email":             "john@example.com",
+		"email_verified_at": nil,
+		"roles":             []any{"user"},
+		"disabled_at":       nil,
+		"created_at":        testTime.Format(time.RFC3339Nano),
+		"updated_at":        testTime.Format(time.RFC3339Nano),
+	}
+}
+
+func TestAdminHandler_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	disabled := true
+	user := testUser()
+
+	testCases := []struct {
+		name           string
+		queryParams    map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *adminMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - users matching the search",
+			queryParams: map[string]string{"q": "john", "disabled": "true", "page": "1", "limit": "5"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("ListUsers", ctx, &model.UserFilter{Query: "john", Disabled: &disabled},
+					&pagination.Request{Page: 1, Limit: 5}).
+					Return(&pagination.Response[*model.User]{
+						Data:     []*model.User{&user},
+						Metadata: pagination.CalculateMetadata(1, 1, 5),
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{testUserJSON()},
+				"metadata": map[string]any{
+					"current_page":  float64(1),
+					"page_size":     float64(5),
+					"first_page":    float64(1),
+					"last_page":     float64(1),
+					"total_records": float64(1),
+				},
+			},
+		},
+		{
+			name:        "error - invalid disabled flag",
+			queryParams: map[string]string{"disabled": "maybe"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				return adminMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - internal server error",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("ListUsers", ctx, &model.UserFilter{}, &pagination.Request{}).Return(nil, assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/admin/users").
+				WithQueryParams(tc.queryParams)
+
+			handler := NewHandler(mocks.NewUser(t), tc.setupMockSvc(t, testCtx.Ctx))
+
+			handler.ListUsers(testCtx.Ctx)
+
+			if tc.expectedBody == nil {
+				assert.Equal(t, tc.expectedStatus, testCtx.Recorder.Code)
+				return
+			}
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestAdminHandler_GetUser(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		userID         string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *adminMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:   "success - user with bookmark count",
+			userID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("GetUser", ctx, testUserID).Return(&model.UserOverview{User: testUser(), BookmarkCount: 3}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func() map[string]any {
+				body := testUserJSON()
+				body["bookmark_count"] = float64(3)
+				return body
+			}(),
+		},
+		{
+			name:   "error - user not found",
+			userID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("GetUser", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "User not found",
+			},
+		},
+		{
+			name:   "error - invalid user ID",
+			userID: "not-a-uuid",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				return adminMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/admin/users/"+tc.userID).
+				WithURIParams(map[string]string{"id": tc.userID})
+
+			handler := NewHandler(mocks.NewUser(t), tc.setupMockSvc(t, testCtx.Ctx))
+
+			handler.GetUser(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestAdminHandler_DeleteUser(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		callerID       string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *adminMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name: "success - user deleted",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("DeleteUser", ctx, testUserID).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "User deleted",
+			},
+		},
+		{
+			name:     "error - own account",
+			callerID: testUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				return adminMocks.NewService(t)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "You cannot disable or delete your own account",
+			},
+		},
+		{
+			name: "error - last enabled administrator",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("DeleteUser", ctx, testUserID).Return(admin.ErrLastAdmin)
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "Cannot disable or delete the last enabled administrator",
+			},
+		},
+		{
+			name: "error - user not found",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("DeleteUser", ctx, testUserID).Return(dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "User not found",
+			},
+		},
+		{
+			name: "error - internal server error",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *adminMocks.Service {
+				svcMock := adminMocks.NewService(t)
+				svcMock.On("DeleteUser", ctx, testUserID).Return(assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodDelete, "/v1/admin/users/"+testUserID).
+				WithURIParams(map[string]string{"id": testUserID})
+			if tc.callerID != "" {
+				testCtx.WithJWTClaims(jwt.MapClaims{"sub": tc.callerID})
+			}
+
+			handler := NewHandler(mocks.NewUser(t), tc.setupMockSvc(t, testCtx.Ctx))
+
+			handler.DeleteUser(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// bulkFilterInput selects bookmarks like the query parameters of GET /v1/bookmarks.
+// At least one field must be set.
+type bulkFilterInput struct {
+	// Link status: broken, ok, redirected or unchecked
+	Status string `json:"status" example:"broken" validate:"omitempty,oneof=broken ok redirected unchecked"`
+	// Only favorites (true) or only non-favorites (false)
+	Favorite *bool `json:"favorite"`
+	// Only pinned (true) or only unpinned (false) bookmarks
+	Pinned *bool `json:"pinned"`
+	// Archived bookmarks (true) instead of the active ones
+	Archived *bool `json:"archived"`
+	// Only unread (true) or only read (false) bookmarks
+	Unread *bool `json:"unread"`
+}
+
+type bulkInput struct {
+	// What to do with the bookmarks: delete, tag, untag, move or archive
+	Action string `json:"action" example:"tag" validate:"required,oneof=delete tag untag move archive"`
+	// IDs of the bookmarks to act on; required unless filter is given
+	IDs []string `json:"ids" validate:"required_without=Filter,excluded_with=Filter,max=1000,dive,uuid"`
+	// Selects the bookmarks to act on instead of ids; at least one field must be set
+	Filter *bulkFilterInput `json:"filter"`
+	// Tags to add or remove; required for tag and untag
+	Tags []string `json:"tags" example:"golang" validate:"required_if=Action tag,required_if=Action untag,max=50,dive,required,lte=64"`
+	// Destination folder of move, empty for the root
+	Folder string `json:"folder" example:"Work/Archive" validate:"lte=512"`
+}
+
+// bulkResponse reports the outcome of a bulk operation
+type bulkResponse struct {
+	// Number of rows changed: bookmarks, or tag assignments for tag and untag
+	Affected int64 `json:"affected" example:"200"`
+}
+
+// BulkUpdate applies an action to many bookmarks of the authenticated user at once.
+//
+// @Summary      Bulk operation
+// @Description  Delete, tag, untag, move or archive many bookmarks in a single transaction.
+// @Description  Bookmarks are selected by ids or by a filter using the same criteria as GET /v1/bookmarks.
+// @Description  The filter only covers link status and the favorite, pinned, archived and unread flags;
+// @Description  tags, folders and text cannot be selected on, list the bookmarks and send their ids instead.
+// @Description  An empty filter is refused: send {"archived": false} to act on every active bookmark.
+// @Description  When ids are given, every one of them must be owned by the user, otherwise nothing is changed.
+// @Description  affected is the number of bookmarks changed, or of tags added or removed for tag and untag.
+// @Tags         Bookmark
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      bulkInput         true  "Action and selection"
+// @Success      200      {object}  bulkResponse
+// @Failure      400      {object}  response.Message "Invalid input"
+// @Failure      401      {object}  response.Message "Unauthorized"
+// @Failure      404      {object}  response.Message "Bookmark not found"
+// @Failure      500      {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/bulk [post]
+func (h *bookmarkHandler) BulkUpdate(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[bulkInput](c)
+	if err != nil {
+		return
+	}
+
+	op := &model.BulkOperation{
+		Action: model.BulkAction(input.Action),
+		IDs:    input.IDs,
+		Tags:   input.Tags,
+		Folder: input.Folder,
+	}
+	if input.Filter != nil {
+		op.Filter = &model.BookmarkFilter{
+			LinkStatus: model.LinkStatus(input.Filter.Status),
+			Favorite:   input.Filter.Favorite,
+			Pinned:     input.Filter.Pinned,
+			Archived:   input.Filter.Archived,
+			Unread:     input.Filter.Unread,
+		}
+	}
+
+	affected, err := h.svc.BulkUpdate(c, uid, op)
+	if err != nil {
+		switch {
+		case errors.Is(err, dbutils.ErrNotFoundType):
+			c.JSON(http.StatusNotFound, &response.Message{Message: "Bookmark not found"})
+		case errors.Is(err, bookmark.ErrEmptySelection):
+			c.JSON(http.StatusBadRequest, &response.Message{Message: "Either ids or a filter with at least one criterion is required"})
+		default:
+			log.Error().Err(err).Str("uid", uid).Str("action", input.Action).Msg("Failed to apply bulk operation")
+			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, bulkResponse{Affected: affected})
+}
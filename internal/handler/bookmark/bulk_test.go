@@ -0,0 +1,177 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkHandler_BulkUpdate(t *testing.T) {
+	t.Parallel()
+
+	const testBookmarkIDBulk = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		requestBody    any
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - tag by IDs",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{
+				"action": "tag",
+				"ids":    []string{testBookmarkIDBulk},
+				"tags":   []string{"go"},
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("BulkUpdate", ctx, testUserID, &model.BulkOperation{
+					Action: model.BulkActionTag,
+					IDs:    []string{testBookmarkIDBulk},
+					Tags:   []string{"go"},
+				}).Return(int64(1), nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]any{"affected": float64(1)},
+		},
+		{
+			name:      "success - delete by filter",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{
+				"action": "delete",
+				"filter": map[string]any{"status": "broken", "archived": true},
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				archived := true
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("BulkUpdate", ctx, testUserID, &model.BulkOperation{
+					Action: model.BulkActionDelete,
+					Filter: &model.BookmarkFilter{LinkStatus: model.LinkStatusBroken, Archived: &archived},
+				}).Return(int64(200), nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]any{"affected": float64(200)},
+		},
+		{
+			name:        "error - missing JWT claims",
+			jwtClaims:   nil,
+			requestBody: map[string]any{"action": "delete", "ids": []string{testBookmarkIDBulk}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"message": "Invalid token"},
+		},
+		{
+			name:        "error - no selection",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"action": "archive"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"IDs is invalid (required_without)"},
+			},
+		},
+		{
+			name:        "error - empty filter",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"action": "delete", "filter": map[string]any{}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("BulkUpdate", ctx, testUserID, &model.BulkOperation{
+					Action: model.BulkActionDelete,
+					Filter: &model.BookmarkFilter{},
+				}).Return(int64(0), bookmark.ErrEmptySelection)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]any{"message": "Either ids or a filter with at least one criterion is required"},
+		},
+		{
+			name:        "error - tags missing for tag action",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"action": "tag", "ids": []string{testBookmarkIDBulk}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Tags is invalid (required_if)"},
+			},
+		},
+		{
+			name:        "error - invalid action",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"action": "purge", "ids": []string{testBookmarkIDBulk}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Action is invalid (oneof)"},
+			},
+		},
+		{
+			name:        "error - bookmark not owned",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"action": "delete", "ids": []string{testBookmarkIDBulk}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("BulkUpdate", ctx, mock.Anything, mock.Anything).Return(int64(0), dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]any{"message": "Bookmark not found"},
+		},
+		{
+			name:        "error - service failure",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{"action": "delete", "ids": []string{testBookmarkIDBulk}},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("BulkUpdate", ctx, mock.Anything, mock.Anything).Return(int64(0), errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/bulk").
+				WithJWTClaims(tc.jwtClaims).
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.BulkUpdate(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
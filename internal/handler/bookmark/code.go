@@ -0,0 +1,67 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type regenerateCodeInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// RegenerateCode gives a bookmark a new generated short code.
+//
+// @Summary      Regenerate bookmark code
+// @Description  Replace the short code of a bookmark with a newly generated one. The previous code stops redirecting.
+// @Description  Only the bookmark owner can change it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/code [post]
+func (h *bookmarkHandler) RegenerateCode(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[regenerateCodeInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.RegenerateCode(c, input.ID, uid)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to regenerate bookmark code")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.Header("ETag", etag.FromVersion(res.Version))
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,131 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestBookmarkHandler_RegenerateCode(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	const testBookmarkIDCode = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - new code",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDCode},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RegenerateCode", ctx, testBookmarkIDCode, testUserID).
+					Return(&model.Bookmark{
+						Base:    model.Base{ID: testBookmarkIDCode, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+						URL:     "https://example.com",
+						Code:    "xyz987654",
+						UserID:  testUserID,
+						Version: 2,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":          testBookmarkIDCode,
+				"description": "",
+				"url":         "https://example.com",
+				"code":        "xyz987654",
+				"user_id":     testUserID,
+				"created_at":  fixedTime.Format(time.RFC3339Nano),
+				"updated_at":  fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name:      "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDCode},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RegenerateCode", ctx, testBookmarkIDCode, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:      "error - invalid id",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": "not-a-uuid"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			uriParams: map[string]string{"id": testBookmarkIDCode},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDCode},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RegenerateCode", ctx, testBookmarkIDCode, testUserID).Return(nil, errors.New("service error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/"+tc.uriParams["id"]+"/code").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).RegenerateCode(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -1,34 +1,54 @@
 package bookmark
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
 	_ "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 )
 
 type createBookmarkInput struct {
-	// Description of the bookmark
-	Description string `json:"description" example:"Your description here" validate:"lte=255,gte=1"`
+	// Description of the bookmark. When empty, it is filled in with the page title in the background.
+	Description string `json:"description" example:"Your description here" validate:"lte=255"`
 	// URL to be shortened
 	URL string `json:"url" example:"https://example.com" validate:"required,url,lte=2048"`
+	// Short code of the bookmark. When empty, a code is generated.
+	Code string `json:"code" example:"golang" validate:"omitempty,alphanum,min=4,max=32"`
+	// What to do when the URL is already bookmarked: reject (default), merge or allow
+	OnDuplicate string `json:"on_duplicate" example:"reject" validate:"omitempty,oneof=reject merge allow"`
+}
+
+// duplicateBookmarkResponse is returned when the URL is already bookmarked and duplicates are rejected.
+type duplicateBookmarkResponse struct {
+	Message    string `json:"message" example:"URL is already bookmarked"`
+	ExistingID string `json:"existing_id" example:"f47ac10b-58cc-4372-a567-0e02b2c3d479"`
 }
 
 // CreateBookmark creates a new bookmark for the authenticated user.
 //
 // @Summary      Create a new bookmark
 // @Description  Create a new bookmark with a description and target URL. Returns the created bookmark with its short code.
+// @Description  URLs are compared in normalized form. With on_duplicate=reject (default) an already bookmarked URL is refused
+// @Description  with the ID of the existing bookmark, merge returns the existing bookmark instead, and allow creates a duplicate.
+// @Description  The page is fetched in the background to fill in its title, meta description, favicon and canonical URL when no description is given.
+// @Description  A code can be chosen instead of the generated one. It shares the redirect namespace with short links and cannot be a reserved word.
 // @Tags         Bookmark
 // @Accept       json
 // @Produce      json
 // @Security 	 BearerAuth
 // @Param        request        body      createBookmarkInput  true  "Bookmark details"
 // @Success      200            {object}  model.Bookmark
-// @Failure      400            {object}  response.Message     "Invalid input"
+// @Failure      400            {object}  response.Message     "Invalid input or reserved code"
 // @Failure      401            {object}  response.Message     "Unauthorized"
+// @Failure      403            {object}  response.Message     "Bookmark quota exceeded"
+// @Failure      409            {object}  duplicateBookmarkResponse "URL already bookmarked, or code already taken"
 // @Failure      500            {object}  response.Message     "Internal server error"
 // @Router       /v1/bookmarks [post]
 func (h *bookmarkHandler) CreateBookmark(c *gin.Context) {
@@ -47,12 +67,33 @@ func (h *bookmarkHandler) CreateBookmark(c *gin.Context) {
 		return
 	}
 
-	res, err := h.svc.CreateBookmark(c, input.Description, input.URL, uid)
+	res, err := h.svc.CreateBookmark(c, input.Description, input.URL, input.Code, uid, bookmark.OnDuplicate(input.OnDuplicate))
+	var dupErr *bookmark.DuplicateBookmarkError
+	if errors.As(err, &dupErr) {
+		c.JSON(http.StatusConflict, &duplicateBookmarkResponse{
+			Message:    "URL is already bookmarked",
+			ExistingID: dupErr.ExistingID,
+		})
+		return
+	}
+	if errors.Is(err, bookmark.ErrCodeReserved) {
+		c.JSON(http.StatusBadRequest, response.Message{Message: "Code is reserved"})
+		return
+	}
+	if errors.Is(err, bookmark.ErrCodeTaken) {
+		c.JSON(http.StatusConflict, response.Message{Message: "Code is already taken"})
+		return
+	}
+	if errors.Is(err, quota.ErrBookmarkQuotaExceeded) {
+		c.JSON(http.StatusForbidden, response.Message{Message: "Bookmark quota exceeded"})
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Str("uid", uid).Msg("Failed to create bookmark")
 		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
 		return
 	}
 
+	c.Header("ETag", etag.FromVersion(res.Version))
 	c.JSON(http.StatusOK, res)
 }
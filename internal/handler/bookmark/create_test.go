@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
 	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
 	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -57,6 +59,8 @@ func TestBookmarkHandler_CreateBookmark(t *testing.T) {
 					mock.Anything,
 					mock.Anything,
 					mock.Anything,
+					mock.Anything,
+					mock.Anything,
 				).Return(&model.Bookmark{
 					Base: model.Base{
 						ID:        "bm-1",
@@ -159,6 +163,105 @@ func TestBookmarkHandler_CreateBookmark(t *testing.T) {
 				"details": []any{"URL is invalid (lte)"},
 			},
 		},
+		{
+			name: "error - URL already bookmarked",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			inputBody: map[string]any{"description": "My Bookmark", "url": "https://example.com", "on_duplicate": "reject"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreateBookmark", ctx, testBookmarkDesc, testBookmarkURL, "", testUserID, bookmark.OnDuplicateReject).
+					Return(nil, &bookmark.DuplicateBookmarkError{ExistingID: "bm-1"})
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message":     "URL is already bookmarked",
+				"existing_id": "bm-1",
+			},
+		},
+		{
+			name: "error - bookmark quota exceeded",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			inputBody: map[string]any{"description": "My Bookmark", "url": "https://example.com"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreateBookmark", ctx, testBookmarkDesc, testBookmarkURL, "", testUserID, bookmark.OnDuplicate("")).
+					Return(nil, quota.ErrBookmarkQuotaExceeded)
+				return svcMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "Bookmark quota exceeded",
+			},
+		},
+		{
+			name: "error - reserved code",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			inputBody: map[string]any{"description": "My Bookmark", "url": "https://example.com", "code": "swagger"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreateBookmark", ctx, testBookmarkDesc, testBookmarkURL, "swagger", testUserID, bookmark.OnDuplicate("")).
+					Return(nil, bookmark.ErrCodeReserved)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": "Code is reserved",
+			},
+		},
+		{
+			name: "error - code already taken",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			inputBody: map[string]any{"description": "My Bookmark", "url": "https://example.com", "code": "golang"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreateBookmark", ctx, testBookmarkDesc, testBookmarkURL, "golang", testUserID, bookmark.OnDuplicate("")).
+					Return(nil, bookmark.ErrCodeTaken)
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "Code is already taken",
+			},
+		},
+		{
+			name: "error - invalid input (code not alphanumeric)",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			inputBody: map[string]any{"description": "My Bookmark", "url": "https://example.com", "code": "go/lang"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Code is invalid (alphanum)"},
+			},
+		},
+		{
+			name: "error - invalid input (unknown on_duplicate)",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			inputBody: map[string]any{"description": "My Bookmark", "url": "https://example.com", "on_duplicate": "ignore"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"OnDuplicate is invalid (oneof)"},
+			},
+		},
 		{
 			name: "error - service failure",
 			jwtClaims: jwt.MapClaims{
@@ -172,6 +275,8 @@ func TestBookmarkHandler_CreateBookmark(t *testing.T) {
 					mock.Anything,
 					mock.Anything,
 					mock.Anything,
+					mock.Anything,
+					mock.Anything,
 				).Return(nil, errors.New("service error"))
 				return svcMock
 			},
@@ -16,18 +16,22 @@ type deleteBookmarkInput struct {
 	ID string `uri:"id" validate:"required,uuid"`
 }
 
-// DeleteBookmark deletes an existing bookmark for the authenticated user.
+// DeleteBookmark moves an existing bookmark of the authenticated user to the trash.
 //
 // @Summary      Delete a bookmark
-// @Description  Delete an existing bookmark. Only the bookmark owner can delete it.
+// @Description  Move an existing bookmark to the trash. Only the bookmark owner can delete it.
+// @Description  Trashed bookmarks can be restored until the retention period has passed.
+// @Description  Send the ETag of the bookmark in If-Match to only delete it if nobody else modified it in the meantime.
 // @Tags         Bookmark
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Param        id        path      string           true  "Bookmark ID (UUID)"
+// @Param        If-Match  header    string           false "ETag the bookmark must still have"
 // @Success      200  {object}  response.Message "Success"
 // @Failure      400  {object}  response.Message "Invalid input"
 // @Failure      401  {object}  response.Message "Unauthorized"
 // @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      412  {object}  response.Message "Bookmark has been modified"
 // @Failure      500  {object}  response.Message "Internal server error"
 // @Router       /v1/bookmarks/{id} [delete]
 func (h *bookmarkHandler) DeleteBookmark(c *gin.Context) {
@@ -46,7 +50,16 @@ func (h *bookmarkHandler) DeleteBookmark(c *gin.Context) {
 		return
 	}
 
-	err = h.svc.DeleteBookmark(c, input.ID, uid)
+	// Honor If-Match, so that concurrent edits don't silently overwrite each other
+	version, ok := utils.GetVersionFromRequest(c)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, &response.Message{
+			Message: "Bookmark has been modified",
+		})
+		return
+	}
+
+	err = h.svc.DeleteBookmark(c, input.ID, uid, version)
 	if err != nil {
 		if errors.Is(err, dbutils.ErrNotFoundType) {
 			c.JSON(http.StatusNotFound, &response.Message{
@@ -54,6 +67,12 @@ func (h *bookmarkHandler) DeleteBookmark(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, dbutils.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, &response.Message{
+				Message: "Bookmark has been modified",
+			})
+			return
+		}
 
 		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to delete bookmark")
 		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
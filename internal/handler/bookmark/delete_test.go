@@ -24,6 +24,7 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 		name           string
 		jwtClaims      jwt.MapClaims
 		uriParams      map[string]string
+		ifMatch        string
 		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
 		expectedStatus int
 		expectedBody   map[string]any
@@ -40,6 +41,7 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 					ctx,
 					testBookmarkIDDelete,
 					testUserID,
+					0,
 				).Return(nil)
 				return svcMock
 			},
@@ -48,6 +50,28 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 				"message": "Success",
 			},
 		},
+		{
+			name: "error - bookmark has been modified",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDDelete},
+			ifMatch:   `"2"`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("DeleteBookmark",
+					ctx,
+					testBookmarkIDDelete,
+					testUserID,
+					2,
+				).Return(dbutils.ErrVersionMismatch)
+				return svcMock
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: map[string]any{
+				"message": "Bookmark has been modified",
+			},
+		},
 		{
 			name:      "error - missing JWT claims",
 			jwtClaims: nil,
@@ -87,6 +111,7 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 					ctx,
 					mock.Anything,
 					mock.Anything,
+					mock.Anything,
 				).Return(dbutils.ErrNotFoundType)
 				return svcMock
 			},
@@ -107,6 +132,7 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 					ctx,
 					mock.Anything,
 					mock.Anything,
+					mock.Anything,
 				).Return(errors.New("service error"))
 				return svcMock
 			},
@@ -124,7 +150,8 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 			// Create test context with JWT claims and URI params
 			testCtx := handlertest.NewTestContext(http.MethodDelete, "/v1/bookmarks/:id").
 				WithJWTClaims(tc.jwtClaims).
-				WithURIParams(tc.uriParams)
+				WithURIParams(tc.uriParams).
+				WithHeader("If-Match", tc.ifMatch)
 
 			// Setup mock service
 			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
@@ -0,0 +1,49 @@
+package bookmark
+
+import (
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// listDuplicatesResponse is the response body of the duplicates endpoint
+type listDuplicatesResponse struct {
+	Data []*bookmark.DuplicateGroup `json:"data"`
+}
+
+// GetDuplicates lists the groups of bookmarks that point to the same page.
+//
+// @Summary      List duplicate bookmarks
+// @Description  Get the groups of bookmarks of the authenticated user that point to the same normalized URL.
+// @Description  URLs are normalized by lowercasing the scheme and host and by dropping default ports, fragments,
+// @Description  tracking parameters (utm_*, fbclid, ...) and trailing slashes. Bookmarks in a group are ordered oldest first.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  listDuplicatesResponse
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/duplicates [get]
+func (h *bookmarkHandler) GetDuplicates(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	groups, err := h.svc.GetDuplicates(c, uid)
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to list duplicate bookmarks")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, listDuplicatesResponse{Data: groups})
+}
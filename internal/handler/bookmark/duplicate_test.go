@@ -0,0 +1,139 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestBookmarkHandler_GetDuplicates(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newBookmark := func(id, url string) *model.Bookmark {
+		return &model.Bookmark{
+			Base:          model.Base{ID: id, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			URL:           url,
+			NormalizedURL: testBookmarkURL + "/",
+			Code:          testBookmarkCode,
+			UserID:        testUserID,
+		}
+	}
+	expectedBookmark := func(id, url string) map[string]any {
+		return map[string]any{
+			"id":          id,
+			"description": "",
+			"url":         url,
+			"code":        testBookmarkCode,
+			"user_id":     testUserID,
+			"created_at":  fixedTime.Format(time.RFC3339Nano),
+			"updated_at":  fixedTime.Format(time.RFC3339Nano),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name: "success - list duplicate groups",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetDuplicates", ctx, testUserID).Return([]*bookmark.DuplicateGroup{
+					{
+						NormalizedURL: testBookmarkURL + "/",
+						Bookmarks: []*model.Bookmark{
+							newBookmark("bm-1", testBookmarkURL),
+							newBookmark("bm-2", testBookmarkURL+"/?utm_source=x"),
+						},
+					},
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"normalized_url": testBookmarkURL + "/",
+						"bookmarks": []any{
+							expectedBookmark("bm-1", testBookmarkURL),
+							expectedBookmark("bm-2", testBookmarkURL+"/?utm_source=x"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "success - no duplicates",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetDuplicates", ctx, testUserID).Return([]*bookmark.DuplicateGroup{}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name: "error - service failure",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetDuplicates", ctx, testUserID).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/duplicates").
+				WithJWTClaims(tc.jwtClaims)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetDuplicates(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
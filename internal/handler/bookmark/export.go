@@ -0,0 +1,210 @@
+package bookmark
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netscape"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type exportBookmarksInput struct {
+	// Format is the file format: "html" (Netscape bookmark file, default), "json" or "csv"
+	Format string `form:"format" validate:"omitempty,oneof=html json csv"`
+}
+
+// ExportBookmarks streams all bookmarks of the authenticated user as a file.
+//
+// @Summary      Export bookmarks
+// @Description  Download all bookmarks of the authenticated user, grouped by folder.
+// @Description  The default html format is a Netscape bookmark file that every browser can import.
+// @Description  The json format is an array of bookmarks; the csv format has the columns
+// @Description  url, description, folder, tags (comma-separated), code and created_at.
+// @Tags         Bookmark
+// @Produce      html
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        format  query     string  false  "File format (default html)"  Enums(html, json, csv)
+// @Success      200     {file}    file    "Bookmark file"
+// @Failure      400     {object}  response.Message "Invalid input"
+// @Failure      401     {object}  response.Message "Unauthorized"
+// @Failure      500     {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/export [get]
+func (h *bookmarkHandler) ExportBookmarks(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	input, err := utils.BindInputFromRequest[exportBookmarksInput](c)
+	if err != nil {
+		return
+	}
+
+	exp := newExporter(input.Format, c.Writer)
+	c.Header("Content-Type", exp.contentType())
+	c.Header("Content-Disposition", `attachment; filename="bookmarks.`+exp.extension()+`"`)
+	c.Status(http.StatusOK)
+
+	err = h.svc.ExportBookmarks(c, uid, exp.write)
+	if err == nil {
+		err = exp.close()
+	}
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to export bookmarks")
+		// Once the body has started, the status can no longer change; the client sees a truncated file
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		}
+	}
+}
+
+// exporter writes bookmarks to a file format batch by batch.
+type exporter interface {
+	contentType() string
+	extension() string
+	write(bookmarks []*model.Bookmark) error
+	// close writes any trailing content and flushes buffered data
+	close() error
+}
+
+// newExporter returns the exporter for format, defaulting to the Netscape HTML format.
+func newExporter(format string, w io.Writer) exporter {
+	switch format {
+	case "json":
+		return &jsonExporter{w: bufio.NewWriter(w)}
+	case "csv":
+		return &csvExporter{w: csv.NewWriter(w)}
+	default:
+		return &htmlExporter{w: netscape.NewWriter(w)}
+	}
+}
+
+// htmlExporter writes a Netscape bookmark file.
+type htmlExporter struct {
+	w *netscape.Writer
+}
+
+func (e *htmlExporter) contentType() string { return "text/html; charset=utf-8" }
+func (e *htmlExporter) extension() string   { return "html" }
+
+func (e *htmlExporter) write(bookmarks []*model.Bookmark) error {
+	for _, b := range bookmarks {
+		var folder []string
+		if b.Folder != "" {
+			folder = strings.Split(b.Folder, "/")
+		}
+		err := e.w.Write(netscape.Bookmark{
+			URL:     b.URL,
+			Title:   b.Description,
+			Folder:  folder,
+			Tags:    b.TagNames(),
+			AddDate: b.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *htmlExporter) close() error { return e.w.Close() }
+
+// jsonExporter writes a JSON array of bookmarks, one element at a time.
+type jsonExporter struct {
+	w       *bufio.Writer
+	started bool
+}
+
+func (e *jsonExporter) contentType() string { return "application/json; charset=utf-8" }
+func (e *jsonExporter) extension() string   { return "json" }
+
+func (e *jsonExporter) write(bookmarks []*model.Bookmark) error {
+	for _, b := range bookmarks {
+		data, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+
+		sep := ","
+		if !e.started {
+			sep = "["
+			e.started = true
+		}
+		if _, err := e.w.WriteString(sep); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *jsonExporter) close() error {
+	closing := "]"
+	if !e.started {
+		closing = "[]"
+	}
+	if _, err := e.w.WriteString(closing); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// csvExporter writes a CSV file with a header row.
+type csvExporter struct {
+	w       *csv.Writer
+	started bool
+}
+
+func (e *csvExporter) contentType() string { return "text/csv; charset=utf-8" }
+func (e *csvExporter) extension() string   { return "csv" }
+
+func (e *csvExporter) write(bookmarks []*model.Bookmark) error {
+	e.header()
+	for _, b := range bookmarks {
+		err := e.w.Write([]string{
+			b.URL,
+			b.Description,
+			b.Folder,
+			strings.Join(b.TagNames(), ","),
+			b.Code,
+			b.CreatedAt.UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvExporter) close() error {
+	e.header()
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// header writes the header row once.
+func (e *csvExporter) header() {
+	if !e.started {
+		e.started = true
+		_ = e.w.Write([]string{"url", "description", "folder", "tags", "code", "created_at"})
+	}
+}
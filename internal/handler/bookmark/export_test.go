@@ -0,0 +1,161 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkHandler_ExportBookmarks(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	exported := []*model.Bookmark{
+		{
+			Base:        model.Base{ID: "bm-1", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			Description: testQueryBookmarkDesc,
+			URL:         testQueryBookmarkURL,
+			Code:        testQueryBookmarkCode,
+			Folder:      "Work/Go",
+			Tags:        model.NewBookmarkTags([]string{"a", "b"}),
+			UserID:      testUserID,
+		},
+	}
+
+	// exportMock makes the service deliver the bookmarks above as a single batch
+	exportMock := func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+		svcMock := serviceMocks.NewService(t)
+		svcMock.On("ExportBookmarks", ctx, testUserID, mock.Anything).
+			Return(func(_ context.Context, _ string, fn func([]*model.Bookmark) error) error {
+				return fn(exported)
+			})
+		return svcMock
+	}
+
+	testCases := []struct {
+		name                string
+		jwtClaims           jwt.MapClaims
+		queryParams         map[string]string
+		setupMockSvc        func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+		expectedJSON        map[string]any
+	}{
+		{
+			name:                "success - html by default",
+			jwtClaims:           jwt.MapClaims{"sub": testUserID},
+			setupMockSvc:        exportMock,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/html; charset=utf-8",
+			expectedBody: `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3>Work</H3>
+    <DL><p>
+        <DT><H3>Go</H3>
+        <DL><p>
+            <DT><A HREF="https://example.com/1" ADD_DATE="1735689600" TAGS="a,b">Bookmark 1</A>
+        </DL><p>
+    </DL><p>
+</DL><p>
+`,
+		},
+		{
+			name:                "success - json",
+			jwtClaims:           jwt.MapClaims{"sub": testUserID},
+			queryParams:         map[string]string{"format": "json"},
+			setupMockSvc:        exportMock,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `[{"id":"bm-1","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","description":"Bookmark 1","url":"https://example.com/1","code":"test-code","folder":"Work/Go","tags":["a","b"],"user_id":"test-user-id"}]`,
+		},
+		{
+			name:                "success - csv",
+			jwtClaims:           jwt.MapClaims{"sub": testUserID},
+			queryParams:         map[string]string{"format": "csv"},
+			setupMockSvc:        exportMock,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "url,description,folder,tags,code,created_at\n" +
+				"https://example.com/1,Bookmark 1,Work/Go,\"a,b\",test-code,2025-01-01T00:00:00Z\n",
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedJSON: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:        "error - unsupported format",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			queryParams: map[string]string{"format": "xml"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedJSON: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Format is invalid (oneof)"},
+			},
+		},
+		{
+			name:      "error - service failure before first write",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ExportBookmarks", ctx, testUserID, mock.Anything).Return(errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedJSON: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/export").
+				WithJWTClaims(tc.jwtClaims).
+				WithQueryParams(tc.queryParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.ExportBookmarks(testCtx.Ctx)
+
+			if tc.expectedJSON != nil {
+				handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedJSON)
+				return
+			}
+
+			assert.Equal(t, tc.expectedStatus, testCtx.Recorder.Code)
+			assert.Equal(t, tc.expectedContentType, testCtx.Recorder.Header().Get("Content-Type"))
+			assert.Contains(t, testCtx.Recorder.Header().Get("Content-Disposition"), "attachment")
+			assert.Equal(t, tc.expectedBody, testCtx.Recorder.Body.String())
+		})
+	}
+}
@@ -11,10 +11,50 @@ type Handler interface {
 	CreateBookmark(c *gin.Context)
 	// GetBookmarks retrieves a list of bookmarks.
 	GetBookmarks(c *gin.Context)
+	// GetDuplicates retrieves the groups of bookmarks pointing to the same page.
+	GetDuplicates(c *gin.Context)
+	// GetBookmark retrieves a single bookmark by its ID.
+	GetBookmark(c *gin.Context)
 	// UpdateBookmark handles updating an existing bookmark.
 	UpdateBookmark(c *gin.Context)
+	// PatchBookmark handles partially updating an existing bookmark with a JSON Merge Patch.
+	PatchBookmark(c *gin.Context)
 	// DeleteBookmark handles the deletion of a bookmark.
 	DeleteBookmark(c *gin.Context)
+	// GetTrash retrieves a list of soft-deleted bookmarks.
+	GetTrash(c *gin.Context)
+	// RestoreBookmark handles moving a bookmark out of the trash.
+	RestoreBookmark(c *gin.Context)
+	// AcceptRedirect handles replacing a bookmark URL with the target of its permanent redirect.
+	AcceptRedirect(c *gin.Context)
+	// ToggleFavorite handles flipping the favorite state of a bookmark.
+	ToggleFavorite(c *gin.Context)
+	// TogglePinned handles flipping the pinned state of a bookmark.
+	TogglePinned(c *gin.Context)
+	// ToggleArchived handles flipping the archived state of a bookmark.
+	ToggleArchived(c *gin.Context)
+	// RegenerateCode handles giving a bookmark a new generated short code.
+	RegenerateCode(c *gin.Context)
+	// GetHistory retrieves the revisions of a bookmark.
+	GetHistory(c *gin.Context)
+	// RevertBookmark handles restoring a bookmark to a revision.
+	RevertBookmark(c *gin.Context)
+	// MarkRead handles marking a bookmark as read.
+	MarkRead(c *gin.Context)
+	// MarkUnread handles putting a bookmark back on the reading list.
+	MarkUnread(c *gin.Context)
+	// GetNotes retrieves the notes of a bookmark rendered to HTML.
+	GetNotes(c *gin.Context)
+	// RequestSnapshot handles queueing the capture of a bookmarked page.
+	RequestSnapshot(c *gin.Context)
+	// GetSnapshot serves the archived copy of a bookmarked page.
+	GetSnapshot(c *gin.Context)
+	// BulkUpdate handles applying an action to many bookmarks at once.
+	BulkUpdate(c *gin.Context)
+	// ImportBookmarks handles importing bookmarks from a browser export file.
+	ImportBookmarks(c *gin.Context)
+	// ExportBookmarks handles downloading all bookmarks as a file.
+	ExportBookmarks(c *gin.Context)
 }
 
 type bookmarkHandler struct {
@@ -0,0 +1,135 @@
+package bookmark
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netscape"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxImportFileSize caps the size of an uploaded bookmark file (10 MiB).
+	maxImportFileSize = 10 << 20
+	// maxImportRequestSize caps the whole upload request: the file, plus the form fields and the multipart boundaries.
+	maxImportRequestSize = maxImportFileSize + 1<<20
+)
+
+type importBookmarksInput struct {
+	// File is the Netscape bookmark file exported by a browser
+	File *multipart.FileHeader `form:"file" validate:"required" swaggerignore:"true"`
+	// FolderMode stores folders as a folder path ("folder") or as tags ("tag")
+	FolderMode string `form:"folder_mode" validate:"omitempty,oneof=folder tag"`
+	// Duplicates handles URLs that are already bookmarked: "skip", "overwrite" or "allow"
+	Duplicates string `form:"duplicates" validate:"omitempty,oneof=skip overwrite allow"`
+}
+
+// importBookmarksResponse summarizes an import
+type importBookmarksResponse struct {
+	Created int `json:"created" example:"42"`
+	Updated int `json:"updated" example:"0"`
+	Skipped int `json:"skipped" example:"3"`
+	Invalid int `json:"invalid" example:"1"`
+}
+
+// ImportBookmarks imports bookmarks from a browser export file.
+//
+// @Summary      Import bookmarks
+// @Description  Import bookmarks from a Netscape bookmark file, the HTML format every browser exports.
+// @Description  Folders are kept as folder paths or turned into tags. URLs that are already bookmarked
+// @Description  are skipped, overwritten or imported again depending on the duplicates mode.
+// @Description  Links that are not http(s) URLs (e.g. bookmarklets) are counted as invalid.
+// @Tags         Bookmark
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file         formData  file    true   "Netscape bookmark file (max 10 MiB)"
+// @Param        folder_mode  formData  string  false  "How folders are stored (default folder)"  Enums(folder, tag)
+// @Param        duplicates   formData  string  false  "How already bookmarked URLs are handled (default skip)"  Enums(skip, overwrite, allow)
+// @Success      200          {object}  importBookmarksResponse
+// @Failure      400          {object}  response.Message "Invalid input"
+// @Failure      401          {object}  response.Message "Unauthorized"
+// @Failure      403          {object}  response.Message "Bookmark quota exceeded, the entries imported before are kept"
+// @Failure      413          {object}  response.Message "File too large"
+// @Failure      500          {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/import [post]
+func (h *bookmarkHandler) ImportBookmarks(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Bound the body before parsing it, since gin would otherwise read a multipart upload
+	// of any size, spilling it to temporary files, before the file size can be checked
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportRequestSize)
+	if err := c.Request.ParseMultipartForm(maxImportRequestSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, &response.Message{
+				Message: "File too large",
+			})
+			return
+		}
+		// Other errors, such as a body that is not multipart, are reported by the binding below
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[importBookmarksInput](c)
+	if err != nil {
+		return
+	}
+
+	if input.File.Size > maxImportFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, &response.Message{
+			Message: "File too large",
+		})
+		return
+	}
+
+	file, err := input.File.Open()
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to open uploaded bookmark file")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+	defer file.Close()
+
+	items, err := netscape.Parse(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &response.Message{
+			Message: "Invalid bookmark file",
+		})
+		return
+	}
+
+	res, err := h.svc.ImportBookmarks(c, uid, items, bookmark.ImportOptions{
+		FolderMode:    bookmark.FolderMode(input.FolderMode),
+		DuplicateMode: bookmark.DuplicateMode(input.Duplicates),
+	})
+	if errors.Is(err, quota.ErrBookmarkQuotaExceeded) {
+		c.JSON(http.StatusForbidden, response.Message{Message: "Bookmark quota exceeded"})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Interface("partial_result", res).Msg("Failed to import bookmarks")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &importBookmarksResponse{
+		Created: res.Created,
+		Updated: res.Updated,
+		Skipped: res.Skipped,
+		Invalid: res.Invalid,
+	})
+}
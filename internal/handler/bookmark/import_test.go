@@ -0,0 +1,174 @@
+package bookmark
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netscape"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testImportFile = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><H3>Work</H3>
+    <DL><p>
+        <DT><A HREF="https://example.com/1" TAGS="go">Bookmark 1</A>
+    </DL><p>
+</DL><p>
+`
+
+func TestBookmarkHandler_ImportBookmarks(t *testing.T) {
+	t.Parallel()
+
+	expectedItems := []netscape.Bookmark{
+		{URL: "https://example.com/1", Title: "Bookmark 1", Folder: []string{"Work"}, Tags: []string{"go"}},
+	}
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		withFile       bool
+		fileContent    []byte
+		fields         map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name: "success - import with options",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			withFile: true,
+			fields:   map[string]string{"folder_mode": "tag", "duplicates": "overwrite"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ImportBookmarks", ctx, testUserID, expectedItems, bookmark.ImportOptions{
+					FolderMode:    bookmark.FolderModeTag,
+					DuplicateMode: bookmark.DuplicateModeOverwrite,
+				}).Return(&bookmark.ImportResult{Created: 1, Invalid: 2}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"created": float64(1),
+				"updated": float64(0),
+				"skipped": float64(0),
+				"invalid": float64(2),
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			withFile:  true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name: "error - missing file",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			withFile: false,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"File is invalid (required)"},
+			},
+		},
+		{
+			name: "error - invalid duplicates mode",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			withFile: true,
+			fields:   map[string]string{"duplicates": "merge"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Duplicates is invalid (oneof)"},
+			},
+		},
+		{
+			name: "error - file too large",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			withFile:    true,
+			fileContent: bytes.Repeat([]byte("a"), maxImportRequestSize+1),
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody: map[string]any{
+				"message": "File too large",
+			},
+		},
+		{
+			name: "error - service failure",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			withFile: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ImportBookmarks", ctx, testUserID, mock.Anything, mock.Anything).
+					Return(&bookmark.ImportResult{}, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/import").
+				WithJWTClaims(tc.jwtClaims)
+			content := []byte(testImportFile)
+			if tc.fileContent != nil {
+				content = tc.fileContent
+			}
+			if tc.withFile {
+				testCtx.WithMultipartFile("file", "bookmarks.html", content, tc.fields)
+			} else {
+				testCtx.WithMultipartFile("other", "bookmarks.html", content, tc.fields)
+			}
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.ImportBookmarks(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+			if tc.expectedStatus == http.StatusRequestEntityTooLarge {
+				// The upload is refused while reading it, not after storing it whole
+				assert.Nil(t, testCtx.Ctx.Request.MultipartForm)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type acceptRedirectInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// AcceptRedirect replaces the URL of a bookmark with the target of its permanent redirect.
+//
+// @Summary      Accept a redirect
+// @Description  Replace the URL of a bookmark with the final_url found by the last link check,
+// @Description  which is only set when the URL permanently redirects (301 or 308). Only the bookmark owner can accept it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      409  {object}  response.Message "Bookmark has no permanent redirect to accept"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/accept-redirect [post]
+func (h *bookmarkHandler) AcceptRedirect(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[acceptRedirectInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.AcceptRedirect(c, input.ID, uid)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+		if errors.Is(err, bookmark.ErrNoRedirect) {
+			c.JSON(http.StatusConflict, &response.Message{
+				Message: "Bookmark has no permanent redirect to accept",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to accept redirect")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.Header("ETag", etag.FromVersion(res.Version))
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,177 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkHandler_AcceptRedirect(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	const testBookmarkIDRedirect = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name: "success - redirect accepted",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRedirect},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("AcceptRedirect", ctx, testBookmarkIDRedirect, testUserID).
+					Return(&model.Bookmark{
+						Base: model.Base{
+							ID:        testBookmarkIDRedirect,
+							CreatedAt: fixedTime,
+							UpdatedAt: fixedTime,
+						},
+						URL:    "https://example.com/moved",
+						Code:   testQueryBookmarkCode,
+						UserID: testUserID,
+						LinkCheck: model.LinkCheck{
+							StatusCode:    http.StatusOK,
+							LastCheckedAt: &fixedTime,
+						},
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":              testBookmarkIDRedirect,
+				"description":     "",
+				"url":             "https://example.com/moved",
+				"code":            testQueryBookmarkCode,
+				"user_id":         testUserID,
+				"status_code":     float64(http.StatusOK),
+				"last_checked_at": fixedTime.Format(time.RFC3339Nano),
+				"created_at":      fixedTime.Format(time.RFC3339Nano),
+				"updated_at":      fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			uriParams: map[string]string{"id": testBookmarkIDRedirect},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name: "error - invalid UUID",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": "not-a-uuid"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name: "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRedirect},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("AcceptRedirect", ctx, mock.Anything, mock.Anything).
+					Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name: "error - no redirect to accept",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRedirect},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("AcceptRedirect", ctx, mock.Anything, mock.Anything).
+					Return(nil, bookmark.ErrNoRedirect)
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "Bookmark has no permanent redirect to accept",
+			},
+		},
+		{
+			name: "error - service failure",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRedirect},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("AcceptRedirect", ctx, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create test context with JWT claims and URI params
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/:id/accept-redirect").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			// Setup mock service
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			// Create handler with mock service
+			handler := NewHandler(svcMock)
+
+			// Call the handler
+			handler.AcceptRedirect(testCtx.Ctx)
+
+			// Assert response
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
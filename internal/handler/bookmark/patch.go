@@ -0,0 +1,116 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mergepatch"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// patchBookmarkInput is an RFC 7396 JSON Merge Patch document for a bookmark.
+// Members that are absent stay unchanged, members set to null are reset.
+type patchBookmarkInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+	// Description of the bookmark, null clears it
+	Description mergepatch.Field[string] `json:"description" swaggertype:"string" example:"Google" validate:"omitempty,lte=255"`
+	// URL to be shortened, cannot be null or empty
+	URL mergepatch.Field[string] `json:"url" swaggertype:"string" example:"https://www.google.com" validate:"omitempty,url,lte=2048"`
+	// Markdown notes, null clears them
+	Notes mergepatch.Field[string] `json:"notes" swaggertype:"string" example:"Read the *second* half first" validate:"omitempty,lte=10000"`
+}
+
+// PatchBookmark partially updates an existing bookmark for the authenticated user.
+//
+// @Summary      Partially update a bookmark
+// @Description  Apply an RFC 7396 JSON Merge Patch to a bookmark. Only the supplied fields change; a null description or notes clears them.
+// @Description  The owner can update it, and so can the users it was shared with as editors, directly or through a shared tag or folder.
+// @Description  Other users, including viewers of the share, get a 404 as if the bookmark did not exist.
+// @Description  Send the ETag of the bookmark in If-Match to only update it if nobody else modified it in the meantime.
+// @Tags         Bookmark
+// @Accept       application/merge-patch+json
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string              true  "Bookmark ID (UUID)"
+// @Param        request  body      patchBookmarkInput  true  "Fields to change"
+// @Param        If-Match header    string              false "ETag the bookmark must still have"
+// @Success      200      {object}  model.Bookmark
+// @Header       200      {string}  ETag                "Version of the updated bookmark"
+// @Failure      400      {object}  response.Message    "Invalid input"
+// @Failure      401      {object}  response.Message    "Unauthorized"
+// @Failure      404      {object}  response.Message    "Bookmark not found"
+// @Failure      412      {object}  response.Message    "Bookmark has been modified"
+// @Failure      500      {object}  response.Message    "Internal server error"
+// @Router       /v1/bookmarks/{id} [patch]
+func (h *bookmarkHandler) PatchBookmark(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[patchBookmarkInput](c)
+	if err != nil {
+		return
+	}
+
+	// The URL is mandatory on a bookmark, so it can be replaced but never removed.
+	// The omitempty rule skips empty strings, so they are refused here like null.
+	if input.URL.Null || (input.URL.Present && input.URL.Value == "") {
+		c.JSON(http.StatusBadRequest, &response.Message{
+			Message: response.InputErrMessage,
+			Details: []string{"URL is invalid (required)"},
+		})
+		return
+	}
+
+	patch := &model.BookmarkPatch{
+		Description: input.Description.Ptr(),
+		URL:         input.URL.Ptr(),
+		Notes:       input.Notes.Ptr(),
+	}
+
+	// Honor If-Match, so that concurrent edits don't silently overwrite each other
+	version, ok := utils.GetVersionFromRequest(c)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, &response.Message{
+			Message: "Bookmark has been modified",
+		})
+		return
+	}
+
+	res, err := h.svc.PatchBookmark(c, input.ID, uid, patch, version)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+		if errors.Is(err, dbutils.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, &response.Message{
+				Message: "Bookmark has been modified",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to patch bookmark")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.Header("ETag", etag.FromVersion(res.Version))
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,290 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testBookmarkIDPatch = "9b2e4c1a-58cc-4372-a567-0e02b2c3d479"
+
+func TestBookmarkHandler_PatchBookmark(t *testing.T) {
+	t.Parallel()
+
+	patchedDescription := "Patched Description"
+	emptyDescription := ""
+	patchedNotes := "Read the *second* half first"
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		inputBody      any
+		ifMatch        string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+		expectedETag   string
+	}{
+		{
+			name: "success - patch description only",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"description": patchedDescription},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, testBookmarkIDPatch, testUserID,
+					&model.BookmarkPatch{Description: &patchedDescription}, 0,
+				).Return(&model.Bookmark{
+					Base:        model.Base{ID: testBookmarkIDPatch},
+					Description: patchedDescription,
+					URL:         "https://example.com",
+					Code:        "abc",
+					UserID:      testUserID,
+					Version:     4,
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name: "success - patch with If-Match",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"description": patchedDescription},
+			ifMatch:   `"3"`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, testBookmarkIDPatch, testUserID,
+					&model.BookmarkPatch{Description: &patchedDescription}, 3,
+				).Return(&model.Bookmark{Base: model.Base{ID: testBookmarkIDPatch}, Version: 4}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name: "error - bookmark has been modified",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"description": patchedDescription},
+			ifMatch:   `"3"`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, mock.Anything, mock.Anything, mock.Anything, 3).
+					Return(nil, dbutils.ErrVersionMismatch)
+				return svcMock
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: map[string]any{
+				"message": "Bookmark has been modified",
+			},
+		},
+		{
+			name: "success - null description clears it",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"description": nil},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, testBookmarkIDPatch, testUserID,
+					&model.BookmarkPatch{Description: &emptyDescription}, 0,
+				).Return(&model.Bookmark{Base: model.Base{ID: testBookmarkIDPatch}}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "success - patch notes",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"notes": patchedNotes},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, testBookmarkIDPatch, testUserID,
+					&model.BookmarkPatch{Notes: &patchedNotes}, 0,
+				).Return(&model.Bookmark{Base: model.Base{ID: testBookmarkIDPatch}, Notes: patchedNotes}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "success - empty patch",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, testBookmarkIDPatch, testUserID, &model.BookmarkPatch{}, 0).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkIDPatch}}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"description": patchedDescription},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name: "error - null URL",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"url": nil},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"URL is invalid (required)"},
+			},
+		},
+		{
+			name: "error - empty URL",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"url": ""},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"URL is invalid (required)"},
+			},
+		},
+		{
+			name: "error - invalid URL",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"url": "not-a-url"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"URL is invalid (url)"},
+			},
+		},
+		{
+			name: "error - invalid UUID",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": "not-a-uuid"},
+			inputBody: map[string]any{"description": patchedDescription},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name: "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"description": patchedDescription},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name: "error - service failure",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDPatch},
+			inputBody: map[string]any{"description": patchedDescription},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("PatchBookmark", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("service error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create test context with JWT claims, URI params, and JSON body
+			testCtx := handlertest.NewTestContext(http.MethodPatch, "/v1/bookmarks/:id").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams).
+				WithJSONBody(tc.inputBody).
+				WithHeader("Content-Type", "application/merge-patch+json").
+				WithHeader("If-Match", tc.ifMatch)
+
+			// Setup mock service
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			// Create handler with mock service
+			handler := NewHandler(svcMock)
+
+			// Call the handler
+			handler.PatchBookmark(testCtx.Ctx)
+
+			// Assert response
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+			if tc.expectedETag != "" {
+				assert.Equal(t, tc.expectedETag, testCtx.Recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package bookmark
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -13,21 +16,48 @@ import (
 
 // listBookmarksResponse is a helper struct for Swagger documentation
 type listBookmarksResponse struct {
-	Data     []*model.Bookmark    `json:"data"`
+	Data     []*model.Bookmark   `json:"data"`
 	Metadata pagination.Metadata `json:"metadata"`
 }
 
+type listBookmarksInput struct {
+	pagination.Request
+	// Status selects bookmarks by the outcome of their last link check
+	Status string `form:"status" validate:"omitempty,oneof=broken ok redirected unchecked"`
+	// Favorite selects favorites (true) or the other bookmarks (false)
+	Favorite *bool `form:"favorite"`
+	// Pinned selects pinned (true) or unpinned (false) bookmarks
+	Pinned *bool `form:"pinned"`
+	// Archived lists archived bookmarks (true) instead of the active ones
+	Archived *bool `form:"archived"`
+	// Unread selects unread (true) or read (false) bookmarks
+	Unread *bool `form:"unread"`
+}
+
 // GetBookmarks returns a paginated list of bookmarks.
 // @Summary      List bookmarks
-// @Description  Get a paginated list of bookmarks for the authenticated user
+// @Description  Get a paginated list of bookmarks for the authenticated user.
+// @Description  Links are checked periodically; status narrows the list down by the outcome of the last check:
+// @Description  broken (unreachable or error status), ok, redirected (permanently moved) or unchecked.
+// @Description  Pinned bookmarks are listed first. Archived bookmarks are only listed with archived=true.
+// @Description  The response carries an ETag; send it back in If-None-Match to get 304 Not Modified while the page is unchanged.
 // @Tags         Bookmark
 // @Produce      json
 // @Security     BearerAuth
-// @Param        page   query     int  false  "Page number (default 1)"
-// @Param        limit  query     int  false  "Items per page (default 10)"
-// @Success      200    {object}  listBookmarksResponse
-// @Failure      401    {object}  response.Message "Unauthorized"
-// @Failure      500    {object}  response.Message "Internal server error"
+// @Param        page      query     int     false  "Page number (default 1)"
+// @Param        limit     query     int     false  "Items per page (default 10)"
+// @Param        status    query     string  false  "Link status" Enums(broken, ok, redirected, unchecked)
+// @Param        favorite  query     bool    false  "Only favorites (true) or only non-favorites (false)"
+// @Param        pinned    query     bool    false  "Only pinned (true) or only unpinned (false) bookmarks"
+// @Param        archived  query     bool    false  "List archived bookmarks instead of active ones"
+// @Param        unread    query     bool    false  "Only unread (true) or only read (false) bookmarks"
+// @Param        If-None-Match  header  string  false  "ETag of a previously fetched page"
+// @Success      200       {object}  listBookmarksResponse
+// @Header       200       {string}  ETag     "Tag of the returned page"
+// @Success      304       "Page not modified"
+// @Failure      400       {object}  response.Message "Invalid input"
+// @Failure      401       {object}  response.Message "Unauthorized"
+// @Failure      500       {object}  response.Message "Internal server error"
 // @Router       /v1/bookmarks [get]
 func (h *bookmarkHandler) GetBookmarks(c *gin.Context) {
 	// Get user id from JWT token
@@ -39,20 +69,85 @@ func (h *bookmarkHandler) GetBookmarks(c *gin.Context) {
 		return
 	}
 
-	input, err := utils.BindInputFromRequest[pagination.Request](c)
+	input, err := utils.BindInputFromRequest[listBookmarksInput](c)
 	if err != nil {
 		return
 	}
 
-	res, err := h.svc.GetBookmarks(c, uid, input)
+	filter := &model.BookmarkFilter{
+		LinkStatus: model.LinkStatus(input.Status),
+		Favorite:   input.Favorite,
+		Pinned:     input.Pinned,
+		Archived:   input.Archived,
+		Unread:     input.Unread,
+	}
+	res, err := h.svc.GetBookmarks(c, uid, filter, &input.Request)
 	if err != nil {
 		log.Error().Err(err).Str("uid", uid).Msg("Failed to list bookmarks")
 		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
 		return
 	}
 
-	c.JSON(http.StatusOK, listBookmarksResponse{
+	utils.JSONWithContentETag(c, listBookmarksResponse{
 		Data:     res.Data,
 		Metadata: res.Metadata,
 	})
 }
+
+type getBookmarkInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// GetBookmark returns a single bookmark owned by the authenticated user.
+//
+// @Summary      Get a bookmark
+// @Description  Get a single bookmark by its ID. The owner can read it, and so can the users it was shared with,
+// @Description  directly or through a shared tag or folder. Other users get a 404 as if the bookmark did not exist.
+// @Description  The ETag of the response identifies the version of the bookmark. Send it in If-Match on updates
+// @Description  to detect concurrent edits, or in If-None-Match to get 304 Not Modified while it is unchanged.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id             path      string  true   "Bookmark ID (UUID)"
+// @Param        If-None-Match  header    string  false  "ETag of a previously fetched version"
+// @Success      200  {object}  model.Bookmark
+// @Header       200  {string}  ETag "Version of the bookmark"
+// @Success      304  "Bookmark not modified"
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id} [get]
+func (h *bookmarkHandler) GetBookmark(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[getBookmarkInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.GetBookmarkByID(c, input.ID, uid)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to get bookmark")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	utils.JSONWithETag(c, etag.FromVersion(res.Version), res)
+}
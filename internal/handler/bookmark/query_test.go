@@ -10,10 +10,12 @@ import (
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
 	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -49,6 +51,7 @@ func TestBookmarkHandler_GetBookmarks(t *testing.T) {
 				svcMock.On("GetBookmarks",
 					mock.Anything,
 					testUserID,
+					&model.BookmarkFilter{},
 					mock.MatchedBy(func(req *pagination.Request) bool {
 						return req.Page == 0 && req.Limit == 0 // Defaults before validation/sanitization in service/repo layer
 					}),
@@ -109,6 +112,7 @@ func TestBookmarkHandler_GetBookmarks(t *testing.T) {
 				svcMock.On("GetBookmarks",
 					mock.Anything,
 					testUserID,
+					&model.BookmarkFilter{},
 					&pagination.Request{Page: 2, Limit: 5},
 				).Return(&pagination.Response[*model.Bookmark]{
 					Data: []*model.Bookmark{},
@@ -132,6 +136,161 @@ func TestBookmarkHandler_GetBookmarks(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "success - filter broken links",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			queryParams: "?status=broken",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetBookmarks",
+					mock.Anything,
+					testUserID,
+					&model.BookmarkFilter{LinkStatus: model.LinkStatusBroken},
+					&pagination.Request{},
+				).Return(&pagination.Response[*model.Bookmark]{
+					Data: []*model.Bookmark{
+						{
+							Base: model.Base{
+								ID:        "bm-1",
+								CreatedAt: fixedTime,
+								UpdatedAt: fixedTime,
+							},
+							URL:    testQueryBookmarkURL,
+							Code:   testQueryBookmarkCode,
+							UserID: testUserID,
+							LinkCheck: model.LinkCheck{
+								StatusCode:    http.StatusNotFound,
+								LastCheckedAt: &fixedTime,
+							},
+						},
+					},
+					Metadata: pagination.Metadata{
+						CurrentPage:  1,
+						PageSize:     10,
+						TotalRecords: 1,
+						FirstPage:    1,
+						LastPage:     1,
+					},
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"id":              "bm-1",
+						"description":     "",
+						"url":             testQueryBookmarkURL,
+						"code":            testQueryBookmarkCode,
+						"user_id":         testUserID,
+						"status_code":     float64(http.StatusNotFound),
+						"last_checked_at": fixedTime.Format(time.RFC3339Nano),
+						"created_at":      fixedTime.Format(time.RFC3339Nano),
+						"updated_at":      fixedTime.Format(time.RFC3339Nano),
+					},
+				},
+				"metadata": map[string]any{
+					"current_page":  float64(1),
+					"page_size":     float64(10),
+					"total_records": float64(1),
+					"first_page":    float64(1),
+					"last_page":     float64(1),
+				},
+			},
+		},
+		{
+			name: "success - filter pinned favorites in the archive",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			queryParams: "?favorite=true&pinned=false&archived=true",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				yes, no := true, false
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetBookmarks",
+					mock.Anything,
+					testUserID,
+					&model.BookmarkFilter{Favorite: &yes, Pinned: &no, Archived: &yes},
+					mock.Anything,
+				).Return(&pagination.Response[*model.Bookmark]{
+					Data: []*model.Bookmark{},
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{},
+				"metadata": map[string]any{
+					"current_page":  float64(0),
+					"page_size":     float64(0),
+					"total_records": float64(0),
+					"first_page":    float64(0),
+					"last_page":     float64(0),
+				},
+			},
+		},
+		{
+			name: "success - filter unread",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			queryParams: "?unread=true",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				yes := true
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetBookmarks",
+					mock.Anything,
+					testUserID,
+					&model.BookmarkFilter{Unread: &yes},
+					mock.Anything,
+				).Return(&pagination.Response[*model.Bookmark]{
+					Data: []*model.Bookmark{},
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{},
+				"metadata": map[string]any{
+					"current_page":  float64(0),
+					"page_size":     float64(0),
+					"total_records": float64(0),
+					"first_page":    float64(0),
+					"last_page":     float64(0),
+				},
+			},
+		},
+		{
+			name: "error - invalid favorite flag",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			queryParams: "?favorite=maybe",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+			},
+		},
+		{
+			name: "error - invalid status",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			queryParams: "?status=dead",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Status is invalid (oneof)"},
+			},
+		},
 		{
 			name:      "error - missing JWT claims",
 			jwtClaims: nil,
@@ -155,6 +314,7 @@ func TestBookmarkHandler_GetBookmarks(t *testing.T) {
 					mock.Anything,
 					testUserID,
 					mock.Anything,
+					mock.Anything,
 				).Return(nil, errors.New("db error"))
 				return svcMock
 			},
@@ -189,6 +349,168 @@ func TestBookmarkHandler_GetBookmarks(t *testing.T) {
 
 			// Assert response
 			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+			if tc.expectedStatus == http.StatusOK {
+				assert.NotEmpty(t, testCtx.Recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestBookmarkHandler_GetBookmark(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	const testBookmarkIDGet = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		ifNoneMatch    string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+		expectedETag   string
+	}{
+		{
+			name: "success - get bookmark",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDGet},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetBookmarkByID", ctx, testBookmarkIDGet, testUserID).
+					Return(&model.Bookmark{
+						Base: model.Base{
+							ID:        testBookmarkIDGet,
+							CreatedAt: fixedTime,
+							UpdatedAt: fixedTime,
+						},
+						Description: testQueryBookmarkDesc,
+						URL:         testQueryBookmarkURL,
+						Code:        testQueryBookmarkCode,
+						UserID:      testUserID,
+						Version:     3,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+			expectedBody: map[string]any{
+				"id":          testBookmarkIDGet,
+				"description": testQueryBookmarkDesc,
+				"url":         testQueryBookmarkURL,
+				"code":        testQueryBookmarkCode,
+				"user_id":     testUserID,
+				"created_at":  fixedTime.Format(time.RFC3339Nano),
+				"updated_at":  fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name: "success - not modified",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams:   map[string]string{"id": testBookmarkIDGet},
+			ifNoneMatch: `W/"2", "3"`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetBookmarkByID", ctx, testBookmarkIDGet, testUserID).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkIDGet}, Version: 3}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			uriParams: map[string]string{"id": testBookmarkIDGet},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name: "error - invalid UUID",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": "not-a-uuid"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name: "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDGet},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetBookmarkByID", ctx, mock.Anything, mock.Anything).
+					Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name: "error - service failure",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDGet},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetBookmarkByID", ctx, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create test context with JWT claims and URI params
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/:id").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams).
+				WithHeader("If-None-Match", tc.ifNoneMatch)
+
+			// Setup mock service
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			// Create handler with mock service
+			handler := NewHandler(svcMock)
+
+			// Call the handler
+			handler.GetBookmark(testCtx.Ctx)
+
+			// Assert response
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+			assert.Equal(t, tc.expectedETag, testCtx.Recorder.Header().Get("ETag"))
+			if tc.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, testCtx.Recorder.Body.String())
+			}
 		})
 	}
 }
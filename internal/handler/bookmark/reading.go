@@ -0,0 +1,146 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type bookmarkIDInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// MarkRead marks a bookmark as read.
+//
+// @Summary      Mark as read
+// @Description  Set read_at on a bookmark, taking it off the reading list. A bookmark that already is
+// @Description  read keeps its original read time. Only the bookmark owner can change it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/read [post]
+func (h *bookmarkHandler) MarkRead(c *gin.Context) {
+	h.setReadStatus(c, true)
+}
+
+// MarkUnread puts a bookmark back on the reading list.
+//
+// @Summary      Mark as unread
+// @Description  Clear read_at on a bookmark, so that it is listed with unread=true again.
+// @Description  Only the bookmark owner can change it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/unread [post]
+func (h *bookmarkHandler) MarkUnread(c *gin.Context) {
+	h.setReadStatus(c, false)
+}
+
+// setReadStatus marks the bookmark from the URL path as read or unread and responds with the updated bookmark.
+func (h *bookmarkHandler) setReadStatus(c *gin.Context, read bool) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[bookmarkIDInput](c)
+	if err != nil {
+		return
+	}
+
+	var res *model.Bookmark
+	if read {
+		res, err = h.svc.MarkRead(c, input.ID, uid)
+	} else {
+		res, err = h.svc.MarkUnread(c, input.ID, uid)
+	}
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Bool("read", read).Msg("Failed to set bookmark read status")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.Header("ETag", etag.FromVersion(res.Version))
+	c.JSON(http.StatusOK, res)
+}
+
+// GetNotes returns the notes of a bookmark rendered to HTML.
+//
+// @Summary      Get bookmark notes
+// @Description  Get the Markdown notes of a bookmark together with their HTML rendering.
+// @Description  Raw HTML in the notes is dropped and only http(s) and mailto links are rendered,
+// @Description  so the HTML can be embedded in a page as-is. Notes are edited with PATCH /v1/bookmarks/{id}.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  bookmark.RenderedNotes
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/notes [get]
+func (h *bookmarkHandler) GetNotes(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[bookmarkIDInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.RenderNotes(c, input.ID, uid)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to render bookmark notes")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,270 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+const testBookmarkIDReading = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func TestBookmarkHandler_SetReadStatus(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		callHandler    func(h Handler, c *gin.Context)
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - mark read",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			uriParams:   map[string]string{"id": testBookmarkIDReading},
+			callHandler: Handler.MarkRead,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("MarkRead", ctx, testBookmarkIDReading, testUserID).
+					Return(&model.Bookmark{
+						Base:   model.Base{ID: testBookmarkIDReading, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+						URL:    "https://example.com",
+						Code:   "abc1234",
+						UserID: testUserID,
+						ReadAt: &fixedTime,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":          testBookmarkIDReading,
+				"description": "",
+				"url":         "https://example.com",
+				"code":        "abc1234",
+				"user_id":     testUserID,
+				"read_at":     fixedTime.Format(time.RFC3339Nano),
+				"created_at":  fixedTime.Format(time.RFC3339Nano),
+				"updated_at":  fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name:        "success - mark unread",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			uriParams:   map[string]string{"id": testBookmarkIDReading},
+			callHandler: Handler.MarkUnread,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("MarkUnread", ctx, testBookmarkIDReading, testUserID).
+					Return(&model.Bookmark{
+						Base:   model.Base{ID: testBookmarkIDReading, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+						URL:    "https://example.com",
+						Code:   "abc1234",
+						UserID: testUserID,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":          testBookmarkIDReading,
+				"description": "",
+				"url":         "https://example.com",
+				"code":        "abc1234",
+				"user_id":     testUserID,
+				"created_at":  fixedTime.Format(time.RFC3339Nano),
+				"updated_at":  fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name:        "error - missing JWT claims",
+			uriParams:   map[string]string{"id": testBookmarkIDReading},
+			callHandler: Handler.MarkRead,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:        "error - invalid UUID",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			uriParams:   map[string]string{"id": "not-a-valid-uuid"},
+			callHandler: Handler.MarkUnread,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name:        "error - bookmark not found",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			uriParams:   map[string]string{"id": testBookmarkIDReading},
+			callHandler: Handler.MarkRead,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("MarkRead", ctx, mock.Anything, mock.Anything).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:        "error - service failure",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			uriParams:   map[string]string{"id": testBookmarkIDReading},
+			callHandler: Handler.MarkUnread,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("MarkUnread", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/:id/read").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			tc.callHandler(handler, testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestBookmarkHandler_GetNotes(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RenderNotes", ctx, testBookmarkIDReading, testUserID).
+					Return(&bookmark.RenderedNotes{Markdown: "Read **twice**", HTML: "<p>Read <strong>twice</strong></p>"}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"markdown": "Read **twice**",
+				"html":     "<p>Read <strong>twice</strong></p>",
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - invalid UUID",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": "not-a-valid-uuid"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name:      "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RenderNotes", ctx, mock.Anything, mock.Anything).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RenderNotes", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/:id/notes").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetNotes(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// listHistoryResponse is the response body of the history endpoint
+type listHistoryResponse struct {
+	Data     []*model.BookmarkRevision `json:"data"`
+	Metadata pagination.Metadata       `json:"metadata"`
+}
+
+type getHistoryInput struct {
+	pagination.Request
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// GetHistory returns a paginated list of the revisions of a bookmark.
+//
+// @Summary      List bookmark history
+// @Description  Get the revisions of a bookmark, most recent first. Every edit of the description or URL
+// @Description  records the values it replaced, who made the edit (changed_by) and when.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string  true   "Bookmark ID (UUID)"
+// @Param        page   query     int     false  "Page number (default 1)"
+// @Param        limit  query     int     false  "Items per page (default 10)"
+// @Success      200    {object}  listHistoryResponse
+// @Failure      400    {object}  response.Message "Invalid input"
+// @Failure      401    {object}  response.Message "Unauthorized"
+// @Failure      404    {object}  response.Message "Bookmark not found"
+// @Failure      500    {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/history [get]
+func (h *bookmarkHandler) GetHistory(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	input, err := utils.BindInputFromRequest[getHistoryInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.GetHistory(c, input.ID, uid, &input.Request)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to list bookmark history")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, listHistoryResponse{
+		Data:     res.Data,
+		Metadata: res.Metadata,
+	})
+}
+
+type revertBookmarkInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+	// Revision is the revision number from the URL path
+	Revision int `uri:"rev" validate:"required,gte=1"`
+}
+
+// RevertBookmark restores the description and URL a bookmark had before a revision.
+//
+// @Summary      Revert a bookmark
+// @Description  Restore the description and URL recorded by a revision, undoing that edit and all later ones.
+// @Description  The revert is recorded as a new revision, so it can be reverted as well.
+// @Description  Only the bookmark owner and editors it was shared with can revert it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Param        rev  path      int              true  "Revision number"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark or revision not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/revert/{rev} [post]
+func (h *bookmarkHandler) RevertBookmark(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[revertBookmarkInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.RevertBookmark(c, input.ID, uid, input.Revision)
+	if err != nil {
+		switch {
+		case errors.Is(err, dbutils.ErrNotFoundType):
+			c.JSON(http.StatusNotFound, &response.Message{Message: "Bookmark not found"})
+		case errors.Is(err, bookmark.ErrRevisionNotFound):
+			c.JSON(http.StatusNotFound, &response.Message{Message: "Revision not found"})
+		default:
+			log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Int("revision", input.Revision).Msg("Failed to revert bookmark")
+			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		}
+		return
+	}
+
+	c.Header("ETag", etag.FromVersion(res.Version))
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,280 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+)
+
+const testBookmarkIDRevision = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func TestBookmarkHandler_GetHistory(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetHistory", ctx, testBookmarkIDRevision, testUserID, mock.Anything).
+					Return(&pagination.Response[*model.BookmarkRevision]{
+						Data: []*model.BookmarkRevision{
+							{
+								BookmarkID:  testBookmarkIDRevision,
+								Revision:    1,
+								Description: "Old",
+								URL:         "https://example.com/old",
+								ChangedBy:   testUserID,
+								CreatedAt:   fixedTime,
+							},
+						},
+						Metadata: pagination.CalculateMetadata(1, 1, 10),
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"bookmark_id": testBookmarkIDRevision,
+						"revision":    float64(1),
+						"description": "Old",
+						"url":         "https://example.com/old",
+						"changed_by":  testUserID,
+						"created_at":  fixedTime.Format(time.RFC3339Nano),
+					},
+				},
+				"metadata": map[string]any{
+					"total_records": float64(1),
+					"current_page":  float64(1),
+					"page_size":     float64(10),
+					"first_page":    float64(1),
+					"last_page":     float64(1),
+				},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			uriParams: map[string]string{"id": testBookmarkIDRevision},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - invalid UUID",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": "not-a-valid-uuid"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name:      "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetHistory", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetHistory", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/:id/history").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetHistory(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestBookmarkHandler_RevertBookmark(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision, "rev": "2"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RevertBookmark", ctx, testBookmarkIDRevision, testUserID, 2).
+					Return(&model.Bookmark{
+						Base:        model.Base{ID: testBookmarkIDRevision, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+						Description: "Old",
+						URL:         "https://example.com/old",
+						Code:        "abc1234",
+						UserID:      testUserID,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":          testBookmarkIDRevision,
+				"description": "Old",
+				"url":         "https://example.com/old",
+				"code":        "abc1234",
+				"user_id":     testUserID,
+				"created_at":  fixedTime.Format(time.RFC3339Nano),
+				"updated_at":  fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			uriParams: map[string]string{"id": testBookmarkIDRevision, "rev": "2"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - invalid revision",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision, "rev": "0"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Revision is invalid (required)"},
+			},
+		},
+		{
+			name:      "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision, "rev": "2"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RevertBookmark", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:      "error - revision not found",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision, "rev": "2"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RevertBookmark", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, bookmark.ErrRevisionNotFound)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Revision not found",
+			},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDRevision, "rev": "2"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RevertBookmark", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/:id/revert/:rev").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.RevertBookmark(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
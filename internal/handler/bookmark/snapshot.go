@@ -0,0 +1,164 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// snapshotContentSecurityPolicy is sent with snapshots so that browsers treat them as untrusted:
+// the sandbox gives the page an opaque origin and forbids scripts, forms and popups, while
+// stylesheets, images and fonts may still load from the original site.
+const snapshotContentSecurityPolicy = "sandbox; default-src 'none'; style-src 'unsafe-inline' http: https:; " +
+	"img-src http: https: data:; font-src http: https: data:"
+
+// RequestSnapshot queues the capture of the page a bookmark points to.
+//
+// @Summary      Request a snapshot
+// @Description  Archive the page a bookmark points to, as HTML with its stylesheets inlined. The page is
+// @Description  captured in the background: the snapshot is pending until GET /v1/bookmarks/{id}/snapshot
+// @Description  serves it. A previous snapshot keeps being served until the new capture succeeds.
+// @Description  Only the bookmark owner can request a snapshot, within their storage quota.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      202  {object}  model.BookmarkSnapshot
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      403  {object}  response.Message "Storage quota exceeded"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Failure      503  {object}  response.Message "Snapshots disabled or queue full"
+// @Router       /v1/bookmarks/{id}/snapshot [post]
+func (h *bookmarkHandler) RequestSnapshot(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[bookmarkIDInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.RequestSnapshot(c, input.ID, uid)
+	if err != nil {
+		switch {
+		case errors.Is(err, dbutils.ErrNotFoundType):
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+		case errors.Is(err, bookmark.ErrSnapshotQuotaExceeded):
+			c.JSON(http.StatusForbidden, &response.Message{
+				Message: "Snapshot storage quota exceeded",
+			})
+		case errors.Is(err, bookmark.ErrSnapshotsDisabled):
+			c.JSON(http.StatusServiceUnavailable, &response.Message{
+				Message: "Snapshots are disabled",
+			})
+		case errors.Is(err, bookmark.ErrSnapshotQueueFull):
+			c.JSON(http.StatusServiceUnavailable, &response.Message{
+				Message: "Too many snapshots pending, try again later",
+			})
+		default:
+			log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to request snapshot")
+			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, res)
+}
+
+// GetSnapshot serves the archived copy of the page a bookmark points to.
+//
+// @Summary      Get the snapshot
+// @Description  Serve the latest successful snapshot of a bookmark as HTML. The document is sent with a
+// @Description  sandboxing Content-Security-Policy, so it can be opened directly in a browser.
+// @Description  While the first capture is pending the snapshot state is returned with status 202.
+// @Description  Anyone who may read the bookmark may read its snapshot.
+// @Tags         Bookmark
+// @Produce      html
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {file}    file             "Snapshot HTML"
+// @Success      202  {object}  model.BookmarkSnapshot "Capture pending"
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark or snapshot not found, or capture failed"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Failure      503  {object}  response.Message "Snapshots disabled"
+// @Router       /v1/bookmarks/{id}/snapshot [get]
+func (h *bookmarkHandler) GetSnapshot(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[bookmarkIDInput](c)
+	if err != nil {
+		return
+	}
+
+	snapshot, content, err := h.svc.GetSnapshot(c, input.ID, uid)
+	if err != nil {
+		switch {
+		case errors.Is(err, dbutils.ErrNotFoundType):
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+		case errors.Is(err, bookmark.ErrSnapshotNotFound):
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Snapshot not found",
+			})
+		case errors.Is(err, bookmark.ErrSnapshotsDisabled):
+			c.JSON(http.StatusServiceUnavailable, &response.Message{
+				Message: "Snapshots are disabled",
+			})
+		default:
+			log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to get snapshot")
+			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		}
+		return
+	}
+
+	// No capture succeeded yet: report where the first one stands
+	if content == nil {
+		if snapshot.Status == model.SnapshotFailed {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Snapshot capture failed",
+				Details: snapshot.Error,
+			})
+			return
+		}
+		c.JSON(http.StatusAccepted, snapshot)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, snapshot.Size, "text/html; charset=utf-8", content, map[string]string{
+		"Content-Security-Policy": snapshotContentSecurityPolicy,
+		"X-Content-Type-Options":  "nosniff",
+		"Referrer-Policy":         "no-referrer",
+		"Last-Modified":           snapshot.CapturedAt.UTC().Format(http.TimeFormat),
+	})
+}
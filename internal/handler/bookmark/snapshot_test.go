@@ -0,0 +1,347 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkHandler_RequestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RequestSnapshot", ctx, testBookmarkIDReading, testUserID).
+					Return(&model.BookmarkSnapshot{
+						BookmarkID: testBookmarkIDReading,
+						UserID:     testUserID,
+						Status:     model.SnapshotPending,
+						CreatedAt:  fixedTime,
+						UpdatedAt:  fixedTime,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]any{
+				"bookmark_id": testBookmarkIDReading,
+				"status":      "pending",
+				"size":        float64(0),
+				"created_at":  fixedTime.Format(time.RFC3339Nano),
+				"updated_at":  fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - invalid UUID",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": "not-a-valid-uuid"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name:      "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RequestSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:      "error - quota exceeded",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RequestSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, bookmark.ErrSnapshotQuotaExceeded)
+				return svcMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "Snapshot storage quota exceeded",
+			},
+		},
+		{
+			name:      "error - snapshots disabled",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RequestSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, bookmark.ErrSnapshotsDisabled)
+				return svcMock
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: map[string]any{
+				"message": "Snapshots are disabled",
+			},
+		},
+		{
+			name:      "error - queue full",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RequestSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, bookmark.ErrSnapshotQueueFull)
+				return svcMock
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: map[string]any{
+				"message": "Too many snapshots pending, try again later",
+			},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RequestSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/:id/snapshot").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.RequestSnapshot(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestBookmarkHandler_GetSnapshot(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshotHTML := "<html><body>Archived</body></html>"
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+		expectedHTML   string
+	}{
+		{
+			name:      "success - stored copy",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSnapshot", ctx, testBookmarkIDReading, testUserID).
+					Return(&model.BookmarkSnapshot{
+						BookmarkID: testBookmarkIDReading,
+						Status:     model.SnapshotPending,
+						Size:       int64(len(snapshotHTML)),
+						CapturedAt: &fixedTime,
+					}, io.NopCloser(strings.NewReader(snapshotHTML)), nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedHTML:   snapshotHTML,
+		},
+		{
+			name:      "success - first capture pending",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSnapshot", ctx, testBookmarkIDReading, testUserID).
+					Return(&model.BookmarkSnapshot{
+						BookmarkID: testBookmarkIDReading,
+						Status:     model.SnapshotPending,
+						CreatedAt:  fixedTime,
+						UpdatedAt:  fixedTime,
+					}, nil, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]any{
+				"bookmark_id": testBookmarkIDReading,
+				"status":      "pending",
+				"size":        float64(0),
+				"created_at":  fixedTime.Format(time.RFC3339Nano),
+				"updated_at":  fixedTime.Format(time.RFC3339Nano),
+			},
+		},
+		{
+			name:      "error - first capture failed",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSnapshot", ctx, testBookmarkIDReading, testUserID).
+					Return(&model.BookmarkSnapshot{Status: model.SnapshotFailed, Error: "snapshot: page is too large"}, nil, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Snapshot capture failed",
+				"details": "snapshot: page is too large",
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - bookmark not found",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:      "error - snapshot never requested",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, nil, bookmark.ErrSnapshotNotFound)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Snapshot not found",
+			},
+		},
+		{
+			name:      "error - snapshots disabled",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, nil, bookmark.ErrSnapshotsDisabled)
+				return svcMock
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: map[string]any{
+				"message": "Snapshots are disabled",
+			},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			uriParams: map[string]string{"id": testBookmarkIDReading},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSnapshot", ctx, mock.Anything, mock.Anything).Return(nil, nil, errors.New("disk error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/:id/snapshot").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetSnapshot(testCtx.Ctx)
+
+			if tc.expectedHTML == "" {
+				handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+				return
+			}
+			handlertest.AssertStatusCode(t, testCtx.Recorder, tc.expectedStatus)
+			assert.Equal(t, tc.expectedHTML, testCtx.Recorder.Body.String())
+			assert.Equal(t, "text/html; charset=utf-8", testCtx.Recorder.Header().Get("Content-Type"))
+			assert.Equal(t, snapshotContentSecurityPolicy, testCtx.Recorder.Header().Get("Content-Security-Policy"))
+			assert.Equal(t, "nosniff", testCtx.Recorder.Header().Get("X-Content-Type-Options"))
+			assert.Equal(t, fixedTime.Format(http.TimeFormat), testCtx.Recorder.Header().Get("Last-Modified"))
+		})
+	}
+}
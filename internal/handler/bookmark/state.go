@@ -0,0 +1,110 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type toggleStateInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// ToggleFavorite marks a bookmark as a favorite, or unmarks it if it already is one.
+//
+// @Summary      Toggle favorite
+// @Description  Flip the is_favorite state of a bookmark. Only the bookmark owner can change it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/favorite [post]
+func (h *bookmarkHandler) ToggleFavorite(c *gin.Context) {
+	h.toggleState(c, model.BookmarkStateFavorite)
+}
+
+// TogglePinned pins a bookmark at the top of the list, or unpins it if it already is pinned.
+//
+// @Summary      Toggle pinned
+// @Description  Flip the pinned state of a bookmark. Pinned bookmarks are listed before all others.
+// @Description  Only the bookmark owner can change it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/pin [post]
+func (h *bookmarkHandler) TogglePinned(c *gin.Context) {
+	h.toggleState(c, model.BookmarkStatePinned)
+}
+
+// ToggleArchived archives a bookmark, or brings it back if it already is archived.
+//
+// @Summary      Toggle archived
+// @Description  Flip the archived state of a bookmark. Archived bookmarks are hidden from
+// @Description  GET /v1/bookmarks unless archived=true is given. Only the bookmark owner can change it.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  model.Bookmark
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Bookmark not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/archive [post]
+func (h *bookmarkHandler) ToggleArchived(c *gin.Context) {
+	h.toggleState(c, model.BookmarkStateArchived)
+}
+
+// toggleState flips state on the bookmark from the URL path and responds with the updated bookmark.
+func (h *bookmarkHandler) toggleState(c *gin.Context, state model.BookmarkState) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[toggleStateInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.ToggleState(c, input.ID, uid, state)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Str("state", string(state)).Msg("Failed to toggle bookmark state")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.Header("ETag", etag.FromVersion(res.Version))
+	c.JSON(http.StatusOK, res)
+}
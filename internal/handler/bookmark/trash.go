@@ -0,0 +1,135 @@
+package bookmark
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// trashedBookmark is a bookmark in the trash.
+// It extends model.Bookmark with the deletion time, which is hidden on active bookmarks.
+type trashedBookmark struct {
+	*model.Bookmark
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// listTrashResponse is the response body of the trash listing endpoint
+type listTrashResponse struct {
+	Data     []*trashedBookmark  `json:"data"`
+	Metadata pagination.Metadata `json:"metadata"`
+}
+
+// GetTrash returns a paginated list of trashed bookmarks.
+// @Summary      List trashed bookmarks
+// @Description  Get a paginated list of soft-deleted bookmarks for the authenticated user, most recently deleted first.
+// @Description  Trashed bookmarks are removed permanently once the retention period has passed.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Page number (default 1)"
+// @Param        limit  query     int  false  "Items per page (default 10)"
+// @Success      200    {object}  listTrashResponse
+// @Failure      401    {object}  response.Message "Unauthorized"
+// @Failure      500    {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/trash [get]
+func (h *bookmarkHandler) GetTrash(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	input, err := utils.BindInputFromRequest[pagination.Request](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.GetTrash(c, uid, input)
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to list trashed bookmarks")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	data := make([]*trashedBookmark, 0, len(res.Data))
+	for _, b := range res.Data {
+		data = append(data, &trashedBookmark{Bookmark: b, DeletedAt: b.DeletedAt.Time})
+	}
+
+	c.JSON(http.StatusOK, listTrashResponse{
+		Data:     data,
+		Metadata: res.Metadata,
+	})
+}
+
+type restoreBookmarkInput struct {
+	// ID is the bookmark identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// RestoreBookmark moves a trashed bookmark back to the authenticated user's bookmarks.
+//
+// @Summary      Restore a bookmark
+// @Description  Restore a soft-deleted bookmark from the trash. Only the bookmark owner can restore it.
+// @Description  Restored bookmarks count towards the bookmark quota again, so restoring is refused once the quota is reached.
+// @Tags         Bookmark
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Bookmark ID (UUID)"
+// @Success      200  {object}  response.Message "Success"
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      403  {object}  response.Message "Bookmark quota exceeded"
+// @Failure      404  {object}  response.Message "Bookmark not found in trash"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/{id}/restore [post]
+func (h *bookmarkHandler) RestoreBookmark(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[restoreBookmarkInput](c)
+	if err != nil {
+		return
+	}
+
+	err = h.svc.RestoreBookmark(c, input.ID, uid)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Bookmark not found in trash",
+			})
+			return
+		}
+		if errors.Is(err, quota.ErrBookmarkQuotaExceeded) {
+			c.JSON(http.StatusForbidden, response.Message{Message: "Bookmark quota exceeded"})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to restore bookmark")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response.Message{
+		Message: "Success",
+	})
+}
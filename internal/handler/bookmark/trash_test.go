@@ -0,0 +1,262 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestBookmarkHandler_GetTrash(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	deletedTime := fixedTime.Add(time.Hour)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name: "success - list trash with deletion time",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetTrash", ctx, testUserID, &pagination.Request{}).
+					Return(&pagination.Response[*model.Bookmark]{
+						Data: []*model.Bookmark{
+							{
+								Base: model.Base{
+									ID:        "bm-1",
+									CreatedAt: fixedTime,
+									UpdatedAt: fixedTime,
+									DeletedAt: gorm.DeletedAt{Time: deletedTime, Valid: true},
+								},
+								Description: testQueryBookmarkDesc,
+								URL:         testQueryBookmarkURL,
+								Code:        testQueryBookmarkCode,
+								UserID:      testUserID,
+							},
+						},
+						Metadata: pagination.Metadata{
+							CurrentPage:  1,
+							PageSize:     10,
+							TotalRecords: 1,
+							FirstPage:    1,
+							LastPage:     1,
+						},
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"id":          "bm-1",
+						"description": testQueryBookmarkDesc,
+						"url":         testQueryBookmarkURL,
+						"code":        testQueryBookmarkCode,
+						"user_id":     testUserID,
+						"created_at":  fixedTime.Format(time.RFC3339Nano),
+						"updated_at":  fixedTime.Format(time.RFC3339Nano),
+						"deleted_at":  deletedTime.Format(time.RFC3339Nano),
+					},
+				},
+				"metadata": map[string]any{
+					"current_page":  float64(1),
+					"page_size":     float64(10),
+					"total_records": float64(1),
+					"first_page":    float64(1),
+					"last_page":     float64(1),
+				},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name: "error - service failure",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetTrash", ctx, testUserID, mock.Anything).
+					Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/trash").
+				WithJWTClaims(tc.jwtClaims)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetTrash(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestBookmarkHandler_RestoreBookmark(t *testing.T) {
+	t.Parallel()
+
+	const testBookmarkIDRestore = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name: "success - restore bookmark",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRestore},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RestoreBookmark", ctx, testBookmarkIDRestore, testUserID).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Success",
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			uriParams: map[string]string{"id": testBookmarkIDRestore},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name: "error - invalid UUID",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": "not-a-uuid"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"ID is invalid (uuid)"},
+			},
+		},
+		{
+			name: "error - bookmark not in trash",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRestore},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RestoreBookmark", ctx, testBookmarkIDRestore, testUserID).
+					Return(dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found in trash",
+			},
+		},
+		{
+			name: "error - bookmark quota exceeded",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRestore},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RestoreBookmark", ctx, testBookmarkIDRestore, testUserID).
+					Return(quota.ErrBookmarkQuotaExceeded)
+				return svcMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "Bookmark quota exceeded",
+			},
+		},
+		{
+			name: "error - service failure",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDRestore},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("RestoreBookmark", ctx, testBookmarkIDRestore, testUserID).
+					Return(errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/bookmarks/:id/restore").
+				WithJWTClaims(tc.jwtClaims).
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.RestoreBookmark(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
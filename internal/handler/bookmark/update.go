@@ -23,17 +23,22 @@ type updateBookmarkInput struct {
 // UpdateBookmark updates an existing bookmark for the authenticated user.
 //
 // @Summary      Update a bookmark
-// @Description  Update an existing bookmark's description and URL. Only the bookmark owner can update it.
+// @Description  Update an existing bookmark's description and URL.
+// @Description  The owner can update it, and so can the users it was shared with as editors, directly or through a shared tag or folder.
+// @Description  Other users, including viewers of the share, get a 404 as if the bookmark did not exist.
+// @Description  Send the ETag of the bookmark in If-Match to only update it if nobody else modified it in the meantime.
 // @Tags         Bookmark
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id       path      string               true  "Bookmark ID (UUID)"
 // @Param        request  body      updateBookmarkInput  true  "Updated bookmark details"
+// @Param        If-Match header    string               false "ETag the bookmark must still have"
 // @Success      200      {object}  response.Message     "Success"
 // @Failure      400      {object}  response.Message     "Invalid input"
 // @Failure      401      {object}  response.Message     "Unauthorized"
 // @Failure      404      {object}  response.Message     "Bookmark not found"
+// @Failure      412      {object}  response.Message     "Bookmark has been modified"
 // @Failure      500      {object}  response.Message     "Internal server error"
 // @Router       /v1/bookmarks/{id} [put]
 func (h *bookmarkHandler) UpdateBookmark(c *gin.Context) {
@@ -52,7 +57,16 @@ func (h *bookmarkHandler) UpdateBookmark(c *gin.Context) {
 		return
 	}
 
-	err = h.svc.UpdateBookmark(c, input.ID, uid, input.Description, input.URL)
+	// Honor If-Match, so that concurrent edits don't silently overwrite each other
+	version, ok := utils.GetVersionFromRequest(c)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, &response.Message{
+			Message: "Bookmark has been modified",
+		})
+		return
+	}
+
+	err = h.svc.UpdateBookmark(c, input.ID, uid, input.Description, input.URL, version)
 	if err != nil {
 		if errors.Is(err, dbutils.ErrNotFoundType) {
 			c.JSON(http.StatusNotFound, &response.Message{
@@ -60,6 +74,12 @@ func (h *bookmarkHandler) UpdateBookmark(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, dbutils.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, &response.Message{
+				Message: "Bookmark has been modified",
+			})
+			return
+		}
 
 		log.Error().Err(err).Str("uid", uid).Str("bookmark_id", input.ID).Msg("Failed to update bookmark")
 		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
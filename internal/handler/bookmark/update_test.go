@@ -28,6 +28,7 @@ func TestBookmarkHandler_UpdateBookmark(t *testing.T) {
 		jwtClaims      jwt.MapClaims
 		uriParams      map[string]string
 		inputBody      any
+		ifMatch        string
 		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
 		expectedStatus int
 		expectedBody   map[string]any
@@ -47,6 +48,7 @@ func TestBookmarkHandler_UpdateBookmark(t *testing.T) {
 					testUserID,
 					"Updated Description",
 					"https://updated.com",
+					0,
 				).Return(nil)
 				return svcMock
 			},
@@ -100,6 +102,72 @@ func TestBookmarkHandler_UpdateBookmark(t *testing.T) {
 				"details": []any{"ID is invalid (uuid)"},
 			},
 		},
+		{
+			name: "success - update bookmark with If-Match",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDUpdate},
+			inputBody: map[string]any{"description": "Updated Description", "url": "https://updated.com"},
+			ifMatch:   `"3"`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("UpdateBookmark",
+					ctx,
+					testBookmarkIDUpdate,
+					testUserID,
+					"Updated Description",
+					"https://updated.com",
+					3,
+				).Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Success",
+			},
+		},
+		{
+			name: "error - bookmark has been modified",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDUpdate},
+			inputBody: map[string]any{"description": "Description", "url": "https://example.com"},
+			ifMatch:   `"3"`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("UpdateBookmark",
+					ctx,
+					mock.Anything,
+					mock.Anything,
+					mock.Anything,
+					mock.Anything,
+					3,
+				).Return(dbutils.ErrVersionMismatch)
+				return svcMock
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: map[string]any{
+				"message": "Bookmark has been modified",
+			},
+		},
+		{
+			name: "error - weak If-Match never matches",
+			jwtClaims: jwt.MapClaims{
+				"sub": testUserID,
+			},
+			uriParams: map[string]string{"id": testBookmarkIDUpdate},
+			inputBody: map[string]any{"description": "Description", "url": "https://example.com"},
+			ifMatch:   `W/"3"`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: map[string]any{
+				"message": "Bookmark has been modified",
+			},
+		},
 		{
 			name: "error - bookmark not found",
 			jwtClaims: jwt.MapClaims{
@@ -115,6 +183,7 @@ func TestBookmarkHandler_UpdateBookmark(t *testing.T) {
 					mock.Anything,
 					mock.Anything,
 					mock.Anything,
+					mock.Anything,
 				).Return(dbutils.ErrNotFoundType)
 				return svcMock
 			},
@@ -138,6 +207,7 @@ func TestBookmarkHandler_UpdateBookmark(t *testing.T) {
 					mock.Anything,
 					mock.Anything,
 					mock.Anything,
+					mock.Anything,
 				).Return(errors.New("service error"))
 				return svcMock
 			},
@@ -156,7 +226,8 @@ func TestBookmarkHandler_UpdateBookmark(t *testing.T) {
 			testCtx := handlertest.NewTestContext(http.MethodPut, "/v1/bookmarks/:id").
 				WithJWTClaims(tc.jwtClaims).
 				WithURIParams(tc.uriParams).
-				WithJSONBody(tc.inputBody)
+				WithJSONBody(tc.inputBody).
+				WithHeader("If-Match", tc.ifMatch)
 
 			// Setup mock service
 			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
@@ -0,0 +1,24 @@
+// Package emailverification provides the HTTP handlers confirming the email address of users.
+package emailverification
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for email verification HTTP handlers.
+type Handler interface {
+	// Verify confirms an email address with a verification token.
+	Verify(c *gin.Context)
+	// ResendVerification emails a new verification link to an unverified address, if any.
+	ResendVerification(c *gin.Context)
+}
+
+type verificationHandler struct {
+	svc emailverification.Service
+}
+
+// NewHandler creates a new instance of the email verification handler.
+func NewHandler(svc emailverification.Service) Handler {
+	return &verificationHandler{svc: svc}
+}
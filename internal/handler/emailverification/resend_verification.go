@@ -0,0 +1,47 @@
+package emailverification
+
+import (
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// resendVerificationMessage is returned whether or not the address belongs to an unverified account.
+const resendVerificationMessage = "If an unverified account with this email exists, a verification link has been sent"
+
+type resendVerificationReqBody struct {
+	// Email is the current address of the account.
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResendVerification handles requests for a new verification link, e.g. when the first one expired.
+// It is public, since users may not be able to log in before verifying their address, and its
+// response does not tell whether an account uses the address.
+//
+// @Summary Resend verification email
+// @Description Email a new verification link to the account with the given address if it is not verified yet. The response does not tell whether such an account exists
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body resendVerificationReqBody true "Email address of the account"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/users/verify/resend [post]
+func (h *verificationHandler) ResendVerification(c *gin.Context) {
+	inputBody, err := utils.BindInputFromRequest[resendVerificationReqBody](c)
+	if err != nil {
+		return
+	}
+
+	if err := h.svc.ResendVerification(c, inputBody.Email); err != nil {
+		log.Error().Err(err).Msg("ResendVerification err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: resendVerificationMessage})
+}
@@ -0,0 +1,81 @@
+package emailverification
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationHandler_ResendVerification(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		requestBody    map[string]any
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - verification requested",
+			requestBody: map[string]any{"email": "john@example.com"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("ResendVerification", ctx, "john@example.com").Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": resendVerificationMessage,
+			},
+		},
+		{
+			name:        "error - invalid email",
+			requestBody: map[string]any{"email": "not-an-email"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Email is invalid (email)"},
+			},
+		},
+		{
+			name:        "error - internal server error",
+			requestBody: map[string]any{"email": "john@example.com"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("ResendVerification", ctx, "john@example.com").Return(assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/users/verify/resend").
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).ResendVerification(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package emailverification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type verifyInput struct {
+	// Token is the verification token received by email.
+	Token string `form:"token" validate:"required"`
+}
+
+// Verify handles the link emailed to confirm an address.
+// The verification token is consumed; a confirmed new address replaces the current one.
+//
+// @Summary Verify email address
+// @Description Confirm the email address a verification link was sent to, at registration or after an email change. The token can be used once
+// @Tags User
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input or invalid verification token"
+// @Failure 409 {object} response.Message "Email already in use"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/users/verify [get]
+func (h *verificationHandler) Verify(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[verifyInput](c)
+	if err != nil {
+		return
+	}
+
+	err = h.svc.Verify(c, input.Token)
+	switch {
+	case errors.Is(err, emailverification.ErrInvalidVerificationToken):
+		c.JSON(http.StatusBadRequest, response.Message{Message: "Invalid or expired verification token"})
+		return
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		c.JSON(http.StatusConflict, response.Message{Message: "Email already in use"})
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("Verify err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: "Email address verified"})
+}
@@ -0,0 +1,108 @@
+package emailverification
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationHandler_Verify(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		queryParams    map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - email verified",
+			queryParams: map[string]string{"token": "verification-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("Verify", ctx, "verification-token").Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Email address verified",
+			},
+		},
+		{
+			name: "error - missing token",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Token is invalid (required)"},
+			},
+		},
+		{
+			name:        "error - invalid token",
+			queryParams: map[string]string{"token": "verification-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("Verify", ctx, "verification-token").Return(emailverification.ErrInvalidVerificationToken)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": "Invalid or expired verification token",
+			},
+		},
+		{
+			name:        "error - email already in use",
+			queryParams: map[string]string{"token": "verification-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("Verify", ctx, "verification-token").Return(dbutils.ErrDuplicationType)
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "Email already in use",
+			},
+		},
+		{
+			name:        "error - internal server error",
+			queryParams: map[string]string{"token": "verification-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("Verify", ctx, "verification-token").Return(assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/users/verify").
+				WithQueryParams(tc.queryParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).Verify(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
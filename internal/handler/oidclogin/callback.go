@@ -0,0 +1,103 @@
+package oidclogin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/oidclogin"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// callbackInput holds the parameters the provider redirects the user back with:
+// either an authorization code, or an error when the login did not succeed.
+type callbackInput struct {
+	Code  string `form:"code"`
+	State string `form:"state"`
+	// Error is the OAuth 2.0 error code, e.g. "access_denied" when the user declined.
+	Error string `form:"error"`
+}
+
+// callbackResBody represents the response body containing the issued tokens,
+// the same as after a password login.
+type callbackResBody struct {
+	Message string `json:"message"`
+	// Data is the short-lived JWT access token.
+	Data string `json:"data"`
+	// RefreshToken is the opaque token exchanged for a new pair at /v1/users/token/refresh.
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// Callback handles the return of the user from the identity provider.
+// The authorization code is redeemed, and the user signed in by the provider receives tokens.
+// On first login, the user is linked to the account with the same verified email address,
+// or an account is created.
+//
+// @Summary Complete the login with the identity provider
+// @Description Redeem the authorization code the OpenID Connect provider redirected with, and return an access token and a refresh token
+// @Tags User
+// @Produce json
+// @Param code query string false "Authorization code"
+// @Param state query string false "State of the login"
+// @Param error query string false "Error reported by the provider"
+// @Success 200 {object} callbackResBody
+// @Failure 400 {object} response.Message "Invalid input or invalid login state"
+// @Failure 401 {object} response.Message "Login refused or failed at the identity provider"
+// @Failure 403 {object} response.Message "Account is disabled or email address is not verified"
+// @Failure 409 {object} response.Message "Account linked to another identity"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/auth/oidc/callback [get]
+func (h *loginHandler) Callback(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[callbackInput](c)
+	if err != nil {
+		return
+	}
+	if input.Error != "" {
+		c.JSON(http.StatusUnauthorized, response.Message{Message: "Login was refused by the identity provider"})
+		return
+	}
+	if input.Code == "" || input.State == "" {
+		c.JSON(http.StatusBadRequest, response.Message{Message: "Missing code or state"})
+		return
+	}
+
+	pair, err := h.svc.CompleteLogin(c, input.State, input.Code)
+	switch {
+	case errors.Is(err, oidclogin.ErrInvalidState):
+		c.JSON(http.StatusBadRequest, response.Message{Message: "Invalid or expired login state"})
+		return
+	case errors.Is(err, oidc.ErrTokenExchange), errors.Is(err, oidc.ErrInvalidIDToken):
+		log.Warn().Err(err).Msg("OIDC Callback err - Login failed at the identity provider")
+		c.JSON(http.StatusUnauthorized, response.Message{Message: "Login failed at the identity provider"})
+		return
+	case errors.Is(err, oidclogin.ErrEmailNotVerified):
+		c.JSON(http.StatusForbidden, response.Message{Message: "Email address is not verified by the identity provider"})
+		return
+	case errors.Is(err, oidclogin.ErrAccountDisabled):
+		c.JSON(http.StatusForbidden, response.Message{Message: "Account is disabled"})
+		return
+	case errors.Is(err, oidclogin.ErrAccountLinked):
+		c.JSON(http.StatusConflict, response.Message{Message: "Account is linked to another identity"})
+		return
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		c.JSON(http.StatusConflict, response.Message{Message: "Email already in use"})
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("OIDC Callback err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &callbackResBody{
+		Message:      "Logged in successfully!",
+		Data:         pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+	})
+}
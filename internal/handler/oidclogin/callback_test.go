@@ -0,0 +1,147 @@
+package oidclogin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/oidclogin"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/oidclogin/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginHandler_Callback(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	validQuery := map[string]string{"code": "auth-code", "state": "login-state"}
+
+	// expectComplete returns a mock service completing the login of validQuery with the given result.
+	expectComplete := func(pair *model.TokenPair, err error) func(t *testing.T, ctx context.Context) *mocks.Service {
+		return func(t *testing.T, ctx context.Context) *mocks.Service {
+			svcMock := mocks.NewService(t)
+			svcMock.On("CompleteLogin", ctx, "login-state", "auth-code").Return(pair, err)
+			return svcMock
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		queryParams    map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - logged in",
+			queryParams: validQuery,
+			setupMockSvc: expectComplete(&model.TokenPair{
+				AccessToken: "access-token", RefreshToken: "refresh-token", ExpiresIn: 900,
+			}, nil),
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message":       "Logged in successfully!",
+				"data":          "access-token",
+				"refresh_token": "refresh-token",
+				"expires_in":    float64(900),
+			},
+		},
+		{
+			name:        "error - refused by the provider",
+			queryParams: map[string]string{"error": "access_denied", "state": "login-state"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Login was refused by the identity provider",
+			},
+		},
+		{
+			name:        "error - missing code",
+			queryParams: map[string]string{"state": "login-state"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": "Missing code or state",
+			},
+		},
+		{
+			name:           "error - invalid state",
+			queryParams:    validQuery,
+			setupMockSvc:   expectComplete(nil, oidclogin.ErrInvalidState),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": "Invalid or expired login state",
+			},
+		},
+		{
+			name:           "error - invalid ID token",
+			queryParams:    validQuery,
+			setupMockSvc:   expectComplete(nil, oidc.ErrInvalidIDToken),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Login failed at the identity provider",
+			},
+		},
+		{
+			name:           "error - email not verified",
+			queryParams:    validQuery,
+			setupMockSvc:   expectComplete(nil, oidclogin.ErrEmailNotVerified),
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "Email address is not verified by the identity provider",
+			},
+		},
+		{
+			name:           "error - account disabled",
+			queryParams:    validQuery,
+			setupMockSvc:   expectComplete(nil, oidclogin.ErrAccountDisabled),
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "Account is disabled",
+			},
+		},
+		{
+			name:           "error - account linked to another identity",
+			queryParams:    validQuery,
+			setupMockSvc:   expectComplete(nil, oidclogin.ErrAccountLinked),
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"message": "Account is linked to another identity",
+			},
+		},
+		{
+			name:           "error - internal server error",
+			queryParams:    validQuery,
+			setupMockSvc:   expectComplete(nil, assert.AnError),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/auth/oidc/callback").
+				WithQueryParams(tc.queryParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).Callback(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Package oidclogin provides the HTTP handlers of the login through the OpenID Connect provider.
+package oidclogin
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/oidclogin"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for OpenID Connect login HTTP handlers.
+type Handler interface {
+	// Login redirects the user to the identity provider.
+	Login(c *gin.Context)
+	// Callback completes the login when the provider redirects the user back.
+	Callback(c *gin.Context)
+}
+
+type loginHandler struct {
+	svc oidclogin.Service
+}
+
+// NewHandler creates a new instance of the OpenID Connect login handler.
+func NewHandler(svc oidclogin.Service) Handler {
+	return &loginHandler{svc: svc}
+}
@@ -0,0 +1,38 @@
+package oidclogin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// Login handles the start of a login through the identity provider.
+// The user is redirected to the provider, which sends the user back to the callback.
+//
+// @Summary Login with the identity provider
+// @Description Redirect to the OpenID Connect provider of the company to sign in. The provider then redirects to GET /v1/auth/oidc/callback
+// @Tags User
+// @Produce json
+// @Success 302 "Redirect to the identity provider"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 502 {object} response.Message "Identity provider unavailable"
+// @Router /v1/auth/oidc/login [get]
+func (h *loginHandler) Login(c *gin.Context) {
+	authURL, err := h.svc.StartLogin(c)
+	switch {
+	case errors.Is(err, oidc.ErrDiscovery):
+		log.Error().Err(err).Msg("OIDC Login err - Identity provider unavailable")
+		c.JSON(http.StatusBadGateway, response.Message{Message: "Identity provider is unavailable"})
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("OIDC Login err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
@@ -0,0 +1,84 @@
+package oidclogin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/oidclogin/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginHandler_Login(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	authURL := "https://idp.example.com/authorize?state=state"
+
+	testCases := []struct {
+		name             string
+		setupMockSvc     func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus   int
+		expectedLocation string
+		expectedBody     map[string]any
+	}{
+		{
+			name: "success - redirected to the provider",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("StartLogin", ctx).Return(authURL, nil)
+				return svcMock
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: authURL,
+		},
+		{
+			name: "error - provider unavailable",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("StartLogin", ctx).Return("", oidc.ErrDiscovery)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody: map[string]any{
+				"message": "Identity provider is unavailable",
+			},
+		},
+		{
+			name: "error - internal server error",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("StartLogin", ctx).Return("", assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/auth/oidc/login")
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).Login(testCtx.Ctx)
+
+			if tc.expectedBody == nil {
+				handlertest.AssertStatusCode(t, testCtx.Recorder, tc.expectedStatus)
+				assert.Equal(t, tc.expectedLocation, testCtx.Recorder.Header().Get("Location"))
+				return
+			}
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package passwordreset
+
+import (
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// forgotPasswordMessage is returned whether or not the address belongs to an account.
+const forgotPasswordMessage = "If an account with this email exists, a password reset link has been sent"
+
+type forgotPasswordReqBody struct {
+	// Email is the address of the account whose password was forgotten.
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ForgotPassword handles forgotten password requests.
+// The response is the same whether or not an account uses the address, so that the
+// endpoint cannot be used to find out which addresses are registered.
+//
+// @Summary Request a password reset
+// @Description Email a single-use, time-limited password reset link to the account with the given address. The response does not tell whether such an account exists
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body forgotPasswordReqBody true "Email address of the account"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/users/password/forgot [post]
+func (h *resetHandler) ForgotPassword(c *gin.Context) {
+	inputBody, err := utils.BindInputFromRequest[forgotPasswordReqBody](c)
+	if err != nil {
+		return
+	}
+
+	if err := h.svc.RequestReset(c, inputBody.Email); err != nil {
+		log.Error().Err(err).Msg("ForgotPassword err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: forgotPasswordMessage})
+}
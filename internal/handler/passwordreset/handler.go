@@ -0,0 +1,24 @@
+// Package passwordreset provides the HTTP handlers of the forgotten password flow.
+package passwordreset
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/passwordreset"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for password reset HTTP handlers.
+type Handler interface {
+	// ForgotPassword emails a reset link to the owner of an address, if any.
+	ForgotPassword(c *gin.Context)
+	// ResetPassword sets a new password with a reset token.
+	ResetPassword(c *gin.Context)
+}
+
+type resetHandler struct {
+	svc passwordreset.Service
+}
+
+// NewHandler creates a new instance of the password reset handler.
+func NewHandler(svc passwordreset.Service) Handler {
+	return &resetHandler{svc: svc}
+}
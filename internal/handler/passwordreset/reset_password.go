@@ -0,0 +1,52 @@
+package passwordreset
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/passwordreset"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type resetPasswordReqBody struct {
+	// Token is the reset token received by email.
+	Token string `json:"token" validate:"required"`
+	// NewPassword must satisfy the same rules as at registration.
+	NewPassword string `json:"new_password" validate:"required,gte=8,lte=20,password"`
+}
+
+// ResetPassword handles password reset requests.
+// The reset token is consumed, every session of the user is logged out and its personal access tokens are revoked.
+//
+// @Summary Reset password
+// @Description Set a new password with the token sent by POST /v1/users/password/forgot. The token can be used once, every existing session is logged out and personal access tokens are revoked
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param request body resetPasswordReqBody true "Reset token and new password"
+// @Success 200 {object} response.Message
+// @Failure 400 {object} response.Message "Invalid input or invalid reset token"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/users/password/reset [post]
+func (h *resetHandler) ResetPassword(c *gin.Context) {
+	inputBody, err := utils.BindInputFromRequest[resetPasswordReqBody](c)
+	if err != nil {
+		return
+	}
+
+	err = h.svc.ResetPassword(c, inputBody.Token, inputBody.NewPassword)
+	switch {
+	case errors.Is(err, passwordreset.ErrInvalidResetToken):
+		c.JSON(http.StatusBadRequest, response.Message{Message: "Invalid or expired reset token"})
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("ResetPassword err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: "Password has been reset"})
+}
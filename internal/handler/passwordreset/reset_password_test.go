@@ -0,0 +1,97 @@
+package passwordreset
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/passwordreset"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/passwordreset/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetHandler_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	validBody := map[string]any{"token": "reset-token", "new_password": "NewPassword1!"}
+
+	testCases := []struct {
+		name           string
+		requestBody    map[string]any
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - password reset",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("ResetPassword", ctx, "reset-token", "NewPassword1!").Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Password has been reset",
+			},
+		},
+		{
+			name:        "error - weak password",
+			requestBody: map[string]any{"token": "reset-token", "new_password": "weak"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"NewPassword is invalid (gte)"},
+			},
+		},
+		{
+			name:        "error - invalid reset token",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("ResetPassword", ctx, "reset-token", "NewPassword1!").Return(passwordreset.ErrInvalidResetToken)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": "Invalid or expired reset token",
+			},
+		},
+		{
+			name:        "error - internal server error",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("ResetPassword", ctx, "reset-token", "NewPassword1!").Return(assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/users/password/reset").
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).ResetPassword(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package quota
+
+import (
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type quotaResBody struct {
+	Data *model.Quota `json:"data"`
+}
+
+// GetSelfQuota handles the request to get the quotas of the authenticated user.
+// It returns the limits that apply to the user together with what they currently use.
+//
+// @Summary Get user quota
+// @Description Get the limits of the authenticated user on bookmarks, daily short links and snapshot storage, with the current usage. A limit of 0 means unlimited.
+// @Tags User
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} quotaResBody
+// @Failure 401 {object} response.Message "Invalid or missing token"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/self/quota [get]
+func (h *quotaHandler) GetSelfQuota(c *gin.Context) {
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	quota, err := h.svc.GetQuota(c, uid)
+	if err != nil {
+		log.Error().Err(err).Str("userID", uid).Msg("GetSelfQuota err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &quotaResBody{
+		Data: quota,
+	})
+}
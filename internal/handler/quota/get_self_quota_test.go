@@ -0,0 +1,93 @@
+package quota
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaHandler_GetSelfQuota(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	resetAt := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - limits and usage",
+			jwtClaims: jwt.MapClaims{"sub": "test-user-id"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("GetQuota", ctx, "test-user-id").Return(&model.Quota{
+					Limits:            model.QuotaLimits{Bookmarks: 100, ShortLinksPerDay: 10, SnapshotBytes: 0},
+					Usage:             model.QuotaUsage{Bookmarks: 42, ShortLinksToday: 3, SnapshotBytes: 512},
+					ShortLinksResetAt: resetAt,
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": map[string]any{
+					"limits":               map[string]any{"bookmarks": float64(100), "short_links_per_day": float64(10), "snapshot_bytes": float64(0)},
+					"usage":                map[string]any{"bookmarks": float64(42), "short_links_today": float64(3), "snapshot_bytes": float64(512)},
+					"short_links_reset_at": resetAt.Format(time.RFC3339Nano),
+				},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - internal server error",
+			jwtClaims: jwt.MapClaims{"sub": "test-user-id"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("GetQuota", ctx, "test-user-id").Return(nil, assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/self/quota").
+				WithJWTClaims(tc.jwtClaims)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).GetSelfQuota(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package quota
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for quota HTTP handlers.
+type Handler interface {
+	// GetSelfQuota retrieves the limits and current usage of the authenticated user.
+	GetSelfQuota(c *gin.Context)
+}
+
+type quotaHandler struct {
+	svc quota.Service
+}
+
+// NewHandler creates a new instance of the quota handler.
+func NewHandler(svc quota.Service) Handler {
+	return &quotaHandler{svc: svc}
+}
@@ -0,0 +1,134 @@
+package share
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/share"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type createShareInput struct {
+	// What is shared: bookmark, tag or folder
+	TargetType string `json:"target_type" example:"folder" validate:"required,oneof=bookmark tag folder"`
+	// The bookmark ID, tag name or folder path
+	Target string `json:"target" example:"Work/Projects" validate:"required,lte=512"`
+	// Username of the user to share with
+	Username string `json:"username" example:"jane" validate:"required"`
+	// What the user may do with the bookmarks: viewer or editor
+	Permission string `json:"permission" example:"viewer" validate:"required,oneof=viewer editor"`
+}
+
+// CreateShare shares a bookmark, a tag or a folder of the authenticated user with another user.
+//
+// @Summary      Share with a user
+// @Description  Give another user access to a bookmark, or to every bookmark carrying a tag or living in a folder (including subfolders).
+// @Description  Viewers can read the bookmarks, editors can also update them. Bookmarks added to a shared tag or folder later are shared as well.
+// @Tags         Share
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      createShareInput  true  "Share details"
+// @Success      200      {object}  shareResponse
+// @Failure      400      {object}  response.Message "Invalid input"
+// @Failure      401      {object}  response.Message "Unauthorized"
+// @Failure      404      {object}  response.Message "User or bookmark not found"
+// @Failure      409      {object}  response.Message "Already shared with this user"
+// @Failure      500      {object}  response.Message "Internal server error"
+// @Router       /v1/shares [post]
+func (h *shareHandler) CreateShare(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[createShareInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.ShareWithUser(c, uid, model.ShareTargetType(input.TargetType), input.Target,
+		input.Username, model.SharePermission(input.Permission))
+	if err != nil {
+		switch {
+		case errors.Is(err, share.ErrGranteeNotFound):
+			c.JSON(http.StatusNotFound, &response.Message{Message: "User not found"})
+		case errors.Is(err, dbutils.ErrNotFoundType):
+			c.JSON(http.StatusNotFound, &response.Message{Message: "Bookmark not found"})
+		case errors.Is(err, share.ErrShareWithSelf):
+			c.JSON(http.StatusBadRequest, &response.Message{Message: "Cannot share with yourself"})
+		case errors.Is(err, dbutils.ErrDuplicationType):
+			c.JSON(http.StatusConflict, &response.Message{Message: "Already shared with this user"})
+		default:
+			log.Error().Err(err).Str("uid", uid).Msg("Failed to create share")
+			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, newShareResponse(res))
+}
+
+type createPublicLinkInput struct {
+	// What is shared: bookmark, tag or folder
+	TargetType string `json:"target_type" example:"tag" validate:"required,oneof=bookmark tag folder"`
+	// The bookmark ID, tag name or folder path
+	Target string `json:"target" example:"golang" validate:"required,lte=512"`
+}
+
+// CreatePublicLink creates a public read-only link to a bookmark, a tag or a folder of the authenticated user.
+//
+// @Summary      Create a public link
+// @Description  Create a read-only link to a bookmark, a tag or a folder. Anyone holding the returned token
+// @Description  can list the bookmarks at /v1/public/shares/{token} without logging in, until the share is deleted.
+// @Tags         Share
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      createPublicLinkInput  true  "Link details"
+// @Success      200      {object}  shareResponse
+// @Failure      400      {object}  response.Message "Invalid input"
+// @Failure      401      {object}  response.Message "Unauthorized"
+// @Failure      404      {object}  response.Message "Bookmark not found"
+// @Failure      500      {object}  response.Message "Internal server error"
+// @Router       /v1/shares/links [post]
+func (h *shareHandler) CreatePublicLink(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[createPublicLinkInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.CreatePublicLink(c, uid, model.ShareTargetType(input.TargetType), input.Target)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{Message: "Bookmark not found"})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to create public link")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, newShareResponse(res))
+}
@@ -0,0 +1,299 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/share"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/share/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+)
+
+const (
+	testUserID    = "test-user-id"
+	testGranteeID = "test-grantee-id"
+	testShareID   = "550e8400-e29b-41d4-a716-446655440000"
+	testToken     = "AbCdEfGhIjKlMnOpQrStUvWxYz012345"
+)
+
+var testTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestShareHandler_CreateShare(t *testing.T) {
+	t.Parallel()
+
+	validBody := map[string]any{
+		"target_type": "folder",
+		"target":      "Work",
+		"username":    "jane",
+		"permission":  "editor",
+	}
+	granteeID := testGranteeID
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		requestBody    any
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - share folder",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ShareWithUser", ctx, testUserID, model.ShareTargetFolder, "Work", "jane", model.SharePermissionEditor).
+					Return(&model.Share{
+						Base:       model.Base{ID: testShareID, CreatedAt: testTime, UpdatedAt: testTime},
+						OwnerID:    testUserID,
+						TargetType: model.ShareTargetFolder,
+						Target:     "Work",
+						GranteeID:  &granteeID,
+						Grantee:    &model.User{Username: "jane"},
+						Permission: model.SharePermissionEditor,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":               testShareID,
+				"created_at":       "2025-01-01T00:00:00Z",
+				"updated_at":       "2025-01-01T00:00:00Z",
+				"owner_id":         testUserID,
+				"target_type":      "folder",
+				"target":           "Work",
+				"grantee_id":       testGranteeID,
+				"grantee_username": "jane",
+				"permission":       "editor",
+			},
+		},
+		{
+			name:        "error - missing JWT claims",
+			jwtClaims:   nil,
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"message": "Invalid token"},
+		},
+		{
+			name:      "error - invalid permission",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{
+				"target_type": "folder",
+				"target":      "Work",
+				"username":    "jane",
+				"permission":  "owner",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Permission is invalid (oneof)"},
+			},
+		},
+		{
+			name:        "error - grantee not found",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ShareWithUser", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, share.ErrGranteeNotFound)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]any{"message": "User not found"},
+		},
+		{
+			name:        "error - bookmark not found",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ShareWithUser", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]any{"message": "Bookmark not found"},
+		},
+		{
+			name:        "error - share with self",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ShareWithUser", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, share.ErrShareWithSelf)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]any{"message": "Cannot share with yourself"},
+		},
+		{
+			name:        "error - already shared",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ShareWithUser", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, dbutils.ErrDuplicationType)
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   map[string]any{"message": "Already shared with this user"},
+		},
+		{
+			name:        "error - service failure",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("ShareWithUser", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/shares").
+				WithJWTClaims(tc.jwtClaims).
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.CreateShare(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestShareHandler_CreatePublicLink(t *testing.T) {
+	t.Parallel()
+
+	validBody := map[string]any{
+		"target_type": "tag",
+		"target":      "golang",
+	}
+	token := testToken
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		requestBody    any
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - public link to tag",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreatePublicLink", ctx, testUserID, model.ShareTargetTag, "golang").
+					Return(&model.Share{
+						Base:       model.Base{ID: testShareID, CreatedAt: testTime, UpdatedAt: testTime},
+						OwnerID:    testUserID,
+						TargetType: model.ShareTargetTag,
+						Target:     "golang",
+						Permission: model.SharePermissionViewer,
+						Token:      &token,
+					}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":          testShareID,
+				"created_at":  "2025-01-01T00:00:00Z",
+				"updated_at":  "2025-01-01T00:00:00Z",
+				"owner_id":    testUserID,
+				"target_type": "tag",
+				"target":      "golang",
+				"permission":  "viewer",
+				"token":       testToken,
+			},
+		},
+		{
+			name:      "error - invalid target type",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			requestBody: map[string]any{
+				"target_type": "user",
+				"target":      "golang",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"TargetType is invalid (oneof)"},
+			},
+		},
+		{
+			name:        "error - bookmark not found",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreatePublicLink", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]any{"message": "Bookmark not found"},
+		},
+		{
+			name:        "error - service failure",
+			jwtClaims:   jwt.MapClaims{"sub": testUserID},
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("CreatePublicLink", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/shares/links").
+				WithJWTClaims(tc.jwtClaims).
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.CreatePublicLink(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package share
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type deleteShareInput struct {
+	// ID is the share identifier from the URL path
+	ID string `uri:"id" validate:"required,uuid"`
+}
+
+// DeleteShare revokes a share or public link created by the authenticated user.
+//
+// @Summary      Delete a share
+// @Description  Revoke a share or public link. The grantee, or anyone holding the link, immediately loses access.
+// @Tags         Share
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string           true  "Share ID (UUID)"
+// @Success      200  {object}  response.Message "Success"
+// @Failure      400  {object}  response.Message "Invalid input"
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      404  {object}  response.Message "Share not found"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/shares/{id} [delete]
+func (h *shareHandler) DeleteShare(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	// Getting input from request and validate
+	input, err := utils.BindInputFromRequest[deleteShareInput](c)
+	if err != nil {
+		return
+	}
+
+	err = h.svc.DeleteShare(c, input.ID, uid)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Share not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Str("uid", uid).Str("share_id", input.ID).Msg("Failed to delete share")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response.Message{
+		Message: "Success",
+	})
+}
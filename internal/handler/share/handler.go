@@ -0,0 +1,54 @@
+package share
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/share"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for share HTTP handlers.
+type Handler interface {
+	// CreateShare handles sharing a bookmark, tag or folder with another user.
+	CreateShare(c *gin.Context)
+	// CreatePublicLink handles creating a public read-only link.
+	CreatePublicLink(c *gin.Context)
+	// GetShares retrieves the shares created by the user.
+	GetShares(c *gin.Context)
+	// GetReceivedShares retrieves the shares granted to the user.
+	GetReceivedShares(c *gin.Context)
+	// DeleteShare handles revoking a share.
+	DeleteShare(c *gin.Context)
+	// GetSharedBookmarks retrieves the bookmarks shared with the user.
+	GetSharedBookmarks(c *gin.Context)
+	// GetPublicBookmarks retrieves the bookmarks behind a public link.
+	GetPublicBookmarks(c *gin.Context)
+}
+
+type shareHandler struct {
+	svc share.Service
+}
+
+// NewHandler creates a new instance of the share handler.
+func NewHandler(svc share.Service) Handler {
+	return &shareHandler{svc: svc}
+}
+
+// shareResponse is a share as returned by the API.
+// It extends model.Share with the usernames of the users involved, which are hidden on the model.
+type shareResponse struct {
+	*model.Share
+	OwnerUsername   string `json:"owner_username,omitempty"`
+	GranteeUsername string `json:"grantee_username,omitempty"`
+}
+
+// newShareResponse converts a share, with its preloaded users if any, for the API.
+func newShareResponse(share *model.Share) *shareResponse {
+	res := &shareResponse{Share: share}
+	if share.Owner != nil {
+		res.OwnerUsername = share.Owner.Username
+	}
+	if share.Grantee != nil {
+		res.GranteeUsername = share.Grantee.Username
+	}
+	return res
+}
@@ -0,0 +1,219 @@
+package share
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// listSharesResponse is the response body of the share listing endpoints
+type listSharesResponse struct {
+	Data []*shareResponse `json:"data"`
+}
+
+// newListSharesResponse converts shares for the API.
+func newListSharesResponse(shares []*model.Share) listSharesResponse {
+	data := make([]*shareResponse, 0, len(shares))
+	for _, s := range shares {
+		data = append(data, newShareResponse(s))
+	}
+	return listSharesResponse{Data: data}
+}
+
+// listBookmarksResponse is a helper struct for Swagger documentation
+type listBookmarksResponse struct {
+	Data     []*model.Bookmark   `json:"data"`
+	Metadata pagination.Metadata `json:"metadata"`
+}
+
+// publicBookmarkResponse is a bookmark as shown behind a public link, to anyone holding the link.
+// Only what describes the link is exposed: the owner, its private notes, states and reading progress,
+// and the link checks stay hidden.
+type publicBookmarkResponse struct {
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Code        string   `json:"code"`
+	Title       string   `json:"title,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Folder      string   `json:"folder,omitempty"`
+}
+
+// listPublicBookmarksResponse is the response body of the public link endpoint
+type listPublicBookmarksResponse struct {
+	Data     []*publicBookmarkResponse `json:"data"`
+	Metadata pagination.Metadata       `json:"metadata"`
+}
+
+// newListPublicBookmarksResponse converts a page of bookmarks for the public link endpoint.
+func newListPublicBookmarksResponse(page *pagination.Response[*model.Bookmark]) listPublicBookmarksResponse {
+	data := make([]*publicBookmarkResponse, 0, len(page.Data))
+	for _, b := range page.Data {
+		res := &publicBookmarkResponse{
+			URL:         b.URL,
+			Description: b.Description,
+			Code:        b.Code,
+			Title:       b.Title,
+			Folder:      b.Folder,
+		}
+		if len(b.Tags) > 0 {
+			res.Tags = b.TagNames()
+		}
+		data = append(data, res)
+	}
+	return listPublicBookmarksResponse{Data: data, Metadata: page.Metadata}
+}
+
+// GetShares lists the shares and public links created by the authenticated user.
+//
+// @Summary      List my shares
+// @Description  Get the shares and public links created by the authenticated user, most recent first.
+// @Tags         Share
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  listSharesResponse
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/shares [get]
+func (h *shareHandler) GetShares(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	shares, err := h.svc.GetShares(c, uid)
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to list shares")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, newListSharesResponse(shares))
+}
+
+// GetReceivedShares lists the shares other users granted to the authenticated user.
+//
+// @Summary      List shares received
+// @Description  Get the bookmarks, tags and folders other users shared with the authenticated user, most recent first.
+// @Tags         Share
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  listSharesResponse
+// @Failure      401  {object}  response.Message "Unauthorized"
+// @Failure      500  {object}  response.Message "Internal server error"
+// @Router       /v1/shares/received [get]
+func (h *shareHandler) GetReceivedShares(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	shares, err := h.svc.GetReceivedShares(c, uid)
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to list received shares")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, newListSharesResponse(shares))
+}
+
+// GetSharedBookmarks returns a paginated list of the bookmarks shared with the authenticated user.
+//
+// @Summary      List bookmarks shared with me
+// @Description  Get a paginated list of the bookmarks other users shared with the authenticated user,
+// @Description  directly or through a shared tag or folder, most recently created first.
+// @Tags         Share
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Page number (default 1)"
+// @Param        limit  query     int  false  "Items per page (default 10)"
+// @Success      200    {object}  listBookmarksResponse
+// @Failure      401    {object}  response.Message "Unauthorized"
+// @Failure      500    {object}  response.Message "Internal server error"
+// @Router       /v1/bookmarks/shared [get]
+func (h *shareHandler) GetSharedBookmarks(c *gin.Context) {
+	// Get user id from JWT token
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	input, err := utils.BindInputFromRequest[pagination.Request](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.GetSharedBookmarks(c, uid, input)
+	if err != nil {
+		log.Error().Err(err).Str("uid", uid).Msg("Failed to list shared bookmarks")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, listBookmarksResponse{
+		Data:     res.Data,
+		Metadata: res.Metadata,
+	})
+}
+
+type getPublicBookmarksInput struct {
+	pagination.Request
+	// Token is the public link token from the URL path
+	Token string `uri:"token" validate:"required,alphanum"`
+}
+
+// GetPublicBookmarks returns a paginated list of the bookmarks behind a public link.
+//
+// @Summary      Open a public link
+// @Description  Get a paginated list of the bookmarks behind a public share link. No authentication is required.
+// @Description  Only the URL, description, code, title, tags and folder of the bookmarks are returned.
+// @Tags         Share
+// @Produce      json
+// @Param        token  path      string  true   "Public link token"
+// @Param        page   query     int     false  "Page number (default 1)"
+// @Param        limit  query     int     false  "Items per page (default 10)"
+// @Success      200    {object}  listPublicBookmarksResponse
+// @Failure      400    {object}  response.Message "Invalid input"
+// @Failure      404    {object}  response.Message "Link not found"
+// @Failure      500    {object}  response.Message "Internal server error"
+// @Router       /v1/public/shares/{token} [get]
+func (h *shareHandler) GetPublicBookmarks(c *gin.Context) {
+	input, err := utils.BindInputFromRequest[getPublicBookmarksInput](c)
+	if err != nil {
+		return
+	}
+
+	res, err := h.svc.GetPublicBookmarks(c, input.Token, &input.Request)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			c.JSON(http.StatusNotFound, &response.Message{
+				Message: "Link not found",
+			})
+			return
+		}
+
+		log.Error().Err(err).Msg("Failed to list public bookmarks")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, newListPublicBookmarksResponse(res))
+}
@@ -0,0 +1,409 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	serviceMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/share/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+)
+
+// testBookmarkPage is the page of bookmarks returned by the mocked service in listing tests.
+var testBookmarkPage = &pagination.Response[*model.Bookmark]{
+	Data: []*model.Bookmark{
+		{
+			Base:        model.Base{ID: "bm-1", CreatedAt: testTime, UpdatedAt: testTime},
+			Description: "Shared bookmark",
+			URL:         "https://example.com/1",
+			Code:        "code1",
+			UserID:      testGranteeID,
+		},
+	},
+	Metadata: pagination.Metadata{CurrentPage: 1, PageSize: 10, TotalRecords: 1, FirstPage: 1, LastPage: 1},
+}
+
+// testBookmarkPageBody is the JSON body expected for testBookmarkPage.
+var testBookmarkPageBody = map[string]any{
+	"data": []any{
+		map[string]any{
+			"id":          "bm-1",
+			"description": "Shared bookmark",
+			"url":         "https://example.com/1",
+			"code":        "code1",
+			"user_id":     testGranteeID,
+			"created_at":  "2025-01-01T00:00:00Z",
+			"updated_at":  "2025-01-01T00:00:00Z",
+		},
+	},
+	"metadata": map[string]any{
+		"current_page":  float64(1),
+		"page_size":     float64(10),
+		"total_records": float64(1),
+		"first_page":    float64(1),
+		"last_page":     float64(1),
+	},
+}
+
+// testPublicBookmarkPage is the page of bookmarks behind a public link returned by the mocked service,
+// with the owner, private notes, states, reading progress and link check that the public link must not expose.
+var testPublicBookmarkPage = &pagination.Response[*model.Bookmark]{
+	Data: []*model.Bookmark{
+		{
+			Base:         model.Base{ID: "bm-1", CreatedAt: testTime, UpdatedAt: testTime},
+			PageMetadata: model.PageMetadata{Title: "Example page"},
+			LinkCheck:    model.LinkCheck{StatusCode: http.StatusOK, FinalURL: "https://example.com/final"},
+			Description:  "Shared bookmark",
+			URL:          "https://example.com/1",
+			Code:         "code1",
+			Folder:       "Work",
+			Tags:         []model.BookmarkTag{{Name: "go"}},
+			IsFavorite:   true,
+			Pinned:       true,
+			ReadAt:       &testTime,
+			Notes:        "Private **notes**",
+			ClickCount:   3,
+			UserID:       testUserID,
+		},
+	},
+	Metadata: pagination.Metadata{CurrentPage: 1, PageSize: 10, TotalRecords: 1, FirstPage: 1, LastPage: 1},
+}
+
+func TestShareHandler_GetShares(t *testing.T) {
+	t.Parallel()
+
+	granteeID := testGranteeID
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - list shares",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetShares", ctx, testUserID).Return([]*model.Share{
+					{
+						Base:       model.Base{ID: testShareID, CreatedAt: testTime, UpdatedAt: testTime},
+						OwnerID:    testUserID,
+						TargetType: model.ShareTargetTag,
+						Target:     "golang",
+						GranteeID:  &granteeID,
+						Grantee:    &model.User{Username: "jane"},
+						Permission: model.SharePermissionViewer,
+					},
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"id":               testShareID,
+						"created_at":       "2025-01-01T00:00:00Z",
+						"updated_at":       "2025-01-01T00:00:00Z",
+						"owner_id":         testUserID,
+						"target_type":      "tag",
+						"target":           "golang",
+						"grantee_id":       testGranteeID,
+						"grantee_username": "jane",
+						"permission":       "viewer",
+					},
+				},
+			},
+		},
+		{
+			name:      "success - no shares",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetShares", ctx, testUserID).Return([]*model.Share{}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]any{"data": []any{}},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"message": "Invalid token"},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetShares", ctx, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/shares").
+				WithJWTClaims(tc.jwtClaims)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetShares(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestShareHandler_GetReceivedShares(t *testing.T) {
+	t.Parallel()
+
+	granteeID := testUserID
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - list received shares",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetReceivedShares", ctx, testUserID).Return([]*model.Share{
+					{
+						Base:       model.Base{ID: testShareID, CreatedAt: testTime, UpdatedAt: testTime},
+						OwnerID:    testGranteeID,
+						Owner:      &model.User{Username: "john"},
+						TargetType: model.ShareTargetFolder,
+						Target:     "Work",
+						GranteeID:  &granteeID,
+						Permission: model.SharePermissionEditor,
+					},
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"id":             testShareID,
+						"created_at":     "2025-01-01T00:00:00Z",
+						"updated_at":     "2025-01-01T00:00:00Z",
+						"owner_id":       testGranteeID,
+						"owner_username": "john",
+						"target_type":    "folder",
+						"target":         "Work",
+						"grantee_id":     testUserID,
+						"permission":     "editor",
+					},
+				},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"message": "Invalid token"},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetReceivedShares", ctx, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/shares/received").
+				WithJWTClaims(tc.jwtClaims)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetReceivedShares(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestShareHandler_GetSharedBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - list shared bookmarks",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSharedBookmarks", ctx, testUserID, mock.AnythingOfType("*pagination.Request")).
+					Return(testBookmarkPage, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   testBookmarkPageBody,
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]any{"message": "Invalid token"},
+		},
+		{
+			name:      "error - service failure",
+			jwtClaims: jwt.MapClaims{"sub": testUserID},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetSharedBookmarks", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/bookmarks/shared").
+				WithJWTClaims(tc.jwtClaims)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetSharedBookmarks(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
+
+func TestShareHandler_GetPublicBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		uriParams      map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *serviceMocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - list public bookmarks",
+			uriParams: map[string]string{"token": testToken},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetPublicBookmarks", ctx, testToken, mock.AnythingOfType("*pagination.Request")).
+					Return(testPublicBookmarkPage, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"url":         "https://example.com/1",
+						"description": "Shared bookmark",
+						"code":        "code1",
+						"title":       "Example page",
+						"tags":        []any{"go"},
+						"folder":      "Work",
+					},
+				},
+				"metadata": testBookmarkPageBody["metadata"],
+			},
+		},
+		{
+			name:      "error - invalid token",
+			uriParams: map[string]string{"token": "not/a-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				return serviceMocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Token is invalid (alphanum)"},
+			},
+		},
+		{
+			name:      "error - link not found",
+			uriParams: map[string]string{"token": testToken},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetPublicBookmarks", ctx, mock.Anything, mock.Anything).Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]any{"message": "Link not found"},
+		},
+		{
+			name:      "error - service failure",
+			uriParams: map[string]string{"token": testToken},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *serviceMocks.Service {
+				svcMock := serviceMocks.NewService(t)
+				svcMock.On("GetPublicBookmarks", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]any{"message": response.InternalErrMessage},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/public/shares/:token").
+				WithURIParams(tc.uriParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewHandler(svcMock)
+
+			handler.GetPublicBookmarks(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// defaultTop is the number of domains and clicked bookmarks listed when the request doesn't say.
+const defaultTop = 10
+
+type getSelfStatsInput struct {
+	// Top is the number of domains and clicked bookmarks listed at most
+	Top int `form:"top" validate:"omitempty,min=1,max=100"`
+}
+
+type statsResBody struct {
+	Data *model.BookmarkStats `json:"data"`
+}
+
+// GetSelfStats handles the request to get the bookmark statistics of the authenticated user.
+// The statistics may be served from a cache, in which case generated_at tells how old they are.
+//
+// @Summary Get bookmark statistics
+// @Description Get the number of bookmarks of the authenticated user outside the trash, the most bookmarked domains, the bookmarks per tag and per month created (UTC), and the bookmarks whose short code was followed most.
+// @Description The statistics are cached for a short while.
+// @Tags User
+// @Produce json
+// @Security BearerAuth
+// @Param top query int false "Number of domains and clicked bookmarks listed (default 10)" minimum(1) maximum(100)
+// @Success 200 {object} statsResBody
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Invalid or missing token"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/self/stats [get]
+func (h *statsHandler) GetSelfStats(c *gin.Context) {
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	input, err := utils.BindInputFromRequest[getSelfStatsInput](c)
+	if err != nil {
+		return
+	}
+	top := input.Top
+	if top == 0 {
+		top = defaultTop
+	}
+
+	stats, err := h.svc.GetStats(c, uid, top)
+	if err != nil {
+		log.Error().Err(err).Str("userID", uid).Msg("GetSelfStats err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &statsResBody{
+		Data: stats,
+	})
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/stats/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsHandler_GetSelfStats(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	generatedAt := time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	stats := &model.BookmarkStats{
+		Total:       3,
+		Domains:     []model.DomainCount{{Domain: "go.dev", Count: 2}},
+		Tags:        []model.TagCount{{Tag: "go", Count: 1}},
+		Months:      []model.MonthCount{{Month: "2025-03", Count: 3}},
+		TopClicked:  []model.CodeClicks{{Code: "abc123456", URL: "https://go.dev", Clicks: 4}},
+		GeneratedAt: generatedAt,
+	}
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		queryParams    map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:      "success - default top",
+			jwtClaims: jwt.MapClaims{"sub": "test-user-id"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("GetStats", ctx, "test-user-id", 10).Return(stats, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": map[string]any{
+					"total":        float64(3),
+					"domains":      []any{map[string]any{"domain": "go.dev", "count": float64(2)}},
+					"tags":         []any{map[string]any{"tag": "go", "count": float64(1)}},
+					"months":       []any{map[string]any{"month": "2025-03", "count": float64(3)}},
+					"top_clicked":  []any{map[string]any{"code": "abc123456", "url": "https://go.dev", "clicks": float64(4)}},
+					"generated_at": generatedAt.Format(time.RFC3339Nano),
+				},
+			},
+		},
+		{
+			name:        "success - custom top",
+			jwtClaims:   jwt.MapClaims{"sub": "test-user-id"},
+			queryParams: map[string]string{"top": "3"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("GetStats", ctx, "test-user-id", 3).Return(&model.BookmarkStats{GeneratedAt: generatedAt}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": map[string]any{
+					"total":        float64(0),
+					"domains":      nil,
+					"tags":         nil,
+					"months":       nil,
+					"top_clicked":  nil,
+					"generated_at": generatedAt.Format(time.RFC3339Nano),
+				},
+			},
+		},
+		{
+			name:        "error - top out of range",
+			jwtClaims:   jwt.MapClaims{"sub": "test-user-id"},
+			queryParams: map[string]string{"top": "101"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"Top is invalid (max)"},
+			},
+		},
+		{
+			name:      "error - missing JWT claims",
+			jwtClaims: nil,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - internal server error",
+			jwtClaims: jwt.MapClaims{"sub": "test-user-id"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("GetStats", ctx, "test-user-id", 10).Return(nil, assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodGet, "/v1/self/stats").
+				WithJWTClaims(tc.jwtClaims).
+				WithQueryParams(tc.queryParams)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+
+			NewHandler(svcMock).GetSelfStats(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package stats
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/stats"
+	"github.com/gin-gonic/gin"
+)
+
+// Handler defines the interface for statistics HTTP handlers.
+type Handler interface {
+	// GetSelfStats retrieves the bookmark statistics of the authenticated user.
+	GetSelfStats(c *gin.Context)
+}
+
+type statsHandler struct {
+	svc stats.Service
+}
+
+// NewHandler creates a new instance of the statistics handler.
+func NewHandler(svc stats.Service) Handler {
+	return &statsHandler{svc: svc}
+}
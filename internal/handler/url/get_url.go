@@ -16,7 +16,8 @@ import (
 // layer for the original URL, and redirects the client using HTTP 302 Found.
 //
 // Path Parameters:
-//   - code: The 7-character alphanumeric short code generated by ShortenUrl.
+//   - code: The 7-character alphanumeric short code generated by ShortenUrl,
+//     or the code of a bookmark. Following a bookmark code counts a click.
 //
 // Responses:
 //   - 302 Found: Redirects to the original URL.
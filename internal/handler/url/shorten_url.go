@@ -1,9 +1,14 @@
 package url
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
@@ -30,13 +35,19 @@ type urlShortenResponse struct {
 }
 
 // @Summary Shorten URL
-// @Description Generate a short code for the provided URL
+// @Description Generate a short code for the provided URL.
+// @Description Anonymous callers are welcome; links created with a bearer token count against the user's daily quota.
+// @Description When QUOTA_ANONYMOUS_SHORT_LINKS_PER_DAY is set, links created without a token count against a daily quota
+// @Description shared by all anonymous callers of the address the request reaches the server from.
 // @Tags URL
 // @Accept json
 // @Produce json
 // @Param request body urlShortenRequest true "URL shorten request"
 // @Success 200 {object} urlShortenResponse
 // @Failure 400 {object} response.Message
+// @Failure 401 {object} response.Message "Invalid token"
+// @Failure 429 {object} response.Message "Daily short link quota exceeded"
+// @Header 429 {integer} Retry-After "Seconds until the quota starts over"
 // @Failure 500 {object} response.Message
 // @Router /v1/links/shorten [post]
 func (h *urlHandler) ShortenUrl(c *gin.Context) {
@@ -45,7 +56,19 @@ func (h *urlHandler) ShortenUrl(c *gin.Context) {
 		return
 	}
 
-	code, err := h.urlService.ShortenUrl(c, req.Url, req.Exp)
+	// The route is public: requests without a token are anonymous and counted by address.
+	// RemoteIP is used rather than ClientIP, as forwarding headers can be set by anyone.
+	userID, _ := utils.GetUIDFromRequest(c)
+
+	code, err := h.urlService.ShortenUrl(c, userID, c.RemoteIP(), req.Url, req.Exp)
+	if errors.Is(err, quota.ErrShortLinkQuotaExceeded) {
+		retryAfter := time.Until(quota.NextReset(time.Now()))
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, response.Message{
+			Message: "Daily short link quota exceeded",
+		})
+		return
+	}
 	if err != nil {
 		// Log the error using Zerolog's structured logging:
 		// - .Str("url", ...): key-value pair for context
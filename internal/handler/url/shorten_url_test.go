@@ -6,12 +6,18 @@ import (
 	"testing"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
 	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
 )
 
+// testClientIP is the address httptest gives to the requests it builds.
+const testClientIP = "192.0.2.1"
+
 // TestUrlShortenHandler_ShortenUrl validates the ShortenUrl handler.
 // It uses table-driven tests to cover the following scenarios:
 //   - Success cases: valid URL with default and custom expiration times
@@ -36,6 +42,7 @@ func TestUrlShortenHandler_ShortenUrl(t *testing.T) {
 	testCases := []struct {
 		name           string
 		requestBody    map[string]any
+		claims         jwt.MapClaims
 		setupMockSvc   func(ctx *gin.Context) *mocks.ShortenUrl
 		expectedStatus int
 		expectedBody   map[string]any
@@ -48,6 +55,8 @@ func TestUrlShortenHandler_ShortenUrl(t *testing.T) {
 				svcMock.On("ShortenUrl",
 					// context matcher
 					ctx,
+					"",
+					testClientIP,
 					"https://example.com",
 					3600,
 				).Return("abc1234", nil).Once()
@@ -66,6 +75,8 @@ func TestUrlShortenHandler_ShortenUrl(t *testing.T) {
 				svcMock := mocks.NewShortenUrl(t)
 				svcMock.On("ShortenUrl",
 					ctx,
+					"",
+					testClientIP,
 					"https://google.com",
 					3600,
 				).Return("xyz7890", nil).Once()
@@ -107,6 +118,51 @@ func TestUrlShortenHandler_ShortenUrl(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   nil,
 		},
+		{
+			name:        "success - authenticated link is attributed to the user",
+			requestBody: fixture.DefaultShortenURLBody(),
+			claims:      jwt.MapClaims{"sub": "user-123"},
+			setupMockSvc: func(ctx *gin.Context) *mocks.ShortenUrl {
+				svcMock := mocks.NewShortenUrl(t)
+				svcMock.On("ShortenUrl", ctx, "user-123", testClientIP, "https://example.com", 3600).
+					Return("abc1234", nil).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Shorten URL generated successfully!",
+				"code":    "abc1234",
+			},
+		},
+		{
+			name:        "too many requests - daily quota exceeded",
+			requestBody: fixture.DefaultShortenURLBody(),
+			claims:      jwt.MapClaims{"sub": "user-123"},
+			setupMockSvc: func(ctx *gin.Context) *mocks.ShortenUrl {
+				svcMock := mocks.NewShortenUrl(t)
+				svcMock.On("ShortenUrl", ctx, "user-123", testClientIP, "https://example.com", 3600).
+					Return("", quota.ErrShortLinkQuotaExceeded).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody: map[string]any{
+				"message": "Daily short link quota exceeded",
+			},
+		},
+		{
+			name:        "too many requests - anonymous quota of the address exceeded",
+			requestBody: fixture.DefaultShortenURLBody(),
+			setupMockSvc: func(ctx *gin.Context) *mocks.ShortenUrl {
+				svcMock := mocks.NewShortenUrl(t)
+				svcMock.On("ShortenUrl", ctx, "", testClientIP, "https://example.com", 3600).
+					Return("", quota.ErrShortLinkQuotaExceeded).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody: map[string]any{
+				"message": "Daily short link quota exceeded",
+			},
+		},
 		{
 			name:        "internal server error - service failure",
 			requestBody: fixture.DefaultShortenURLBody(),
@@ -114,6 +170,8 @@ func TestUrlShortenHandler_ShortenUrl(t *testing.T) {
 				svcMock := mocks.NewShortenUrl(t)
 				svcMock.On("ShortenUrl",
 					ctx,
+					"",
+					testClientIP,
 					"https://example.com",
 					3600,
 				).Return("", errors.New("redis connection failed")).Once()
@@ -134,6 +192,9 @@ func TestUrlShortenHandler_ShortenUrl(t *testing.T) {
 			// Create test context with JSON body using helper
 			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/links/shorten").
 				WithJSONBody(tc.requestBody)
+			if tc.claims != nil {
+				testCtx.WithJWTClaims(tc.claims)
+			}
 
 			// Setup the mock service
 			svcMock := tc.setupMockSvc(testCtx.Ctx)
@@ -146,6 +207,9 @@ func TestUrlShortenHandler_ShortenUrl(t *testing.T) {
 
 			// Assert response using helper
 			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+			if tc.expectedStatus == http.StatusTooManyRequests {
+				assert.NotEmpty(t, testCtx.Recorder.Header().Get("Retry-After"))
+			}
 		})
 	}
 }
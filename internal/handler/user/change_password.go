@@ -0,0 +1,69 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type changePasswordReqBody struct {
+	// CurrentPassword is checked against the stored password before anything changes.
+	CurrentPassword string `json:"current_password" validate:"required"`
+	// NewPassword must satisfy the same rules as at registration.
+	NewPassword string `json:"new_password" validate:"required,gte=8,lte=20,password"`
+}
+
+// ChangePassword handles password change requests.
+// It replaces the password of the authenticated user and revokes every token issued
+// before, including the one used for the request and the personal access tokens;
+// new tokens are returned instead.
+//
+// @Summary Change password
+// @Description Change the authenticated user's password. Every existing session is logged out, personal access tokens are revoked and new tokens are returned
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body changePasswordReqBody true "Current and new password"
+// @Success 200 {object} loginResBody
+// @Failure 400 {object} response.Message "Invalid input or wrong current password"
+// @Failure 401 {object} response.Message "Invalid or missing token"
+// @Failure 404 {object} response.Message "User does not exist"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/self/password [put]
+func (u *userHandler) ChangePassword(c *gin.Context) {
+	uid, err := utils.GetUIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	reqBody, err := utils.BindInputFromRequest[changePasswordReqBody](c)
+	if err != nil {
+		return
+	}
+
+	pair, err := u.svc.ChangePassword(c, uid, reqBody.CurrentPassword, reqBody.NewPassword)
+	switch {
+	case errors.Is(err, service.ErrClientWrongPassword):
+		c.JSON(http.StatusBadRequest, &response.Message{Message: "Current password is incorrect"})
+		return
+	case errors.Is(err, dbutils.ErrNotFoundType):
+		c.JSON(http.StatusNotFound, &response.Message{Message: "User does not exist"})
+		return
+	case err != nil:
+		log.Error().Err(err).Str("userID", uid).Msg("ChangePassword err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResBody("Password changed successfully!", pair))
+}
@@ -0,0 +1,143 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUserHandler_ChangePassword tests the ChangePassword handler method.
+// It verifies that new tokens are returned on success, and that a wrong current password,
+// a weak new password, an unknown user and service errors are reported.
+func TestUserHandler_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	claims := jwt.MapClaims{"sub": "user-123"}
+	validBody := map[string]string{"current_password": "OldPassword1!", "new_password": "NewPassword1!"}
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		requestBody    map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.User
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - password changed",
+			jwtClaims:   claims,
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "user-123", "OldPassword1!", "NewPassword1!").Return(&model.TokenPair{
+					AccessToken:  "new.jwt.token",
+					RefreshToken: "new-refresh-token",
+					ExpiresIn:    900,
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message":       "Password changed successfully!",
+				"data":          "new.jwt.token",
+				"refresh_token": "new-refresh-token",
+				"expires_in":    float64(900),
+			},
+		},
+		{
+			name:        "error - wrong current password",
+			jwtClaims:   claims,
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "user-123", "OldPassword1!", "NewPassword1!").Return(nil, service.ErrClientWrongPassword)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": "Current password is incorrect",
+			},
+		},
+		{
+			name:        "error - weak new password",
+			jwtClaims:   claims,
+			requestBody: map[string]string{"current_password": "OldPassword1!", "new_password": "weakpassword"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"NewPassword is invalid (password)"},
+			},
+		},
+		{
+			name:        "error - user not found",
+			jwtClaims:   claims,
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "user-123", "OldPassword1!", "NewPassword1!").Return(nil, dbutils.ErrNotFoundType)
+				return svcMock
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "User does not exist",
+			},
+		},
+		{
+			name:        "error - missing JWT claims",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:        "error - internal server error",
+			jwtClaims:   claims,
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "user-123", "OldPassword1!", "NewPassword1!").Return(nil, assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPut, "/v1/self/password").
+				WithJWTClaims(tc.jwtClaims).
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewUserHandler(svcMock)
+
+			handler.ChangePassword(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
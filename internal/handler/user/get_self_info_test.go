@@ -63,18 +63,22 @@ func TestUserHandler_GetSelfInfo(t *testing.T) {
 					Username:    "testuser",
 					DisplayName: "Test User",
 					Email:       "test@example.com",
+					Roles:       model.Roles{model.RoleUser},
 				}, nil)
 				return svcMock
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]any{
 				"data": map[string]any{
-					"id":           "test-user-id",
-					"username":     "testuser",
-					"display_name": "Test User",
-					"email":        "test@example.com",
-					"created_at":   fixedTime.Format(time.RFC3339Nano),
-					"updated_at":   fixedTime.Format(time.RFC3339Nano),
+					"id":                "test-user-id",
+					"username":          "testuser",
+					"display_name":      "Test User",
+					"email":             "test@example.com",
+					"email_verified_at": nil,
+					"roles":             []any{"user"},
+					"disabled_at":       nil,
+					"created_at":        fixedTime.Format(time.RFC3339Nano),
+					"updated_at":        fixedTime.Format(time.RFC3339Nano),
 				},
 			},
 		},
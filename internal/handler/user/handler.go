@@ -11,10 +11,16 @@ type UserHandler interface {
 	Register(c *gin.Context)
 	// Login handles the user login request.
 	Login(c *gin.Context)
+	// RefreshToken handles the request to exchange a refresh token for a new token pair.
+	RefreshToken(c *gin.Context)
+	// Logout handles the request to revoke the tokens of the current login.
+	Logout(c *gin.Context)
 	// GetSelfInfo handles the request to get the currently authenticated user's info.
 	GetSelfInfo(c *gin.Context)
 	// UpdateSelfInfo handles the request to update the currently authenticated user's info.
 	UpdateSelfInfo(c *gin.Context)
+	// ChangePassword handles the request to change the currently authenticated user's password.
+	ChangePassword(c *gin.Context)
 }
 
 // userHandler implements the UserHandler interface.
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/service"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
@@ -19,24 +20,40 @@ type loginInputBody struct {
 	Password string `json:"password" validate:"required,gte=8"`
 }
 
-// loginResBody represents the response body containing the JWT token.
+// loginResBody represents the response body containing the issued tokens.
 type loginResBody struct {
 	Message string `json:"message"`
-	// Token is the JWT authentication token.
+	// Data is the short-lived JWT access token.
 	Data string `json:"data"`
+	// RefreshToken is the opaque token exchanged for a new pair at /v1/users/token/refresh.
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// newLoginResBody builds the response body returned for a newly issued token pair.
+func newLoginResBody(message string, pair *model.TokenPair) *loginResBody {
+	return &loginResBody{
+		Message:      message,
+		Data:         pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+	}
 }
 
 // Login handles user login requests.
-// It validates credentials, authenticates the user, and returns a JWT token.
+// It validates credentials, authenticates the user, and returns a short-lived JWT
+// access token together with a refresh token.
 //
 // @Summary Login a user
-// @Description Authenticate a user with username and password, returns a JWT token
+// @Description Authenticate a user with username and password, returns an access token and a refresh token
 // @Tags User
 // @Accept json
 // @Produce json
 // @Param body body loginInputBody true "User login credentials"
 // @Success 200 {object} loginResBody
 // @Failure 400 {object} response.Message "Invalid username or password"
+// @Failure 403 {object} response.Message "Account is disabled or email address is not verified"
 // @Failure 500 {object} response.Message "Internal server error"
 // @Router /v1/users/login [post]
 func (u *userHandler) Login(c *gin.Context) {
@@ -46,7 +63,7 @@ func (u *userHandler) Login(c *gin.Context) {
 	}
 
 	// call service
-	token, err := u.svc.Login(c, inputBody.Username, inputBody.Password)
+	pair, err := u.svc.Login(c, inputBody.Username, inputBody.Password)
 	switch {
 	case errors.Is(err, service.ErrClientErr):
 		c.JSON(http.StatusBadRequest, response.Message{
@@ -56,14 +73,22 @@ func (u *userHandler) Login(c *gin.Context) {
 	case errors.Is(err, dbutils.ErrNotFoundType):
 		c.JSON(http.StatusNotFound, gin.H{"error": "invalid username or password"})
 		return
+	case errors.Is(err, service.ErrClientAccountDisabled):
+		c.JSON(http.StatusForbidden, response.Message{
+			Message: "Account is disabled",
+		})
+		return
+	case errors.Is(err, service.ErrClientEmailNotVerified):
+		c.JSON(http.StatusForbidden, response.Message{
+			Message: "Email address is not verified",
+		})
+		return
 	case errors.Is(err, nil):
 	default:
 		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
 		return
 	}
 
-	// return token
-	c.JSON(http.StatusOK, &loginResBody{
-		Message: "Logged in successfully!",
-		Data:    token})
+	// return tokens
+	c.JSON(http.StatusOK, newLoginResBody("Logged in successfully!", pair))
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/service"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
@@ -17,7 +18,7 @@ import (
 
 // TestUserHandler_Login tests the Login handler method.
 // It uses table-driven tests with mocked User service to verify:
-//   - Successful login returns a JWT token with 200 status
+//   - Successful login returns the token pair with 200 status
 //   - Invalid credentials return 400 status
 //   - User not found returns 404 status
 //   - Internal server error returns 500 status
@@ -52,13 +53,19 @@ func TestUserHandler_Login(t *testing.T) {
 				svcMock.On("Login",
 					ctx, // gin.Context (implements context.Context)
 					"testuser", "password123",
-				).Return("valid.jwt.token", nil)
+				).Return(&model.TokenPair{
+					AccessToken:  "valid.jwt.token",
+					RefreshToken: "valid-refresh-token",
+					ExpiresIn:    900,
+				}, nil)
 				return svcMock
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]any{
-				"message": "Logged in successfully!",
-				"data":    "valid.jwt.token",
+				"message":       "Logged in successfully!",
+				"data":          "valid.jwt.token",
+				"refresh_token": "valid-refresh-token",
+				"expires_in":    float64(900),
 			},
 		},
 		{
@@ -68,7 +75,7 @@ func TestUserHandler_Login(t *testing.T) {
 				svcMock := mocks.NewUser(t)
 				svcMock.On("Login",
 					ctx, "testuser", "wrongpassword123",
-				).Return("", service.ErrClientErr)
+				).Return(nil, service.ErrClientErr)
 				return svcMock
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -83,7 +90,7 @@ func TestUserHandler_Login(t *testing.T) {
 				svcMock := mocks.NewUser(t)
 				svcMock.On("Login",
 					ctx, "nonexistent", "password123",
-				).Return("", dbutils.ErrNotFoundType)
+				).Return(nil, dbutils.ErrNotFoundType)
 				return svcMock
 			},
 			expectedStatus: http.StatusNotFound,
@@ -91,6 +98,36 @@ func TestUserHandler_Login(t *testing.T) {
 				"error": "invalid username or password",
 			},
 		},
+		{
+			name:        "error - account disabled",
+			requestBody: fixture.DefaultLoginBody(),
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Login",
+					ctx, "testuser", "password123",
+				).Return(nil, service.ErrClientAccountDisabled)
+				return svcMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "Account is disabled",
+			},
+		},
+		{
+			name:        "error - email not verified",
+			requestBody: fixture.DefaultLoginBody(),
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Login",
+					ctx, "testuser", "password123",
+				).Return(nil, service.ErrClientEmailNotVerified)
+				return svcMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]any{
+				"message": "Email address is not verified",
+			},
+		},
 		{
 			name:        "error - internal server error",
 			requestBody: fixture.DefaultLoginBody(),
@@ -98,7 +135,7 @@ func TestUserHandler_Login(t *testing.T) {
 				svcMock := mocks.NewUser(t)
 				svcMock.On("Login",
 					ctx, "testuser", "password123",
-				).Return("", assert.AnError) // generic error
+				).Return(nil, assert.AnError) // generic error
 				return svcMock
 			},
 			expectedStatus: http.StatusInternalServerError,
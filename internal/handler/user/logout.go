@@ -0,0 +1,54 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// logoutInputBody represents the optional JSON request body for logout.
+type logoutInputBody struct {
+	// RefreshToken is the refresh token of the same login; when given, it is revoked too.
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout handles user logout requests.
+// It revokes the access token of the request until it expires and, when a refresh token
+// is given, every refresh token of the same login.
+//
+// @Summary Logout
+// @Description Revoke the access token used for the request and, when given, the refresh token of the same login
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body logoutInputBody false "Refresh token to revoke"
+// @Success 200 {object} response.Message
+// @Failure 401 {object} response.Message "Invalid or missing token"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/users/logout [post]
+func (u *userHandler) Logout(c *gin.Context) {
+	claims, err := utils.GetJWTClaimsFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, &response.Message{
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	inputBody, err := utils.BindInputFromRequest[logoutInputBody](c)
+	if err != nil {
+		return
+	}
+
+	if err := u.svc.Logout(c, claims, inputBody.RefreshToken); err != nil {
+		log.Error().Err(err).Msg("Logout err - Internal Server Error")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response.Message{Message: "Logged out successfully!"})
+}
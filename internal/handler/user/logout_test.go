@@ -0,0 +1,104 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUserHandler_Logout tests the Logout handler method.
+// It verifies that the claims of the request and the optional refresh token are
+// passed to the service, and that missing claims and service errors are reported.
+func TestUserHandler_Logout(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	claims := jwt.MapClaims{"sub": "user-123", "jti": "jti-1"}
+
+	testCases := []struct {
+		name           string
+		jwtClaims      jwt.MapClaims
+		requestBody    map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.User
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - access and refresh tokens revoked",
+			jwtClaims:   claims,
+			requestBody: map[string]string{"refresh_token": "refresh-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Logout", ctx, claims, "refresh-token").Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Logged out successfully!",
+			},
+		},
+		{
+			name:      "success - without body",
+			jwtClaims: claims,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Logout", ctx, claims, "").Return(nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Logged out successfully!",
+			},
+		},
+		{
+			name: "error - missing JWT claims",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:      "error - internal server error",
+			jwtClaims: claims,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Logout", ctx, claims, "").Return(assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/users/logout").
+				WithJWTClaims(tc.jwtClaims)
+			if tc.requestBody != nil {
+				testCtx = testCtx.WithJSONBody(tc.requestBody)
+			}
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewUserHandler(svcMock)
+
+			handler.Logout(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/handler/utils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// refreshTokenInputBody represents the expected JSON request body for a token refresh.
+type refreshTokenInputBody struct {
+	// RefreshToken is the refresh token returned by the last login or refresh.
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// RefreshToken handles token refresh requests.
+// It exchanges a refresh token for a new access token and a new refresh token.
+// The submitted refresh token can not be used again: presenting it a second time
+// revokes every token issued from the same login.
+//
+// @Summary Refresh the access token
+// @Description Exchange a refresh token for a new access token and a new (rotated) refresh token. Reusing a refresh token revokes all tokens of the login it belongs to
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body refreshTokenInputBody true "Refresh token"
+// @Success 200 {object} loginResBody
+// @Failure 400 {object} response.Message "Invalid input"
+// @Failure 401 {object} response.Message "Invalid refresh token"
+// @Failure 500 {object} response.Message "Internal server error"
+// @Router /v1/users/token/refresh [post]
+func (u *userHandler) RefreshToken(c *gin.Context) {
+	inputBody, err := utils.BindInputFromRequest[refreshTokenInputBody](c)
+	if err != nil {
+		return
+	}
+
+	pair, err := u.svc.RefreshToken(c, inputBody.RefreshToken)
+	switch {
+	case errors.Is(err, token.ErrInvalidRefreshToken), errors.Is(err, token.ErrRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, response.Message{Message: "Invalid refresh token"})
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("failed to refresh token")
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, newLoginResBody("Token refreshed successfully!", pair))
+}
@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	handlertest "github.com/HadesHo3820/ebvn-golang-course/internal/test/handler"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUserHandler_RefreshToken tests the RefreshToken handler method.
+// It verifies that a valid refresh token yields a new token pair, that unknown
+// and reused tokens are rejected with 401, and that other errors yield 500.
+func TestUserHandler_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		requestBody    map[string]string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.User
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - token refreshed",
+			requestBody: map[string]string{"refresh_token": "old-refresh-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("RefreshToken", ctx, "old-refresh-token").Return(&model.TokenPair{
+					AccessToken:  "new.jwt.token",
+					RefreshToken: "new-refresh-token",
+					ExpiresIn:    900,
+				}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message":       "Token refreshed successfully!",
+				"data":          "new.jwt.token",
+				"refresh_token": "new-refresh-token",
+				"expires_in":    float64(900),
+			},
+		},
+		{
+			name:        "error - invalid refresh token",
+			requestBody: map[string]string{"refresh_token": "unknown"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("RefreshToken", ctx, "unknown").Return(nil, token.ErrInvalidRefreshToken)
+				return svcMock
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid refresh token",
+			},
+		},
+		{
+			name:        "error - reused refresh token",
+			requestBody: map[string]string{"refresh_token": "old-refresh-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("RefreshToken", ctx, "old-refresh-token").Return(nil, token.ErrRefreshTokenReused)
+				return svcMock
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"message": "Invalid refresh token",
+			},
+		},
+		{
+			name:        "error - internal server error",
+			requestBody: map[string]string{"refresh_token": "old-refresh-token"},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("RefreshToken", ctx, "old-refresh-token").Return(nil, assert.AnError)
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"message": response.InternalErrMessage,
+			},
+		},
+		{
+			name:        "error - missing refresh token",
+			requestBody: map[string]string{},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+				"details": []any{"RefreshToken is invalid (required)"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testCtx := handlertest.NewTestContext(http.MethodPost, "/v1/users/token/refresh").
+				WithJSONBody(tc.requestBody)
+
+			svcMock := tc.setupMockSvc(t, testCtx.Ctx)
+			handler := NewUserHandler(svcMock)
+
+			handler.RefreshToken(testCtx.Ctx)
+
+			handlertest.AssertJSONResponse(t, testCtx.Recorder, tc.expectedStatus, tc.expectedBody)
+		})
+	}
+}
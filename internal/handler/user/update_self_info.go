@@ -24,9 +24,11 @@ const (
 
 // UpdateSelfInfo handles user profile update requests.
 // It extracts the user ID from the JWT token and updates the user's display name and/or email.
+// When email verification is enabled, a new email stays pending until it is confirmed.
 //
 // @Summary Update user profile
 // @Description Update the authenticated user's profile information (display name and/or email)
+// @Description When email verification is enabled, a new email is returned as pending_email by GET /v1/self/info and a verification link is sent to it; it replaces the current email once confirmed
 // @Tags User
 // @Accept json
 // @Produce json
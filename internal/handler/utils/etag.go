@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/etag"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// GetVersionFromRequest returns the version a write is conditional on, read from the If-Match header.
+// The version is 0 when the header is absent or the wildcard, meaning the write is unconditional.
+// ok is false when the header can never match, in which case the request must fail with 412.
+func GetVersionFromRequest(c *gin.Context) (version int, ok bool) {
+	return etag.ParseVersion(c.GetHeader("If-Match"))
+}
+
+// JSONWithETag writes obj as a 200 JSON response tagged with the given entity tag,
+// or an empty 304 Not Modified response if the request's If-None-Match already matches it.
+func JSONWithETag(c *gin.Context, tag string, obj any) {
+	c.Header("ETag", tag)
+	if etag.MatchNone(c.GetHeader("If-None-Match"), tag) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	c.JSON(http.StatusOK, obj)
+}
+
+// JSONWithContentETag writes obj as a 200 JSON response tagged with a hash of its encoding,
+// or an empty 304 Not Modified response if the request's If-None-Match already matches it.
+// It suits collections, which have no version of their own.
+func JSONWithContentETag(c *gin.Context, obj any) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		return
+	}
+
+	tag := etag.FromContent(body)
+	c.Header("ETag", tag)
+	if etag.MatchNone(c.GetHeader("If-None-Match"), tag) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"regexp"
 
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mergepatch"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -40,7 +42,8 @@ func validatePassword(fl validator.FieldLevel) bool {
 // by consolidating all binding and validation logic into one reusable function.
 //
 // The function binds data from the following sources in order:
-//  1. JSON body - using struct tags `json:"fieldName"`
+//  1. JSON body - using struct tags `json:"fieldName"`, or for multipart/form-data
+//     bodies the form fields and files - using struct tags `form:"fieldName"`
 //  2. URI path parameters - using struct tags `uri:"paramName"`
 //  3. Query string parameters - using struct tags `form:"paramName"`
 //  4. HTTP headers - using struct tags `header:"headerName"`
@@ -51,6 +54,9 @@ func validatePassword(fl validator.FieldLevel) bool {
 // Custom validators available:
 //   - password: Validates password contains uppercase, lowercase, digit, and special character
 //
+// Fields of type mergepatch.Field are validated against their wrapped value,
+// and skipped entirely when the member is absent or null in the JSON body.
+//
 // Type Parameters:
 //   - T: The type of the input struct to bind to. Must have appropriate struct tags
 //     for the desired binding sources and validation rules.
@@ -84,9 +90,16 @@ func validatePassword(fl validator.FieldLevel) bool {
 func BindInputFromRequest[T any](c *gin.Context) (*T, error) {
 	reqInput := new(T)
 
-	// Skip JSON binding for GET requests to avoid EOF error on empty body
+	// Skip body binding for GET requests to avoid EOF error on empty body.
+	// Multipart bodies (file uploads) are bound from the form instead of JSON.
 	if c.Request.Method != http.MethodGet {
-		if err := c.ShouldBindJSON(reqInput); err != nil && err.Error() != "EOF" {
+		var err error
+		if c.ContentType() == binding.MIMEMultipartPOSTForm {
+			err = c.ShouldBindWith(reqInput, binding.FormMultipart)
+		} else {
+			err = c.ShouldBindJSON(reqInput)
+		}
+		if err != nil && err.Error() != "EOF" {
 			c.JSON(http.StatusBadRequest, response.InputFieldError(err))
 			c.Abort()
 			return nil, err
@@ -114,6 +127,8 @@ func BindInputFromRequest[T any](c *gin.Context) (*T, error) {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 	// Register custom password validator
 	validate.RegisterValidation("password", validatePassword)
+	// Validate JSON Merge Patch members against their wrapped value
+	validate.RegisterCustomTypeFunc(mergepatch.ValidatorTypeFunc, mergepatch.Field[string]{})
 
 	if err := validate.Struct(reqInput); err != nil {
 		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -34,6 +35,11 @@ type testPasswordInput struct {
 	Password string `json:"password" validate:"required,password"`
 }
 
+type testMultipartInput struct {
+	File *multipart.FileHeader `form:"file" validate:"required"`
+	Mode string                `form:"mode"`
+}
+
 type testCombinedInput struct {
 	Username string `json:"username" validate:"required"`
 	ID       string `uri:"id"`
@@ -113,6 +119,74 @@ func TestBindInputFromRequest_JSONBinding(t *testing.T) {
 	}
 }
 
+// TestBindInputFromRequest_MultipartBinding tests multipart form binding, including file uploads.
+func TestBindInputFromRequest_MultipartBinding(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		withFile       bool
+		query          string
+		expectedStatus int
+		expectError    bool
+		expectedMode   string
+	}{
+		{
+			name:         "success - file and form field",
+			withFile:     true,
+			expectedMode: "form",
+		},
+		{
+			name:         "success - query parameter alongside file",
+			withFile:     true,
+			query:        "?mode=query",
+			expectedMode: "query",
+		},
+		{
+			name:           "error - missing file",
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			if tc.withFile {
+				part, err := writer.CreateFormFile("file", "bookmarks.html")
+				assert.NoError(t, err)
+				_, _ = part.Write([]byte("<DL></DL>"))
+			}
+			if tc.query == "" {
+				_ = writer.WriteField("mode", "form")
+			}
+			assert.NoError(t, writer.Close())
+
+			rec := httptest.NewRecorder()
+			gctx, _ := gin.CreateTestContext(rec)
+			gctx.Request = httptest.NewRequest(http.MethodPost, "/test"+tc.query, body)
+			gctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			result, err := BindInputFromRequest[testMultipartInput](gctx)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Equal(t, tc.expectedStatus, rec.Code)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "bookmarks.html", result.File.Filename)
+			assert.Equal(t, tc.expectedMode, result.Mode)
+		})
+	}
+}
+
 // TestBindInputFromRequest_URIBinding tests URI parameter binding.
 func TestBindInputFromRequest_URIBinding(t *testing.T) {
 	t.Parallel()
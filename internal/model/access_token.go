@@ -0,0 +1,71 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Scopes a personal access token can be granted. Each one opens a group of routes;
+// the rest of the API, such as the account and sharing endpoints, is reserved to signed-in users.
+const (
+	// ScopeBookmarksRead allows listing, exporting and reading bookmarks.
+	ScopeBookmarksRead = "bookmarks:read"
+	// ScopeBookmarksWrite allows creating, importing, updating and deleting bookmarks.
+	ScopeBookmarksWrite = "bookmarks:write"
+	// ScopeLinksWrite allows shortening links.
+	ScopeLinksWrite = "links:write"
+)
+
+// Scopes is the set of scopes of a personal access token, stored as a comma-separated list
+// in a single column.
+type Scopes []string
+
+// Has reports whether scope is one of the scopes.
+func (s Scopes) Has(scope string) bool {
+	return slices.Contains(s, scope)
+}
+
+// Scan implements sql.Scanner.
+func (s *Scopes) Scan(value any) error {
+	scopes, err := scanList(value)
+	if err != nil {
+		return fmt.Errorf("cannot scan %T into Scopes", value)
+	}
+	*s = scopes
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, ","), nil
+}
+
+// PersonalAccessToken lets scripts and integrations call the API on behalf of a user
+// without their password. This struct maps to the "personal_access_tokens" table.
+//
+// Only the hash of the token is stored; the token itself is returned once, when it is created.
+//
+// Fields:
+//   - Base: Embedded struct providing ID, CreatedAt, UpdatedAt, and DeletedAt
+//   - UserID: The user the token acts for
+//   - User: The associated user (excluded from JSON, loaded via GORM association when authenticating)
+//   - Name: A label chosen by the user to tell their tokens apart
+//   - TokenHash: SHA-256 of the token
+//   - Scopes: The routes the token may call
+//   - ExpiresAt: When the token stops working, nil for tokens that do not expire
+//   - LastUsedAt: When the token last authenticated a request, nil if it never did
+//   - Token: The token itself, only set in the response to its creation
+type PersonalAccessToken struct {
+	Base
+	UserID     string     `json:"-" gorm:"not null;index"`
+	User       *User      `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	Name       string     `json:"name" gorm:"not null"`
+	TokenHash  string     `json:"-" gorm:"not null;unique"`
+	Scopes     Scopes     `json:"scopes" gorm:"type:varchar(255);not null"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Token      string     `json:"token,omitempty" gorm:"-"`
+}
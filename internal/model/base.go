@@ -19,11 +19,15 @@ import (
 //   - CreatedAt: Timestamp when the record was created (auto-managed by GORM)
 //   - UpdatedAt: Timestamp when the record was last updated (auto-managed by GORM)
 //   - DeletedAt: Timestamp for soft deletion (NULL means active, non-NULL means deleted)
+//
+// Because DeletedAt is a gorm.DeletedAt, GORM turns Delete calls into an UPDATE of
+// deleted_at and automatically adds "deleted_at IS NULL" to every query.
+// Use Unscoped() to read trashed rows or to remove them permanently.
 type Base struct {
-	ID        string    `gorm:"type:uuid;primaryKey;column:id" json:"id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	DeletedAt time.Time `json:"-"`
+	ID        string         `gorm:"type:uuid;primaryKey;column:id" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // BeforeCreate is a GORM hook that runs automatically before inserting a new record.
@@ -1,5 +1,11 @@
 package model
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 // Bookmark represents a shortened URL bookmark in the system.
 // This struct maps to the "bookmarks" table in the database and stores
 // URL shortening information with ownership tracking and soft delete support.
@@ -9,18 +15,54 @@ package model
 //
 // Fields:
 //   - Base: Embedded struct providing ID, CreatedAt, UpdatedAt, and DeletedAt
+//   - PageMetadata: Embedded page metadata filled in by the enrichment worker
+//   - LinkCheck: Embedded outcome of the last broken-link check
 //   - Description: Optional user-provided description or title for the bookmark
 //   - URL: The original long URL that the short code redirects to
+//   - NormalizedURL: URL normalized for duplicate detection (not serialized)
+//   - Domain: Host name of the URL without "www.", used for statistics (not serialized)
 //   - Code: The unique short code used for redirection (e.g., "abc123")
+//   - Folder: Slash-separated folder path (e.g., "Work/Projects"), empty for the root
+//   - Tags: Tags attached to the bookmark (stored in "bookmark_tags", serialized as strings)
+//   - IsFavorite: Whether the owner marked the bookmark as a favorite
+//   - Pinned: Whether the bookmark is pinned at the top of the owner's list
+//   - Archived: Whether the bookmark is archived, which hides it from the default list
+//   - ReadAt: When the owner marked the bookmark as read, nil while it is unread
+//   - Notes: Free-form Markdown notes, rendered to HTML on request
+//   - ClickCount: Number of times the short code of the bookmark was followed
+//   - Version: Incremented on every update, it is exposed as the ETag of the bookmark (not serialized)
 //   - UserID: Foreign key referencing the user who created this bookmark
 //   - User: The associated User object (excluded from JSON, loaded via GORM association)
 type Bookmark struct {
 	Base
-	Description string `json:"description"`
-	URL         string `json:"url"`
-	Code        string `json:"code" gorm:"unique"`
-	UserID      string `json:"user_id"`
-	User        *User  `gorm:"references:ID" json:"-"`
+	PageMetadata
+	LinkCheck
+	Description   string        `json:"description"`
+	URL           string        `json:"url"`
+	NormalizedURL string        `json:"-" gorm:"not null;default:'';index:idx_bookmarks_user_normalized_url,priority:2"`
+	Domain        string        `json:"-" gorm:"not null;default:''"`
+	Code          string        `json:"code" gorm:"unique"`
+	Folder        string        `json:"folder,omitempty" gorm:"not null;default:''"`
+	Tags          []BookmarkTag `json:"tags,omitempty" gorm:"foreignKey:BookmarkID;constraint:OnDelete:CASCADE" swaggertype:"array,string"`
+	IsFavorite    bool          `json:"is_favorite,omitempty" gorm:"not null;default:false"`
+	Pinned        bool          `json:"pinned,omitempty" gorm:"not null;default:false"`
+	Archived      bool          `json:"archived,omitempty" gorm:"not null;default:false"`
+	ReadAt        *time.Time    `json:"read_at,omitempty"`
+	Notes         string        `json:"notes,omitempty" gorm:"type:text;not null;default:''"`
+	ClickCount    int64         `json:"click_count,omitempty" gorm:"not null;default:0"`
+	Version       int           `json:"-" gorm:"not null;default:1"`
+	UserID        string        `json:"user_id" gorm:"index:idx_bookmarks_user_normalized_url,priority:1"`
+	User          *User         `gorm:"references:ID" json:"-"`
+}
+
+// BeforeUpdate is a GORM hook that runs automatically before updating bookmarks.
+// It increments the version of every updated bookmark, so that a client holding an
+// older version (through its ETag) can tell that the bookmark changed in the meantime.
+//
+// Updates that skip hooks (UpdateColumn, SkipHooks) leave the version unchanged.
+func (b *Bookmark) BeforeUpdate(tx *gorm.DB) error {
+	tx.Statement.SetColumn("version", gorm.Expr("version + 1"))
+	return nil
 }
 
 // User represents the "Belongs To" relationship with the User model.
@@ -51,3 +93,75 @@ type Bookmark struct {
 // Summary:
 //   - foreignKey: "Which field in THIS struct holds the value?" (Default: UserID)
 //   - references: "Which field in the OTHER struct should we match against?" (Default: ID)
+
+// PageMetadata holds what was extracted from the page a bookmark points to.
+// It is filled in asynchronously after creation, so every field may still be empty.
+//
+// Fields:
+//   - Title: The page title
+//   - MetaDescription: The page's meta description
+//   - FaviconURL: Absolute URL of the page icon
+//   - CanonicalURL: Absolute canonical URL declared by the page
+//   - ReadingTime: Estimated reading time of the page in minutes, 0 when unknown
+type PageMetadata struct {
+	Title           string `json:"title,omitempty" gorm:"not null;default:''"`
+	MetaDescription string `json:"meta_description,omitempty" gorm:"not null;default:''"`
+	FaviconURL      string `json:"favicon_url,omitempty" gorm:"not null;default:''"`
+	CanonicalURL    string `json:"canonical_url,omitempty" gorm:"not null;default:''"`
+	ReadingTime     int    `json:"reading_time,omitempty" gorm:"not null;default:0"`
+}
+
+// LinkCheck holds the outcome of the last broken-link check of a bookmark.
+// All fields are empty until the bookmark has been checked once.
+//
+// Fields:
+//   - StatusCode: HTTP status of the last response, 0 if the URL could not be reached
+//   - FinalURL: Where the URL permanently redirects to; empty when it does not redirect permanently
+//   - CheckError: Why the URL could not be reached, when StatusCode is 0
+//   - LastCheckedAt: When the URL was last checked, nil if never
+type LinkCheck struct {
+	StatusCode    int        `json:"status_code,omitempty" gorm:"not null;default:0"`
+	FinalURL      string     `json:"final_url,omitempty" gorm:"not null;default:''"`
+	CheckError    string     `json:"check_error,omitempty" gorm:"not null;default:''"`
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty" gorm:"index"`
+}
+
+// BookmarkState is an on/off state of a bookmark that its owner can toggle.
+type BookmarkState string
+
+const (
+	// BookmarkStateFavorite marks a bookmark as a favorite.
+	BookmarkStateFavorite BookmarkState = "favorite"
+	// BookmarkStatePinned keeps a bookmark at the top of the list.
+	BookmarkStatePinned BookmarkState = "pinned"
+	// BookmarkStateArchived hides a bookmark from the default list.
+	BookmarkStateArchived BookmarkState = "archived"
+)
+
+// BookmarkPatch describes a partial update of a bookmark.
+// Each field follows the same convention: nil means "leave unchanged",
+// while a non-nil pointer is written as-is (an empty string clears the value).
+//
+// Fields:
+//   - Description: New description, or nil to keep the current one
+//   - URL: New target URL, or nil to keep the current one
+//   - Notes: New Markdown notes, or nil to keep the current ones
+type BookmarkPatch struct {
+	Description *string
+	URL         *string
+	Notes       *string
+}
+
+// TagNames returns the names of the bookmark's tags.
+func (b *Bookmark) TagNames() []string {
+	names := make([]string, 0, len(b.Tags))
+	for _, tag := range b.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// IsEmpty reports whether the patch does not change any field.
+func (p *BookmarkPatch) IsEmpty() bool {
+	return p == nil || (p.Description == nil && p.URL == nil && p.Notes == nil)
+}
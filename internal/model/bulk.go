@@ -0,0 +1,34 @@
+package model
+
+// BulkAction is an operation applied to many bookmarks at once.
+type BulkAction string
+
+const (
+	// BulkActionDelete moves the bookmarks to the trash.
+	BulkActionDelete BulkAction = "delete"
+	// BulkActionTag adds tags to the bookmarks.
+	BulkActionTag BulkAction = "tag"
+	// BulkActionUntag removes tags from the bookmarks.
+	BulkActionUntag BulkAction = "untag"
+	// BulkActionMove moves the bookmarks to a folder.
+	BulkActionMove BulkAction = "move"
+	// BulkActionArchive archives the bookmarks.
+	BulkActionArchive BulkAction = "archive"
+)
+
+// BulkOperation describes an action applied to a selection of bookmarks.
+// Bookmarks are selected either by ID or by filter, never both.
+//
+// Fields:
+//   - Action: What to do with the selected bookmarks
+//   - IDs: The bookmarks to act on; every one of them must be owned by the user
+//   - Filter: Selects the bookmarks to act on when IDs is empty
+//   - Tags: Tags added or removed by BulkActionTag and BulkActionUntag
+//   - Folder: Destination of BulkActionMove, empty for the root
+type BulkOperation struct {
+	Action BulkAction
+	IDs    []string
+	Filter *BookmarkFilter
+	Tags   []string
+	Folder string
+}
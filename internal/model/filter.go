@@ -0,0 +1,50 @@
+package model
+
+// LinkStatus selects bookmarks by the outcome of their last broken-link check.
+type LinkStatus string
+
+const (
+	// LinkStatusBroken selects links that could not be reached or answered with an error status.
+	LinkStatusBroken LinkStatus = "broken"
+	// LinkStatusOK selects links that answered with a success or redirect status.
+	LinkStatusOK LinkStatus = "ok"
+	// LinkStatusRedirected selects links that permanently redirect elsewhere.
+	LinkStatusRedirected LinkStatus = "redirected"
+	// LinkStatusUnchecked selects links that have not been checked yet.
+	LinkStatusUnchecked LinkStatus = "unchecked"
+)
+
+// BookmarkFilter narrows down a bookmark listing.
+// Zero-valued fields do not filter, except Archived: archived bookmarks are
+// hidden unless they are explicitly asked for.
+//
+// Fields:
+//   - LinkStatus: Only bookmarks whose last link check has this outcome
+//   - Favorite: Only favorites (true) or only non-favorites (false)
+//   - Pinned: Only pinned (true) or only unpinned (false) bookmarks
+//   - Archived: Only archived (true) or only active (false) bookmarks; nil behaves like false
+//   - Unread: Only unread (true) or only read (false) bookmarks
+type BookmarkFilter struct {
+	LinkStatus LinkStatus
+	Favorite   *bool
+	Pinned     *bool
+	Archived   *bool
+	Unread     *bool
+}
+
+// IsEmpty reports whether no field of the filter is set, so that it would
+// select every active bookmark.
+func (f *BookmarkFilter) IsEmpty() bool {
+	return f.LinkStatus == "" && f.Favorite == nil && f.Pinned == nil && f.Archived == nil && f.Unread == nil
+}
+
+// UserFilter narrows down the users listed to administrators.
+// Zero values do not filter.
+//
+// Fields:
+//   - Query: Only users whose username, display name or email contains this text, ignoring case
+//   - Disabled: Only disabled (true) or only enabled (false) accounts
+type UserFilter struct {
+	Query    string
+	Disabled *bool
+}
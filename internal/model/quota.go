@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// QuotaLimits are the limits that apply to a user. A limit of 0 means unlimited.
+//
+// Fields:
+//   - Bookmarks: Maximum number of bookmarks outside the trash
+//   - ShortLinksPerDay: Maximum number of short links created per UTC day
+//   - SnapshotBytes: Maximum storage used by offline snapshots, in bytes
+type QuotaLimits struct {
+	Bookmarks        int64 `json:"bookmarks"`
+	ShortLinksPerDay int64 `json:"short_links_per_day"`
+	SnapshotBytes    int64 `json:"snapshot_bytes"`
+}
+
+// QuotaUsage is what a user currently consumes of each limit.
+type QuotaUsage struct {
+	Bookmarks       int64 `json:"bookmarks"`
+	ShortLinksToday int64 `json:"short_links_today"`
+	SnapshotBytes   int64 `json:"snapshot_bytes"`
+}
+
+// Quota reports the limits of a user together with their current usage.
+//
+// Fields:
+//   - Limits: The limits of the user, 0 meaning unlimited
+//   - Usage: The current usage
+//   - ShortLinksResetAt: When the daily short link count starts over
+type Quota struct {
+	Limits            QuotaLimits `json:"limits"`
+	Usage             QuotaUsage  `json:"usage"`
+	ShortLinksResetAt time.Time   `json:"short_links_reset_at"`
+}
+
+// UserQuota overrides the default limits for a single user.
+// This struct maps to the "user_quotas" table, with at most one row per user.
+// A nil field keeps the default limit, 0 lifts the limit.
+//
+// Fields:
+//   - UserID: ID of the user the overrides apply to
+//   - MaxBookmarks: Override of QuotaLimits.Bookmarks
+//   - MaxShortLinksPerDay: Override of QuotaLimits.ShortLinksPerDay
+//   - MaxSnapshotBytes: Override of QuotaLimits.SnapshotBytes
+type UserQuota struct {
+	UserID              string    `json:"user_id" gorm:"type:uuid;primaryKey"`
+	MaxBookmarks        *int64    `json:"max_bookmarks"`
+	MaxShortLinksPerDay *int64    `json:"max_short_links_per_day"`
+	MaxSnapshotBytes    *int64    `json:"max_snapshot_bytes"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// Apply returns the limits with the overrides of the user applied.
+func (q *UserQuota) Apply(limits QuotaLimits) QuotaLimits {
+	if q.MaxBookmarks != nil {
+		limits.Bookmarks = *q.MaxBookmarks
+	}
+	if q.MaxShortLinksPerDay != nil {
+		limits.ShortLinksPerDay = *q.MaxShortLinksPerDay
+	}
+	if q.MaxSnapshotBytes != nil {
+		limits.SnapshotBytes = *q.MaxSnapshotBytes
+	}
+	return limits
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// BookmarkRevision is a version of a bookmark that was replaced by an edit.
+// This struct maps to the "bookmark_revisions" table, whose primary key is the
+// (bookmark_id, revision) pair. Revisions are numbered from 1 for each bookmark.
+//
+// A revision holds the description and URL the bookmark had before the edit,
+// together with the user who made the edit and when, so the current values are
+// never stored as a revision and reverting to a revision restores what the edit replaced.
+//
+// Fields:
+//   - BookmarkID: Foreign key referencing the edited bookmark
+//   - Revision: Sequence number of the edit, starting at 1
+//   - Description: The description before the edit
+//   - URL: The URL before the edit
+//   - ChangedBy: ID of the user who made the edit (the owner or an editor)
+//   - CreatedAt: When the edit was made
+type BookmarkRevision struct {
+	BookmarkID  string    `json:"bookmark_id" gorm:"type:uuid;primaryKey"`
+	Revision    int       `json:"revision" gorm:"primaryKey;autoIncrement:false"`
+	Description string    `json:"description" gorm:"not null;default:''"`
+	URL         string    `json:"url" gorm:"not null"`
+	ChangedBy   string    `json:"changed_by" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
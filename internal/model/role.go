@@ -0,0 +1,60 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Roles granted to users. Every user has RoleUser; RoleAdmin opens the administration API.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Roles is the set of roles of a user. It is stored as a comma-separated list in a single column
+// and embedded in the access tokens as the "roles" claim.
+type Roles []string
+
+// Has reports whether role is one of the roles.
+func (r Roles) Has(role string) bool {
+	return slices.Contains(r, role)
+}
+
+// Scan implements sql.Scanner.
+func (r *Roles) Scan(value any) error {
+	roles, err := scanList(value)
+	if err != nil {
+		return fmt.Errorf("cannot scan %T into Roles", value)
+	}
+	*r = roles
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (r Roles) Value() (driver.Value, error) {
+	return strings.Join(r, ","), nil
+}
+
+// scanList reads a comma-separated list stored in a single column, skipping empty items.
+func scanList(value any) ([]string, error) {
+	var s string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return nil, fmt.Errorf("unsupported type %T", value)
+	}
+
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,51 @@
+package model
+
+// SharePermission is what the recipient of a share may do with the shared bookmarks.
+type SharePermission string
+
+const (
+	// SharePermissionViewer allows reading the shared bookmarks.
+	SharePermissionViewer SharePermission = "viewer"
+	// SharePermissionEditor allows reading and updating the shared bookmarks.
+	SharePermissionEditor SharePermission = "editor"
+)
+
+// ShareTargetType is the kind of thing a share gives access to.
+type ShareTargetType string
+
+const (
+	// ShareTargetBookmark shares a single bookmark; the target is its ID.
+	ShareTargetBookmark ShareTargetType = "bookmark"
+	// ShareTargetTag shares every bookmark carrying a tag; the target is the tag name.
+	ShareTargetTag ShareTargetType = "tag"
+	// ShareTargetFolder shares every bookmark in a folder and its subfolders; the target is the folder path.
+	ShareTargetFolder ShareTargetType = "folder"
+)
+
+// Share gives access to bookmarks of its owner, either to a single user or to anyone
+// holding its token. This struct maps to the "shares" table.
+//
+// Tag and folder shares are resolved when bookmarks are read, so bookmarks added to
+// the collection later are shared as well.
+//
+// Fields:
+//   - Base: Embedded struct providing ID, CreatedAt, UpdatedAt, and DeletedAt
+//   - OwnerID: The user whose bookmarks are shared
+//   - Owner: The associated owner (excluded from JSON, loaded via GORM association when listing shares)
+//   - TargetType: Whether a bookmark, a tag or a folder is shared
+//   - Target: The bookmark ID, tag name or folder path
+//   - GranteeID: The user the bookmarks are shared with, nil for public links
+//   - Grantee: The associated grantee (excluded from JSON, loaded via GORM association when listing shares)
+//   - Permission: What the grantee may do; public links are always read-only
+//   - Token: The unguessable token of a public link, nil for user shares
+type Share struct {
+	Base
+	OwnerID    string          `json:"owner_id" gorm:"not null;index;uniqueIndex:idx_shares_grantee_target,priority:1"`
+	Owner      *User           `json:"-" gorm:"foreignKey:OwnerID;references:ID"`
+	TargetType ShareTargetType `json:"target_type" gorm:"not null;uniqueIndex:idx_shares_grantee_target,priority:2"`
+	Target     string          `json:"target" gorm:"not null;uniqueIndex:idx_shares_grantee_target,priority:3"`
+	GranteeID  *string         `json:"grantee_id,omitempty" gorm:"index;uniqueIndex:idx_shares_grantee_target,priority:4"`
+	Grantee    *User           `json:"-" gorm:"foreignKey:GranteeID;references:ID"`
+	Permission SharePermission `json:"permission" gorm:"not null"`
+	Token      *string         `json:"token,omitempty" gorm:"unique"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// SnapshotStatus is the state of the latest capture requested for a bookmark.
+type SnapshotStatus string
+
+const (
+	// SnapshotPending means a capture is queued or in progress.
+	SnapshotPending SnapshotStatus = "pending"
+	// SnapshotReady means the latest capture succeeded and the snapshot can be read.
+	SnapshotReady SnapshotStatus = "ready"
+	// SnapshotFailed means the latest capture failed; Error tells why.
+	SnapshotFailed SnapshotStatus = "failed"
+)
+
+// BookmarkSnapshot describes the archived copy of the page a bookmark points to.
+// This struct maps to the "bookmark_snapshots" table, with one row per bookmark.
+// The HTML itself lives in the blob store, under the key returned by SnapshotKey.
+//
+// A bookmark keeps its last successful capture while a new one is pending or after it failed,
+// so CapturedAt and Size describe the stored copy whatever the Status.
+//
+// The row has no foreign key to the bookmark: when the bookmark is purged the row is left
+// behind so that the cleanup job can find and delete the blob before removing it.
+//
+// Fields:
+//   - BookmarkID: ID of the archived bookmark
+//   - UserID: ID of the bookmark owner, whose storage quota the snapshot counts against
+//   - Status: State of the latest capture
+//   - Size: Size of the stored copy in bytes, 0 if there is none
+//   - Error: Why the latest capture failed, empty otherwise
+//   - CapturedAt: When the stored copy was captured, nil if there is none
+//   - CreatedAt: When a snapshot was first requested
+//   - UpdatedAt: When the latest capture was requested or finished
+type BookmarkSnapshot struct {
+	BookmarkID string         `json:"bookmark_id" gorm:"type:uuid;primaryKey"`
+	UserID     string         `json:"-" gorm:"type:uuid;not null;index"`
+	Status     SnapshotStatus `json:"status" gorm:"not null"`
+	Size       int64          `json:"size" gorm:"not null;default:0"`
+	Error      string         `json:"error,omitempty" gorm:"not null;default:''"`
+	CapturedAt *time.Time     `json:"captured_at,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// SnapshotKey returns the blob store key of the snapshot of a bookmark.
+func SnapshotKey(bookmarkID string) string {
+	return "snapshots/" + bookmarkID + ".html"
+}
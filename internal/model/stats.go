@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// BookmarkStats is an overview of the bookmarks of a user, computed over the bookmarks outside the trash.
+//
+// Fields:
+//   - Total: Number of bookmarks
+//   - Domains: The domains bookmarked most, by number of bookmarks
+//   - Tags: Number of bookmarks carrying each tag, most used first
+//   - Months: Number of bookmarks created in each month, oldest first
+//   - TopClicked: The bookmarks whose short code was followed most
+//   - GeneratedAt: When the statistics were computed; they may be served from a cache for a short while
+type BookmarkStats struct {
+	Total       int64         `json:"total"`
+	Domains     []DomainCount `json:"domains"`
+	Tags        []TagCount    `json:"tags"`
+	Months      []MonthCount  `json:"months"`
+	TopClicked  []CodeClicks  `json:"top_clicked"`
+	GeneratedAt time.Time     `json:"generated_at"`
+}
+
+// DomainCount is the number of bookmarks pointing to a domain.
+type DomainCount struct {
+	Domain string `json:"domain"`
+	Count  int64  `json:"count"`
+}
+
+// TagCount is the number of bookmarks carrying a tag.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// MonthCount is the number of bookmarks created in a month, formatted as "2006-01" in UTC.
+type MonthCount struct {
+	Month string `json:"month"`
+	Count int64  `json:"count"`
+}
+
+// CodeClicks is the number of times the short code of a bookmark was followed.
+type CodeClicks struct {
+	Code   string `json:"code"`
+	URL    string `json:"url"`
+	Clicks int64  `json:"clicks"`
+}
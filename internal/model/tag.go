@@ -0,0 +1,38 @@
+package model
+
+import "encoding/json"
+
+// BookmarkTag is a single tag attached to a bookmark.
+// This struct maps to the "bookmark_tags" table, whose primary key is the
+// (bookmark_id, name) pair, so a bookmark carries each tag at most once.
+//
+// In API responses a tag is serialized as its plain name, so a bookmark's
+// tags appear as ["go", "docs"] rather than a list of objects.
+//
+// Fields:
+//   - BookmarkID: Foreign key referencing the tagged bookmark
+//   - Name: The tag itself
+type BookmarkTag struct {
+	BookmarkID string `gorm:"type:uuid;primaryKey"`
+	Name       string `gorm:"primaryKey;index"`
+}
+
+// NewBookmarkTags converts tag names into BookmarkTag values for a bookmark that may not exist yet.
+// GORM fills in BookmarkID when the tags are saved together with their bookmark.
+func NewBookmarkTags(names []string) []BookmarkTag {
+	tags := make([]BookmarkTag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, BookmarkTag{Name: name})
+	}
+	return tags
+}
+
+// MarshalJSON implements json.Marshaler by encoding the tag as its name.
+func (t BookmarkTag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Name)
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding the tag from its name.
+func (t *BookmarkTag) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &t.Name)
+}
@@ -0,0 +1,30 @@
+package model
+
+// TokenPair is what a client receives when it logs in or refreshes its tokens.
+//
+// Fields:
+//   - AccessToken: Short-lived JWT sent as bearer token with each request
+//   - RefreshToken: Opaque token exchanged for a new pair once the access token expires; valid for one use
+//   - ExpiresIn: Seconds until the access token expires
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+// RefreshToken is the server-side record of a refresh token, stored under the hash of the token.
+// Every token obtained by refreshing belongs to the family of the token it replaced, so the
+// whole chain started by a login can be revoked at once.
+//
+// Fields:
+//   - UserID: The user the token was issued to
+//   - FamilyID: The family of the token, one per login
+//   - Generation: Token generation of the user when the family was started; revoking every
+//     token of the user moves to the next generation, which invalidates older families
+//   - Roles: Roles of the user at login, embedded in every access token issued for the family
+type RefreshToken struct {
+	UserID     string
+	FamilyID   string
+	Generation int64
+	Roles      Roles
+}
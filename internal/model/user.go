@@ -3,6 +3,8 @@
 // tables and are used for data transfer between layers.
 package model
 
+import "time"
+
 // User represents a user account in the system.
 // This struct maps to the "users" table in the database and contains
 // all user-related information including authentication credentials.
@@ -13,10 +15,52 @@ package model
 //   - Password: Hashed password (excluded from JSON serialization for security)
 //   - DisplayName: User's display name shown in the UI
 //   - Email: Unique email address for the user
+//   - EmailVerifiedAt: When the user confirmed the email address, nil while it is unverified
+//   - PendingEmail: New email address waiting to be confirmed before it replaces Email
+//   - Roles: Roles granted to the user, see RoleUser and RoleAdmin
+//   - DisabledAt: When an administrator disabled the account, nil while it is enabled
+//   - OIDCSubject: The subject of the user at the OpenID Connect provider, nil until the user signs in through it
 type User struct {
 	Base
-	Username    string `gorm:"unique;column:username" json:"username"`
-	Password    string `gorm:"column:password" json:"-"`
-	DisplayName string `gorm:"column:display_name" json:"display_name"`
-	Email       string `gorm:"unique;column:email" json:"email"`
+	Username        string     `gorm:"unique;column:username" json:"username"`
+	Password        string     `gorm:"column:password" json:"-"`
+	DisplayName     string     `gorm:"column:display_name" json:"display_name"`
+	Email           string     `gorm:"unique;column:email" json:"email"`
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at" json:"email_verified_at"`
+	PendingEmail    *string    `gorm:"column:pending_email" json:"pending_email,omitempty"`
+	Roles           Roles      `gorm:"column:roles;type:varchar(255);not null;default:user" json:"roles"`
+	DisabledAt      *time.Time `gorm:"column:disabled_at" json:"disabled_at"`
+	OIDCSubject     *string    `gorm:"unique;column:oidc_subject" json:"-"`
+}
+
+// UserOverview is a user as seen by administrators, with a summary of the user's data.
+//
+// Fields:
+//   - User: The user account
+//   - BookmarkCount: Number of bookmarks the user owns outside the trash
+type UserOverview struct {
+	User
+	BookmarkCount int64 `json:"bookmark_count"`
+}
+
+// EmailVerification is the server-side record of an email verification token,
+// stored under the hash of the token.
+//
+// Fields:
+//   - UserID: The user the token was issued to
+//   - Email: The address the token confirms, either the current or the pending email of the user
+type EmailVerification struct {
+	UserID string
+	Email  string
+}
+
+// OIDCLogin is the server-side record of a login through the OpenID Connect provider,
+// kept between the redirect to the provider and the callback, under the hash of the state.
+//
+// Fields:
+//   - CodeVerifier: The PKCE code verifier the authorization code is redeemed with
+//   - Nonce: The nonce the ID token must carry
+type OIDCLogin struct {
+	CodeVerifier string
+	Nonce        string
 }
@@ -0,0 +1,165 @@
+// Package accesstoken provides the data access for personal access tokens,
+// which let scripts and integrations call the API on behalf of a user.
+package accesstoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for personal access token database operations.
+//
+//go:generate mockery --name Repository --filename accesstoken.go
+type Repository interface {
+	CreateToken(ctx context.Context, token *model.PersonalAccessToken) (*model.PersonalAccessToken, error)
+	GetTokens(ctx context.Context, userID string) ([]*model.PersonalAccessToken, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error)
+	UpdateLastUsed(ctx context.Context, tokenID string, usedAt time.Time) error
+	DeleteToken(ctx context.Context, tokenID, userID string) error
+	DeleteUserTokens(ctx context.Context, userID string) error
+}
+
+// accessTokenRepo is the concrete implementation of the Repository interface using GORM.
+type accessTokenRepo struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new instance of accessTokenRepo with the provided GORM database connection.
+func NewRepository(db *gorm.DB) Repository {
+	return &accessTokenRepo{db: db}
+}
+
+// CreateToken inserts a new personal access token.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - token: The token to create, with the hash of the token
+//
+// Returns:
+//   - *model.PersonalAccessToken: The created token with ID and timestamps populated
+//   - error: Any database error
+func (r *accessTokenRepo) CreateToken(ctx context.Context, token *model.PersonalAccessToken) (*model.PersonalAccessToken, error) {
+	err := r.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return token, nil
+}
+
+// GetTokens retrieves every personal access token of a user, expired ones included,
+// most recent first.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - []*model.PersonalAccessToken: The tokens, empty when there are none
+//   - error: Any database error
+func (r *accessTokenRepo) GetTokens(ctx context.Context, userID string) ([]*model.PersonalAccessToken, error) {
+	tokens := make([]*model.PersonalAccessToken, 0)
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id ASC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return tokens, nil
+}
+
+// GetTokenByHash retrieves the personal access token with the given hash.
+// Its user is preloaded so that tokens of disabled users can be rejected;
+// User is nil when the user is in the trash.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - tokenHash: The SHA-256 of the token
+//
+// Returns:
+//   - *model.PersonalAccessToken: The matching token
+//   - error: ErrNotFoundType if no token has this hash
+func (r *accessTokenRepo) GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	token := &model.PersonalAccessToken{}
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("token_hash = ?", tokenHash).
+		First(token).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return token, nil
+}
+
+// UpdateLastUsed records when a personal access token last authenticated a request.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - tokenID: The ID of the token
+//   - usedAt: When the token was used
+//
+// Returns:
+//   - error: ErrNotFoundType if the token doesn't exist, or a database error
+func (r *accessTokenRepo) UpdateLastUsed(ctx context.Context, tokenID string, usedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&model.PersonalAccessToken{}).
+		Where("id = ?", tokenID).
+		UpdateColumn("last_used_at", usedAt)
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+	return nil
+}
+
+// DeleteToken revokes a personal access token. Revoked tokens are removed permanently.
+// It performs an ownership check to ensure only the owner can revoke a token.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - tokenID: The ID of the token to revoke
+//   - userID: The ID of the user attempting the revocation (for ownership validation)
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the token doesn't exist or the user doesn't own it
+func (r *accessTokenRepo) DeleteToken(ctx context.Context, tokenID, userID string) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND user_id = ?", tokenID, userID).
+		Delete(&model.PersonalAccessToken{})
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	// Check if any row was actually deleted
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// DeleteUserTokens revokes every personal access token of a user, e.g. once the password
+// of the user was reset. Revoked tokens are removed permanently. A user without tokens is
+// not an error.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - error: Any database error
+func (r *accessTokenRepo) DeleteUserTokens(ctx context.Context, userID string) error {
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&model.PersonalAccessToken{}).Error
+	if err != nil {
+		return dbutils.CatchDBErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package accesstoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTokenHash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+// newTestToken returns a token of the first fixture user.
+func newTestToken() *model.PersonalAccessToken {
+	return &model.PersonalAccessToken{
+		UserID:    fixture.FixtureUserOneID,
+		Name:      "CI",
+		TokenHash: testTokenHash,
+		Scopes:    model.Scopes{model.ScopeBookmarksRead, model.ScopeLinksWrite},
+	}
+}
+
+func TestAccessTokenRepo_CreateToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputToken  func() *model.PersonalAccessToken
+		expectedErr error
+	}{
+		{
+			name: "success - second token of the same user",
+			inputToken: func() *model.PersonalAccessToken {
+				token := newTestToken()
+				token.TokenHash = "another-hash"
+				return token
+			},
+		},
+		{
+			name:        "error - hash already used",
+			inputToken:  newTestToken,
+			expectedErr: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+			_, err := repo.CreateToken(t.Context(), newTestToken())
+			assert.NoError(t, err)
+
+			created, err := repo.CreateToken(t.Context(), tc.inputToken())
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, created)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, created.ID)
+		})
+	}
+}
+
+func TestAccessTokenRepo_GetTokens(t *testing.T) {
+	t.Parallel()
+
+	repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+	token := newTestToken()
+	_, err := repo.CreateToken(t.Context(), token)
+	assert.NoError(t, err)
+
+	t.Run("tokens of the user", func(t *testing.T) {
+		tokens, err := repo.GetTokens(t.Context(), fixture.FixtureUserOneID)
+
+		assert.NoError(t, err)
+		if assert.Len(t, tokens, 1) {
+			assert.Equal(t, token.ID, tokens[0].ID)
+			assert.Equal(t, model.Scopes{model.ScopeBookmarksRead, model.ScopeLinksWrite}, tokens[0].Scopes)
+		}
+	})
+
+	t.Run("no tokens", func(t *testing.T) {
+		tokens, err := repo.GetTokens(t.Context(), fixture.FixtureUserTwoID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, tokens)
+	})
+}
+
+func TestAccessTokenRepo_GetTokenByHash(t *testing.T) {
+	t.Parallel()
+
+	repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+	token := newTestToken()
+	_, err := repo.CreateToken(t.Context(), token)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		inputHash   string
+		expectedErr error
+	}{
+		{
+			name:      "success - user preloaded",
+			inputHash: testTokenHash,
+		},
+		{
+			name:        "error - unknown hash",
+			inputHash:   "unknown",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			found, err := repo.GetTokenByHash(t.Context(), tc.inputHash)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, token.ID, found.ID)
+			if assert.NotNil(t, found.User) {
+				assert.Equal(t, fixture.FixtureUserOneUsername, found.User.Username)
+			}
+		})
+	}
+}
+
+func TestAccessTokenRepo_UpdateLastUsed(t *testing.T) {
+	t.Parallel()
+
+	repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+	token := newTestToken()
+	_, err := repo.CreateToken(t.Context(), token)
+	assert.NoError(t, err)
+
+	usedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		err := repo.UpdateLastUsed(t.Context(), token.ID, usedAt)
+
+		assert.NoError(t, err)
+		found, err := repo.GetTokenByHash(t.Context(), testTokenHash)
+		assert.NoError(t, err)
+		if assert.NotNil(t, found.LastUsedAt) {
+			assert.True(t, usedAt.Equal(*found.LastUsedAt))
+		}
+	})
+
+	t.Run("error - unknown token", func(t *testing.T) {
+		err := repo.UpdateLastUsed(t.Context(), fixture.FixtureUserTwoID, usedAt)
+
+		assert.ErrorIs(t, err, dbutils.ErrNotFoundType)
+	})
+}
+
+func TestAccessTokenRepo_DeleteToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputUserID string
+		expectedErr error
+	}{
+		{
+			name:        "success - token no longer found",
+			inputUserID: fixture.FixtureUserOneID,
+		},
+		{
+			name:        "error - token belongs to different user",
+			inputUserID: fixture.FixtureUserTwoID,
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+			token := newTestToken()
+			_, err := repo.CreateToken(t.Context(), token)
+			assert.NoError(t, err)
+
+			err = repo.DeleteToken(t.Context(), token.ID, tc.inputUserID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			_, err = repo.GetTokenByHash(t.Context(), testTokenHash)
+			assert.ErrorIs(t, err, dbutils.ErrNotFoundType)
+		})
+	}
+}
+
+func TestAccessTokenRepo_DeleteUserTokens(t *testing.T) {
+	t.Parallel()
+
+	repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+	_, err := repo.CreateToken(t.Context(), newTestToken())
+	assert.NoError(t, err)
+	second := newTestToken()
+	second.TokenHash = "60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"
+	_, err = repo.CreateToken(t.Context(), second)
+	assert.NoError(t, err)
+	other := newTestToken()
+	other.UserID = fixture.FixtureUserTwoID
+	other.TokenHash = "fd61a03af4f77d870fc21e05e7e80678095c92d808cfb3b5c279ee04c74aca13"
+	_, err = repo.CreateToken(t.Context(), other)
+	assert.NoError(t, err)
+
+	err = repo.DeleteUserTokens(t.Context(), fixture.FixtureUserOneID)
+
+	assert.NoError(t, err)
+	tokens, err := repo.GetTokens(t.Context(), fixture.FixtureUserOneID)
+	assert.NoError(t, err)
+	assert.Empty(t, tokens)
+	tokens, err = repo.GetTokens(t.Context(), fixture.FixtureUserTwoID)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1, "tokens of other users are left alone")
+
+	// Nothing left to delete
+	assert.NoError(t, repo.DeleteUserTokens(t.Context(), fixture.FixtureUserOneID))
+}
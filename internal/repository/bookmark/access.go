@@ -0,0 +1,95 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"gorm.io/gorm"
+)
+
+// shareCoversBookmark matches a row of "shares" against the bookmark of the outer query:
+// the bookmark itself, a tag it carries, or the folder it lives in (including subfolders).
+const shareCoversBookmark = `shares.deleted_at IS NULL AND shares.owner_id = bookmarks.user_id AND (
+	(shares.target_type = 'bookmark' AND shares.target = bookmarks.id)
+	OR (shares.target_type = 'tag' AND EXISTS (
+		SELECT 1 FROM bookmark_tags WHERE bookmark_tags.bookmark_id = bookmarks.id AND bookmark_tags.name = shares.target))
+	OR (shares.target_type = 'folder' AND (
+		bookmarks.folder = shares.target OR substr(bookmarks.folder, 1, length(shares.target) + 1) = shares.target || '/')))`
+
+// sharedWithCondition selects bookmarks shared with a user (first argument) with one of the permissions (second argument).
+const sharedWithCondition = `EXISTS (SELECT 1 FROM shares WHERE ` + shareCoversBookmark + `
+	AND shares.grantee_id = ? AND shares.permission IN ?)`
+
+// Permissions that grant read and write access respectively.
+var (
+	readPermissions  = []model.SharePermission{model.SharePermissionViewer, model.SharePermissionEditor}
+	writePermissions = []model.SharePermission{model.SharePermissionEditor}
+)
+
+// accessibleBy returns a GORM scope selecting the bookmarks a user may access with one of
+// the permissions: the user's own bookmarks and the bookmarks other users shared with them.
+// It replaces the plain "user_id = ?" ownership check for operations open to grantees.
+func accessibleBy(userID string, permissions []model.SharePermission) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(bookmarks.user_id = ? OR "+sharedWithCondition+")", userID, userID, permissions)
+	}
+}
+
+// GetSharedBookmarks retrieves a paginated list of the bookmarks other users shared with a user,
+// either directly or through a shared tag or folder, most recently created first.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the grantee
+//   - limit: Maximum number of bookmarks returned
+//   - offset: Number of bookmarks skipped
+//
+// Returns:
+//   - []*model.Bookmark: The page of shared bookmarks
+//   - int64: Total number of shared bookmarks
+//   - error: Any database error
+func (r *bookmarkRepo) GetSharedBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.Bookmark, int64, error) {
+	db := r.db.WithContext(ctx).Model(&model.Bookmark{}).
+		Where("bookmarks.user_id <> ?", userID).
+		Where(sharedWithCondition, userID, readPermissions)
+	return r.paginate(db, limit, offset)
+}
+
+// GetBookmarksByShare retrieves a paginated list of the bookmarks covered by a share,
+// most recently created first. It is used to serve public links, so no user is checked.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - shareID: The ID of the share
+//   - limit: Maximum number of bookmarks returned
+//   - offset: Number of bookmarks skipped
+//
+// Returns:
+//   - []*model.Bookmark: The page of shared bookmarks
+//   - int64: Total number of shared bookmarks
+//   - error: Any database error
+func (r *bookmarkRepo) GetBookmarksByShare(ctx context.Context, shareID string, limit, offset int) ([]*model.Bookmark, int64, error) {
+	db := r.db.WithContext(ctx).Model(&model.Bookmark{}).
+		Where("EXISTS (SELECT 1 FROM shares WHERE "+shareCoversBookmark+" AND shares.id = ?)", shareID)
+	return r.paginate(db, limit, offset)
+}
+
+// paginate counts the bookmarks selected by db and loads one page of them, most recently created first.
+func (r *bookmarkRepo) paginate(db *gorm.DB, limit, offset int) ([]*model.Bookmark, int64, error) {
+	bookmarks := make([]*model.Bookmark, 0)
+	var total int64
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return bookmarks, 0, nil
+	}
+
+	err := db.Preload("Tags", orderTagsByName).Order("created_at DESC").Limit(limit).Offset(offset).Find(&bookmarks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return bookmarks, total, nil
+}
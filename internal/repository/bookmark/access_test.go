@@ -0,0 +1,169 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// sharedTestData holds the IDs created by setupSharedBookmarks.
+type sharedTestData struct {
+	taggedID      string // user two, tagged "golang", shared with user one as viewer
+	folderID      string // user two, in "Work/Go", shared with user one as editor through "Work"
+	siblingID     string // user two, in "Workshop", not covered by the "Work" share
+	folderShareID string
+}
+
+// setupSharedBookmarks seeds bookmarks of the second user and shares some of them with the first user.
+func setupSharedBookmarks(t *testing.T) (*gorm.DB, *sharedTestData) {
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+
+	tagged := &model.Bookmark{URL: "https://go.dev", Code: "share001", UserID: fixture.FixtureUserTwoID, Tags: model.NewBookmarkTags([]string{"golang"})}
+	inFolder := &model.Bookmark{URL: "https://go.dev/doc", Code: "share002", UserID: fixture.FixtureUserTwoID, Folder: "Work/Go"}
+	sibling := &model.Bookmark{URL: "https://example.com/workshop", Code: "share003", UserID: fixture.FixtureUserTwoID, Folder: "Workshop"}
+	assert.NoError(t, db.Create([]*model.Bookmark{tagged, inFolder, sibling}).Error)
+
+	grantee := fixture.FixtureUserOneID
+	owner := fixture.FixtureUserTwoID
+	folderShare := &model.Share{OwnerID: owner, TargetType: model.ShareTargetFolder, Target: "Work", GranteeID: &grantee, Permission: model.SharePermissionEditor}
+	shares := []*model.Share{
+		{OwnerID: owner, TargetType: model.ShareTargetTag, Target: "golang", GranteeID: &grantee, Permission: model.SharePermissionViewer},
+		folderShare,
+		// Shares only cover bookmarks of their owner: this one grants nothing
+		{OwnerID: grantee, TargetType: model.ShareTargetBookmark, Target: sibling.ID, GranteeID: &grantee, Permission: model.SharePermissionEditor},
+	}
+	assert.NoError(t, db.Create(shares).Error)
+
+	return db, &sharedTestData{
+		taggedID:      tagged.ID,
+		folderID:      inFolder.ID,
+		siblingID:     sibling.ID,
+		folderShareID: folderShare.ID,
+	}
+}
+
+func TestBookmarkRepo_SharedAccess(t *testing.T) {
+	t.Parallel()
+
+	db, data := setupSharedBookmarks(t)
+	repo := NewRepository(db)
+
+	testCases := []struct {
+		name       string
+		bookmarkID func() string
+		canRead    bool
+		canWrite   bool
+	}{
+		{name: "viewer through tag", bookmarkID: func() string { return data.taggedID }, canRead: true},
+		{name: "editor through parent folder", bookmarkID: func() string { return data.folderID }, canRead: true, canWrite: true},
+		{name: "folder with a common prefix is not covered", bookmarkID: func() string { return data.siblingID }},
+		{name: "unshared bookmark", bookmarkID: func() string { return fixture.FixtureBookmarkTwoID }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bookmarkID := tc.bookmarkID()
+
+			_, err := repo.GetBookmarkByID(t.Context(), bookmarkID, fixture.FixtureUserOneID)
+			if tc.canRead {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, dbutils.ErrNotFoundType)
+			}
+
+			description := "Edited by grantee"
+			err = repo.PatchBookmark(t.Context(), bookmarkID, fixture.FixtureUserOneID, &model.BookmarkPatch{Description: &description}, 0)
+			if tc.canWrite {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, dbutils.ErrNotFoundType)
+			}
+
+			// Editors may not delete
+			err = repo.DeleteBookmark(t.Context(), bookmarkID, fixture.FixtureUserOneID, 0)
+			assert.ErrorIs(t, err, dbutils.ErrNotFoundType)
+		})
+	}
+}
+
+func TestBookmarkRepo_GetSharedBookmarks(t *testing.T) {
+	t.Parallel()
+
+	db, data := setupSharedBookmarks(t)
+	repo := NewRepository(db)
+
+	testCases := []struct {
+		name          string
+		inputUserID   string
+		expectedIDs   []string
+		expectedTotal int64
+	}{
+		{
+			name:          "bookmarks shared through tag and folder",
+			inputUserID:   fixture.FixtureUserOneID,
+			expectedIDs:   []string{data.taggedID, data.folderID},
+			expectedTotal: 2,
+		},
+		{
+			name:        "nothing shared",
+			inputUserID: fixture.FixtureUserTwoID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bookmarks, total, err := repo.GetSharedBookmarks(t.Context(), tc.inputUserID, 10, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTotal, total)
+			ids := make([]string, 0, len(bookmarks))
+			for _, bookmark := range bookmarks {
+				ids = append(ids, bookmark.ID)
+			}
+			assert.ElementsMatch(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestBookmarkRepo_GetBookmarksByShare(t *testing.T) {
+	t.Parallel()
+
+	db, data := setupSharedBookmarks(t)
+	repo := NewRepository(db)
+
+	testCases := []struct {
+		name          string
+		inputShareID  string
+		expectedIDs   []string
+		expectedTotal int64
+	}{
+		{
+			name:          "folder share",
+			inputShareID:  data.folderShareID,
+			expectedIDs:   []string{data.folderID},
+			expectedTotal: 1,
+		},
+		{
+			name:         "unknown share",
+			inputShareID: "00000000-0000-0000-0000-000000000000",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bookmarks, total, err := repo.GetBookmarksByShare(t.Context(), tc.inputShareID, 10, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTotal, total)
+			ids := make([]string, 0, len(bookmarks))
+			for _, bookmark := range bookmarks {
+				ids = append(ids, bookmark.ID)
+			}
+			assert.ElementsMatch(t, tc.expectedIDs, ids)
+		})
+	}
+}
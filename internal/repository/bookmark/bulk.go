@@ -0,0 +1,123 @@
+package bookmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BulkUpdate applies an operation to many bookmarks of a user in a single transaction.
+// When the operation lists IDs, every one of them must be a live bookmark owned by the user;
+// otherwise nothing is changed. When it uses a filter, the bookmarks matching it are selected
+// like GetBookmarks would.
+//
+// The returned count is the number of rows changed: bookmarks for delete, move and archive,
+// tag assignments for tag and untag. Tags a bookmark already carries, or does not carry when
+// untagging, are not counted.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - op: The operation and the bookmarks it applies to
+//
+// Returns:
+//   - int64: Number of rows changed
+//   - error: ErrNotFoundType if one of the IDs does not exist or isn't owned by the user, or a database error
+func (r *bookmarkRepo) BulkUpdate(ctx context.Context, userID string, op *model.BulkOperation) (int64, error) {
+	var affected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids, err := selectBulkTargets(tx, userID, op)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		affected, err = applyBulkAction(tx, ids, op)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// selectBulkTargets returns the IDs of the bookmarks an operation applies to.
+func selectBulkTargets(tx *gorm.DB, userID string, op *model.BulkOperation) ([]string, error) {
+	query := tx.Model(&model.Bookmark{}).Where("user_id = ?", userID)
+	if len(op.IDs) == 0 {
+		query = query.Scopes(filterBookmarks(op.Filter))
+	} else {
+		query = query.Where("id IN ?", op.IDs)
+	}
+
+	var ids []string
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	// Every listed bookmark must have been found, otherwise one of them belongs to someone else
+	if len(op.IDs) > 0 && len(ids) != countDistinct(op.IDs) {
+		return nil, dbutils.ErrNotFoundType
+	}
+	return ids, nil
+}
+
+// applyBulkAction performs the action of op on the bookmarks with the given IDs.
+func applyBulkAction(tx *gorm.DB, ids []string, op *model.BulkOperation) (int64, error) {
+	var result *gorm.DB
+	switch op.Action {
+	case model.BulkActionDelete:
+		result = tx.Where("id IN ?", ids).Delete(&model.Bookmark{})
+	case model.BulkActionArchive:
+		result = tx.Model(&model.Bookmark{}).Where("id IN ?", ids).Update("archived", true)
+	case model.BulkActionMove:
+		result = tx.Model(&model.Bookmark{}).Where("id IN ?", ids).Update("folder", op.Folder)
+	case model.BulkActionTag:
+		if len(op.Tags) == 0 {
+			return 0, nil
+		}
+		tags := make([]model.BookmarkTag, 0, len(ids)*len(op.Tags))
+		for _, id := range ids {
+			for _, name := range op.Tags {
+				tags = append(tags, model.BookmarkTag{BookmarkID: id, Name: name})
+			}
+		}
+		// Tags the bookmark already carries are left alone
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags)
+	case model.BulkActionUntag:
+		if len(op.Tags) == 0 {
+			return 0, nil
+		}
+		result = tx.Where("bookmark_id IN ? AND name IN ?", ids, op.Tags).Delete(&model.BookmarkTag{})
+	default:
+		return 0, fmt.Errorf("unknown bulk action %q", op.Action)
+	}
+
+	if result.Error != nil {
+		return 0, dbutils.CatchDBErr(result.Error)
+	}
+
+	// Tags live in their own table: touch the bookmarks so that their version changes too
+	if (op.Action == model.BulkActionTag || op.Action == model.BulkActionUntag) && result.RowsAffected > 0 {
+		if err := tx.Model(&model.Bookmark{}).Where("id IN ?", ids).Update("updated_at", time.Now()).Error; err != nil {
+			return 0, dbutils.CatchDBErr(err)
+		}
+	}
+	return result.RowsAffected, nil
+}
+
+// countDistinct returns the number of distinct values in values.
+func countDistinct(values []string) int {
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		seen[v] = struct{}{}
+	}
+	return len(seen)
+}
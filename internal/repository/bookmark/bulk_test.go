@@ -0,0 +1,140 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// bulkBookmarkID is the ID of the extra bookmark created for user one by setupBulkBookmarks.
+const bulkBookmarkID = "b0000000-58cc-4372-a567-0e02b2c3d479"
+
+// setupBulkBookmarks adds a second bookmark for user one, tagged "go" and broken.
+func setupBulkBookmarks(t *testing.T) *gorm.DB {
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	checkedAt := fixture.FixtureTimestamp
+	assert.NoError(t, db.Create(&model.Bookmark{
+		Base:      model.Base{ID: bulkBookmarkID},
+		URL:       "https://example.com/bulk",
+		Code:      "bulk0001",
+		UserID:    fixture.FixtureUserOneID,
+		Tags:      model.NewBookmarkTags([]string{"go"}),
+		LinkCheck: model.LinkCheck{StatusCode: 404, LastCheckedAt: &checkedAt},
+	}).Error)
+	return db
+}
+
+func TestBookmarkRepo_BulkUpdate(t *testing.T) {
+	t.Parallel()
+
+	bothIDs := []string{fixture.FixtureBookmarkOneID, bulkBookmarkID}
+	active := false
+
+	testCases := []struct {
+		name             string
+		inputOp          *model.BulkOperation
+		expectedAffected int64
+		expectedErr      error
+		verify           func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name:             "success - delete by IDs",
+			inputOp:          &model.BulkOperation{Action: model.BulkActionDelete, IDs: bothIDs},
+			expectedAffected: 2,
+			verify: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.Bookmark{}).Where("user_id = ?", fixture.FixtureUserOneID).Count(&count)
+				assert.Zero(t, count)
+			},
+		},
+		{
+			name:             "success - delete by filter",
+			inputOp:          &model.BulkOperation{Action: model.BulkActionDelete, Filter: &model.BookmarkFilter{LinkStatus: model.LinkStatusBroken}},
+			expectedAffected: 1,
+			verify: func(t *testing.T, db *gorm.DB) {
+				assert.ErrorIs(t, db.First(&model.Bookmark{}, "id = ?", bulkBookmarkID).Error, gorm.ErrRecordNotFound)
+				assert.NoError(t, db.First(&model.Bookmark{}, "id = ?", fixture.FixtureBookmarkOneID).Error)
+			},
+		},
+		{
+			name:             "success - tag skips tags already present",
+			inputOp:          &model.BulkOperation{Action: model.BulkActionTag, IDs: bothIDs, Tags: []string{"go", "docs"}},
+			expectedAffected: 3,
+			verify: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.BookmarkTag{}).Where("bookmark_id IN ?", bothIDs).Count(&count)
+				assert.Equal(t, int64(4), count)
+			},
+		},
+		{
+			name:             "success - untag",
+			inputOp:          &model.BulkOperation{Action: model.BulkActionUntag, IDs: bothIDs, Tags: []string{"go"}},
+			expectedAffected: 1,
+		},
+		{
+			name:             "success - move",
+			inputOp:          &model.BulkOperation{Action: model.BulkActionMove, IDs: bothIDs, Folder: "Work/Archive"},
+			expectedAffected: 2,
+			verify: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.Bookmark{}).Where("folder = ?", "Work/Archive").Count(&count)
+				assert.Equal(t, int64(2), count)
+			},
+		},
+		{
+			name:             "success - archive by filter",
+			inputOp:          &model.BulkOperation{Action: model.BulkActionArchive, Filter: &model.BookmarkFilter{Archived: &active}},
+			expectedAffected: 2,
+			verify: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.Bookmark{}).Where("archived = ?", true).Count(&count)
+				assert.Equal(t, int64(2), count, "bookmarks of other users are left alone")
+			},
+		},
+		{
+			name:             "success - duplicate IDs",
+			inputOp:          &model.BulkOperation{Action: model.BulkActionArchive, IDs: []string{bulkBookmarkID, bulkBookmarkID}},
+			expectedAffected: 1,
+		},
+		{
+			name:        "error - bookmark of another user rolls back everything",
+			inputOp:     &model.BulkOperation{Action: model.BulkActionDelete, IDs: append(bothIDs, fixture.FixtureBookmarkTwoID)},
+			expectedErr: dbutils.ErrNotFoundType,
+			verify: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.Bookmark{}).Count(&count)
+				assert.Equal(t, int64(3), count)
+			},
+		},
+		{
+			name:        "error - trashed bookmark",
+			inputOp:     &model.BulkOperation{Action: model.BulkActionMove, IDs: []string{fixture.FixtureBookmarkTrashedID}},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := setupBulkBookmarks(t)
+			repo := NewRepository(db)
+
+			affected, err := repo.BulkUpdate(t.Context(), fixture.FixtureUserOneID, tc.inputOp)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAffected, affected)
+			}
+			if tc.verify != nil {
+				tc.verify(t, db)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+)
+
+// SetCode replaces the short code of a bookmark owned by the specified user.
+// The previous code stops redirecting as soon as the update is committed.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the owner (for ownership validation)
+//   - code: The new short code
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist or isn't owned by the user,
+//     ErrDuplicationType if another bookmark already has the code
+func (r *bookmarkRepo) SetCode(ctx context.Context, bookmarkID, userID, code string) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.Bookmark{}).
+		Where("id = ? AND user_id = ?", bookmarkID, userID).
+		Update("code", code)
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	// Check if any row was actually updated
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkRepo_SetCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		inputBookmarkID string
+		inputUserID     string
+		inputCode       string
+		expectedErr     error
+	}{
+		{
+			name:            "success - code replaced",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputCode:       "newcode12",
+		},
+		{
+			name:            "error - code of another bookmark",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputCode:       fixture.FixtureBookmarkTwoCode,
+			expectedErr:     dbutils.ErrDuplicationType,
+		},
+		{
+			name:            "error - bookmark belongs to different user",
+			inputBookmarkID: fixture.FixtureBookmarkTwoID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputCode:       "newcode12",
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			repo := NewRepository(db)
+
+			err := repo.SetCode(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputCode)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			bookmark := &model.Bookmark{}
+			assert.NoError(t, db.First(bookmark, "id = ?", tc.inputBookmarkID).Error)
+			assert.Equal(t, tc.inputCode, bookmark.Code)
+			assert.Equal(t, 2, bookmark.Version)
+		})
+	}
+}
@@ -5,12 +5,17 @@ import (
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/urlnorm"
 )
 
 // CreateBookmark inserts a new bookmark record into the database.
 // It returns the created bookmark model with populated fields (like ID and timestamps) or an error.
+// The normalized URL used for duplicate detection and the domain used for statistics
+// are derived from the URL before inserting.
 // Any database errors are translated into application-specific errors using dbutils.CatchDBErr.
 func (r *bookmarkRepo) CreateBookmark(ctx context.Context, bookmark *model.Bookmark) (*model.Bookmark, error) {
+	bookmark.NormalizedURL = urlnorm.Normalize(bookmark.URL)
+	bookmark.Domain = urlnorm.Domain(bookmark.URL)
 	err := r.db.WithContext(ctx).Create(&bookmark).Error
 	if err != nil {
 		return nil, dbutils.CatchDBErr(err)
@@ -14,13 +14,17 @@ import (
 //   - ctx: Context for the operation
 //   - bookmarkID: The ID of the bookmark to delete
 //   - userID: The ID of the user attempting the deletion (for ownership validation)
+//   - version: The version the bookmark must have to be deleted, 0 to delete unconditionally
 //
 // Returns:
-//   - error: nil on success, ErrNotFoundType if bookmark doesn't exist or user doesn't own it
-func (r *bookmarkRepo) DeleteBookmark(ctx context.Context, bookmarkID, userID string) error {
-	result := r.db.WithContext(ctx).
-		Where("id = ? AND user_id = ?", bookmarkID, userID).
-		Delete(&model.Bookmark{})
+//   - error: nil on success, ErrNotFoundType if bookmark doesn't exist or user doesn't own it,
+//     ErrVersionMismatch if the bookmark was modified since the given version
+func (r *bookmarkRepo) DeleteBookmark(ctx context.Context, bookmarkID, userID string, version int) error {
+	query := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", bookmarkID, userID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Delete(&model.Bookmark{})
 
 	if result.Error != nil {
 		return dbutils.CatchDBErr(result.Error)
@@ -28,7 +32,22 @@ func (r *bookmarkRepo) DeleteBookmark(ctx context.Context, bookmarkID, userID st
 
 	// Check if any row was actually deleted
 	if result.RowsAffected == 0 {
-		return dbutils.ErrNotFoundType
+		if version == 0 {
+			return dbutils.ErrNotFoundType
+		}
+
+		// Tell a stale version apart from a missing bookmark
+		var exists int64
+		err := r.db.WithContext(ctx).Model(&model.Bookmark{}).
+			Where("id = ? AND user_id = ?", bookmarkID, userID).
+			Count(&exists).Error
+		if err != nil {
+			return dbutils.CatchDBErr(err)
+		}
+		if exists == 0 {
+			return dbutils.ErrNotFoundType
+		}
+		return dbutils.ErrVersionMismatch
 	}
 
 	return nil
@@ -17,6 +17,7 @@ func TestBookmarkRepo_DeleteBookmark(t *testing.T) {
 		setupDB         func(t *testing.T) *gorm.DB
 		inputBookmarkID string
 		inputUserID     string
+		inputVersion    int
 		expectedErr     error
 		expectAnyErr    bool // Set to true to check for any error (not specific type)
 		verifyFunc      func(t *testing.T, db *gorm.DB)
@@ -38,6 +39,49 @@ func TestBookmarkRepo_DeleteBookmark(t *testing.T) {
 				assert.Equal(t, int64(0), count, "Bookmark should be soft-deleted")
 			},
 		},
+		{
+			name: "success - version matches",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputVersion:    1,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Table("bookmarks").
+					Where("id = ? AND deleted_at IS NULL", fixture.FixtureBookmarkOneID).
+					Count(&count)
+				assert.Equal(t, int64(0), count, "Bookmark should be soft-deleted")
+			},
+		},
+		{
+			name: "error - version mismatch",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputVersion:    2,
+			expectedErr:     dbutils.ErrVersionMismatch,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Table("bookmarks").
+					Where("id = ? AND deleted_at IS NULL", fixture.FixtureBookmarkOneID).
+					Count(&count)
+				assert.Equal(t, int64(1), count, "Bookmark must not be deleted")
+			},
+		},
+		{
+			name: "error - version of a bookmark belonging to different user",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserTwoID,
+			inputVersion:    2,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
 		{
 			name: "error - bookmark not found",
 			setupDB: func(t *testing.T) *gorm.DB {
@@ -79,7 +123,7 @@ func TestBookmarkRepo_DeleteBookmark(t *testing.T) {
 			db := tc.setupDB(t)
 			repo := NewRepository(db)
 
-			err := repo.DeleteBookmark(ctx, tc.inputBookmarkID, tc.inputUserID)
+			err := repo.DeleteBookmark(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputVersion)
 
 			if tc.expectAnyErr {
 				assert.Error(t, err)
@@ -88,6 +132,9 @@ func TestBookmarkRepo_DeleteBookmark(t *testing.T) {
 
 			if tc.expectedErr != nil {
 				assert.ErrorIs(t, err, tc.expectedErr)
+				if tc.verifyFunc != nil {
+					tc.verifyFunc(t, db)
+				}
 				return
 			}
 
@@ -0,0 +1,39 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+)
+
+// GetDuplicateBookmarks retrieves every bookmark of the user whose normalized URL
+// is shared with at least one other bookmark of the same user.
+// Bookmarks are ordered by normalized URL, then by creation time, so each group of
+// duplicates is contiguous and starts with the original bookmark.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - []*model.Bookmark: The duplicated bookmarks, empty when there are none
+//   - error: Any database error
+func (r *bookmarkRepo) GetDuplicateBookmarks(ctx context.Context, userID string) ([]*model.Bookmark, error) {
+	duplicated := r.db.Model(&model.Bookmark{}).
+		Select("normalized_url").
+		Where("user_id = ?", userID).
+		Group("normalized_url").
+		Having("COUNT(*) > 1")
+
+	bookmarks := make([]*model.Bookmark, 0)
+	err := r.db.WithContext(ctx).
+		Preload("Tags", orderTagsByName).
+		Where("user_id = ? AND normalized_url IN (?)", userID, duplicated).
+		Order("normalized_url ASC, created_at ASC, id ASC").
+		Find(&bookmarks).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return bookmarks, nil
+}
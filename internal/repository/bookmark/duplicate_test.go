@@ -0,0 +1,82 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBookmarkRepo_GetDuplicateBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		inputUserID   string
+		expectedCodes []string
+	}{
+		{
+			name: "success - trashed bookmarks do not count as duplicates",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputUserID:   fixture.FixtureUserOneID,
+			expectedCodes: []string{},
+		},
+		{
+			name: "success - groups spellings of the same URL",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				repo := NewRepository(db)
+				for _, bookmark := range []*model.Bookmark{
+					{URL: "https://EXAMPLE.com/long-url/?utm_source=x", Code: "dup00001"},
+					{URL: "https://example.com/other#a", Code: "dup00002"},
+					{URL: "https://example.com/other", Code: "dup00003"},
+					{URL: "https://example.com/unique", Code: "dup00004"},
+				} {
+					bookmark.UserID = fixture.FixtureUserOneID
+					_, err := repo.CreateBookmark(t.Context(), bookmark)
+					assert.NoError(t, err)
+				}
+				return db
+			},
+			inputUserID:   fixture.FixtureUserOneID,
+			expectedCodes: []string{fixture.FixtureBookmarkOneCode, "dup00001", "dup00002", "dup00003"},
+		},
+		{
+			name: "success - other users' bookmarks are not considered",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				_, err := NewRepository(db).CreateBookmark(t.Context(), &model.Bookmark{
+					URL:    fixture.FixtureBookmarkURL,
+					Code:   "dup00001",
+					UserID: fixture.FixtureUserTwoID,
+				})
+				assert.NoError(t, err)
+				return db
+			},
+			inputUserID:   fixture.FixtureUserOneID,
+			expectedCodes: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(tc.setupDB(t))
+
+			bookmarks, err := repo.GetDuplicateBookmarks(t.Context(), tc.inputUserID)
+
+			assert.NoError(t, err)
+			codes := make([]string, 0, len(bookmarks))
+			for _, bookmark := range bookmarks {
+				codes = append(codes, bookmark.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
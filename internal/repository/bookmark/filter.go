@@ -0,0 +1,44 @@
+package bookmark
+
+import (
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"gorm.io/gorm"
+)
+
+// filterBookmarks returns a GORM scope applying the filter to a bookmark query.
+// A nil filter selects every bookmark that is not archived.
+func filterBookmarks(filter *model.BookmarkFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter == nil {
+			return db.Where("archived = ?", false)
+		}
+
+		db = db.Where("archived = ?", filter.Archived != nil && *filter.Archived)
+
+		if filter.Favorite != nil {
+			db = db.Where("is_favorite = ?", *filter.Favorite)
+		}
+		if filter.Pinned != nil {
+			db = db.Where("pinned = ?", *filter.Pinned)
+		}
+		if filter.Unread != nil {
+			if *filter.Unread {
+				db = db.Where("read_at IS NULL")
+			} else {
+				db = db.Where("read_at IS NOT NULL")
+			}
+		}
+
+		switch filter.LinkStatus {
+		case model.LinkStatusBroken:
+			db = db.Where("last_checked_at IS NOT NULL AND (status_code = 0 OR status_code >= 400)")
+		case model.LinkStatusOK:
+			db = db.Where("last_checked_at IS NOT NULL AND status_code > 0 AND status_code < 400")
+		case model.LinkStatusRedirected:
+			db = db.Where("final_url <> ''")
+		case model.LinkStatusUnchecked:
+			db = db.Where("last_checked_at IS NULL")
+		}
+		return db
+	}
+}
@@ -0,0 +1,95 @@
+package bookmark
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// GetBookmarksDueForCheck retrieves bookmarks of all users whose link has not been checked
+// since checkedBefore. Bookmarks that were never checked come first, then the ones checked
+// least recently, so that repeated calls eventually cover every bookmark.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - checkedBefore: Bookmarks checked at or after this time are skipped
+//   - limit: Maximum number of bookmarks returned
+//
+// Returns:
+//   - []*model.Bookmark: The bookmarks to check, without tags
+//   - error: Any database error
+func (r *bookmarkRepo) GetBookmarksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.Bookmark, error) {
+	bookmarks := make([]*model.Bookmark, 0)
+	err := r.db.WithContext(ctx).
+		Where("last_checked_at IS NULL OR last_checked_at < ?", checkedBefore).
+		Order("last_checked_at IS NOT NULL, last_checked_at ASC, id ASC").
+		Limit(limit).
+		Find(&bookmarks).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return bookmarks, nil
+}
+
+// UpdateLinkCheck stores the outcome of a link check on a bookmark.
+// It is called by the link checker job rather than on behalf of a user, so no ownership check is done,
+// and the version of the bookmark is left unchanged: clients holding its ETag can still update it.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the checked bookmark
+//   - check: The outcome of the check
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist or is in the trash
+func (r *bookmarkRepo) UpdateLinkCheck(ctx context.Context, bookmarkID string, check *model.LinkCheck) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.Bookmark{}).
+		Where("id = ?", bookmarkID).
+		UpdateColumns(map[string]any{
+			"status_code":     check.StatusCode,
+			"final_url":       check.FinalURL,
+			"check_error":     check.CheckError,
+			"last_checked_at": check.LastCheckedAt,
+		})
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	// Check if any row was actually updated
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// AcceptRedirect replaces the URL of a bookmark with the target of its permanent redirect.
+// The update only applies while finalURL is still the redirect recorded on the bookmark,
+// so a concurrent link check cannot make the user accept a different target than the one shown.
+// The replaced URL is recorded as a revision in the same transaction.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the user accepting the redirect (for ownership validation)
+//   - finalURL: The redirect target the user accepts
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist, isn't owned by the user
+//     or no longer redirects to finalURL
+func (r *bookmarkRepo) AcceptRedirect(ctx context.Context, bookmarkID, userID, finalURL string) error {
+	redirectsTo := func(db *gorm.DB) *gorm.DB {
+		return db.Where("bookmarks.user_id = ? AND bookmarks.final_url = ?", userID, finalURL)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := urlColumns(finalURL)
+		updates["final_url"] = ""
+		return updateWithRevision(tx, bookmarkID, userID, redirectsTo, updates, 0)
+	})
+}
@@ -0,0 +1,170 @@
+package bookmark
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkRepo_GetBookmarksDueForCheck(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	repo := NewRepository(db)
+
+	recent := fixture.FixtureTimestamp.Add(time.Hour)
+	old := fixture.FixtureTimestamp.Add(-time.Hour)
+	assert.NoError(t, repo.UpdateLinkCheck(t.Context(), fixture.FixtureBookmarkOneID, &model.LinkCheck{StatusCode: 200, LastCheckedAt: &recent}))
+	assert.NoError(t, repo.UpdateLinkCheck(t.Context(), fixture.FixtureBookmarkTwoID, &model.LinkCheck{StatusCode: 200, LastCheckedAt: &old}))
+	unchecked, err := repo.CreateBookmark(t.Context(), &model.Bookmark{URL: "https://example.com/new", Code: "check001", UserID: fixture.FixtureUserOneID})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		inputBefore time.Time
+		inputLimit  int
+		expectedIDs []string
+	}{
+		{
+			name:        "never checked first, then least recently checked",
+			inputBefore: fixture.FixtureTimestamp.Add(2 * time.Hour),
+			inputLimit:  10,
+			expectedIDs: []string{unchecked.ID, fixture.FixtureBookmarkTwoID, fixture.FixtureBookmarkOneID},
+		},
+		{
+			name:        "recently checked bookmarks are skipped",
+			inputBefore: fixture.FixtureTimestamp,
+			inputLimit:  10,
+			expectedIDs: []string{unchecked.ID, fixture.FixtureBookmarkTwoID},
+		},
+		{
+			name:        "limit",
+			inputBefore: fixture.FixtureTimestamp,
+			inputLimit:  1,
+			expectedIDs: []string{unchecked.ID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bookmarks, err := repo.GetBookmarksDueForCheck(t.Context(), tc.inputBefore, tc.inputLimit)
+
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(bookmarks))
+			for _, bookmark := range bookmarks {
+				ids = append(ids, bookmark.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids, "trashed bookmarks are never checked")
+		})
+	}
+}
+
+func TestBookmarkRepo_UpdateLinkCheck(t *testing.T) {
+	t.Parallel()
+
+	checkedAt := fixture.FixtureTimestamp
+	testCheck := &model.LinkCheck{
+		StatusCode:    200,
+		FinalURL:      "https://example.com/moved",
+		LastCheckedAt: &checkedAt,
+	}
+
+	testCases := []struct {
+		name            string
+		inputBookmarkID string
+		expectedErr     error
+	}{
+		{
+			name:            "success - store the check",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+		},
+		{
+			name:            "error - bookmark in trash",
+			inputBookmarkID: fixture.FixtureBookmarkTrashedID,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+
+			err := repo.UpdateLinkCheck(t.Context(), tc.inputBookmarkID, testCheck)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			bookmark, err := repo.GetBookmarkByID(t.Context(), tc.inputBookmarkID, fixture.FixtureUserOneID)
+			assert.NoError(t, err)
+			assert.Equal(t, testCheck.StatusCode, bookmark.StatusCode)
+			assert.Equal(t, testCheck.FinalURL, bookmark.FinalURL)
+			assert.True(t, checkedAt.Equal(*bookmark.LastCheckedAt))
+			assert.Equal(t, 1, bookmark.Version, "a link check must not change the ETag of the bookmark")
+		})
+	}
+}
+
+func TestBookmarkRepo_AcceptRedirect(t *testing.T) {
+	t.Parallel()
+
+	const movedURL = "https://Example.com/moved/"
+
+	testCases := []struct {
+		name          string
+		inputUserID   string
+		inputFinalURL string
+		expectedErr   error
+	}{
+		{
+			name:          "success - URL replaced by redirect target",
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFinalURL: movedURL,
+		},
+		{
+			name:          "error - redirect target changed in the meantime",
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFinalURL: "https://example.com/elsewhere",
+			expectedErr:   dbutils.ErrNotFoundType,
+		},
+		{
+			name:          "error - bookmark belongs to different user",
+			inputUserID:   fixture.FixtureUserTwoID,
+			inputFinalURL: movedURL,
+			expectedErr:   dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+			checkedAt := fixture.FixtureTimestamp
+			assert.NoError(t, repo.UpdateLinkCheck(t.Context(), fixture.FixtureBookmarkOneID,
+				&model.LinkCheck{StatusCode: 200, FinalURL: movedURL, LastCheckedAt: &checkedAt}))
+
+			err := repo.AcceptRedirect(t.Context(), fixture.FixtureBookmarkOneID, tc.inputUserID, tc.inputFinalURL)
+
+			bookmark, getErr := repo.GetBookmarkByID(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserOneID)
+			assert.NoError(t, getErr)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, fixture.FixtureBookmarkURL, bookmark.URL)
+				assert.Equal(t, movedURL, bookmark.FinalURL)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, movedURL, bookmark.URL)
+			assert.Equal(t, "https://example.com/moved", bookmark.NormalizedURL)
+			assert.Empty(t, bookmark.FinalURL)
+		})
+	}
+}
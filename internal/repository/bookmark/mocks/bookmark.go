@@ -7,6 +7,8 @@ import (
 
 	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -14,6 +16,98 @@ type Repository struct {
 	mock.Mock
 }
 
+// AcceptRedirect provides a mock function with given fields: ctx, bookmarkID, userID, finalURL
+func (_m *Repository) AcceptRedirect(ctx context.Context, bookmarkID string, userID string, finalURL string) error {
+	ret := _m.Called(ctx, bookmarkID, userID, finalURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptRedirect")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, finalURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BulkUpdate provides a mock function with given fields: ctx, userID, op
+func (_m *Repository) BulkUpdate(ctx context.Context, userID string, op *model.BulkOperation) (int64, error) {
+	ret := _m.Called(ctx, userID, op)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BulkOperation) (int64, error)); ok {
+		return rf(ctx, userID, op)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BulkOperation) int64); ok {
+		r0 = rf(ctx, userID, op)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.BulkOperation) error); ok {
+		r1 = rf(ctx, userID, op)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteSnapshot provides a mock function with given fields: ctx, bookmarkID, size, capturedAt
+func (_m *Repository) CompleteSnapshot(ctx context.Context, bookmarkID string, size int64, capturedAt time.Time) error {
+	ret := _m.Called(ctx, bookmarkID, size, capturedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Time) error); ok {
+		r0 = rf(ctx, bookmarkID, size, capturedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountBookmarks provides a mock function with given fields: ctx, userID
+func (_m *Repository) CountBookmarks(ctx context.Context, userID string) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBookmarks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateBookmark provides a mock function with given fields: ctx, _a1
 func (_m *Repository) CreateBookmark(ctx context.Context, _a1 *model.Bookmark) (*model.Bookmark, error) {
 	ret := _m.Called(ctx, _a1)
@@ -44,17 +138,53 @@ func (_m *Repository) CreateBookmark(ctx context.Context, _a1 *model.Bookmark) (
 	return r0, r1
 }
 
-// DeleteBookmark provides a mock function with given fields: ctx, bookmarkID, userID
-func (_m *Repository) DeleteBookmark(ctx context.Context, bookmarkID string, userID string) error {
-	ret := _m.Called(ctx, bookmarkID, userID)
+// DeleteBookmark provides a mock function with given fields: ctx, bookmarkID, userID, version
+func (_m *Repository) DeleteBookmark(ctx context.Context, bookmarkID string, userID string, version int) error {
+	ret := _m.Called(ctx, bookmarkID, userID, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteBookmark")
 	}
 
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSnapshot provides a mock function with given fields: ctx, bookmarkID
+func (_m *Repository) DeleteSnapshot(ctx context.Context, bookmarkID string) error {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailSnapshot provides a mock function with given fields: ctx, bookmarkID, reason
+func (_m *Repository) FailSnapshot(ctx context.Context, bookmarkID string, reason string) error {
+	ret := _m.Called(ctx, bookmarkID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailSnapshot")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, bookmarkID, userID)
+		r0 = rf(ctx, bookmarkID, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,22 +192,119 @@ func (_m *Repository) DeleteBookmark(ctx context.Context, bookmarkID string, use
 	return r0
 }
 
-// GetBookmarks provides a mock function with given fields: ctx, userID, limit, offset
-func (_m *Repository) GetBookmarks(ctx context.Context, userID string, limit int, offset int) ([]*model.Bookmark, int64, error) {
-	ret := _m.Called(ctx, userID, limit, offset)
+// FindBookmarkByURL provides a mock function with given fields: ctx, userID, url
+func (_m *Repository) FindBookmarkByURL(ctx context.Context, userID string, url string) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, userID, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBookmarkByURL")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Bookmark, error)); ok {
+		return rf(ctx, userID, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Bookmark); ok {
+		r0 = rf(ctx, userID, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarkByID provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Repository) GetBookmarkByID(ctx context.Context, bookmarkID string, userID string) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookmarkByID")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarks provides a mock function with given fields: ctx, userID, filter, limit, offset
+func (_m *Repository) GetBookmarks(ctx context.Context, userID string, filter *model.BookmarkFilter, limit int, offset int) ([]*model.Bookmark, int64, error) {
+	ret := _m.Called(ctx, userID, filter, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBookmarks")
 	}
 
+	var r0 []*model.Bookmark
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BookmarkFilter, int, int) ([]*model.Bookmark, int64, error)); ok {
+		return rf(ctx, userID, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BookmarkFilter, int, int) []*model.Bookmark); ok {
+		r0 = rf(ctx, userID, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.BookmarkFilter, int, int) int64); ok {
+		r1 = rf(ctx, userID, filter, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *model.BookmarkFilter, int, int) error); ok {
+		r2 = rf(ctx, userID, filter, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBookmarksByShare provides a mock function with given fields: ctx, shareID, limit, offset
+func (_m *Repository) GetBookmarksByShare(ctx context.Context, shareID string, limit int, offset int) ([]*model.Bookmark, int64, error) {
+	ret := _m.Called(ctx, shareID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookmarksByShare")
+	}
+
 	var r0 []*model.Bookmark
 	var r1 int64
 	var r2 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.Bookmark, int64, error)); ok {
-		return rf(ctx, userID, limit, offset)
+		return rf(ctx, shareID, limit, offset)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.Bookmark); ok {
-		r0 = rf(ctx, userID, limit, offset)
+		r0 = rf(ctx, shareID, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Bookmark)
@@ -85,13 +312,13 @@ func (_m *Repository) GetBookmarks(ctx context.Context, userID string, limit int
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
-		r1 = rf(ctx, userID, limit, offset)
+		r1 = rf(ctx, shareID, limit, offset)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = rf(ctx, userID, limit, offset)
+		r2 = rf(ctx, shareID, limit, offset)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -99,17 +326,602 @@ func (_m *Repository) GetBookmarks(ctx context.Context, userID string, limit int
 	return r0, r1, r2
 }
 
-// UpdateBookmark provides a mock function with given fields: ctx, bookmarkID, userID, description, url
-func (_m *Repository) UpdateBookmark(ctx context.Context, bookmarkID string, userID string, description string, url string) error {
-	ret := _m.Called(ctx, bookmarkID, userID, description, url)
+// GetBookmarksDueForCheck provides a mock function with given fields: ctx, checkedBefore, limit
+func (_m *Repository) GetBookmarksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.Bookmark, error) {
+	ret := _m.Called(ctx, checkedBefore, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateBookmark")
+		panic("no return value specified for GetBookmarksDueForCheck")
+	}
+
+	var r0 []*model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*model.Bookmark, error)); ok {
+		return rf(ctx, checkedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*model.Bookmark); ok {
+		r0 = rf(ctx, checkedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, checkedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicateBookmarks provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetDuplicateBookmarks(ctx context.Context, userID string) ([]*model.Bookmark, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicateBookmarks")
+	}
+
+	var r0 []*model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Bookmark, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Bookmark); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrphanedSnapshots provides a mock function with given fields: ctx, limit
+func (_m *Repository) GetOrphanedSnapshots(ctx context.Context, limit int) ([]*model.BookmarkSnapshot, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrphanedSnapshots")
+	}
+
+	var r0 []*model.BookmarkSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.BookmarkSnapshot, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.BookmarkSnapshot); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BookmarkSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevision provides a mock function with given fields: ctx, bookmarkID, revision
+func (_m *Repository) GetRevision(ctx context.Context, bookmarkID string, revision int) (*model.BookmarkRevision, error) {
+	ret := _m.Called(ctx, bookmarkID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *model.BookmarkRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*model.BookmarkRevision, error)); ok {
+		return rf(ctx, bookmarkID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *model.BookmarkRevision); ok {
+		r0 = rf(ctx, bookmarkID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, bookmarkID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, bookmarkID, limit, offset
+func (_m *Repository) GetRevisions(ctx context.Context, bookmarkID string, limit int, offset int) ([]*model.BookmarkRevision, int64, error) {
+	ret := _m.Called(ctx, bookmarkID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []*model.BookmarkRevision
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.BookmarkRevision, int64, error)); ok {
+		return rf(ctx, bookmarkID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.BookmarkRevision); ok {
+		r0 = rf(ctx, bookmarkID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BookmarkRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, bookmarkID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, bookmarkID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSharedBookmarks provides a mock function with given fields: ctx, userID, limit, offset
+func (_m *Repository) GetSharedBookmarks(ctx context.Context, userID string, limit int, offset int) ([]*model.Bookmark, int64, error) {
+	ret := _m.Called(ctx, userID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedBookmarks")
+	}
+
+	var r0 []*model.Bookmark
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.Bookmark, int64, error)); ok {
+		return rf(ctx, userID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.Bookmark); ok {
+		r0 = rf(ctx, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, userID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, userID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSnapshot provides a mock function with given fields: ctx, bookmarkID
+func (_m *Repository) GetSnapshot(ctx context.Context, bookmarkID string) (*model.BookmarkSnapshot, error) {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshot")
+	}
+
+	var r0 *model.BookmarkSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.BookmarkSnapshot, error)); ok {
+		return rf(ctx, bookmarkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.BookmarkSnapshot); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, bookmarkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshotUsage provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetSnapshotUsage(ctx context.Context, userID string) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshotUsage")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStats provides a mock function with given fields: ctx, userID, limit
+func (_m *Repository) GetStats(ctx context.Context, userID string, limit int) (*model.BookmarkStats, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 *model.BookmarkStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*model.BookmarkStats, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *model.BookmarkStats); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrashedBookmarks provides a mock function with given fields: ctx, userID, limit, offset
+func (_m *Repository) GetTrashedBookmarks(ctx context.Context, userID string, limit int, offset int) ([]*model.Bookmark, int64, error) {
+	ret := _m.Called(ctx, userID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashedBookmarks")
+	}
+
+	var r0 []*model.Bookmark
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.Bookmark, int64, error)); ok {
+		return rf(ctx, userID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.Bookmark); ok {
+		r0 = rf(ctx, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, userID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, userID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IterateBookmarks provides a mock function with given fields: ctx, userID, batchSize, fn
+func (_m *Repository) IterateBookmarks(ctx context.Context, userID string, batchSize int, fn func([]*model.Bookmark) error) error {
+	ret := _m.Called(ctx, userID, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IterateBookmarks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, func([]*model.Bookmark) error) error); ok {
+		r0 = rf(ctx, userID, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OverwriteBookmark provides a mock function with given fields: ctx, _a1
+func (_m *Repository) OverwriteBookmark(ctx context.Context, _a1 *model.Bookmark) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OverwriteBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Bookmark) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PatchBookmark provides a mock function with given fields: ctx, bookmarkID, userID, patch, version
+func (_m *Repository) PatchBookmark(ctx context.Context, bookmarkID string, userID string, patch *model.BookmarkPatch, version int) error {
+	ret := _m.Called(ctx, bookmarkID, userID, patch, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.BookmarkPatch, int) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, patch, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeTrashedBookmarks provides a mock function with given fields: ctx, before
+func (_m *Repository) PurgeTrashedBookmarks(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrashedBookmarks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordClick provides a mock function with given fields: ctx, code
+func (_m *Repository) RecordClick(ctx context.Context, code string) (string, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordClick")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestSnapshot provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Repository) RequestSnapshot(ctx context.Context, bookmarkID string, userID string) (*model.BookmarkSnapshot, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestSnapshot")
+	}
+
+	var r0 *model.BookmarkSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.BookmarkSnapshot, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.BookmarkSnapshot); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreBookmark provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Repository) RestoreBookmark(ctx context.Context, bookmarkID string, userID string) error {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertBookmark provides a mock function with given fields: ctx, bookmarkID, userID, revision
+func (_m *Repository) RevertBookmark(ctx context.Context, bookmarkID string, userID string, revision int) error {
+	ret := _m.Called(ctx, bookmarkID, userID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCode provides a mock function with given fields: ctx, bookmarkID, userID, code
+func (_m *Repository) SetCode(ctx context.Context, bookmarkID string, userID string, code string) error {
+	ret := _m.Called(ctx, bookmarkID, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetReadAt provides a mock function with given fields: ctx, bookmarkID, userID, readAt
+func (_m *Repository) SetReadAt(ctx context.Context, bookmarkID string, userID string, readAt *time.Time) error {
+	ret := _m.Called(ctx, bookmarkID, userID, readAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReadAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, readAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ToggleBookmarkState provides a mock function with given fields: ctx, bookmarkID, userID, state
+func (_m *Repository) ToggleBookmarkState(ctx context.Context, bookmarkID string, userID string, state model.BookmarkState) error {
+	ret := _m.Called(ctx, bookmarkID, userID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ToggleBookmarkState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.BookmarkState) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBookmark provides a mock function with given fields: ctx, bookmarkID, userID, description, url, version
+func (_m *Repository) UpdateBookmark(ctx context.Context, bookmarkID string, userID string, description string, url string, version int) error {
+	ret := _m.Called(ctx, bookmarkID, userID, description, url, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, description, url, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLinkCheck provides a mock function with given fields: ctx, bookmarkID, check
+func (_m *Repository) UpdateLinkCheck(ctx context.Context, bookmarkID string, check *model.LinkCheck) error {
+	ret := _m.Called(ctx, bookmarkID, check)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLinkCheck")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.LinkCheck) error); ok {
+		r0 = rf(ctx, bookmarkID, check)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePageMetadata provides a mock function with given fields: ctx, bookmarkID, meta
+func (_m *Repository) UpdatePageMetadata(ctx context.Context, bookmarkID string, meta *model.PageMetadata) error {
+	ret := _m.Called(ctx, bookmarkID, meta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePageMetadata")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, bookmarkID, userID, description, url)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.PageMetadata) error); ok {
+		r0 = rf(ctx, bookmarkID, meta)
 	} else {
 		r0 = ret.Error(0)
 	}
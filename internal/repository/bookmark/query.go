@@ -4,31 +4,136 @@ import (
 	"context"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/urlnorm"
+	"gorm.io/gorm"
 )
 
-// GetBookmarks retrieves a paginated list of bookmarks for a specific user.
+// GetBookmarks retrieves a paginated list of bookmarks for a specific user, narrowed down by filter.
+// Pinned bookmarks come first, then the most recently created ones.
 // It returns the slice of bookmarks, the total count of records matching the criteria, and any error encountered.
 //
 // The pagination is implemented using a two-step approach:
 // 1. Count the total number of records matching the user ID.
 // 2. If records exist, retrieve the specific page of data using limit and offset.
-func (r *bookmarkRepo) GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.Bookmark, int64, error) {
-	bookmarks := make([]*model.Bookmark, 0)
-	var total int64
-
-	db := r.db.WithContext(ctx).Model(&model.Bookmark{}).Where("user_id = ?", userID)
+func (r *bookmarkRepo) GetBookmarks(ctx context.Context, userID string, filter *model.BookmarkFilter, limit, offset int) ([]*model.Bookmark, int64, error) {
+	db := r.db.WithContext(ctx).Model(&model.Bookmark{}).Where("user_id = ?", userID).Scopes(filterBookmarks(filter))
+	return r.paginate(db.Order("pinned DESC"), limit, offset)
+}
 
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, err
+// GetBookmarkByID retrieves a single bookmark the specified user owns or was given access to.
+// The permission check is part of the query, so a bookmark the user may not read is
+// reported exactly like a missing one. Callers that need ownership compare UserID.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to retrieve
+//   - userID: The ID of the user requesting the bookmark (for permission validation)
+//
+// Returns:
+//   - *model.Bookmark: The matching bookmark
+//   - error: ErrNotFoundType if bookmark doesn't exist or user may not read it
+func (r *bookmarkRepo) GetBookmarkByID(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error) {
+	bookmark := &model.Bookmark{}
+	err := r.db.WithContext(ctx).
+		Preload("Tags", orderTagsByName).
+		Where("id = ?", bookmarkID).
+		Scopes(accessibleBy(userID, readPermissions)).
+		First(bookmark).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
 	}
+	return bookmark, nil
+}
 
-	if total == 0 {
-		return bookmarks, 0, nil
+// FindBookmarkByURL retrieves the oldest bookmark of the user that points to the given URL.
+// URLs are compared in their normalized form (see pkg/urlnorm), so spellings that only differ
+// in host case, tracking parameters, fragment or trailing slash are considered equal.
+// It is used to detect duplicates when creating and importing bookmarks.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - url: The target URL to look for
+//
+// Returns:
+//   - *model.Bookmark: The matching bookmark
+//   - error: ErrNotFoundType if the user has no bookmark for the URL
+func (r *bookmarkRepo) FindBookmarkByURL(ctx context.Context, userID, url string) (*model.Bookmark, error) {
+	bookmark := &model.Bookmark{}
+	err := r.db.WithContext(ctx).
+		Preload("Tags", orderTagsByName).
+		Where("user_id = ? AND normalized_url = ?", userID, urlnorm.Normalize(url)).
+		Order("created_at ASC").
+		First(bookmark).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
 	}
+	return bookmark, nil
+}
 
-	err := db.Order("created_at DESC").Limit(limit).Offset(offset).Find(&bookmarks).Error
+// CountBookmarks returns the number of bookmarks a user owns outside the trash,
+// which is what their bookmark quota is checked against.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - int64: The number of bookmarks
+//   - error: Database error, if any
+func (r *bookmarkRepo) CountBookmarks(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Bookmark{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
 	if err != nil {
-		return nil, 0, err
+		return 0, dbutils.CatchDBErr(err)
 	}
-	return bookmarks, total, nil
+	return count, nil
+}
+
+// IterateBookmarks walks through all bookmarks of a user in batches, calling fn for each batch.
+// Bookmarks are ordered by folder, then by creation time, so that bookmarks of the same folder
+// are delivered next to each other. Iteration stops at the first error returned by fn.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - batchSize: Maximum number of bookmarks passed to a single fn call
+//   - fn: Callback receiving each batch
+//
+// Returns:
+//   - error: Database error or the error returned by fn
+func (r *bookmarkRepo) IterateBookmarks(ctx context.Context, userID string, batchSize int, fn func([]*model.Bookmark) error) error {
+	for offset := 0; ; offset += batchSize {
+		bookmarks := make([]*model.Bookmark, 0, batchSize)
+		err := r.db.WithContext(ctx).
+			Preload("Tags", orderTagsByName).
+			Where("user_id = ?", userID).
+			Order("folder ASC, created_at ASC, id ASC").
+			Limit(batchSize).Offset(offset).
+			Find(&bookmarks).Error
+		if err != nil {
+			return dbutils.CatchDBErr(err)
+		}
+
+		if len(bookmarks) == 0 {
+			return nil
+		}
+
+		if err := fn(bookmarks); err != nil {
+			return err
+		}
+
+		if len(bookmarks) < batchSize {
+			return nil
+		}
+	}
+}
+
+// orderTagsByName is used with Preload("Tags") so that tags are always returned in a stable order.
+func orderTagsByName(db *gorm.DB) *gorm.DB {
+	return db.Order("name ASC")
 }
@@ -1,21 +1,63 @@
 package bookmark
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
 
+// setupLinkCheckedBookmarks adds bookmarks for user one covering every link check outcome.
+func setupLinkCheckedBookmarks(t *testing.T) *gorm.DB {
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	checkedAt := fixture.FixtureTimestamp
+	bookmarks := []*model.Bookmark{
+		{Code: "check001", LinkCheck: model.LinkCheck{StatusCode: 200, LastCheckedAt: &checkedAt}},
+		{Code: "check002", LinkCheck: model.LinkCheck{StatusCode: 404, LastCheckedAt: &checkedAt}},
+		{Code: "check003", LinkCheck: model.LinkCheck{CheckError: "connection refused", LastCheckedAt: &checkedAt}},
+		{Code: "check004", LinkCheck: model.LinkCheck{StatusCode: 200, FinalURL: "https://example.com/new", LastCheckedAt: &checkedAt}},
+	}
+	for _, bookmark := range bookmarks {
+		bookmark.URL = "https://example.com/" + bookmark.Code
+		bookmark.UserID = fixture.FixtureUserOneID
+	}
+	assert.NoError(t, db.Create(&bookmarks).Error)
+	return db
+}
+
+// setupStatefulBookmarks adds a read favorite, a pinned and an archived bookmark for user one.
+// The pinned bookmark is the oldest one, so that it would be listed last if it were not pinned.
+func setupStatefulBookmarks(t *testing.T) *gorm.DB {
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	readAt := fixture.FixtureTimestamp
+	bookmarks := []*model.Bookmark{
+		{Code: "state001", IsFavorite: true, ReadAt: &readAt},
+		{Code: "state002", Pinned: true, Base: model.Base{CreatedAt: fixture.FixtureTimestamp.Add(-time.Hour)}},
+		{Code: "state003", IsFavorite: true, Archived: true},
+	}
+	for _, bookmark := range bookmarks {
+		bookmark.URL = "https://example.com/" + bookmark.Code
+		bookmark.UserID = fixture.FixtureUserOneID
+	}
+	assert.NoError(t, db.Create(&bookmarks).Error)
+	return db
+}
+
 func TestBookmarkRepo_GetBookmarks(t *testing.T) {
 	t.Parallel()
 
+	yes, no := true, false
+
 	testCases := []struct {
 		name          string
 		setupDB       func(t *testing.T) *gorm.DB
 		inputUserID   string
+		inputFilter   *model.BookmarkFilter
 		inputLimit    int
 		inputOffset   int
 		expectedLen   int
@@ -77,6 +119,95 @@ func TestBookmarkRepo_GetBookmarks(t *testing.T) {
 			expectedLen:   2,
 			expectedTotal: 3, // 1 (fixture) + 2 (extra)
 		},
+		{
+			name:          "success - filter broken links",
+			setupDB:       setupLinkCheckedBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{LinkStatus: model.LinkStatusBroken},
+			inputLimit:    10,
+			expectedLen:   2, // 404 and unreachable
+			expectedTotal: 2,
+		},
+		{
+			name:          "success - filter working links",
+			setupDB:       setupLinkCheckedBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{LinkStatus: model.LinkStatusOK},
+			inputLimit:    10,
+			expectedLen:   2, // 200 and permanently redirected
+			expectedTotal: 2,
+		},
+		{
+			name:          "success - filter redirected links",
+			setupDB:       setupLinkCheckedBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{LinkStatus: model.LinkStatusRedirected},
+			inputLimit:    10,
+			expectedLen:   1,
+			expectedTotal: 1,
+		},
+		{
+			name:          "success - filter unchecked links",
+			setupDB:       setupLinkCheckedBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{LinkStatus: model.LinkStatusUnchecked},
+			inputLimit:    10,
+			expectedLen:   1, // the fixture bookmark
+			expectedTotal: 1,
+		},
+		{
+			name:          "success - archived bookmarks are hidden by default",
+			setupDB:       setupStatefulBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputLimit:    10,
+			expectedLen:   3, // the fixture bookmark, the favorite and the pinned one
+			expectedTotal: 3,
+		},
+		{
+			name:          "success - filter favorites",
+			setupDB:       setupStatefulBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{Favorite: &yes},
+			inputLimit:    10,
+			expectedLen:   1, // the archived favorite stays hidden
+			expectedTotal: 1,
+		},
+		{
+			name:          "success - filter unpinned",
+			setupDB:       setupStatefulBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{Pinned: &no},
+			inputLimit:    10,
+			expectedLen:   2,
+			expectedTotal: 2,
+		},
+		{
+			name:          "success - filter archived favorites",
+			setupDB:       setupStatefulBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{Favorite: &yes, Archived: &yes},
+			inputLimit:    10,
+			expectedLen:   1,
+			expectedTotal: 1,
+		},
+		{
+			name:          "success - filter unread",
+			setupDB:       setupStatefulBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{Unread: &yes},
+			inputLimit:    10,
+			expectedLen:   2, // the fixture bookmark and the pinned one
+			expectedTotal: 2,
+		},
+		{
+			name:          "success - filter read",
+			setupDB:       setupStatefulBookmarks,
+			inputUserID:   fixture.FixtureUserOneID,
+			inputFilter:   &model.BookmarkFilter{Unread: &no},
+			inputLimit:    10,
+			expectedLen:   1,
+			expectedTotal: 1,
+		},
 		{
 			name: "error - database error (disconnected)",
 			setupDB: func(t *testing.T) *gorm.DB {
@@ -101,7 +232,7 @@ func TestBookmarkRepo_GetBookmarks(t *testing.T) {
 			db := tc.setupDB(t)
 			repo := NewRepository(db)
 
-			bookmarks, total, err := repo.GetBookmarks(ctx, tc.inputUserID, tc.inputLimit, tc.inputOffset)
+			bookmarks, total, err := repo.GetBookmarks(ctx, tc.inputUserID, tc.inputFilter, tc.inputLimit, tc.inputOffset)
 
 			if tc.expectAnyErr {
 				assert.Error(t, err)
@@ -119,3 +250,267 @@ func TestBookmarkRepo_GetBookmarks(t *testing.T) {
 		})
 	}
 }
+
+func TestBookmarkRepo_GetBookmarkByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		setupDB         func(t *testing.T) *gorm.DB
+		inputBookmarkID string
+		inputUserID     string
+		expectedCode    string
+		expectedErr     error
+		expectAnyErr    bool // true to check for any error, not specific type
+	}{
+		{
+			name: "success - get own bookmark",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			expectedCode:    fixture.FixtureBookmarkOneCode,
+		},
+		{
+			name: "error - bookmark not found",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: "00000000-0000-0000-0000-000000000000",
+			inputUserID:     fixture.FixtureUserOneID,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+		{
+			name: "error - bookmark belongs to different user",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID, // Belongs to User One
+			inputUserID:     fixture.FixtureUserTwoID,     // Trying with User Two
+			expectedErr:     dbutils.ErrNotFoundType,      // Should return not found for security
+		},
+		{
+			name: "error - database error (disconnected)",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				// Close connection to simulate DB error
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+				return db
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			expectAnyErr:    true, // Any database error is expected
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := tc.setupDB(t)
+			repo := NewRepository(db)
+
+			bookmark, err := repo.GetBookmarkByID(ctx, tc.inputBookmarkID, tc.inputUserID)
+
+			if tc.expectAnyErr {
+				assert.Error(t, err)
+				return
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, bookmark)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.inputBookmarkID, bookmark.ID)
+			assert.Equal(t, tc.inputUserID, bookmark.UserID)
+			assert.Equal(t, tc.expectedCode, bookmark.Code)
+		})
+	}
+}
+
+func TestBookmarkRepo_FindBookmarkByURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupDB     func(t *testing.T) *gorm.DB
+		inputUserID string
+		inputURL    string
+		expectedID  string
+		expectedErr error
+	}{
+		{
+			name: "success - find own bookmark by URL",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputUserID: fixture.FixtureUserTwoID,
+			inputURL:    fixture.FixtureBookmarkURL,
+			expectedID:  fixture.FixtureBookmarkTwoID,
+		},
+		{
+			name: "success - URL is compared in normalized form",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputUserID: fixture.FixtureUserTwoID,
+			inputURL:    "HTTPS://Example.com:443/long-url/?utm_source=newsletter#top",
+			expectedID:  fixture.FixtureBookmarkTwoID,
+		},
+		{
+			name: "success - trashed bookmarks are ignored",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputUserID: fixture.FixtureUserOneID,
+			inputURL:    fixture.FixtureBookmarkURL,
+			expectedID:  fixture.FixtureBookmarkOneID,
+		},
+		{
+			name: "error - URL not bookmarked by user",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputUserID: fixture.FixtureUserOneID,
+			inputURL:    "https://example.com/other",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(tc.setupDB(t))
+
+			bookmark, err := repo.FindBookmarkByURL(t.Context(), tc.inputUserID, tc.inputURL)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, bookmark)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedID, bookmark.ID)
+		})
+	}
+}
+
+func TestBookmarkRepo_IterateBookmarks(t *testing.T) {
+	t.Parallel()
+
+	setupDB := func(t *testing.T) *gorm.DB {
+		db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+		extraBookmarks := []*model.Bookmark{
+			{
+				Base:   model.Base{ID: "extra-1"},
+				Code:   "extra1",
+				URL:    "https://example.com/1",
+				Folder: "Work",
+				Tags:   model.NewBookmarkTags([]string{"go", "docs"}),
+				UserID: fixture.FixtureUserOneID,
+			},
+			{
+				Base:   model.Base{ID: "extra-2"},
+				Code:   "extra2",
+				URL:    "https://example.com/2",
+				Folder: "Archive",
+				UserID: fixture.FixtureUserOneID,
+			},
+		}
+		assert.NoError(t, db.Create(&extraBookmarks).Error)
+		return db
+	}
+
+	testCases := []struct {
+		name            string
+		inputBatchSize  int
+		fnErr           error
+		expectedBatches [][]string
+		expectedErr     error
+	}{
+		{
+			name:            "success - single batch ordered by folder",
+			inputBatchSize:  10,
+			expectedBatches: [][]string{{fixture.FixtureBookmarkOneID, "extra-2", "extra-1"}},
+		},
+		{
+			name:            "success - multiple batches",
+			inputBatchSize:  2,
+			expectedBatches: [][]string{{fixture.FixtureBookmarkOneID, "extra-2"}, {"extra-1"}},
+		},
+		{
+			name:            "error - callback error stops iteration",
+			inputBatchSize:  1,
+			fnErr:           errors.New("write failed"),
+			expectedBatches: [][]string{{fixture.FixtureBookmarkOneID}},
+			expectedErr:     errors.New("write failed"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(setupDB(t))
+
+			var batches [][]string
+			err := repo.IterateBookmarks(t.Context(), fixture.FixtureUserOneID, tc.inputBatchSize, func(bookmarks []*model.Bookmark) error {
+				ids := make([]string, 0, len(bookmarks))
+				for _, b := range bookmarks {
+					ids = append(ids, b.ID)
+					if b.ID == "extra-1" {
+						assert.Equal(t, []string{"docs", "go"}, b.TagNames(), "Tags should be preloaded in name order")
+					}
+				}
+				batches = append(batches, ids)
+				return tc.fnErr
+			})
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedBatches, batches)
+		})
+	}
+}
+
+func TestBookmarkRepo_CountBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputUserID string
+		expected    int64
+	}{
+		{
+			name:        "success - trashed bookmarks are not counted",
+			inputUserID: fixture.FixtureUserOneID,
+			expected:    1,
+		},
+		{
+			name:        "success - user without bookmarks",
+			inputUserID: "00000000-0000-0000-0000-000000000000",
+			expected:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			repo := NewRepository(db)
+
+			count, err := repo.CountBookmarks(t.Context(), tc.inputUserID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, count)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package bookmark
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// SetReadAt marks a bookmark owned by the specified user as read at readAt, or as unread when readAt is nil.
+// Marking a bookmark that already is read keeps the time it was first read.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the owner (for ownership validation)
+//   - readAt: When the bookmark was read, or nil to mark it unread
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist or isn't owned by the user
+func (r *bookmarkRepo) SetReadAt(ctx context.Context, bookmarkID, userID string, readAt *time.Time) error {
+	var value any
+	if readAt != nil {
+		value = gorm.Expr("COALESCE(read_at, ?)", *readAt)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&model.Bookmark{}).
+		Where("id = ? AND user_id = ?", bookmarkID, userID).
+		Update("read_at", value)
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	// Check if any row was actually updated
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
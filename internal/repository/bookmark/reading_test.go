@@ -0,0 +1,87 @@
+package bookmark
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkRepo_SetReadAt(t *testing.T) {
+	t.Parallel()
+
+	firstRead := fixture.FixtureTimestamp.Add(time.Hour)
+	secondRead := fixture.FixtureTimestamp.Add(2 * time.Hour)
+
+	testCases := []struct {
+		name            string
+		inputBookmarkID string
+		inputUserID     string
+		inputReadAts    []*time.Time
+		expectedReadAt  *time.Time
+		expectedErr     error
+	}{
+		{
+			name:            "success - mark read",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputReadAts:    []*time.Time{&firstRead},
+			expectedReadAt:  &firstRead,
+		},
+		{
+			name:            "success - marking read again keeps the first read time",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputReadAts:    []*time.Time{&firstRead, &secondRead},
+			expectedReadAt:  &firstRead,
+		},
+		{
+			name:            "success - mark unread",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputReadAts:    []*time.Time{&firstRead, nil},
+			expectedReadAt:  nil,
+		},
+		{
+			name:            "error - bookmark belongs to different user",
+			inputBookmarkID: fixture.FixtureBookmarkTwoID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputReadAts:    []*time.Time{&firstRead},
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			repo := NewRepository(db)
+
+			var err error
+			for _, readAt := range tc.inputReadAts {
+				err = repo.SetReadAt(ctx, tc.inputBookmarkID, tc.inputUserID, readAt)
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			bookmark := &model.Bookmark{}
+			assert.NoError(t, db.Where("id = ?", tc.inputBookmarkID).First(bookmark).Error)
+			if tc.expectedReadAt == nil {
+				assert.Nil(t, bookmark.ReadAt)
+				return
+			}
+			if assert.NotNil(t, bookmark.ReadAt) {
+				assert.True(t, tc.expectedReadAt.Equal(*bookmark.ReadAt))
+			}
+		})
+	}
+}
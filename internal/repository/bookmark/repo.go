@@ -2,6 +2,7 @@ package bookmark
 
 import (
 	"context"
+	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"gorm.io/gorm"
@@ -13,9 +14,41 @@ import (
 //go:generate mockery --name Repository --filename bookmark.go
 type Repository interface {
 	CreateBookmark(ctx context.Context, bookmark *model.Bookmark) (*model.Bookmark, error)
-	GetBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.Bookmark, int64, error)
-	UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string) error
-	DeleteBookmark(ctx context.Context, bookmarkID, userID string) error
+	GetBookmarks(ctx context.Context, userID string, filter *model.BookmarkFilter, limit, offset int) ([]*model.Bookmark, int64, error)
+	GetBookmarkByID(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error)
+	GetSharedBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.Bookmark, int64, error)
+	GetBookmarksByShare(ctx context.Context, shareID string, limit, offset int) ([]*model.Bookmark, int64, error)
+	FindBookmarkByURL(ctx context.Context, userID, url string) (*model.Bookmark, error)
+	CountBookmarks(ctx context.Context, userID string) (int64, error)
+	GetDuplicateBookmarks(ctx context.Context, userID string) ([]*model.Bookmark, error)
+	IterateBookmarks(ctx context.Context, userID string, batchSize int, fn func([]*model.Bookmark) error) error
+	UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string, version int) error
+	PatchBookmark(ctx context.Context, bookmarkID, userID string, patch *model.BookmarkPatch, version int) error
+	OverwriteBookmark(ctx context.Context, bookmark *model.Bookmark) error
+	UpdatePageMetadata(ctx context.Context, bookmarkID string, meta *model.PageMetadata) error
+	DeleteBookmark(ctx context.Context, bookmarkID, userID string, version int) error
+	GetTrashedBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.Bookmark, int64, error)
+	RestoreBookmark(ctx context.Context, bookmarkID, userID string) error
+	PurgeTrashedBookmarks(ctx context.Context, before time.Time) (int64, error)
+	GetBookmarksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*model.Bookmark, error)
+	UpdateLinkCheck(ctx context.Context, bookmarkID string, check *model.LinkCheck) error
+	AcceptRedirect(ctx context.Context, bookmarkID, userID, finalURL string) error
+	ToggleBookmarkState(ctx context.Context, bookmarkID, userID string, state model.BookmarkState) error
+	SetCode(ctx context.Context, bookmarkID, userID, code string) error
+	BulkUpdate(ctx context.Context, userID string, op *model.BulkOperation) (int64, error)
+	GetRevision(ctx context.Context, bookmarkID string, revision int) (*model.BookmarkRevision, error)
+	GetRevisions(ctx context.Context, bookmarkID string, limit, offset int) ([]*model.BookmarkRevision, int64, error)
+	RevertBookmark(ctx context.Context, bookmarkID, userID string, revision int) error
+	SetReadAt(ctx context.Context, bookmarkID, userID string, readAt *time.Time) error
+	RequestSnapshot(ctx context.Context, bookmarkID, userID string) (*model.BookmarkSnapshot, error)
+	GetSnapshot(ctx context.Context, bookmarkID string) (*model.BookmarkSnapshot, error)
+	CompleteSnapshot(ctx context.Context, bookmarkID string, size int64, capturedAt time.Time) error
+	FailSnapshot(ctx context.Context, bookmarkID, reason string) error
+	GetSnapshotUsage(ctx context.Context, userID string) (int64, error)
+	GetOrphanedSnapshots(ctx context.Context, limit int) ([]*model.BookmarkSnapshot, error)
+	DeleteSnapshot(ctx context.Context, bookmarkID string) error
+	GetStats(ctx context.Context, userID string, limit int) (*model.BookmarkStats, error)
+	RecordClick(ctx context.Context, code string) (string, error)
 }
 
 // bookmarkRepo is the concrete implementation of the Repository interface using GORM.
@@ -0,0 +1,175 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetRevision retrieves a single revision of a bookmark.
+// No permission check is done; callers check that the user may read the bookmark first.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//   - revision: The revision number
+//
+// Returns:
+//   - *model.BookmarkRevision: The matching revision
+//   - error: ErrNotFoundType if the bookmark has no such revision
+func (r *bookmarkRepo) GetRevision(ctx context.Context, bookmarkID string, revision int) (*model.BookmarkRevision, error) {
+	rev := &model.BookmarkRevision{}
+	err := r.db.WithContext(ctx).
+		Where("bookmark_id = ? AND revision = ?", bookmarkID, revision).
+		First(rev).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return rev, nil
+}
+
+// GetRevisions retrieves a paginated list of the revisions of a bookmark, most recent first.
+// No permission check is done; callers check that the user may read the bookmark first.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//   - limit: Maximum number of revisions to return
+//   - offset: Number of revisions to skip
+//
+// Returns:
+//   - []*model.BookmarkRevision: The revisions of the page
+//   - int64: Total number of revisions of the bookmark
+//   - error: Database error
+func (r *bookmarkRepo) GetRevisions(ctx context.Context, bookmarkID string, limit, offset int) ([]*model.BookmarkRevision, int64, error) {
+	revisions := make([]*model.BookmarkRevision, 0)
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&model.BookmarkRevision{}).Where("bookmark_id = ?", bookmarkID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, dbutils.CatchDBErr(err)
+	}
+
+	if total == 0 {
+		return revisions, 0, nil
+	}
+
+	if err := db.Order("revision DESC").Limit(limit).Offset(offset).Find(&revisions).Error; err != nil {
+		return nil, 0, dbutils.CatchDBErr(err)
+	}
+	return revisions, total, nil
+}
+
+// RevertBookmark restores the description and URL a bookmark had before the given revision.
+// The revert is an edit itself, so the values it replaces are recorded as a new revision.
+// It performs a permission check so that only the owner and editors it was shared with can revert it.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to revert
+//   - userID: The ID of the user reverting the bookmark (for permission validation)
+//   - revision: The revision to restore
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark or revision doesn't exist or user may not edit it
+func (r *bookmarkRepo) RevertBookmark(ctx context.Context, bookmarkID, userID string, revision int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		rev := &model.BookmarkRevision{}
+		err := tx.Where("bookmark_id = ? AND revision = ?", bookmarkID, revision).First(rev).Error
+		if err != nil {
+			return dbutils.CatchDBErr(err)
+		}
+
+		updates := urlColumns(rev.URL)
+		updates["description"] = rev.Description
+		return updateWithRevision(tx, bookmarkID, userID, accessibleBy(userID, writePermissions), updates, 0)
+	})
+}
+
+// updateWithRevision applies updates to the bookmark selected by bookmarkID and scope.
+// When the updates change the description or URL, the current values are first stored
+// as the next revision of the bookmark, attributed to userID.
+// The bookmark row stays locked until tx ends, so concurrent edits get consecutive revisions.
+// It must run inside a transaction.
+//
+// When version is not 0, the update only applies if the bookmark still has that version.
+// The check is repeated in the UPDATE statement itself, so it holds even where the
+// database ignores the row lock.
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if no bookmark matches bookmarkID and scope,
+//     ErrVersionMismatch if the bookmark no longer has the expected version
+func updateWithRevision(tx *gorm.DB, bookmarkID, userID string, scope func(*gorm.DB) *gorm.DB, updates map[string]any, version int) error {
+	current := &model.Bookmark{}
+	err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Select("bookmarks.id", "bookmarks.description", "bookmarks.url", "bookmarks.version").
+		Where("bookmarks.id = ?", bookmarkID).
+		Scopes(scope).
+		First(current).Error
+	if err != nil {
+		return dbutils.CatchDBErr(err)
+	}
+
+	if version != 0 && current.Version != version {
+		return dbutils.ErrVersionMismatch
+	}
+
+	if changesContent(current, updates) {
+		var last int
+		err := tx.Model(&model.BookmarkRevision{}).
+			Where("bookmark_id = ?", bookmarkID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return dbutils.CatchDBErr(err)
+		}
+
+		err = tx.Create(&model.BookmarkRevision{
+			BookmarkID:  bookmarkID,
+			Revision:    last + 1,
+			Description: current.Description,
+			URL:         current.URL,
+			ChangedBy:   userID,
+		}).Error
+		if err != nil {
+			return dbutils.CatchDBErr(err)
+		}
+	}
+
+	query := tx.Model(&model.Bookmark{}).Where("id = ?", bookmarkID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Updates(updates)
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if version != 0 {
+			return dbutils.ErrVersionMismatch
+		}
+		return dbutils.ErrNotFoundType
+	}
+	return nil
+}
+
+// changesContent reports whether updates change the description or URL of current.
+func changesContent(current *model.Bookmark, updates map[string]any) bool {
+	if description, ok := updates["description"]; ok && description != current.Description {
+		return true
+	}
+	if url, ok := updates["url"]; ok && url != current.URL {
+		return true
+	}
+	return false
+}
+
+// ownedBy is a scope selecting the bookmarks owned by userID.
+func ownedBy(userID string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("bookmarks.user_id = ?", userID)
+	}
+}
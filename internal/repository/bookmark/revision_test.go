@@ -0,0 +1,172 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupRevisedBookmark edits the first fixture bookmark twice, giving it two revisions.
+func setupRevisedBookmark(t *testing.T) *gorm.DB {
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	repo := NewRepository(db)
+	assert.NoError(t, repo.UpdateBookmark(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserOneID, "Second", "https://example.com/second", 0))
+	assert.NoError(t, repo.UpdateBookmark(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserOneID, "Third", "https://example.com/third", 0))
+	return db
+}
+
+func TestBookmarkRepo_Revisions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		edit      func(repo Repository) error
+		expected  []model.BookmarkRevision
+		expectErr error
+	}{
+		{
+			name: "update records the replaced values",
+			edit: func(repo Repository) error {
+				return repo.UpdateBookmark(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserOneID, "New", "https://example.com/new", 0)
+			},
+			expected: []model.BookmarkRevision{
+				{Revision: 1, Description: fixture.FixtureBookmarkDescription, URL: fixture.FixtureBookmarkURL, ChangedBy: fixture.FixtureUserOneID},
+			},
+		},
+		{
+			name: "patch records the replaced values",
+			edit: func(repo Repository) error {
+				description := "Patched"
+				return repo.PatchBookmark(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserOneID, &model.BookmarkPatch{Description: &description}, 0)
+			},
+			expected: []model.BookmarkRevision{
+				{Revision: 1, Description: fixture.FixtureBookmarkDescription, URL: fixture.FixtureBookmarkURL, ChangedBy: fixture.FixtureUserOneID},
+			},
+		},
+		{
+			name: "edit without changes records nothing",
+			edit: func(repo Repository) error {
+				return repo.UpdateBookmark(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserOneID, fixture.FixtureBookmarkDescription, fixture.FixtureBookmarkURL, 0)
+			},
+			expected: []model.BookmarkRevision{},
+		},
+		{
+			name: "edit refused records nothing",
+			edit: func(repo Repository) error {
+				return repo.UpdateBookmark(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserTwoID, "Stolen", "https://example.com/stolen", 0)
+			},
+			expected:  []model.BookmarkRevision{},
+			expectErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			repo := NewRepository(db)
+
+			err := tc.edit(repo)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			revisions, total, err := repo.GetRevisions(t.Context(), fixture.FixtureBookmarkOneID, 10, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expected)), total)
+			got := make([]model.BookmarkRevision, 0, len(revisions))
+			for _, rev := range revisions {
+				got = append(got, model.BookmarkRevision{Revision: rev.Revision, Description: rev.Description, URL: rev.URL, ChangedBy: rev.ChangedBy})
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestBookmarkRepo_GetRevisions(t *testing.T) {
+	t.Parallel()
+
+	db := setupRevisedBookmark(t)
+	repo := NewRepository(db)
+
+	revisions, total, err := repo.GetRevisions(t.Context(), fixture.FixtureBookmarkOneID, 1, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	if assert.Len(t, revisions, 1) {
+		assert.Equal(t, 2, revisions[0].Revision, "most recent first")
+		assert.Equal(t, "Second", revisions[0].Description)
+	}
+
+	rev, err := repo.GetRevision(t.Context(), fixture.FixtureBookmarkOneID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, fixture.FixtureBookmarkURL, rev.URL)
+
+	_, err = repo.GetRevision(t.Context(), fixture.FixtureBookmarkOneID, 3)
+	assert.ErrorIs(t, err, dbutils.ErrNotFoundType)
+}
+
+func TestBookmarkRepo_RevertBookmark(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		inputUserID   string
+		inputRevision int
+		expectedURL   string
+		expectedErr   error
+	}{
+		{
+			name:          "success - revert to the original",
+			inputUserID:   fixture.FixtureUserOneID,
+			inputRevision: 1,
+			expectedURL:   fixture.FixtureBookmarkURL,
+		},
+		{
+			name:          "error - revision not found",
+			inputUserID:   fixture.FixtureUserOneID,
+			inputRevision: 5,
+			expectedErr:   dbutils.ErrNotFoundType,
+		},
+		{
+			name:          "error - user may not edit the bookmark",
+			inputUserID:   fixture.FixtureUserTwoID,
+			inputRevision: 1,
+			expectedErr:   dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := setupRevisedBookmark(t)
+			repo := NewRepository(db)
+
+			err := repo.RevertBookmark(t.Context(), fixture.FixtureBookmarkOneID, tc.inputUserID, tc.inputRevision)
+
+			bookmark := &model.Bookmark{}
+			assert.NoError(t, db.First(bookmark, "id = ?", fixture.FixtureBookmarkOneID).Error)
+			_, total, _ := repo.GetRevisions(t.Context(), fixture.FixtureBookmarkOneID, 10, 0)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, "https://example.com/third", bookmark.URL)
+				assert.Equal(t, int64(2), total)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedURL, bookmark.URL)
+			assert.Equal(t, tc.expectedURL, bookmark.NormalizedURL)
+			assert.Equal(t, int64(3), total, "the revert is recorded as a revision")
+		})
+	}
+}
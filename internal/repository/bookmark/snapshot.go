@@ -0,0 +1,202 @@
+package bookmark
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RequestSnapshot records that a new snapshot of a bookmark owned by the user was requested.
+// The snapshot row is created on the first request; later requests set it back to pending
+// and clear the previous error, but keep the size and capture time of the stored copy.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to archive
+//   - userID: The ID of the owner (for ownership validation)
+//
+// Returns:
+//   - *model.BookmarkSnapshot: The pending snapshot
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist or isn't owned by the user
+func (r *bookmarkRepo) RequestSnapshot(ctx context.Context, bookmarkID, userID string) (*model.BookmarkSnapshot, error) {
+	snapshot := &model.BookmarkSnapshot{}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var owned int64
+		if err := tx.Model(&model.Bookmark{}).Where("id = ? AND user_id = ?", bookmarkID, userID).Count(&owned).Error; err != nil {
+			return err
+		}
+		if owned == 0 {
+			return dbutils.ErrNotFoundType
+		}
+
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "bookmark_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"status":     model.SnapshotPending,
+				"error":      "",
+				"updated_at": time.Now(),
+			}),
+		}).Create(&model.BookmarkSnapshot{
+			BookmarkID: bookmarkID,
+			UserID:     userID,
+			Status:     model.SnapshotPending,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("bookmark_id = ?", bookmarkID).First(snapshot).Error
+	})
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	return snapshot, nil
+}
+
+// GetSnapshot retrieves the snapshot of a bookmark. Access to the bookmark is checked by the caller.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//
+// Returns:
+//   - *model.BookmarkSnapshot: The snapshot
+//   - error: nil on success, ErrNotFoundType if no snapshot was ever requested for the bookmark
+func (r *bookmarkRepo) GetSnapshot(ctx context.Context, bookmarkID string) (*model.BookmarkSnapshot, error) {
+	snapshot := &model.BookmarkSnapshot{}
+
+	err := r.db.WithContext(ctx).Where("bookmark_id = ?", bookmarkID).First(snapshot).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	return snapshot, nil
+}
+
+// CompleteSnapshot records a successful capture whose copy was stored in the blob store.
+// It is not scoped to a user and is meant to be called by the snapshot worker.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the archived bookmark
+//   - size: Size of the stored copy in bytes
+//   - capturedAt: When the page was captured
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the snapshot doesn't exist
+func (r *bookmarkRepo) CompleteSnapshot(ctx context.Context, bookmarkID string, size int64, capturedAt time.Time) error {
+	return r.updateSnapshot(ctx, bookmarkID, map[string]any{
+		"status":      model.SnapshotReady,
+		"size":        size,
+		"error":       "",
+		"captured_at": capturedAt,
+	})
+}
+
+// FailSnapshot records a failed capture. The previously stored copy, if any, is kept.
+// It is not scoped to a user and is meant to be called by the snapshot worker.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//   - reason: Why the capture failed
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the snapshot doesn't exist
+func (r *bookmarkRepo) FailSnapshot(ctx context.Context, bookmarkID, reason string) error {
+	return r.updateSnapshot(ctx, bookmarkID, map[string]any{
+		"status": model.SnapshotFailed,
+		"error":  reason,
+	})
+}
+
+// updateSnapshot applies updates to the snapshot of a bookmark.
+func (r *bookmarkRepo) updateSnapshot(ctx context.Context, bookmarkID string, updates map[string]any) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.BookmarkSnapshot{}).
+		Where("bookmark_id = ?", bookmarkID).
+		Updates(updates)
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// GetSnapshotUsage returns the storage used by the snapshots of a user, in bytes.
+// Snapshots of trashed bookmarks count until they are purged.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - int64: Total size of the user's stored snapshots
+//   - error: Database error, if any
+func (r *bookmarkRepo) GetSnapshotUsage(ctx context.Context, userID string) (int64, error) {
+	var usage int64
+
+	err := r.db.WithContext(ctx).
+		Model(&model.BookmarkSnapshot{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&usage).Error
+	if err != nil {
+		return 0, dbutils.CatchDBErr(err)
+	}
+
+	return usage, nil
+}
+
+// GetOrphanedSnapshots retrieves snapshots whose bookmark no longer exists, i.e. was purged
+// from the trash or removed together with its owner. Trashed bookmarks keep their snapshot.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - limit: Maximum number of snapshots to return
+//
+// Returns:
+//   - []*model.BookmarkSnapshot: The orphaned snapshots
+//   - error: Database error, if any
+func (r *bookmarkRepo) GetOrphanedSnapshots(ctx context.Context, limit int) ([]*model.BookmarkSnapshot, error) {
+	snapshots := make([]*model.BookmarkSnapshot, 0)
+
+	err := r.db.WithContext(ctx).
+		Where("NOT EXISTS (SELECT 1 FROM bookmarks WHERE bookmarks.id = bookmark_snapshots.bookmark_id)").
+		Order("updated_at").
+		Limit(limit).
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	return snapshots, nil
+}
+
+// DeleteSnapshot removes the snapshot row of a bookmark. The blob is deleted by the caller.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//
+// Returns:
+//   - error: Database error, if any. Deleting a missing snapshot is not an error.
+func (r *bookmarkRepo) DeleteSnapshot(ctx context.Context, bookmarkID string) error {
+	err := r.db.WithContext(ctx).Where("bookmark_id = ?", bookmarkID).Delete(&model.BookmarkSnapshot{}).Error
+	if err != nil {
+		return dbutils.CatchDBErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package bookmark
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupCapturedSnapshot stores a ready snapshot of 1000 bytes for the first fixture bookmark.
+func setupCapturedSnapshot(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	capturedAt := fixture.FixtureTimestamp
+	assert.NoError(t, db.Create(&model.BookmarkSnapshot{
+		BookmarkID: fixture.FixtureBookmarkOneID,
+		UserID:     fixture.FixtureUserOneID,
+		Status:     model.SnapshotReady,
+		Size:       1000,
+		CapturedAt: &capturedAt,
+	}).Error)
+}
+
+func TestBookmarkRepo_RequestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		setup           func(t *testing.T, db *gorm.DB)
+		inputBookmarkID string
+		inputUserID     string
+		expectedSize    int64
+		expectsCapture  bool
+		expectedErr     error
+	}{
+		{
+			name:            "success - first request",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+		},
+		{
+			name:            "success - new request keeps the stored copy",
+			setup:           setupCapturedSnapshot,
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			expectedSize:    1000,
+			expectsCapture:  true,
+		},
+		{
+			name: "success - new request clears the previous error",
+			setup: func(t *testing.T, db *gorm.DB) {
+				assert.NoError(t, db.Create(&model.BookmarkSnapshot{
+					BookmarkID: fixture.FixtureBookmarkOneID,
+					UserID:     fixture.FixtureUserOneID,
+					Status:     model.SnapshotFailed,
+					Error:      "timeout",
+				}).Error)
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+		},
+		{
+			name:            "error - bookmark belongs to different user",
+			inputBookmarkID: fixture.FixtureBookmarkTwoID,
+			inputUserID:     fixture.FixtureUserOneID,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			if tc.setup != nil {
+				tc.setup(t, db)
+			}
+			repo := NewRepository(db)
+
+			snapshot, err := repo.RequestSnapshot(ctx, tc.inputBookmarkID, tc.inputUserID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, snapshot)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.inputBookmarkID, snapshot.BookmarkID)
+			assert.Equal(t, tc.inputUserID, snapshot.UserID)
+			assert.Equal(t, model.SnapshotPending, snapshot.Status)
+			assert.Empty(t, snapshot.Error)
+			assert.Equal(t, tc.expectedSize, snapshot.Size)
+			assert.Equal(t, tc.expectsCapture, snapshot.CapturedAt != nil)
+		})
+	}
+}
+
+func TestBookmarkRepo_CompleteAndFailSnapshot(t *testing.T) {
+	t.Parallel()
+
+	capturedAt := fixture.FixtureTimestamp.Add(time.Hour)
+
+	testCases := []struct {
+		name            string
+		setup           func(t *testing.T, db *gorm.DB)
+		inputBookmarkID string
+		update          func(ctx context.Context, repo Repository, bookmarkID string) error
+		expected        *model.BookmarkSnapshot
+		expectedErr     error
+	}{
+		{
+			name:            "success - complete",
+			setup:           setupCapturedSnapshot,
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			update: func(ctx context.Context, repo Repository, bookmarkID string) error {
+				return repo.CompleteSnapshot(ctx, bookmarkID, 2000, capturedAt)
+			},
+			expected: &model.BookmarkSnapshot{Status: model.SnapshotReady, Size: 2000, CapturedAt: &capturedAt},
+		},
+		{
+			name:            "success - failure keeps the stored copy",
+			setup:           setupCapturedSnapshot,
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			update: func(ctx context.Context, repo Repository, bookmarkID string) error {
+				return repo.FailSnapshot(ctx, bookmarkID, "timeout")
+			},
+			expected: &model.BookmarkSnapshot{Status: model.SnapshotFailed, Size: 1000, Error: "timeout", CapturedAt: &fixture.FixtureTimestamp},
+		},
+		{
+			name:            "error - no snapshot",
+			inputBookmarkID: fixture.FixtureBookmarkTwoID,
+			update: func(ctx context.Context, repo Repository, bookmarkID string) error {
+				return repo.FailSnapshot(ctx, bookmarkID, "timeout")
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			if tc.setup != nil {
+				tc.setup(t, db)
+			}
+			repo := NewRepository(db)
+
+			err := tc.update(ctx, repo, tc.inputBookmarkID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			snapshot, err := repo.GetSnapshot(ctx, tc.inputBookmarkID)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.Status, snapshot.Status)
+			assert.Equal(t, tc.expected.Size, snapshot.Size)
+			assert.Equal(t, tc.expected.Error, snapshot.Error)
+			if assert.NotNil(t, snapshot.CapturedAt) {
+				assert.True(t, tc.expected.CapturedAt.Equal(*snapshot.CapturedAt))
+			}
+		})
+	}
+}
+
+func TestBookmarkRepo_GetSnapshotUsage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setup         func(t *testing.T, db *gorm.DB)
+		inputUserID   string
+		expectedUsage int64
+	}{
+		{
+			name:          "success - no snapshots",
+			inputUserID:   fixture.FixtureUserOneID,
+			expectedUsage: 0,
+		},
+		{
+			name: "success - sums the user's snapshots",
+			setup: func(t *testing.T, db *gorm.DB) {
+				setupCapturedSnapshot(t, db)
+				assert.NoError(t, db.Create(&model.BookmarkSnapshot{
+					BookmarkID: fixture.FixtureBookmarkTwoID,
+					UserID:     fixture.FixtureUserTwoID,
+					Status:     model.SnapshotReady,
+					Size:       500,
+				}).Error)
+			},
+			inputUserID:   fixture.FixtureUserOneID,
+			expectedUsage: 1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			if tc.setup != nil {
+				tc.setup(t, db)
+			}
+			repo := NewRepository(db)
+
+			usage, err := repo.GetSnapshotUsage(t.Context(), tc.inputUserID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUsage, usage)
+		})
+	}
+}
+
+func TestBookmarkRepo_OrphanedSnapshots(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	repo := NewRepository(db)
+
+	// The first bookmark is trashed and the second one purged: only the second snapshot is orphaned
+	setupCapturedSnapshot(t, db)
+	assert.NoError(t, db.Create(&model.BookmarkSnapshot{
+		BookmarkID: fixture.FixtureBookmarkTwoID,
+		UserID:     fixture.FixtureUserTwoID,
+		Status:     model.SnapshotReady,
+	}).Error)
+	assert.NoError(t, db.Where("id = ?", fixture.FixtureBookmarkOneID).Delete(&model.Bookmark{}).Error)
+	assert.NoError(t, db.Unscoped().Where("id = ?", fixture.FixtureBookmarkTwoID).Delete(&model.Bookmark{}).Error)
+
+	orphans, err := repo.GetOrphanedSnapshots(ctx, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, orphans, 1) {
+		assert.Equal(t, fixture.FixtureBookmarkTwoID, orphans[0].BookmarkID)
+	}
+
+	assert.NoError(t, repo.DeleteSnapshot(ctx, fixture.FixtureBookmarkTwoID))
+	assert.NoError(t, repo.DeleteSnapshot(ctx, fixture.FixtureBookmarkTwoID))
+
+	orphans, err = repo.GetOrphanedSnapshots(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, orphans)
+}
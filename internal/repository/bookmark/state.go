@@ -0,0 +1,52 @@
+package bookmark
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// stateColumns maps each toggleable state to the column storing it.
+var stateColumns = map[model.BookmarkState]string{
+	model.BookmarkStateFavorite: "is_favorite",
+	model.BookmarkStatePinned:   "pinned",
+	model.BookmarkStateArchived: "archived",
+}
+
+// ToggleBookmarkState flips a state of a bookmark owned by the specified user.
+// The flip happens in a single UPDATE, so two concurrent toggles cancel each other out
+// instead of both writing the same value.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the user toggling the state (for ownership validation)
+//   - state: The state to flip
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist or isn't owned by the user
+func (r *bookmarkRepo) ToggleBookmarkState(ctx context.Context, bookmarkID, userID string, state model.BookmarkState) error {
+	column, ok := stateColumns[state]
+	if !ok {
+		return fmt.Errorf("unknown bookmark state %q", state)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&model.Bookmark{}).
+		Where("id = ? AND user_id = ?", bookmarkID, userID).
+		Update(column, gorm.Expr("NOT "+column))
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	// Check if any row was actually updated
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package bookmark
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkRepo_ToggleBookmarkState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		inputBookmarkID string
+		inputUserID     string
+		inputState      model.BookmarkState
+		inputToggles    int
+		expected        func(*model.Bookmark) bool
+		expectedErr     error
+		expectAnyErr    bool
+	}{
+		{
+			name:            "success - mark as favorite",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputState:      model.BookmarkStateFavorite,
+			inputToggles:    1,
+			expected:        func(b *model.Bookmark) bool { return b.IsFavorite && !b.Pinned && !b.Archived },
+		},
+		{
+			name:            "success - pin",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputState:      model.BookmarkStatePinned,
+			inputToggles:    1,
+			expected:        func(b *model.Bookmark) bool { return b.Pinned && !b.IsFavorite },
+		},
+		{
+			name:            "success - archive and unarchive",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputState:      model.BookmarkStateArchived,
+			inputToggles:    2,
+			expected:        func(b *model.Bookmark) bool { return !b.Archived },
+		},
+		{
+			name:            "error - bookmark belongs to different user",
+			inputBookmarkID: fixture.FixtureBookmarkTwoID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputState:      model.BookmarkStatePinned,
+			inputToggles:    1,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+		{
+			name:            "error - unknown state",
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputState:      model.BookmarkState("hidden"),
+			inputToggles:    1,
+			expectAnyErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			repo := NewRepository(db)
+
+			var err error
+			for range tc.inputToggles {
+				err = repo.ToggleBookmarkState(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputState)
+			}
+
+			if tc.expectAnyErr {
+				assert.Error(t, err)
+				return
+			}
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			bookmark := &model.Bookmark{}
+			assert.NoError(t, db.Where("id = ?", tc.inputBookmarkID).First(bookmark).Error)
+			assert.True(t, tc.expected(bookmark))
+		})
+	}
+}
+
+func TestBookmarkRepo_GetBookmarks_PinnedFirst(t *testing.T) {
+	t.Parallel()
+
+	db := setupStatefulBookmarks(t)
+	repo := NewRepository(db)
+
+	bookmarks, _, err := repo.GetBookmarks(t.Context(), fixture.FixtureUserOneID, nil, 10, 0)
+
+	assert.NoError(t, err)
+	codes := make([]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		codes = append(codes, bookmark.Code)
+	}
+	// The pinned bookmark comes first although it is the oldest one
+	if assert.Len(t, codes, 3) {
+		assert.Equal(t, "state002", codes[0])
+	}
+}
@@ -0,0 +1,116 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// GetStats computes the statistics of the bookmarks a user owns outside the trash.
+// Every figure is aggregated by the database, so only the aggregated rows are loaded.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - limit: Maximum number of domains and clicked codes returned
+//
+// Returns:
+//   - *model.BookmarkStats: The statistics, without GeneratedAt
+//   - error: Database error, if any
+func (r *bookmarkRepo) GetStats(ctx context.Context, userID string, limit int) (*model.BookmarkStats, error) {
+	owned := func() *gorm.DB {
+		return r.db.WithContext(ctx).Model(&model.Bookmark{}).Where("bookmarks.user_id = ?", userID)
+	}
+
+	stats := &model.BookmarkStats{
+		Domains:    []model.DomainCount{},
+		Tags:       []model.TagCount{},
+		Months:     []model.MonthCount{},
+		TopClicked: []model.CodeClicks{},
+	}
+
+	if err := owned().Count(&stats.Total).Error; err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	err := owned().
+		Select("domain, COUNT(*) AS count").
+		Where("domain <> ''").
+		Group("domain").
+		Order("count DESC, domain ASC").
+		Limit(limit).
+		Scan(&stats.Domains).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	err = owned().
+		Joins("JOIN bookmark_tags ON bookmark_tags.bookmark_id = bookmarks.id").
+		Select("bookmark_tags.name AS tag, COUNT(*) AS count").
+		Group("bookmark_tags.name").
+		Order("count DESC, tag ASC").
+		Scan(&stats.Tags).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	err = owned().
+		Select(monthOf(r.db, "bookmarks.created_at") + " AS month, COUNT(*) AS count").
+		Group("month").
+		Order("month ASC").
+		Scan(&stats.Months).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	err = owned().
+		Select("code, url, click_count AS clicks").
+		Where("click_count > 0").
+		Order("click_count DESC, code ASC").
+		Limit(limit).
+		Scan(&stats.TopClicked).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+
+	return stats, nil
+}
+
+// monthOf returns the SQL expression formatting a timestamp column as "2006-01" in UTC.
+// Date functions are not portable, so the expression depends on the database in use.
+func monthOf(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "postgres" {
+		return "to_char(" + column + " AT TIME ZONE 'UTC', 'YYYY-MM')"
+	}
+	return "strftime('%Y-%m', " + column + ")"
+}
+
+// RecordClick resolves the short code of a bookmark and counts the click.
+// The count is updated in place, without bumping the version or the update time,
+// since following a link does not modify the bookmark.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - code: The short code that was followed
+//
+// Returns:
+//   - string: The URL of the bookmark
+//   - error: ErrNotFoundType if no bookmark outside the trash has the code, or a database error
+func (r *bookmarkRepo) RecordClick(ctx context.Context, code string) (string, error) {
+	bookmark := &model.Bookmark{}
+	err := r.db.WithContext(ctx).Select("id", "url").Where("code = ?", code).First(bookmark).Error
+	if err != nil {
+		return "", dbutils.CatchDBErr(err)
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(bookmark).
+		UpdateColumn("click_count", gorm.Expr("click_count + 1")).Error
+	if err != nil {
+		return "", dbutils.CatchDBErr(err)
+	}
+
+	return bookmark.URL, nil
+}
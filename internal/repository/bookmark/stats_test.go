@@ -0,0 +1,174 @@
+package bookmark
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBookmarkRepo_GetStats(t *testing.T) {
+	t.Parallel()
+
+	// seed creates bookmarks for user one next to the fixture bookmark, which has no domain
+	seed := func(t *testing.T, db *gorm.DB) {
+		repo := NewRepository(db)
+		bookmarks := []*model.Bookmark{
+			{URL: "https://go.dev/doc", Code: "stats0001", Tags: model.NewBookmarkTags([]string{"go", "docs"})},
+			{URL: "https://www.go.dev/blog", Code: "stats0002", Tags: model.NewBookmarkTags([]string{"go"})},
+			{URL: "https://github.com/golang/go", Code: "stats0003"},
+		}
+		for i, bookmark := range bookmarks {
+			bookmark.UserID = fixture.FixtureUserOneID
+			bookmark.CreatedAt = time.Date(2025, time.Month(i+1), 15, 0, 0, 0, 0, time.UTC)
+			_, err := repo.CreateBookmark(t.Context(), bookmark)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, db.Model(&model.Bookmark{}).Where("code = ?", "stats0003").UpdateColumn("click_count", 7).Error)
+		assert.NoError(t, db.Model(&model.Bookmark{}).Where("code = ?", "stats0001").UpdateColumn("click_count", 2).Error)
+		// Bookmarks of other users are not counted
+		assert.NoError(t, db.Model(&model.Bookmark{}).Where("id = ?", fixture.FixtureBookmarkTwoID).UpdateColumn("click_count", 100).Error)
+	}
+
+	testCases := []struct {
+		name          string
+		setup         func(t *testing.T, db *gorm.DB)
+		inputUserID   string
+		inputLimit    int
+		expectedStats *model.BookmarkStats
+	}{
+		{
+			name:        "success - aggregates the user's bookmarks outside the trash",
+			setup:       seed,
+			inputUserID: fixture.FixtureUserOneID,
+			inputLimit:  10,
+			expectedStats: &model.BookmarkStats{
+				Total: 4,
+				Domains: []model.DomainCount{
+					{Domain: "go.dev", Count: 2},
+					{Domain: "github.com", Count: 1},
+				},
+				Tags: []model.TagCount{
+					{Tag: "go", Count: 2},
+					{Tag: "docs", Count: 1},
+				},
+				Months: []model.MonthCount{
+					{Month: "2025-01", Count: 2},
+					{Month: "2025-02", Count: 1},
+					{Month: "2025-03", Count: 1},
+				},
+				TopClicked: []model.CodeClicks{
+					{Code: "stats0003", URL: "https://github.com/golang/go", Clicks: 7},
+					{Code: "stats0001", URL: "https://go.dev/doc", Clicks: 2},
+				},
+			},
+		},
+		{
+			name:        "success - limit applies to domains and clicked codes",
+			setup:       seed,
+			inputUserID: fixture.FixtureUserOneID,
+			inputLimit:  1,
+			expectedStats: &model.BookmarkStats{
+				Total:   4,
+				Domains: []model.DomainCount{{Domain: "go.dev", Count: 2}},
+				Tags: []model.TagCount{
+					{Tag: "go", Count: 2},
+					{Tag: "docs", Count: 1},
+				},
+				Months: []model.MonthCount{
+					{Month: "2025-01", Count: 2},
+					{Month: "2025-02", Count: 1},
+					{Month: "2025-03", Count: 1},
+				},
+				TopClicked: []model.CodeClicks{
+					{Code: "stats0003", URL: "https://github.com/golang/go", Clicks: 7},
+				},
+			},
+		},
+		{
+			name:        "success - user without bookmarks",
+			inputUserID: "unknown-user",
+			inputLimit:  10,
+			expectedStats: &model.BookmarkStats{
+				Domains:    []model.DomainCount{},
+				Tags:       []model.TagCount{},
+				Months:     []model.MonthCount{},
+				TopClicked: []model.CodeClicks{},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			if tc.setup != nil {
+				tc.setup(t, db)
+			}
+			repo := NewRepository(db)
+
+			stats, err := repo.GetStats(t.Context(), tc.inputUserID, tc.inputLimit)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStats, stats)
+		})
+	}
+}
+
+func TestBookmarkRepo_RecordClick(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		inputCode      string
+		expectedURL    string
+		expectedErr    error
+		expectedClicks int64
+	}{
+		{
+			name:           "success - click counted",
+			inputCode:      fixture.FixtureBookmarkOneCode,
+			expectedURL:    fixture.FixtureBookmarkURL,
+			expectedClicks: 1,
+		},
+		{
+			name:        "error - trashed bookmark",
+			inputCode:   fixture.FixtureBookmarkTrashedCode,
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name:        "error - unknown code",
+			inputCode:   "unknown",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			repo := NewRepository(db)
+
+			url, err := repo.RecordClick(t.Context(), tc.inputCode)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedURL, url)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			bookmark := &model.Bookmark{}
+			assert.NoError(t, db.Where("code = ?", tc.inputCode).First(bookmark).Error)
+			assert.Equal(t, tc.expectedClicks, bookmark.ClickCount)
+			// Following a link does not modify the bookmark
+			assert.Equal(t, 1, bookmark.Version)
+			assert.True(t, bookmark.UpdatedAt.Equal(fixture.FixtureTimestamp))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package bookmark
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+)
+
+// GetTrashedBookmarks retrieves a paginated list of soft-deleted bookmarks for a specific user.
+// Trashed rows are hidden from regular queries by GORM, so the query runs Unscoped
+// and filters on deleted_at explicitly. The most recently deleted bookmarks come first.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - limit: Maximum number of bookmarks to return
+//   - offset: Number of bookmarks to skip
+//
+// Returns:
+//   - []*model.Bookmark: The page of trashed bookmarks
+//   - int64: Total number of trashed bookmarks for the user
+//   - error: Database error, if any
+func (r *bookmarkRepo) GetTrashedBookmarks(ctx context.Context, userID string, limit, offset int) ([]*model.Bookmark, int64, error) {
+	bookmarks := make([]*model.Bookmark, 0)
+	var total int64
+
+	db := r.db.WithContext(ctx).Unscoped().Model(&model.Bookmark{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return bookmarks, 0, nil
+	}
+
+	err := db.Preload("Tags", orderTagsByName).Order("deleted_at DESC").Limit(limit).Offset(offset).Find(&bookmarks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return bookmarks, total, nil
+}
+
+// RestoreBookmark moves a soft-deleted bookmark out of the trash.
+// Only bookmarks that are currently trashed and owned by the user can be restored.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to restore
+//   - userID: The ID of the user attempting the restore (for ownership validation)
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark is not in the user's trash
+func (r *bookmarkRepo) RestoreBookmark(ctx context.Context, bookmarkID, userID string) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&model.Bookmark{}).
+		Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", bookmarkID, userID).
+		Update("deleted_at", nil)
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// PurgeTrashedBookmarks permanently removes bookmarks that were soft-deleted before the given time.
+// It is not scoped to a user and is meant to be run by the retention background job.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - before: Bookmarks trashed strictly before this time are removed
+//
+// Returns:
+//   - int64: Number of bookmarks removed
+//   - error: Database error, if any
+func (r *bookmarkRepo) PurgeTrashedBookmarks(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&model.Bookmark{})
+
+	if result.Error != nil {
+		return 0, dbutils.CatchDBErr(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,258 @@
+package bookmark
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBookmarkRepo_GetTrashedBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		inputUserID   string
+		inputLimit    int
+		inputOffset   int
+		expectedIDs   []string
+		expectedTotal int64
+		expectAnyErr  bool
+	}{
+		{
+			name: "success - only trashed bookmarks are returned",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputUserID:   fixture.FixtureUserOneID,
+			inputLimit:    10,
+			expectedIDs:   []string{fixture.FixtureBookmarkTrashedID},
+			expectedTotal: 1,
+		},
+		{
+			name: "success - most recently deleted first",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				err := db.Where("id = ?", fixture.FixtureBookmarkOneID).Delete(&model.Bookmark{}).Error
+				assert.NoError(t, err)
+				return db
+			},
+			inputUserID:   fixture.FixtureUserOneID,
+			inputLimit:    10,
+			expectedIDs:   []string{fixture.FixtureBookmarkOneID, fixture.FixtureBookmarkTrashedID},
+			expectedTotal: 2,
+		},
+		{
+			name: "success - empty trash",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputUserID:   fixture.FixtureUserTwoID,
+			inputLimit:    10,
+			expectedIDs:   []string{},
+			expectedTotal: 0,
+		},
+		{
+			name: "error - database error (disconnected)",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+				return db
+			},
+			inputUserID:  fixture.FixtureUserOneID,
+			inputLimit:   10,
+			expectAnyErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			repo := NewRepository(tc.setupDB(t))
+
+			bookmarks, total, err := repo.GetTrashedBookmarks(ctx, tc.inputUserID, tc.inputLimit, tc.inputOffset)
+
+			if tc.expectAnyErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTotal, total)
+
+			ids := make([]string, 0, len(bookmarks))
+			for _, b := range bookmarks {
+				assert.True(t, b.DeletedAt.Valid)
+				ids = append(ids, b.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestBookmarkRepo_RestoreBookmark(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		setupDB         func(t *testing.T) *gorm.DB
+		inputBookmarkID string
+		inputUserID     string
+		expectedErr     error
+		expectAnyErr    bool
+		verifyFunc      func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name: "success - restore trashed bookmark",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkTrashedID,
+			inputUserID:     fixture.FixtureUserOneID,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				var bookmark model.Bookmark
+				err := db.Where("id = ?", fixture.FixtureBookmarkTrashedID).First(&bookmark).Error
+				assert.NoError(t, err, "Restored bookmark should be visible to regular queries")
+			},
+		},
+		{
+			name: "error - bookmark is not trashed",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+		{
+			name: "error - bookmark belongs to different user",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkTrashedID,
+			inputUserID:     fixture.FixtureUserTwoID,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+		{
+			name: "error - database error (disconnected)",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+				return db
+			},
+			inputBookmarkID: fixture.FixtureBookmarkTrashedID,
+			inputUserID:     fixture.FixtureUserOneID,
+			expectAnyErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := tc.setupDB(t)
+			repo := NewRepository(db)
+
+			err := repo.RestoreBookmark(ctx, tc.inputBookmarkID, tc.inputUserID)
+
+			if tc.expectAnyErr {
+				assert.Error(t, err)
+				return
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			if tc.verifyFunc != nil {
+				tc.verifyFunc(t, db)
+			}
+		})
+	}
+}
+
+func TestBookmarkRepo_PurgeTrashedBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		inputBefore   time.Time
+		expectedCount int64
+		expectAnyErr  bool
+		verifyFunc    func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name: "success - purge bookmarks trashed before cutoff",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBefore:   fixture.FixtureTimestamp.Add(time.Hour),
+			expectedCount: 1,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Table("bookmarks").Where("id = ?", fixture.FixtureBookmarkTrashedID).Count(&count)
+				assert.Equal(t, int64(0), count, "Trashed bookmark should be removed permanently")
+
+				db.Table("bookmarks").Count(&count)
+				assert.Equal(t, int64(2), count, "Active bookmarks should be kept")
+			},
+		},
+		{
+			name: "success - keep bookmarks trashed within retention",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBefore:   fixture.FixtureTimestamp,
+			expectedCount: 0,
+		},
+		{
+			name: "error - database error (disconnected)",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+				return db
+			},
+			inputBefore:  time.Now(),
+			expectAnyErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := tc.setupDB(t)
+			repo := NewRepository(db)
+
+			count, err := repo.PurgeTrashedBookmarks(ctx, tc.inputBefore)
+
+			if tc.expectAnyErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+
+			if tc.verifyFunc != nil {
+				tc.verifyFunc(t, db)
+			}
+		})
+	}
+}
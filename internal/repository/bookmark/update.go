@@ -5,27 +5,143 @@ import (
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/urlnorm"
+	"gorm.io/gorm"
 )
 
 // UpdateBookmark updates an existing bookmark's description and URL.
-// It performs an ownership check to ensure only the bookmark owner can update it.
+// The replaced values are recorded as a revision in the same transaction.
+// It performs a permission check so that only the owner and editors it was shared with can update it.
+// The version check is atomic: the bookmark cannot change between the check and the update.
 //
 // Parameters:
 //   - ctx: Context for the operation
 //   - bookmarkID: The ID of the bookmark to update
-//   - userID: The ID of the user attempting the update (for ownership validation)
+//   - userID: The ID of the user attempting the update (for permission validation)
 //   - description: The new description for the bookmark
 //   - url: The new URL for the bookmark
+//   - version: The version the bookmark must have for the update to apply, 0 to update unconditionally
 //
 // Returns:
-//   - error: nil on success, ErrNotFoundType if bookmark doesn't exist or user doesn't own it
-func (r *bookmarkRepo) UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string) error {
+//   - error: nil on success, ErrNotFoundType if bookmark doesn't exist or user may not edit it,
+//     ErrVersionMismatch if the bookmark was modified since the given version
+func (r *bookmarkRepo) UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string, version int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := urlColumns(url)
+		updates["description"] = description
+		return updateWithRevision(tx, bookmarkID, userID, accessibleBy(userID, writePermissions), updates, version)
+	})
+}
+
+// PatchBookmark applies a partial update to an existing bookmark.
+// Only the non-nil fields of the patch are written; all other columns keep their values.
+// The replaced values are recorded as a revision in the same transaction.
+// It performs a permission check so that only the owner and editors it was shared with can update it.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the user attempting the update (for permission validation)
+//   - patch: The fields to change
+//   - version: The version the bookmark must have for the patch to apply, 0 to patch unconditionally
+//
+// Returns:
+//   - error: nil on success (or when the patch is empty), ErrNotFoundType if bookmark doesn't exist or user may not edit it,
+//     ErrVersionMismatch if the bookmark was modified since the given version
+func (r *bookmarkRepo) PatchBookmark(ctx context.Context, bookmarkID, userID string, patch *model.BookmarkPatch, version int) error {
+	if patch.IsEmpty() {
+		return nil
+	}
+
+	// Build updates map from the provided fields only
+	updates := make(map[string]any)
+	if patch.URL != nil {
+		updates = urlColumns(*patch.URL)
+	}
+	if patch.Description != nil {
+		updates["description"] = *patch.Description
+	}
+	if patch.Notes != nil {
+		updates["notes"] = *patch.Notes
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updateWithRevision(tx, bookmarkID, userID, accessibleBy(userID, writePermissions), updates, version)
+	})
+}
+
+// urlColumns returns the columns to write when the URL of a bookmark changes:
+// the URL itself and the values derived from it, which must never get out of sync.
+func urlColumns(url string) map[string]any {
+	return map[string]any{
+		"url":            url,
+		"normalized_url": urlnorm.Normalize(url),
+		"domain":         urlnorm.Domain(url),
+	}
+}
+
+// OverwriteBookmark replaces the description, folder and tags of an existing bookmark.
+// The tag set is replaced as a whole, inside a transaction, so the bookmark never ends up
+// with a partial set of tags. A replaced description is recorded as a revision. The URL, code and ownership are left untouched.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmark: The bookmark to overwrite, identified by its ID and UserID
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist or the user doesn't own it
+func (r *bookmarkRepo) OverwriteBookmark(ctx context.Context, bookmark *model.Bookmark) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := updateWithRevision(tx, bookmark.ID, bookmark.UserID, ownedBy(bookmark.UserID), map[string]any{
+			"description": bookmark.Description,
+			"folder":      bookmark.Folder,
+		}, 0)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("bookmark_id = ?", bookmark.ID).Delete(&model.BookmarkTag{}).Error; err != nil {
+			return dbutils.CatchDBErr(err)
+		}
+
+		if len(bookmark.Tags) == 0 {
+			return nil
+		}
+
+		for i := range bookmark.Tags {
+			bookmark.Tags[i].BookmarkID = bookmark.ID
+		}
+		if err := tx.Create(&bookmark.Tags).Error; err != nil {
+			return dbutils.CatchDBErr(err)
+		}
+		return nil
+	})
+}
+
+// UpdatePageMetadata stores the metadata fetched from the page a bookmark points to.
+// When the bookmark still has no description, the page title becomes its description,
+// so that the user-visible field is filled in without overwriting anything the user wrote.
+// It is called by background jobs rather than on behalf of a user, so no ownership check is done,
+// and the version of the bookmark is left unchanged: clients holding its ETag can still update it.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - meta: The fetched metadata
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the bookmark doesn't exist or is in the trash
+func (r *bookmarkRepo) UpdatePageMetadata(ctx context.Context, bookmarkID string, meta *model.PageMetadata) error {
 	result := r.db.WithContext(ctx).
 		Model(&model.Bookmark{}).
-		Where("id = ? AND user_id = ?", bookmarkID, userID).
-		Updates(map[string]any{
-			"description": description,
-			"url":         url,
+		Where("id = ?", bookmarkID).
+		UpdateColumns(map[string]any{
+			"title":            meta.Title,
+			"meta_description": meta.MetaDescription,
+			"favicon_url":      meta.FaviconURL,
+			"canonical_url":    meta.CanonicalURL,
+			"reading_time":     meta.ReadingTime,
+			"description":      gorm.Expr("CASE WHEN COALESCE(description, '') = '' THEN ? ELSE description END", meta.Title),
 		})
 
 	if result.Error != nil {
@@ -3,6 +3,7 @@ package bookmark
 import (
 	"testing"
 
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,7 @@ func TestBookmarkRepo_UpdateBookmark(t *testing.T) {
 		inputUserID      string
 		inputDescription string
 		inputURL         string
+		inputVersion     int
 		expectedErr      error
 		expectAnyErr     bool // true to check for any error, not specific type
 		verifyFunc       func(t *testing.T, db *gorm.DB)
@@ -37,6 +39,7 @@ func TestBookmarkRepo_UpdateBookmark(t *testing.T) {
 				var bookmark struct {
 					Description string
 					URL         string
+					Version     int
 				}
 				err := db.Table("bookmarks").
 					Where("id = ?", fixture.FixtureBookmarkOneID).
@@ -44,8 +47,38 @@ func TestBookmarkRepo_UpdateBookmark(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, "Updated Description", bookmark.Description)
 				assert.Equal(t, "https://updated-example.com", bookmark.URL)
+				assert.Equal(t, 2, bookmark.Version)
 			},
 		},
+		{
+			name: "success - version matches",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID:  fixture.FixtureBookmarkOneID,
+			inputUserID:      fixture.FixtureUserOneID,
+			inputDescription: "Updated Description",
+			inputURL:         "https://updated-example.com",
+			inputVersion:     1,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				var bookmark model.Bookmark
+				assert.NoError(t, db.Where("id = ?", fixture.FixtureBookmarkOneID).First(&bookmark).Error)
+				assert.Equal(t, "Updated Description", bookmark.Description)
+				assert.Equal(t, 2, bookmark.Version)
+			},
+		},
+		{
+			name: "error - version mismatch",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID:  fixture.FixtureBookmarkOneID,
+			inputUserID:      fixture.FixtureUserOneID,
+			inputDescription: "Updated Description",
+			inputURL:         "https://updated-example.com",
+			inputVersion:     2,
+			expectedErr:      dbutils.ErrVersionMismatch,
+		},
 		{
 			name: "error - bookmark not found",
 			setupDB: func(t *testing.T) *gorm.DB {
@@ -93,7 +126,7 @@ func TestBookmarkRepo_UpdateBookmark(t *testing.T) {
 			db := tc.setupDB(t)
 			repo := NewRepository(db)
 
-			err := repo.UpdateBookmark(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputDescription, tc.inputURL)
+			err := repo.UpdateBookmark(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputDescription, tc.inputURL, tc.inputVersion)
 
 			if tc.expectAnyErr {
 				assert.Error(t, err)
@@ -113,3 +146,312 @@ func TestBookmarkRepo_UpdateBookmark(t *testing.T) {
 		})
 	}
 }
+
+func TestBookmarkRepo_PatchBookmark(t *testing.T) {
+	t.Parallel()
+
+	newDescription := "Patched Description"
+	emptyDescription := ""
+	newURL := "https://patched-example.com"
+	newNotes := "Read the *second* half first"
+
+	testCases := []struct {
+		name            string
+		setupDB         func(t *testing.T) *gorm.DB
+		inputBookmarkID string
+		inputUserID     string
+		inputPatch      *model.BookmarkPatch
+		inputVersion    int
+		expectedErr     error
+		expectAnyErr    bool // true to check for any error, not specific type
+		expectedDesc    string
+		expectedURL     string
+		expectedNotes   string
+	}{
+		{
+			name: "success - patch description only",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{Description: &newDescription},
+			expectedDesc:    newDescription,
+			expectedURL:     fixture.FixtureBookmarkURL, // URL must be untouched
+		},
+		{
+			name: "success - patch URL only",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{URL: &newURL},
+			expectedDesc:    fixture.FixtureBookmarkDescription, // Description must be untouched
+			expectedURL:     newURL,
+		},
+		{
+			name: "success - clear description",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{Description: &emptyDescription},
+			expectedDesc:    "",
+			expectedURL:     fixture.FixtureBookmarkURL,
+		},
+		{
+			name: "success - patch notes only",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{Notes: &newNotes},
+			expectedDesc:    fixture.FixtureBookmarkDescription,
+			expectedURL:     fixture.FixtureBookmarkURL,
+			expectedNotes:   newNotes,
+		},
+		{
+			name: "success - empty patch is a no-op",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{},
+			expectedDesc:    fixture.FixtureBookmarkDescription,
+			expectedURL:     fixture.FixtureBookmarkURL,
+		},
+		{
+			name: "success - version matches",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{URL: &newURL},
+			inputVersion:    1,
+			expectedDesc:    fixture.FixtureBookmarkDescription,
+			expectedURL:     newURL,
+		},
+		{
+			name: "error - version mismatch",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{URL: &newURL},
+			inputVersion:    5,
+			expectedErr:     dbutils.ErrVersionMismatch,
+		},
+		{
+			name: "error - bookmark belongs to different user",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID, // Belongs to User One
+			inputUserID:     fixture.FixtureUserTwoID,     // Trying with User Two
+			inputPatch:      &model.BookmarkPatch{URL: &newURL},
+			expectedErr:     dbutils.ErrNotFoundType, // Should return not found for security
+		},
+		{
+			name: "error - database error (disconnected)",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				// Close connection to simulate DB error
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+				return db
+			},
+			inputBookmarkID: fixture.FixtureBookmarkOneID,
+			inputUserID:     fixture.FixtureUserOneID,
+			inputPatch:      &model.BookmarkPatch{URL: &newURL},
+			expectAnyErr:    true, // Any database error is expected
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := tc.setupDB(t)
+			repo := NewRepository(db)
+
+			err := repo.PatchBookmark(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputPatch, tc.inputVersion)
+
+			if tc.expectAnyErr {
+				assert.Error(t, err)
+				return
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var bookmark model.Bookmark
+			err = db.Where("id = ?", tc.inputBookmarkID).First(&bookmark).Error
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDesc, bookmark.Description)
+			assert.Equal(t, tc.expectedURL, bookmark.URL)
+			assert.Equal(t, tc.expectedNotes, bookmark.Notes)
+		})
+	}
+}
+
+func TestBookmarkRepo_OverwriteBookmark(t *testing.T) {
+	t.Parallel()
+
+	setupDB := func(t *testing.T) *gorm.DB {
+		db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+		tags := []model.BookmarkTag{
+			{BookmarkID: fixture.FixtureBookmarkOneID, Name: "old"},
+		}
+		assert.NoError(t, db.Create(&tags).Error)
+		return db
+	}
+
+	testCases := []struct {
+		name         string
+		inputBook    *model.Bookmark
+		expectedErr  error
+		expectedTags []string
+	}{
+		{
+			name: "success - replace description, folder and tags",
+			inputBook: &model.Bookmark{
+				Base:        model.Base{ID: fixture.FixtureBookmarkOneID},
+				UserID:      fixture.FixtureUserOneID,
+				Description: "Imported",
+				Folder:      "Work/Go",
+				Tags:        model.NewBookmarkTags([]string{"go", "new"}),
+			},
+			expectedTags: []string{"go", "new"},
+		},
+		{
+			name: "success - clear tags",
+			inputBook: &model.Bookmark{
+				Base:        model.Base{ID: fixture.FixtureBookmarkOneID},
+				UserID:      fixture.FixtureUserOneID,
+				Description: "Imported",
+				Folder:      "Work/Go",
+			},
+			expectedTags: []string{},
+		},
+		{
+			name: "error - bookmark belongs to different user",
+			inputBook: &model.Bookmark{
+				Base:   model.Base{ID: fixture.FixtureBookmarkOneID},
+				UserID: fixture.FixtureUserTwoID,
+				Tags:   model.NewBookmarkTags([]string{"go"}),
+			},
+			expectedErr:  dbutils.ErrNotFoundType,
+			expectedTags: []string{"old"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := setupDB(t)
+			repo := NewRepository(db)
+
+			err := repo.OverwriteBookmark(t.Context(), tc.inputBook)
+
+			bookmark, getErr := repo.GetBookmarkByID(t.Context(), fixture.FixtureBookmarkOneID, fixture.FixtureUserOneID)
+			assert.NoError(t, getErr)
+			assert.Equal(t, tc.expectedTags, bookmark.TagNames())
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, fixture.FixtureBookmarkDescription, bookmark.Description)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.inputBook.Description, bookmark.Description)
+			assert.Equal(t, tc.inputBook.Folder, bookmark.Folder)
+			assert.Equal(t, fixture.FixtureBookmarkURL, bookmark.URL, "URL must be untouched")
+		})
+	}
+}
+
+func TestBookmarkRepo_UpdatePageMetadata(t *testing.T) {
+	t.Parallel()
+
+	testMeta := &model.PageMetadata{
+		Title:           "Example Domain",
+		MetaDescription: "An example page",
+		FaviconURL:      "https://example.com/favicon.ico",
+		CanonicalURL:    "https://example.com/",
+		ReadingTime:     4,
+	}
+
+	testCases := []struct {
+		name                string
+		inputBookmarkID     string
+		initialDescription  *string
+		expectedErr         error
+		expectedDescription string
+	}{
+		{
+			name:                "success - keeps the user description",
+			inputBookmarkID:     fixture.FixtureBookmarkOneID,
+			expectedDescription: fixture.FixtureBookmarkDescription,
+		},
+		{
+			name:                "success - fills an empty description with the title",
+			inputBookmarkID:     fixture.FixtureBookmarkOneID,
+			initialDescription:  new(string),
+			expectedDescription: testMeta.Title,
+		},
+		{
+			name:            "error - bookmark in trash",
+			inputBookmarkID: fixture.FixtureBookmarkTrashedID,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+		{
+			name:            "error - bookmark not found",
+			inputBookmarkID: "non-existent-id",
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			if tc.initialDescription != nil {
+				assert.NoError(t, db.Model(&model.Bookmark{}).
+					Where("id = ?", tc.inputBookmarkID).
+					Update("description", *tc.initialDescription).Error)
+			}
+			repo := NewRepository(db)
+			var versionBefore int
+			assert.NoError(t, db.Model(&model.Bookmark{}).Where("id = ?", tc.inputBookmarkID).
+				Select("version").Scan(&versionBefore).Error)
+
+			err := repo.UpdatePageMetadata(t.Context(), tc.inputBookmarkID, testMeta)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			bookmark, err := repo.GetBookmarkByID(t.Context(), tc.inputBookmarkID, fixture.FixtureUserOneID)
+			assert.NoError(t, err)
+			assert.Equal(t, *testMeta, bookmark.PageMetadata)
+			assert.Equal(t, tc.expectedDescription, bookmark.Description)
+			assert.Equal(t, versionBefore, bookmark.Version, "enrichment must not change the ETag of the bookmark")
+		})
+	}
+}
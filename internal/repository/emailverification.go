@@ -0,0 +1,70 @@
+// Package repository provides the data access layer for the application.
+// This file contains the Redis storage of email verification tokens.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// EmailVerificationStore defines the interface for storing email verification tokens.
+// Tokens are stored under their hash only and can be consumed once.
+//
+//go:generate mockery --name EmailVerificationStore --filename email_verification_store.go
+type EmailVerificationStore interface {
+	// SaveVerificationToken stores the user and the address a verification token confirms, under the hash of the token, for ttl.
+	SaveVerificationToken(ctx context.Context, hash string, verification *model.EmailVerification, ttl time.Duration) error
+	// ConsumeVerificationToken deletes a verification token and returns what it confirms,
+	// or redis.Nil if the token is unknown, expired or already consumed.
+	ConsumeVerificationToken(ctx context.Context, hash string) (*model.EmailVerification, error)
+}
+
+// emailVerificationStore is a Redis-backed implementation of EmailVerificationStore.
+type emailVerificationStore struct {
+	c *redis.Client
+}
+
+// NewEmailVerificationStore creates a new instance of EmailVerificationStore.
+func NewEmailVerificationStore(c *redis.Client) EmailVerificationStore {
+	return &emailVerificationStore{c: c}
+}
+
+// emailVerificationKey returns the Redis hash holding the verification token with the given hash.
+func emailVerificationKey(hash string) string {
+	return "email_verification:" + hash
+}
+
+// SaveVerificationToken stores the token as a Redis hash, which expires after ttl.
+func (s *emailVerificationStore) SaveVerificationToken(ctx context.Context, hash string, verification *model.EmailVerification, ttl time.Duration) error {
+	key := emailVerificationKey(hash)
+
+	pipe := s.c.TxPipeline()
+	pipe.HSet(ctx, key, "user_id", verification.UserID, "email", verification.Email)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ConsumeVerificationToken reads and deletes the hash of the token in one transaction,
+// so two concurrent requests cannot both consume the same token.
+func (s *emailVerificationStore) ConsumeVerificationToken(ctx context.Context, hash string) (*model.EmailVerification, error) {
+	key := emailVerificationKey(hash)
+
+	pipe := s.c.TxPipeline()
+	fields := pipe.HGetAll(ctx, key)
+	deleted := pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	if deleted.Val() == 0 {
+		return nil, redis.Nil
+	}
+
+	return &model.EmailVerification{
+		UserID: fields.Val()["user_id"],
+		Email:  fields.Val()["email"],
+	}, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEmailVerificationStore validates that a verification token expires with its TTL
+// and can be consumed only once.
+func TestEmailVerificationStore(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	redisMock := redisPkg.InitMockRedis(t)
+	store := NewEmailVerificationStore(redisMock)
+	verification := &model.EmailVerification{UserID: "user-1", Email: "john@example.com"}
+
+	assert.NoError(t, store.SaveVerificationToken(ctx, "hash-1", verification, time.Hour))
+
+	ttl, err := redisMock.TTL(ctx, "email_verification:hash-1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, ttl)
+
+	got, err := store.ConsumeVerificationToken(ctx, "hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, verification, got)
+
+	_, err = store.ConsumeVerificationToken(ctx, "hash-1")
+	assert.ErrorIs(t, err, redis.Nil)
+
+	_, err = store.ConsumeVerificationToken(ctx, "unknown")
+	assert.ErrorIs(t, err, redis.Nil)
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// EmailVerificationStore is an autogenerated mock type for the EmailVerificationStore type
+type EmailVerificationStore struct {
+	mock.Mock
+}
+
+// ConsumeVerificationToken provides a mock function with given fields: ctx, hash
+func (_m *EmailVerificationStore) ConsumeVerificationToken(ctx context.Context, hash string) (*model.EmailVerification, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeVerificationToken")
+	}
+
+	var r0 *model.EmailVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.EmailVerification, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.EmailVerification); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmailVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveVerificationToken provides a mock function with given fields: ctx, hash, verification, ttl
+func (_m *EmailVerificationStore) SaveVerificationToken(ctx context.Context, hash string, verification *model.EmailVerification, ttl time.Duration) error {
+	ret := _m.Called(ctx, hash, verification, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveVerificationToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.EmailVerification, time.Duration) error); ok {
+		r0 = rf(ctx, hash, verification, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEmailVerificationStore creates a new instance of EmailVerificationStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEmailVerificationStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EmailVerificationStore {
+	mock := &EmailVerificationStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
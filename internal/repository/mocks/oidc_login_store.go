@@ -0,0 +1,79 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// OIDCLoginStore is an autogenerated mock type for the OIDCLoginStore type
+type OIDCLoginStore struct {
+	mock.Mock
+}
+
+// ConsumeLogin provides a mock function with given fields: ctx, hash
+func (_m *OIDCLoginStore) ConsumeLogin(ctx context.Context, hash string) (*model.OIDCLogin, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeLogin")
+	}
+
+	var r0 *model.OIDCLogin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.OIDCLogin, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.OIDCLogin); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OIDCLogin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveLogin provides a mock function with given fields: ctx, hash, login, ttl
+func (_m *OIDCLoginStore) SaveLogin(ctx context.Context, hash string, login *model.OIDCLogin, ttl time.Duration) error {
+	ret := _m.Called(ctx, hash, login, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.OIDCLogin, time.Duration) error); ok {
+		r0 = rf(ctx, hash, login, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOIDCLoginStore creates a new instance of OIDCLoginStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCLoginStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCLoginStore {
+	mock := &OIDCLoginStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
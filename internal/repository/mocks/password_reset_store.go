@@ -0,0 +1,76 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PasswordResetStore is an autogenerated mock type for the PasswordResetStore type
+type PasswordResetStore struct {
+	mock.Mock
+}
+
+// ConsumeResetToken provides a mock function with given fields: ctx, hash
+func (_m *PasswordResetStore) ConsumeResetToken(ctx context.Context, hash string) (string, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeResetToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveResetToken provides a mock function with given fields: ctx, hash, userID, ttl
+func (_m *PasswordResetStore) SaveResetToken(ctx context.Context, hash string, userID string, ttl time.Duration) error {
+	ret := _m.Called(ctx, hash, userID, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResetToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, hash, userID, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPasswordResetStore creates a new instance of PasswordResetStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordResetStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordResetStore {
+	mock := &PasswordResetStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// StatsCache is an autogenerated mock type for the StatsCache type
+type StatsCache struct {
+	mock.Mock
+}
+
+// GetStats provides a mock function with given fields: ctx, key
+func (_m *StatsCache) GetStats(ctx context.Context, key string) (*model.BookmarkStats, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 *model.BookmarkStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.BookmarkStats, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.BookmarkStats); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetStats provides a mock function with given fields: ctx, key, stats, ttl
+func (_m *StatsCache) SetStats(ctx context.Context, key string, stats *model.BookmarkStats, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, stats, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BookmarkStats, time.Duration) error); ok {
+		r0 = rf(ctx, key, stats, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStatsCache creates a new instance of StatsCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatsCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatsCache {
+	mock := &StatsCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
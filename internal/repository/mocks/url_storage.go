@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UrlStorage is an autogenerated mock type for the UrlStorage type
@@ -13,6 +15,24 @@ type UrlStorage struct {
 	mock.Mock
 }
 
+// DecrLinkCount provides a mock function with given fields: ctx, userID, day
+func (_m *UrlStorage) DecrLinkCount(ctx context.Context, userID string, day time.Time) error {
+	ret := _m.Called(ctx, userID, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecrLinkCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, day)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Exists provides a mock function with given fields: ctx, code
 func (_m *UrlStorage) Exists(ctx context.Context, code string) (bool, error) {
 	ret := _m.Called(ctx, code)
@@ -41,6 +61,34 @@ func (_m *UrlStorage) Exists(ctx context.Context, code string) (bool, error) {
 	return r0, r1
 }
 
+// GetLinkCount provides a mock function with given fields: ctx, userID, day
+func (_m *UrlStorage) GetLinkCount(ctx context.Context, userID string, day time.Time) (int64, error) {
+	ret := _m.Called(ctx, userID, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinkCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, error)); ok {
+		return rf(ctx, userID, day)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = rf(ctx, userID, day)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUrl provides a mock function with given fields: ctx, code
 func (_m *UrlStorage) GetUrl(ctx context.Context, code string) (string, error) {
 	ret := _m.Called(ctx, code)
@@ -69,6 +117,34 @@ func (_m *UrlStorage) GetUrl(ctx context.Context, code string) (string, error) {
 	return r0, r1
 }
 
+// IncrLinkCount provides a mock function with given fields: ctx, userID, day
+func (_m *UrlStorage) IncrLinkCount(ctx context.Context, userID string, day time.Time) (int64, error) {
+	ret := _m.Called(ctx, userID, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrLinkCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, error)); ok {
+		return rf(ctx, userID, day)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = rf(ctx, userID, day)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StoreUrl provides a mock function with given fields: ctx, code, url
 func (_m *UrlStorage) StoreUrl(ctx context.Context, code string, url string) error {
 	ret := _m.Called(ctx, code, url)
@@ -7,6 +7,8 @@ import (
 
 	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // User is an autogenerated mock type for the User type
@@ -14,6 +16,34 @@ type User struct {
 	mock.Mock
 }
 
+// CountEnabledAdmins provides a mock function with given fields: ctx
+func (_m *User) CountEnabledAdmins(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountEnabledAdmins")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: ctx, newUser
 func (_m *User) CreateUser(ctx context.Context, newUser *model.User) (*model.User, error) {
 	ret := _m.Called(ctx, newUser)
@@ -44,6 +74,54 @@ func (_m *User) CreateUser(ctx context.Context, newUser *model.User) (*model.Use
 	return r0, r1
 }
 
+// DeleteUser provides a mock function with given fields: ctx, userID
+func (_m *User) DeleteUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *User) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserById provides a mock function with given fields: ctx, userID
 func (_m *User) GetUserById(ctx context.Context, userID string) (*model.User, error) {
 	ret := _m.Called(ctx, userID)
@@ -74,6 +152,36 @@ func (_m *User) GetUserById(ctx context.Context, userID string) (*model.User, er
 	return r0, r1
 }
 
+// GetUserByOIDCSubject provides a mock function with given fields: ctx, subject
+func (_m *User) GetUserByOIDCSubject(ctx context.Context, subject string) (*model.User, error) {
+	ret := _m.Called(ctx, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByOIDCSubject")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByUsername provides a mock function with given fields: ctx, username
 func (_m *User) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	ret := _m.Called(ctx, username)
@@ -104,6 +212,161 @@ func (_m *User) GetUserByUsername(ctx context.Context, username string) (*model.
 	return r0, r1
 }
 
+// ListUsers provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *User) ListUsers(ctx context.Context, filter *model.UserFilter, limit int, offset int) ([]*model.User, int64, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []*model.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserFilter, int, int) ([]*model.User, int64, error)); ok {
+		return rf(ctx, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserFilter, int, int) []*model.User); ok {
+		r0 = rf(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UserFilter, int, int) int64); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.UserFilter, int, int) error); ok {
+		r2 = rf(ctx, filter, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PurgeDeletedUsers provides a mock function with given fields: ctx, before
+func (_m *User) PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDisabledAt provides a mock function with given fields: ctx, userID, disabledAt
+func (_m *User) SetDisabledAt(ctx context.Context, userID string, disabledAt *time.Time) error {
+	ret := _m.Called(ctx, userID, disabledAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDisabledAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
+		r0 = rf(ctx, userID, disabledAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetOIDCSubject provides a mock function with given fields: ctx, userID, subject
+func (_m *User) SetOIDCSubject(ctx context.Context, userID string, subject string) error {
+	ret := _m.Called(ctx, userID, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOIDCSubject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, subject)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPendingEmail provides a mock function with given fields: ctx, userID, email
+func (_m *User) SetPendingEmail(ctx context.Context, userID string, email string) error {
+	ret := _m.Called(ctx, userID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPendingEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, userID, passwordHash
+func (_m *User) UpdatePassword(ctx context.Context, userID string, passwordHash string) error {
+	ret := _m.Called(ctx, userID, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRoles provides a mock function with given fields: ctx, userID, roles
+func (_m *User) UpdateRoles(ctx context.Context, userID string, roles model.Roles) error {
+	ret := _m.Called(ctx, userID, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRoles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Roles) error); ok {
+		r0 = rf(ctx, userID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUser provides a mock function with given fields: ctx, userID, displayName, email
 func (_m *User) UpdateUser(ctx context.Context, userID string, displayName string, email string) error {
 	ret := _m.Called(ctx, userID, displayName, email)
@@ -122,6 +385,24 @@ func (_m *User) UpdateUser(ctx context.Context, userID string, displayName strin
 	return r0
 }
 
+// VerifyEmail provides a mock function with given fields: ctx, userID, email, verifiedAt
+func (_m *User) VerifyEmail(ctx context.Context, userID string, email string, verifiedAt time.Time) error {
+	ret := _m.Called(ctx, userID, email, verifiedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, email, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUser creates a new instance of User. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUser(t interface {
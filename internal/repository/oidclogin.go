@@ -0,0 +1,70 @@
+// Package repository provides the data access layer for the application.
+// This file contains the Redis storage of pending OpenID Connect logins.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// OIDCLoginStore defines the interface for storing the logins waiting for the callback of the
+// OpenID Connect provider. Logins are stored under the hash of their state and can be consumed once.
+//
+//go:generate mockery --name OIDCLoginStore --filename oidc_login_store.go
+type OIDCLoginStore interface {
+	// SaveLogin stores the code verifier and nonce of a login under the hash of its state, for ttl.
+	SaveLogin(ctx context.Context, hash string, login *model.OIDCLogin, ttl time.Duration) error
+	// ConsumeLogin deletes a login and returns it,
+	// or redis.Nil if the state is unknown, expired or already consumed.
+	ConsumeLogin(ctx context.Context, hash string) (*model.OIDCLogin, error)
+}
+
+// oidcLoginStore is a Redis-backed implementation of OIDCLoginStore.
+type oidcLoginStore struct {
+	c *redis.Client
+}
+
+// NewOIDCLoginStore creates a new instance of OIDCLoginStore.
+func NewOIDCLoginStore(c *redis.Client) OIDCLoginStore {
+	return &oidcLoginStore{c: c}
+}
+
+// oidcLoginKey returns the Redis hash holding the login with the given state hash.
+func oidcLoginKey(hash string) string {
+	return "oidc_login:" + hash
+}
+
+// SaveLogin stores the login as a Redis hash, which expires after ttl.
+func (s *oidcLoginStore) SaveLogin(ctx context.Context, hash string, login *model.OIDCLogin, ttl time.Duration) error {
+	key := oidcLoginKey(hash)
+
+	pipe := s.c.TxPipeline()
+	pipe.HSet(ctx, key, "code_verifier", login.CodeVerifier, "nonce", login.Nonce)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ConsumeLogin reads and deletes the hash of the login in one transaction,
+// so a callback cannot be replayed.
+func (s *oidcLoginStore) ConsumeLogin(ctx context.Context, hash string) (*model.OIDCLogin, error) {
+	key := oidcLoginKey(hash)
+
+	pipe := s.c.TxPipeline()
+	fields := pipe.HGetAll(ctx, key)
+	deleted := pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	if deleted.Val() == 0 {
+		return nil, redis.Nil
+	}
+
+	return &model.OIDCLogin{
+		CodeVerifier: fields.Val()["code_verifier"],
+		Nonce:        fields.Val()["nonce"],
+	}, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOIDCLoginStore validates that a pending login expires with its TTL
+// and can be consumed only once.
+func TestOIDCLoginStore(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	redisMock := redisPkg.InitMockRedis(t)
+	store := NewOIDCLoginStore(redisMock)
+	login := &model.OIDCLogin{CodeVerifier: "verifier-1", Nonce: "nonce-1"}
+
+	assert.NoError(t, store.SaveLogin(ctx, "hash-1", login, 10*time.Minute))
+
+	ttl, err := redisMock.TTL(ctx, "oidc_login:hash-1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, ttl)
+
+	got, err := store.ConsumeLogin(ctx, "hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, login, got)
+
+	_, err = store.ConsumeLogin(ctx, "hash-1")
+	assert.ErrorIs(t, err, redis.Nil)
+
+	_, err = store.ConsumeLogin(ctx, "unknown")
+	assert.ErrorIs(t, err, redis.Nil)
+}
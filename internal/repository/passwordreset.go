@@ -0,0 +1,48 @@
+// Package repository provides the data access layer for the application.
+// This file contains the Redis storage of password reset tokens.
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PasswordResetStore defines the interface for storing password reset tokens.
+// Tokens are stored under their hash only and can be consumed once.
+//
+//go:generate mockery --name PasswordResetStore --filename password_reset_store.go
+type PasswordResetStore interface {
+	// SaveResetToken stores the ID of the user a reset token was issued to, under the hash of the token, for ttl.
+	SaveResetToken(ctx context.Context, hash, userID string, ttl time.Duration) error
+	// ConsumeResetToken deletes a reset token and returns the ID of its user,
+	// or redis.Nil if the token is unknown, expired or already consumed.
+	ConsumeResetToken(ctx context.Context, hash string) (string, error)
+}
+
+// passwordResetStore is a Redis-backed implementation of PasswordResetStore.
+type passwordResetStore struct {
+	c *redis.Client
+}
+
+// NewPasswordResetStore creates a new instance of PasswordResetStore.
+func NewPasswordResetStore(c *redis.Client) PasswordResetStore {
+	return &passwordResetStore{c: c}
+}
+
+// passwordResetKey returns the Redis key holding the reset token with the given hash.
+func passwordResetKey(hash string) string {
+	return "password_reset:" + hash
+}
+
+// SaveResetToken sets the key of the token, which expires after ttl.
+func (s *passwordResetStore) SaveResetToken(ctx context.Context, hash, userID string, ttl time.Duration) error {
+	return s.c.Set(ctx, passwordResetKey(hash), userID, ttl).Err()
+}
+
+// ConsumeResetToken reads and deletes the key of the token in a single GETDEL,
+// so two concurrent requests cannot both consume the same token.
+func (s *passwordResetStore) ConsumeResetToken(ctx context.Context, hash string) (string, error) {
+	return s.c.GetDel(ctx, passwordResetKey(hash)).Result()
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPasswordResetStore validates that a reset token expires with its TTL
+// and can be consumed only once.
+func TestPasswordResetStore(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	redisMock := redisPkg.InitMockRedis(t)
+	store := NewPasswordResetStore(redisMock)
+
+	assert.NoError(t, store.SaveResetToken(ctx, "hash-1", "user-1", time.Hour))
+
+	ttl, err := redisMock.TTL(ctx, "password_reset:hash-1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, ttl)
+
+	userID, err := store.ConsumeResetToken(ctx, "hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+
+	_, err = store.ConsumeResetToken(ctx, "hash-1")
+	assert.ErrorIs(t, err, redis.Nil)
+
+	_, err = store.ConsumeResetToken(ctx, "unknown")
+	assert.ErrorIs(t, err, redis.Nil)
+}
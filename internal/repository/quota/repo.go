@@ -0,0 +1,62 @@
+// Package quota provides the data access for the per-user overrides of the default quotas.
+package quota
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for quota override database operations.
+// The usage the quotas are checked against is read from the repositories of the limited resources.
+//
+//go:generate mockery --name Repository --filename quota.go
+type Repository interface {
+	GetUserQuota(ctx context.Context, userID string) (*model.UserQuota, error)
+	SaveUserQuota(ctx context.Context, quota *model.UserQuota) error
+}
+
+// quotaRepo is the concrete implementation of the Repository interface using GORM.
+type quotaRepo struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new instance of quotaRepo with the provided GORM database connection.
+func NewRepository(db *gorm.DB) Repository {
+	return &quotaRepo{db: db}
+}
+
+// GetUserQuota retrieves the quota overrides of a user.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - *model.UserQuota: The overrides of the user
+//   - error: ErrNotFoundType if the user has no overrides, or a database error
+func (r *quotaRepo) GetUserQuota(ctx context.Context, userID string) (*model.UserQuota, error) {
+	quota := &model.UserQuota{}
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(quota).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return quota, nil
+}
+
+// SaveUserQuota creates or replaces the quota overrides of a user.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - quota: The overrides to store, nil fields reset a limit to its default
+//
+// Returns:
+//   - error: nil on success, or a database error
+func (r *quotaRepo) SaveUserQuota(ctx context.Context, quota *model.UserQuota) error {
+	if err := r.db.WithContext(ctx).Save(quota).Error; err != nil {
+		return dbutils.CatchDBErr(err)
+	}
+	return nil
+}
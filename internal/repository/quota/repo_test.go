@@ -0,0 +1,81 @@
+package quota
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaRepo_GetUserQuota(t *testing.T) {
+	t.Parallel()
+
+	maxBookmarks := int64(5)
+
+	testCases := []struct {
+		name        string
+		inputUserID string
+		expected    *model.UserQuota
+		expectedErr error
+	}{
+		{
+			name:        "success - overrides of the user",
+			inputUserID: fixture.FixtureUserOneID,
+			expected:    &model.UserQuota{UserID: fixture.FixtureUserOneID, MaxBookmarks: &maxBookmarks},
+		},
+		{
+			name:        "error - user without overrides",
+			inputUserID: fixture.FixtureUserTwoID,
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			repo := NewRepository(db)
+			assert.NoError(t, repo.SaveUserQuota(t.Context(), &model.UserQuota{
+				UserID:       fixture.FixtureUserOneID,
+				MaxBookmarks: &maxBookmarks,
+			}))
+
+			quota, err := repo.GetUserQuota(t.Context(), tc.inputUserID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, quota)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.UserID, quota.UserID)
+			assert.Equal(t, tc.expected.MaxBookmarks, quota.MaxBookmarks)
+			assert.Nil(t, quota.MaxShortLinksPerDay)
+			assert.Nil(t, quota.MaxSnapshotBytes)
+		})
+	}
+}
+
+func TestQuotaRepo_SaveUserQuota(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	repo := NewRepository(db)
+	first, second := int64(5), int64(0)
+
+	assert.NoError(t, repo.SaveUserQuota(t.Context(), &model.UserQuota{UserID: fixture.FixtureUserOneID, MaxBookmarks: &first}))
+	assert.NoError(t, repo.SaveUserQuota(t.Context(), &model.UserQuota{UserID: fixture.FixtureUserOneID, MaxShortLinksPerDay: &second}))
+
+	quota, err := repo.GetUserQuota(t.Context(), fixture.FixtureUserOneID)
+	assert.NoError(t, err)
+	assert.Nil(t, quota.MaxBookmarks, "saving replaces every override")
+	assert.Equal(t, &second, quota.MaxShortLinksPerDay)
+
+	var count int64
+	assert.NoError(t, db.Model(&model.UserQuota{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,138 @@
+// Package repository provides the data access layer for the application.
+// This file contains the Redis storage of refresh tokens.
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// RefreshTokenStore defines the interface for storing refresh tokens.
+// Tokens are stored under their hash only, so a leak of the storage does not leak usable tokens.
+//
+//go:generate mockery --name RefreshTokenStore --filename refresh_token_store.go
+type RefreshTokenStore interface {
+	// SaveRefreshToken stores a refresh token under its hash for ttl, and keeps its family alive as long.
+	SaveRefreshToken(ctx context.Context, hash string, token *model.RefreshToken, ttl time.Duration) error
+	// UseRefreshToken counts a use of a refresh token. It returns the token with the number of times it
+	// was used, this one included, or redis.Nil if the token is unknown, expired or its family was revoked.
+	UseRefreshToken(ctx context.Context, hash string) (*model.RefreshToken, int64, error)
+	// GetRefreshToken returns a refresh token without using it, or redis.Nil if it is unknown or expired.
+	GetRefreshToken(ctx context.Context, hash string) (*model.RefreshToken, error)
+	// RevokeFamily invalidates every refresh token of a family.
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+// refreshTokenStore is a Redis-backed implementation of RefreshTokenStore.
+type refreshTokenStore struct {
+	c *redis.Client
+}
+
+// NewRefreshTokenStore creates a new instance of RefreshTokenStore.
+func NewRefreshTokenStore(c *redis.Client) RefreshTokenStore {
+	return &refreshTokenStore{c: c}
+}
+
+// refreshTokenKey returns the Redis hash holding the refresh token with the given hash.
+func refreshTokenKey(hash string) string {
+	return "refresh_token:" + hash
+}
+
+// refreshFamilyKey returns the Redis key whose existence keeps the tokens of a family valid.
+func refreshFamilyKey(familyID string) string {
+	return "refresh_family:" + familyID
+}
+
+// useRefreshTokenScript increments the use count of a token whose family is still alive,
+// and returns the user, the family, the generation, the new count and the roles. Running it as a script makes the
+// check and the increment atomic, so two concurrent uses of a token cannot both be the first.
+var useRefreshTokenScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return false
+end
+local family = redis.call('HGET', KEYS[1], 'family_id')
+if redis.call('EXISTS', ARGV[1] .. family) == 0 then
+	return false
+end
+local uses = redis.call('HINCRBY', KEYS[1], 'uses', 1)
+return {redis.call('HGET', KEYS[1], 'user_id'), family, redis.call('HGET', KEYS[1], 'generation'), uses,
+	redis.call('HGET', KEYS[1], 'roles') or ''}
+`)
+
+// SaveRefreshToken stores the token as a Redis hash and sets the expiry of both the token and its family.
+func (s *refreshTokenStore) SaveRefreshToken(ctx context.Context, hash string, token *model.RefreshToken, ttl time.Duration) error {
+	key := refreshTokenKey(hash)
+
+	pipe := s.c.TxPipeline()
+	pipe.HSet(ctx, key, "user_id", token.UserID, "family_id", token.FamilyID, "generation", token.Generation,
+		"roles", strings.Join(token.Roles, ","), "uses", 0)
+	pipe.Expire(ctx, key, ttl)
+	pipe.Set(ctx, refreshFamilyKey(token.FamilyID), token.UserID, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// UseRefreshToken atomically counts a use of the token, see useRefreshTokenScript.
+func (s *refreshTokenStore) UseRefreshToken(ctx context.Context, hash string) (*model.RefreshToken, int64, error) {
+	res, err := useRefreshTokenScript.Run(ctx, s.c, []string{refreshTokenKey(hash)}, refreshFamilyKey("")).Slice()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	generation, err := strconv.ParseInt(res[2].(string), 10, 64)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	token := &model.RefreshToken{
+		UserID:     res[0].(string),
+		FamilyID:   res[1].(string),
+		Generation: generation,
+	}
+	if err := token.Roles.Scan(res[4]); err != nil {
+		return nil, 0, err
+	}
+	return token, res[3].(int64), nil
+}
+
+// GetRefreshToken reads the fields of the token hash. The family is not checked:
+// the token is returned even if its family was revoked.
+func (s *refreshTokenStore) GetRefreshToken(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	var stored struct {
+		UserID     string `redis:"user_id"`
+		FamilyID   string `redis:"family_id"`
+		Generation int64  `redis:"generation"`
+		Roles      string `redis:"roles"`
+	}
+	res := s.c.HGetAll(ctx, refreshTokenKey(hash))
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+	if len(res.Val()) == 0 {
+		return nil, redis.Nil
+	}
+	if err := res.Scan(&stored); err != nil {
+		return nil, err
+	}
+
+	token := &model.RefreshToken{
+		UserID:     stored.UserID,
+		FamilyID:   stored.FamilyID,
+		Generation: stored.Generation,
+	}
+	if err := token.Roles.Scan(stored.Roles); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// RevokeFamily deletes the family key. The tokens of the family are left to expire,
+// but can no longer be used.
+func (s *refreshTokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	return s.c.Del(ctx, refreshFamilyKey(familyID)).Err()
+}
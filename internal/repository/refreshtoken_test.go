@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRefreshTokenStore_UseRefreshToken validates that uses of a refresh token are counted
+// and that tokens of revoked families can no longer be used.
+func TestRefreshTokenStore_UseRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	token := &model.RefreshToken{UserID: "user-1", FamilyID: "family-1", Generation: 2, Roles: model.Roles{model.RoleUser, model.RoleAdmin}}
+
+	testCases := []struct {
+		name          string
+		setupMock     func() *redis.Client
+		save          bool
+		revoke        bool
+		uses          int
+		expectedToken *model.RefreshToken
+		expectedUses  int64
+		expectedErr   error
+	}{
+		{
+			name:          "first use",
+			setupMock:     func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			save:          true,
+			uses:          1,
+			expectedToken: token,
+			expectedUses:  1,
+		},
+		{
+			name:          "reuse",
+			setupMock:     func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			save:          true,
+			uses:          2,
+			expectedToken: token,
+			expectedUses:  2,
+		},
+		{
+			name:        "unknown token",
+			setupMock:   func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			uses:        1,
+			expectedErr: redis.Nil,
+		},
+		{
+			name:        "revoked family",
+			setupMock:   func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			save:        true,
+			revoke:      true,
+			uses:        1,
+			expectedErr: redis.Nil,
+		},
+		{
+			name: "redis connection",
+			setupMock: func() *redis.Client {
+				mock := redisPkg.InitMockRedis(t)
+				_ = mock.Close()
+				return mock
+			},
+			uses:        1,
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			redisMock := tc.setupMock()
+			store := NewRefreshTokenStore(redisMock)
+			if tc.save {
+				assert.NoError(t, store.SaveRefreshToken(ctx, "hash-1", token, time.Hour))
+				ttl, err := redisMock.TTL(ctx, "refresh_family:family-1").Result()
+				assert.NoError(t, err)
+				assert.Equal(t, time.Hour, ttl)
+			}
+			if tc.revoke {
+				assert.NoError(t, store.RevokeFamily(ctx, token.FamilyID))
+			}
+
+			var (
+				got  *model.RefreshToken
+				uses int64
+				err  error
+			)
+			for range tc.uses {
+				got, uses, err = store.UseRefreshToken(ctx, "hash-1")
+			}
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedToken, got)
+			assert.Equal(t, tc.expectedUses, uses)
+		})
+	}
+}
+
+// TestRefreshTokenStore_GetRefreshToken validates that a refresh token can be read without
+// counting a use, and that unknown tokens return redis.Nil.
+func TestRefreshTokenStore_GetRefreshToken(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	token := &model.RefreshToken{UserID: "user-1", FamilyID: "family-1", Generation: 3, Roles: model.Roles{model.RoleUser}}
+	store := NewRefreshTokenStore(redisPkg.InitMockRedis(t))
+	assert.NoError(t, store.SaveRefreshToken(ctx, "hash-1", token, time.Hour))
+
+	got, err := store.GetRefreshToken(ctx, "hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, token, got)
+
+	_, uses, err := store.UseRefreshToken(ctx, "hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), uses)
+
+	_, err = store.GetRefreshToken(ctx, "unknown")
+	assert.ErrorIs(t, err, redis.Nil)
+}
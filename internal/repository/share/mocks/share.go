@@ -0,0 +1,167 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// CreateShare provides a mock function with given fields: ctx, _a1
+func (_m *Repository) CreateShare(ctx context.Context, _a1 *model.Share) (*model.Share, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateShare")
+	}
+
+	var r0 *model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Share) (*model.Share, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Share) *model.Share); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Share) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteShare provides a mock function with given fields: ctx, shareID, ownerID
+func (_m *Repository) DeleteShare(ctx context.Context, shareID string, ownerID string) error {
+	ret := _m.Called(ctx, shareID, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteShare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, shareID, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReceivedShares provides a mock function with given fields: ctx, granteeID
+func (_m *Repository) GetReceivedShares(ctx context.Context, granteeID string) ([]*model.Share, error) {
+	ret := _m.Called(ctx, granteeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceivedShares")
+	}
+
+	var r0 []*model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Share, error)); ok {
+		return rf(ctx, granteeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Share); ok {
+		r0 = rf(ctx, granteeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, granteeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShareByToken provides a mock function with given fields: ctx, token
+func (_m *Repository) GetShareByToken(ctx context.Context, token string) (*model.Share, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShareByToken")
+	}
+
+	var r0 *model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Share, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Share); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShares provides a mock function with given fields: ctx, ownerID
+func (_m *Repository) GetShares(ctx context.Context, ownerID string) ([]*model.Share, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShares")
+	}
+
+	var r0 []*model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Share, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Share); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
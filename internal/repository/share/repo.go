@@ -0,0 +1,143 @@
+// Package share provides the data access for shares, which give other users
+// or holders of a public link access to bookmarks.
+package share
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for share-related database operations.
+// Checking permissions on shared bookmarks is done by the bookmark repository.
+//
+//go:generate mockery --name Repository --filename share.go
+type Repository interface {
+	CreateShare(ctx context.Context, share *model.Share) (*model.Share, error)
+	GetShares(ctx context.Context, ownerID string) ([]*model.Share, error)
+	GetReceivedShares(ctx context.Context, granteeID string) ([]*model.Share, error)
+	GetShareByToken(ctx context.Context, token string) (*model.Share, error)
+	DeleteShare(ctx context.Context, shareID, ownerID string) error
+}
+
+// shareRepo is the concrete implementation of the Repository interface using GORM.
+type shareRepo struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new instance of shareRepo with the provided GORM database connection.
+func NewRepository(db *gorm.DB) Repository {
+	return &shareRepo{db: db}
+}
+
+// CreateShare inserts a new share.
+// Sharing the same collection with the same user twice violates a unique constraint.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - share: The share to create
+//
+// Returns:
+//   - *model.Share: The created share with ID and timestamps populated
+//   - error: ErrDuplicationType if the collection is already shared with the grantee, or a database error
+func (r *shareRepo) CreateShare(ctx context.Context, share *model.Share) (*model.Share, error) {
+	err := r.db.WithContext(ctx).Create(share).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return share, nil
+}
+
+// GetShares retrieves every share created by a user, user shares and public links alike,
+// most recent first. The grantee of each user share is preloaded.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - ownerID: The ID of the owner
+//
+// Returns:
+//   - []*model.Share: The shares, empty when there are none
+//   - error: Any database error
+func (r *shareRepo) GetShares(ctx context.Context, ownerID string) ([]*model.Share, error) {
+	shares := make([]*model.Share, 0)
+	err := r.db.WithContext(ctx).
+		Preload("Grantee").
+		Where("owner_id = ?", ownerID).
+		Order("created_at DESC, id ASC").
+		Find(&shares).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return shares, nil
+}
+
+// GetReceivedShares retrieves every share granted to a user, most recent first.
+// The owner of each share is preloaded.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - granteeID: The ID of the grantee
+//
+// Returns:
+//   - []*model.Share: The shares, empty when there are none
+//   - error: Any database error
+func (r *shareRepo) GetReceivedShares(ctx context.Context, granteeID string) ([]*model.Share, error) {
+	shares := make([]*model.Share, 0)
+	err := r.db.WithContext(ctx).
+		Preload("Owner").
+		Where("grantee_id = ?", granteeID).
+		Order("created_at DESC, id ASC").
+		Find(&shares).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return shares, nil
+}
+
+// GetShareByToken retrieves the public link identified by token.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - token: The token of the public link
+//
+// Returns:
+//   - *model.Share: The matching share
+//   - error: ErrNotFoundType if no public link has this token
+func (r *shareRepo) GetShareByToken(ctx context.Context, token string) (*model.Share, error) {
+	share := &model.Share{}
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(share).Error
+	if err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return share, nil
+}
+
+// DeleteShare revokes a share. Revoked shares are removed permanently,
+// so the collection can be shared with the same user again later.
+// It performs an ownership check to ensure only the owner can revoke a share.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - shareID: The ID of the share to revoke
+//   - ownerID: The ID of the user attempting the revocation (for ownership validation)
+//
+// Returns:
+//   - error: nil on success, ErrNotFoundType if the share doesn't exist or the user doesn't own it
+func (r *shareRepo) DeleteShare(ctx context.Context, shareID, ownerID string) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND owner_id = ?", shareID, ownerID).
+		Delete(&model.Share{})
+
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+
+	// Check if any row was actually deleted
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testToken = "public-token"
+
+// newTestShares returns a user share and a public link of the first user's fixture bookmark.
+func newTestShares() (*model.Share, *model.Share) {
+	granteeID := fixture.FixtureUserTwoID
+	token := testToken
+	userShare := &model.Share{
+		OwnerID:    fixture.FixtureUserOneID,
+		TargetType: model.ShareTargetBookmark,
+		Target:     fixture.FixtureBookmarkOneID,
+		GranteeID:  &granteeID,
+		Permission: model.SharePermissionEditor,
+	}
+	publicLink := &model.Share{
+		OwnerID:    fixture.FixtureUserOneID,
+		TargetType: model.ShareTargetFolder,
+		Target:     "Work",
+		Permission: model.SharePermissionViewer,
+		Token:      &token,
+	}
+	return userShare, publicLink
+}
+
+func TestShareRepo_CreateShare(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputShare  func() *model.Share
+		expectedErr error
+	}{
+		{
+			name: "success - another collection shared with the same user",
+			inputShare: func() *model.Share {
+				userShare, _ := newTestShares()
+				userShare.TargetType = model.ShareTargetTag
+				userShare.Target = "golang"
+				return userShare
+			},
+		},
+		{
+			name: "success - second public link of the same collection",
+			inputShare: func() *model.Share {
+				_, publicLink := newTestShares()
+				token := "another-token"
+				publicLink.Token = &token
+				return publicLink
+			},
+		},
+		{
+			name: "error - already shared with the user",
+			inputShare: func() *model.Share {
+				userShare, _ := newTestShares()
+				userShare.Permission = model.SharePermissionViewer
+				return userShare
+			},
+			expectedErr: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "error - token already used",
+			inputShare: func() *model.Share {
+				_, publicLink := newTestShares()
+				publicLink.Target = "Home"
+				return publicLink
+			},
+			expectedErr: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+			userShare, publicLink := newTestShares()
+			_, err := repo.CreateShare(t.Context(), userShare)
+			assert.NoError(t, err)
+			_, err = repo.CreateShare(t.Context(), publicLink)
+			assert.NoError(t, err)
+
+			created, err := repo.CreateShare(t.Context(), tc.inputShare())
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, created)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, created.ID)
+		})
+	}
+}
+
+func TestShareRepo_GetShares(t *testing.T) {
+	t.Parallel()
+
+	repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+	userShare, publicLink := newTestShares()
+	_, err := repo.CreateShare(t.Context(), userShare)
+	assert.NoError(t, err)
+	_, err = repo.CreateShare(t.Context(), publicLink)
+	assert.NoError(t, err)
+
+	t.Run("shares created by the owner", func(t *testing.T) {
+		shares, err := repo.GetShares(t.Context(), fixture.FixtureUserOneID)
+
+		assert.NoError(t, err)
+		assert.Len(t, shares, 2)
+		for _, share := range shares {
+			if share.GranteeID != nil {
+				assert.Equal(t, fixture.FixtureUserTwoUsername, share.Grantee.Username)
+			} else {
+				assert.Equal(t, testToken, *share.Token)
+			}
+		}
+	})
+
+	t.Run("shares received by the grantee", func(t *testing.T) {
+		shares, err := repo.GetReceivedShares(t.Context(), fixture.FixtureUserTwoID)
+
+		assert.NoError(t, err)
+		if assert.Len(t, shares, 1) {
+			assert.Equal(t, userShare.ID, shares[0].ID)
+			assert.Equal(t, fixture.FixtureUserOneUsername, shares[0].Owner.Username)
+		}
+	})
+
+	t.Run("no shares", func(t *testing.T) {
+		shares, err := repo.GetShares(t.Context(), fixture.FixtureUserTwoID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, shares)
+	})
+}
+
+func TestShareRepo_GetShareByToken(t *testing.T) {
+	t.Parallel()
+
+	repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+	_, publicLink := newTestShares()
+	_, err := repo.CreateShare(t.Context(), publicLink)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		inputToken  string
+		expectedErr error
+	}{
+		{
+			name:       "success",
+			inputToken: testToken,
+		},
+		{
+			name:        "error - unknown token",
+			inputToken:  "unknown",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			share, err := repo.GetShareByToken(t.Context(), tc.inputToken)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, publicLink.ID, share.ID)
+		})
+	}
+}
+
+func TestShareRepo_DeleteShare(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		inputOwnerID string
+		expectedErr  error
+	}{
+		{
+			name:         "success - share can be created again afterwards",
+			inputOwnerID: fixture.FixtureUserOneID,
+		},
+		{
+			name:         "error - share belongs to different user",
+			inputOwnerID: fixture.FixtureUserTwoID,
+			expectedErr:  dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewRepository(fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}))
+			userShare, _ := newTestShares()
+			_, err := repo.CreateShare(t.Context(), userShare)
+			assert.NoError(t, err)
+
+			err = repo.DeleteShare(t.Context(), userShare.ID, tc.inputOwnerID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			again, _ := newTestShares()
+			_, err = repo.CreateShare(t.Context(), again)
+			assert.NoError(t, err)
+		})
+	}
+}
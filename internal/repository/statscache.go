@@ -0,0 +1,60 @@
+// Package repository provides the data access layer for the application.
+// This file contains the Redis cache of the bookmark statistics.
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// StatsCache defines the interface for caching the bookmark statistics of a user.
+//
+//go:generate mockery --name StatsCache --filename stats_cache.go
+type StatsCache interface {
+	// GetStats returns the cached statistics stored under key, or redis.Nil if there are none.
+	GetStats(ctx context.Context, key string) (*model.BookmarkStats, error)
+	// SetStats caches the statistics under key for ttl.
+	SetStats(ctx context.Context, key string, stats *model.BookmarkStats, ttl time.Duration) error
+}
+
+// statsCache is a Redis-backed implementation of StatsCache.
+type statsCache struct {
+	c *redis.Client
+}
+
+// NewStatsCache creates a new instance of StatsCache.
+func NewStatsCache(c *redis.Client) StatsCache {
+	return &statsCache{c: c}
+}
+
+// statsKey prefixes the cache keys so they never collide with short link codes.
+func statsKey(key string) string {
+	return "stats:" + key
+}
+
+// GetStats reads the JSON encoded statistics from Redis.
+func (s *statsCache) GetStats(ctx context.Context, key string) (*model.BookmarkStats, error) {
+	data, err := s.c.Get(ctx, statsKey(key)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &model.BookmarkStats{}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// SetStats stores the statistics in Redis as JSON.
+func (s *statsCache) SetStats(ctx context.Context, key string, stats *model.BookmarkStats, ttl time.Duration) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	return s.c.Set(ctx, statsKey(key), data, ttl).Err()
+}
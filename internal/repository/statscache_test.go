@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStatsCache_SetAndGetStats validates that statistics survive a round trip through Redis.
+func TestStatsCache_SetAndGetStats(t *testing.T) {
+	t.Parallel()
+
+	stats := &model.BookmarkStats{
+		Total:       2,
+		Domains:     []model.DomainCount{{Domain: "go.dev", Count: 2}},
+		Tags:        []model.TagCount{},
+		Months:      []model.MonthCount{{Month: "2025-01", Count: 2}},
+		TopClicked:  []model.CodeClicks{{Code: "abc12345", URL: "https://go.dev", Clicks: 3}},
+		GeneratedAt: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name          string
+		setupMock     func() *redis.Client
+		setStats      bool
+		expectedStats *model.BookmarkStats
+		expectedErr   error
+	}{
+		{
+			name:          "cached",
+			setupMock:     func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			setStats:      true,
+			expectedStats: stats,
+		},
+		{
+			name:        "not cached",
+			setupMock:   func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			expectedErr: redis.Nil,
+		},
+		{
+			name: "redis connection",
+			setupMock: func() *redis.Client {
+				mock := redisPkg.InitMockRedis(t)
+				_ = mock.Close()
+				return mock
+			},
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			redisMock := tc.setupMock()
+			cache := NewStatsCache(redisMock)
+			if tc.setStats {
+				assert.NoError(t, cache.SetStats(ctx, "user-1:10", stats, time.Minute))
+				ttl, err := redisMock.TTL(ctx, "stats:user-1:10").Result()
+				assert.NoError(t, err)
+				assert.Equal(t, time.Minute, ttl)
+			}
+
+			got, err := cache.GetStats(ctx, "user-1:10")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedStats, got)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package repository provides the data access layer for the application.
+// This file contains the Redis storage used to revoke access tokens before they expire.
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TokenRevocationStore defines the interface for revoking access tokens.
+// Single tokens are revoked through a denylist of token IDs (the "jti" claim), every token
+// of a user at once by moving the user to the next token generation (the "gen" claim).
+//
+//go:generate mockery --name TokenRevocationStore --filename token_revocation_store.go
+type TokenRevocationStore interface {
+	// DenyToken denylists a token ID for ttl, which should be the remaining lifetime of the token.
+	DenyToken(ctx context.Context, jti string, ttl time.Duration) error
+	// IsTokenDenied reports whether a token ID is denylisted.
+	IsTokenDenied(ctx context.Context, jti string) (bool, error)
+	// GetTokenGeneration returns the current token generation of a user, 0 if it was never incremented.
+	GetTokenGeneration(ctx context.Context, userID string) (int64, error)
+	// IncrTokenGeneration moves a user to the next token generation and returns it.
+	IncrTokenGeneration(ctx context.Context, userID string) (int64, error)
+}
+
+// tokenRevocationStore is a Redis-backed implementation of TokenRevocationStore.
+type tokenRevocationStore struct {
+	c *redis.Client
+}
+
+// NewTokenRevocationStore creates a new instance of TokenRevocationStore.
+func NewTokenRevocationStore(c *redis.Client) TokenRevocationStore {
+	return &tokenRevocationStore{c: c}
+}
+
+// deniedTokenKey returns the Redis key whose existence revokes the token with the given ID.
+func deniedTokenKey(jti string) string {
+	return "denied_token:" + jti
+}
+
+// tokenGenerationKey returns the Redis key holding the token generation of a user.
+// The key has no expiry: forgetting it would make revoked tokens valid again.
+func tokenGenerationKey(userID string) string {
+	return "token_generation:" + userID
+}
+
+// DenyToken sets the denylist key of the token, which expires together with the token.
+func (s *tokenRevocationStore) DenyToken(ctx context.Context, jti string, ttl time.Duration) error {
+	return s.c.Set(ctx, deniedTokenKey(jti), 1, ttl).Err()
+}
+
+// IsTokenDenied checks whether the denylist key of the token exists.
+func (s *tokenRevocationStore) IsTokenDenied(ctx context.Context, jti string) (bool, error) {
+	n, err := s.c.Exists(ctx, deniedTokenKey(jti)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// GetTokenGeneration reads the generation counter of the user.
+func (s *tokenRevocationStore) GetTokenGeneration(ctx context.Context, userID string) (int64, error) {
+	gen, err := s.c.Get(ctx, tokenGenerationKey(userID)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return gen, err
+}
+
+// IncrTokenGeneration increments the generation counter of the user.
+func (s *tokenRevocationStore) IncrTokenGeneration(ctx context.Context, userID string) (int64, error) {
+	return s.c.Incr(ctx, tokenGenerationKey(userID)).Result()
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTokenRevocationStore_DenyToken validates that denylisted token IDs are reported
+// as denied until their TTL runs out.
+func TestTokenRevocationStore_DenyToken(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	redisMock := redisPkg.InitMockRedis(t)
+	store := NewTokenRevocationStore(redisMock)
+
+	denied, err := store.IsTokenDenied(ctx, "jti-1")
+	assert.NoError(t, err)
+	assert.False(t, denied)
+
+	assert.NoError(t, store.DenyToken(ctx, "jti-1", 10*time.Minute))
+
+	denied, err = store.IsTokenDenied(ctx, "jti-1")
+	assert.NoError(t, err)
+	assert.True(t, denied)
+
+	ttl, err := redisMock.TTL(ctx, "denied_token:jti-1").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, ttl)
+
+	denied, err = store.IsTokenDenied(ctx, "jti-2")
+	assert.NoError(t, err)
+	assert.False(t, denied)
+}
+
+// TestTokenRevocationStore_TokenGeneration validates that the token generation of a user
+// starts at 0 and is incremented per user.
+func TestTokenRevocationStore_TokenGeneration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupMock     func() *redis.Client
+		increments    int
+		expectedGen   int64
+		expectedError error
+	}{
+		{
+			name:        "never incremented",
+			setupMock:   func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			expectedGen: 0,
+		},
+		{
+			name:        "incremented twice",
+			setupMock:   func() *redis.Client { return redisPkg.InitMockRedis(t) },
+			increments:  2,
+			expectedGen: 2,
+		},
+		{
+			name: "redis connection",
+			setupMock: func() *redis.Client {
+				mock := redisPkg.InitMockRedis(t)
+				_ = mock.Close()
+				return mock
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			store := NewTokenRevocationStore(tc.setupMock())
+			for i := range tc.increments {
+				gen, err := store.IncrTokenGeneration(ctx, "user-1")
+				assert.NoError(t, err)
+				assert.Equal(t, int64(i+1), gen)
+			}
+			_, _ = store.IncrTokenGeneration(ctx, "user-2")
+
+			gen, err := store.GetTokenGeneration(ctx, "user-1")
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedGen, gen)
+		})
+	}
+}
@@ -3,14 +3,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
 // urlExpTime defines the expiration duration for URLs stored in the cache.
+// linkCountExpTime is how long the daily link count of a user is kept, long enough
+// to outlive the UTC day it counts whatever the time zone of the server.
 const (
-	urlExpTime = 24 * time.Hour
+	urlExpTime       = 24 * time.Hour
+	linkCountExpTime = 48 * time.Hour
 )
 
 // UrlStorage defines the interface for storing and retrieving URLs.
@@ -26,6 +30,12 @@ type UrlStorage interface {
 	GetUrl(ctx context.Context, code string) (string, error)
 	// Exists checks if a code is already stored.
 	Exists(ctx context.Context, code string) (bool, error)
+	// IncrLinkCount counts one more short link created by a user on a UTC day and returns the new count.
+	IncrLinkCount(ctx context.Context, userID string, day time.Time) (int64, error)
+	// DecrLinkCount takes back a short link counted by IncrLinkCount.
+	DecrLinkCount(ctx context.Context, userID string, day time.Time) error
+	// GetLinkCount returns the number of short links created by a user on a UTC day.
+	GetLinkCount(ctx context.Context, userID string, day time.Time) (int64, error)
 }
 
 // urlStorage is a Redis-backed implementation of UrlStorage.
@@ -69,3 +79,38 @@ func (s *urlStorage) Exists(ctx context.Context, code string) (bool, error) {
 
 	return result > 0, nil
 }
+
+// linkCountKey returns the Redis key counting the short links of a user on a UTC day.
+// Codes are alphanumeric, so the colons keep counters apart from stored URLs.
+func linkCountKey(userID string, day time.Time) string {
+	return "link_count:" + userID + ":" + day.UTC().Format(time.DateOnly)
+}
+
+// IncrLinkCount atomically increments the daily link count of a user using Redis INCR.
+// The counter expires after linkCountExpTime, so past days need no cleanup.
+func (s *urlStorage) IncrLinkCount(ctx context.Context, userID string, day time.Time) (int64, error) {
+	key := linkCountKey(userID, day)
+
+	pipe := s.c.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, linkCountExpTime)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+// DecrLinkCount decrements the daily link count of a user using Redis DECR.
+func (s *urlStorage) DecrLinkCount(ctx context.Context, userID string, day time.Time) error {
+	return s.c.Decr(ctx, linkCountKey(userID, day)).Err()
+}
+
+// GetLinkCount reads the daily link count of a user, 0 if the user created no link that day.
+func (s *urlStorage) GetLinkCount(ctx context.Context, userID string, day time.Time) (int64, error) {
+	count, err := s.c.Get(ctx, linkCountKey(userID, day)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return count, err
+}
@@ -6,6 +6,7 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
 	"github.com/redis/go-redis/v9"
@@ -287,3 +288,89 @@ func TestUrlStorage_GetUrl(t *testing.T) {
 		})
 	}
 }
+
+// TestUrlStorage_LinkCount validates the daily short link counter of a user:
+// counts are kept per user and per UTC day, and taken back by DecrLinkCount.
+func TestUrlStorage_LinkCount(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 3, 10, 23, 30, 0, 0, time.UTC)
+	nextDay := day.Add(time.Hour)
+
+	testCases := []struct {
+		name          string
+		setup         func(ctx context.Context, s UrlStorage)
+		userID        string
+		day           time.Time
+		expectedCount int64
+	}{
+		{
+			name:          "no link created",
+			setup:         func(ctx context.Context, s UrlStorage) {},
+			userID:        "user-1",
+			day:           day,
+			expectedCount: 0,
+		},
+		{
+			name: "links created by the user on the day",
+			setup: func(ctx context.Context, s UrlStorage) {
+				_, _ = s.IncrLinkCount(ctx, "user-1", day)
+				_, _ = s.IncrLinkCount(ctx, "user-1", day)
+				_, _ = s.IncrLinkCount(ctx, "user-2", day)
+				_, _ = s.IncrLinkCount(ctx, "user-1", nextDay)
+			},
+			userID:        "user-1",
+			day:           day,
+			expectedCount: 2,
+		},
+		{
+			name: "decremented link is taken back",
+			setup: func(ctx context.Context, s UrlStorage) {
+				_, _ = s.IncrLinkCount(ctx, "user-1", day)
+				_, _ = s.IncrLinkCount(ctx, "user-1", day)
+				_ = s.DecrLinkCount(ctx, "user-1", day)
+			},
+			userID:        "user-1",
+			day:           day,
+			expectedCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			urlRepo := NewUrlStorage(redisPkg.InitMockRedis(t))
+			tc.setup(ctx, urlRepo)
+
+			count, err := urlRepo.GetLinkCount(ctx, tc.userID, tc.day)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+// TestUrlStorage_IncrLinkCount validates that IncrLinkCount returns the new count
+// and sets an expiry on the counter.
+func TestUrlStorage_IncrLinkCount(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	redisMock := redisPkg.InitMockRedis(t)
+	urlRepo := NewUrlStorage(redisMock)
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	count, err := urlRepo.IncrLinkCount(ctx, "user-1", day)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = urlRepo.IncrLinkCount(ctx, "user-1", day)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	ttl, err := redisMock.TTL(ctx, "link_count:user-1:2025-03-10").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, linkCountExpTime, ttl)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
@@ -13,14 +15,39 @@ import (
 // User defines the interface for user-related database operations.
 // This interface follows the repository pattern, abstracting data access
 // and enabling easier testing through mock implementations.
+//
 //go:generate mockery --name User --filename user.go
 type User interface {
 	// CreateUser persists a new user record to the database.
 	CreateUser(ctx context.Context, newUser *model.User) (*model.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
 	GetUserById(ctx context.Context, userID string) (*model.User, error)
+	// GetUserByEmail retrieves the user with the given email address.
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+	// GetUserByOIDCSubject retrieves the user linked to the given subject at the OpenID Connect provider.
+	GetUserByOIDCSubject(ctx context.Context, subject string) (*model.User, error)
+	// SetOIDCSubject links a user not linked yet to a subject at the OpenID Connect provider.
+	SetOIDCSubject(ctx context.Context, userID, subject string) error
 	// UpdateUser updates the display_name and email fields by user ID.
 	UpdateUser(ctx context.Context, userID, displayName, email string) error
+	// UpdatePassword replaces the password hash of a user.
+	UpdatePassword(ctx context.Context, userID, passwordHash string) error
+	// SetPendingEmail records a new email address of a user, waiting to be confirmed.
+	SetPendingEmail(ctx context.Context, userID, email string) error
+	// VerifyEmail marks an email address of a user as confirmed, replacing the current address by the pending one.
+	VerifyEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error
+	// UpdateRoles replaces the roles of a user.
+	UpdateRoles(ctx context.Context, userID string, roles model.Roles) error
+	// ListUsers retrieves a page of the users matching filter, the newest first, with the number of matches.
+	ListUsers(ctx context.Context, filter *model.UserFilter, limit, offset int) ([]*model.User, int64, error)
+	// SetDisabledAt disables a user from the given time, or enables it again when disabledAt is nil.
+	SetDisabledAt(ctx context.Context, userID string, disabledAt *time.Time) error
+	// CountEnabledAdmins returns the number of users granted RoleAdmin whose account is not disabled.
+	CountEnabledAdmins(ctx context.Context) (int64, error)
+	// DeleteUser permanently removes a user.
+	DeleteUser(ctx context.Context, userID string) error
+	// PurgeDeletedUsers permanently removes users soft-deleted before the given time.
+	PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error)
 }
 
 // user is the concrete implementation of the User interface.
@@ -81,6 +108,14 @@ func (u *user) GetUserById(ctx context.Context, userID string) (*model.User, err
 	return u.GetUserByField(ctx, "id", userID)
 }
 
+func (u *user) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	return u.GetUserByField(ctx, "email", email)
+}
+
+func (u *user) GetUserByOIDCSubject(ctx context.Context, subject string) (*model.User, error) {
+	return u.GetUserByField(ctx, "oidc_subject", subject)
+}
+
 func (u *user) GetUserByField(ctx context.Context, field string, value string) (*model.User, error) {
 	chosenUser := &model.User{}
 	err := u.db.WithContext(ctx).Where(fmt.Sprintf("%s = ?", field), value).First(chosenUser).Error
@@ -126,3 +161,257 @@ func (u *user) UpdateUser(ctx context.Context, userID, displayName, email string
 
 	return nil
 }
+
+// UpdatePassword replaces the stored password hash of a user.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//   - passwordHash: The hash of the new password
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or an error if the database operation fails
+func (u *user) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
+	result := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Update("password", passwordHash)
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// SetPendingEmail records the address a user wants to switch to. The current address stays
+// in use until the new one is confirmed with VerifyEmail. A later call replaces the pending address.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//   - email: The new email address
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or an error if the database operation fails
+func (u *user) SetPendingEmail(ctx context.Context, userID, email string) error {
+	result := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Update("pending_email", email)
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// VerifyEmail confirms an address of a user: either the current address, or the pending one,
+// which then becomes the current address. Addresses the user no longer has or waits for,
+// e.g. a pending address replaced by another one since, are not confirmed.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//   - email: The confirmed email address
+//   - verifiedAt: When the address was confirmed
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist or does not have the address,
+//     dbutils.ErrDuplicationType if another user took the address meanwhile, or a database error
+func (u *user) VerifyEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+	result := u.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ? AND (email = ? OR pending_email = ?)", userID, email, email).
+		Updates(map[string]any{
+			"email":             email,
+			"email_verified_at": verifiedAt,
+			"pending_email":     gorm.Expr("CASE WHEN pending_email = ? THEN NULL ELSE pending_email END", email),
+		})
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// SetOIDCSubject links a user to the subject of the user at the OpenID Connect provider.
+// Users already linked keep their subject, so that an account is never taken over
+// by another identity at the provider.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//   - subject: The subject of the user at the provider
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist or is already linked,
+//     dbutils.ErrDuplicationType if another user is linked to the subject, or a database error
+func (u *user) SetOIDCSubject(ctx context.Context, userID, subject string) error {
+	result := u.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ? AND oidc_subject IS NULL", userID).
+		Update("oidc_subject", subject)
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// UpdateRoles replaces the roles granted to a user.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//   - roles: The new roles of the user
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or an error if the database operation fails
+func (u *user) UpdateRoles(ctx context.Context, userID string, roles model.Roles) error {
+	result := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Update("roles", roles)
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// ListUsers retrieves a page of the users matching filter, the most recently created first.
+// The search text is matched case-insensitively against the username, display name and email;
+// LIKE wildcards in it are matched literally.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - filter: Optional filter, nil to list every user
+//   - limit: Maximum number of users returned
+//   - offset: Number of matching users skipped
+//
+// Returns:
+//   - []*model.User: The users of the page
+//   - int64: Total number of matching users
+//   - error: An error if the database operation fails, nil otherwise
+func (u *user) ListUsers(ctx context.Context, filter *model.UserFilter, limit, offset int) ([]*model.User, int64, error) {
+	db := u.db.WithContext(ctx).Model(&model.User{})
+	if filter != nil {
+		if filter.Query != "" {
+			pattern := "%" + likeEscaper.Replace(strings.ToLower(filter.Query)) + "%"
+			db = db.Where("LOWER(username) LIKE ? ESCAPE '\\' OR LOWER(display_name) LIKE ? ESCAPE '\\' OR LOWER(email) LIKE ? ESCAPE '\\'",
+				pattern, pattern, pattern)
+		}
+		if filter.Disabled != nil {
+			if *filter.Disabled {
+				db = db.Where("disabled_at IS NOT NULL")
+			} else {
+				db = db.Where("disabled_at IS NULL")
+			}
+		}
+	}
+
+	users := make([]*model.User, 0)
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, dbutils.CatchDBErr(err)
+	}
+	if total == 0 {
+		return users, 0, nil
+	}
+
+	if err := db.Order("created_at DESC").Order("id").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, 0, dbutils.CatchDBErr(err)
+	}
+	return users, total, nil
+}
+
+// likeEscaper escapes the LIKE wildcards of search text, with a backslash as escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// SetDisabledAt disables a user from the given time, or enables it again when disabledAt is nil.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//   - disabledAt: When the user was disabled, nil to enable the user
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or an error if the database operation fails
+func (u *user) SetDisabledAt(ctx context.Context, userID string, disabledAt *time.Time) error {
+	result := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Update("disabled_at", disabledAt)
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// CountEnabledAdmins returns the number of users granted RoleAdmin whose account is not disabled.
+// Roles are stored as a comma-separated list, so the list is wrapped in commas to match whole roles.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//
+// Returns:
+//   - int64: Number of enabled administrators
+//   - error: An error if the database operation fails, nil otherwise
+func (u *user) CountEnabledAdmins(ctx context.Context) (int64, error) {
+	var count int64
+	err := u.db.WithContext(ctx).Model(&model.User{}).
+		Where("disabled_at IS NULL AND ',' || roles || ',' LIKE ?", "%,"+model.RoleAdmin+",%").
+		Count(&count).Error
+	if err != nil {
+		return 0, dbutils.CatchDBErr(err)
+	}
+
+	return count, nil
+}
+
+// DeleteUser permanently removes a user, bypassing the trash.
+// Their bookmarks are removed by the ON DELETE CASCADE constraint on bookmarks.user_id.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or an error if the database operation fails
+func (u *user) DeleteUser(ctx context.Context, userID string) error {
+	result := u.db.WithContext(ctx).Unscoped().Where("id = ?", userID).Delete(&model.User{})
+	if result.Error != nil {
+		return dbutils.CatchDBErr(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// PurgeDeletedUsers permanently removes users that were soft-deleted before the given time.
+// Their bookmarks are removed by the ON DELETE CASCADE constraint on bookmarks.user_id.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - before: Users deleted strictly before this time are removed
+//
+// Returns:
+//   - int64: Number of users removed
+//   - error: An error if the database operation fails, nil otherwise
+func (u *user) PurgeDeletedUsers(ctx context.Context, before time.Time) (int64, error) {
+	result := u.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&model.User{})
+	if result.Error != nil {
+		return 0, dbutils.CatchDBErr(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
@@ -59,6 +60,7 @@ func TestUser_CreateUser(t *testing.T) {
 				Username:    "johnny.ho1",
 				Email:       "johnny.ho1@example.com",
 				Password:    fixture.FixtureUserPassword,
+				Roles:       model.Roles{model.RoleUser},
 			},
 			verifyFunc: func(db *gorm.DB, user *model.User) {
 				checkUser := &model.User{}
@@ -142,6 +144,7 @@ func TestUser_GetUserByUsername(t *testing.T) {
 				Username:    fixture.FixtureUserOneUsername,
 				Email:       fixture.FixtureUserOneEmail,
 				Password:    fixture.FixtureUserPassword,
+				Roles:       model.Roles{model.RoleUser},
 			},
 		},
 		{
@@ -209,6 +212,7 @@ func TestUser_GetUserById(t *testing.T) {
 				Username:    "huy.ho",
 				Email:       "huy.ho@example.com",
 				Password:    fixture.FixtureUserPassword,
+				Roles:       model.Roles{model.RoleUser},
 			},
 		},
 		{
@@ -246,6 +250,48 @@ func TestUser_GetUserById(t *testing.T) {
 	}
 }
 
+func TestUser_GetUserByEmail(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		inputEmail     string
+		expectedErr    error
+		expectedUserID string
+	}{
+		{
+			name:           "success - user exists",
+			inputEmail:     "huy.ho@example.com",
+			expectedUserID: fixture.FixtureUserTwoID,
+		},
+		{
+			name:        "error - user not found",
+			inputEmail:  "nobody@example.com",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			userRepo := NewUser(fixture.NewFixture(t, &fixture.UserCommonTestDB{}))
+
+			output, err := userRepo.GetUserByEmail(ctx, tc.inputEmail)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, output)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUserID, output.ID)
+		})
+	}
+}
+
 // TestUser_UpdateUser tests the UpdateUser method of the User repository.
 // It uses table-driven tests with the UserCommonTestDB fixture to verify:
 //   - Successful update of display_name only
@@ -364,3 +410,620 @@ func TestUser_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_UpdatePassword(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputUserID string
+		expectedErr error
+	}{
+		{
+			name:        "success - password hash replaced",
+			inputUserID: fixture.FixtureUserOneID,
+		},
+		{
+			name:        "error - user not found",
+			inputUserID: "00000000-0000-4000-8000-000000000000",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			repo := NewUser(db)
+
+			err := repo.UpdatePassword(ctx, tc.inputUserID, "new-hash")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			var user model.User
+			assert.NoError(t, db.Where(whereIDClause, tc.inputUserID).First(&user).Error)
+			assert.Equal(t, "new-hash", user.Password)
+
+			var other model.User
+			assert.NoError(t, db.Where(whereIDClause, fixture.FixtureUserTwoID).First(&other).Error)
+			assert.Equal(t, fixture.FixtureUserPassword, other.Password)
+		})
+	}
+}
+
+func TestUser_SetPendingEmail(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputUserID string
+		expectedErr error
+	}{
+		{
+			name:        "success - pending email recorded",
+			inputUserID: fixture.FixtureUserOneID,
+		},
+		{
+			name:        "error - user not found",
+			inputUserID: "00000000-0000-4000-8000-000000000000",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			repo := NewUser(db)
+
+			err := repo.SetPendingEmail(ctx, tc.inputUserID, "new@example.com")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			var user model.User
+			assert.NoError(t, db.Where(whereIDClause, tc.inputUserID).First(&user).Error)
+			assert.Equal(t, fixture.FixtureUserOneEmail, user.Email)
+			if assert.NotNil(t, user.PendingEmail) {
+				assert.Equal(t, "new@example.com", *user.PendingEmail)
+			}
+		})
+	}
+}
+
+func TestUser_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	verifiedAt := fixture.FixtureTimestamp.Add(time.Hour)
+	pendingEmail := "new@example.com"
+
+	testCases := []struct {
+		name            string
+		inputEmail      string
+		pendingEmail    string
+		expectedErr     error
+		expectedEmail   string
+		expectedPending *string
+	}{
+		{
+			name:          "success - current email verified",
+			inputEmail:    fixture.FixtureUserOneEmail,
+			expectedEmail: fixture.FixtureUserOneEmail,
+		},
+		{
+			name:            "success - current email verified, pending email kept",
+			inputEmail:      fixture.FixtureUserOneEmail,
+			pendingEmail:    pendingEmail,
+			expectedEmail:   fixture.FixtureUserOneEmail,
+			expectedPending: &pendingEmail,
+		},
+		{
+			name:          "success - pending email replaces current email",
+			inputEmail:    pendingEmail,
+			pendingEmail:  pendingEmail,
+			expectedEmail: pendingEmail,
+		},
+		{
+			name:         "error - email no longer pending",
+			inputEmail:   "old-pending@example.com",
+			pendingEmail: pendingEmail,
+			expectedErr:  dbutils.ErrNotFoundType,
+		},
+		{
+			name:         "error - email taken by another user",
+			inputEmail:   fixture.FixtureUserTwoEmail,
+			pendingEmail: fixture.FixtureUserTwoEmail,
+			expectedErr:  dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			repo := NewUser(db)
+			if tc.pendingEmail != "" {
+				assert.NoError(t, repo.SetPendingEmail(ctx, fixture.FixtureUserOneID, tc.pendingEmail))
+			}
+
+			err := repo.VerifyEmail(ctx, fixture.FixtureUserOneID, tc.inputEmail, verifiedAt)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			var user model.User
+			assert.NoError(t, db.Where(whereIDClause, fixture.FixtureUserOneID).First(&user).Error)
+			assert.Equal(t, tc.expectedEmail, user.Email)
+			assert.Equal(t, tc.expectedPending, user.PendingEmail)
+			if assert.NotNil(t, user.EmailVerifiedAt) {
+				assert.True(t, verifiedAt.Equal(*user.EmailVerifiedAt))
+			}
+		})
+	}
+}
+
+func TestUser_GetUserByOIDCSubject(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		inputSubject   string
+		expectedErr    error
+		expectedUserID string
+	}{
+		{
+			name:           "success - user linked",
+			inputSubject:   "subject-1",
+			expectedUserID: fixture.FixtureUserTwoID,
+		},
+		{
+			name:         "error - no user linked",
+			inputSubject: "subject-2",
+			expectedErr:  dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			assert.NoError(t, db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserTwoID).
+				Update("oidc_subject", "subject-1").Error)
+			repo := NewUser(db)
+
+			output, err := repo.GetUserByOIDCSubject(ctx, tc.inputSubject)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, output)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUserID, output.ID)
+		})
+	}
+}
+
+func TestUser_SetOIDCSubject(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		inputUserID     string
+		inputSubject    string
+		expectedErr     error
+		expectedSubject string
+	}{
+		{
+			name:            "success - user linked",
+			inputUserID:     fixture.FixtureUserOneID,
+			inputSubject:    "subject-2",
+			expectedSubject: "subject-2",
+		},
+		{
+			name:            "error - user already linked",
+			inputUserID:     fixture.FixtureUserTwoID,
+			inputSubject:    "subject-2",
+			expectedErr:     dbutils.ErrNotFoundType,
+			expectedSubject: "subject-1",
+		},
+		{
+			name:         "error - subject linked to another user",
+			inputUserID:  fixture.FixtureUserOneID,
+			inputSubject: "subject-1",
+			expectedErr:  dbutils.ErrDuplicationType,
+		},
+		{
+			name:         "error - user not found",
+			inputUserID:  "00000000-0000-4000-8000-000000000000",
+			inputSubject: "subject-2",
+			expectedErr:  dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			assert.NoError(t, db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserTwoID).
+				Update("oidc_subject", "subject-1").Error)
+			repo := NewUser(db)
+
+			err := repo.SetOIDCSubject(ctx, tc.inputUserID, tc.inputSubject)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedSubject == "" {
+				return
+			}
+
+			var user model.User
+			assert.NoError(t, db.Where(whereIDClause, tc.inputUserID).First(&user).Error)
+			if assert.NotNil(t, user.OIDCSubject) {
+				assert.Equal(t, tc.expectedSubject, *user.OIDCSubject)
+			}
+		})
+	}
+}
+
+func TestUser_UpdateRoles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputUserID string
+		expectedErr error
+	}{
+		{
+			name:        "success - roles replaced",
+			inputUserID: fixture.FixtureUserOneID,
+		},
+		{
+			name:        "error - user not found",
+			inputUserID: "00000000-0000-4000-8000-000000000000",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			repo := NewUser(db)
+
+			err := repo.UpdateRoles(ctx, tc.inputUserID, model.Roles{model.RoleUser, model.RoleAdmin})
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			var user model.User
+			assert.NoError(t, db.Where(whereIDClause, tc.inputUserID).First(&user).Error)
+			assert.Equal(t, model.Roles{model.RoleUser, model.RoleAdmin}, user.Roles)
+
+			var other model.User
+			assert.NoError(t, db.Where(whereIDClause, fixture.FixtureUserTwoID).First(&other).Error)
+			assert.Equal(t, model.Roles{model.RoleUser}, other.Roles)
+		})
+	}
+}
+
+func TestUser_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	disabled, enabled := true, false
+
+	testCases := []struct {
+		name          string
+		inputFilter   *model.UserFilter
+		inputLimit    int
+		inputOffset   int
+		expectedIDs   []string
+		expectedTotal int64
+	}{
+		{
+			name:          "success - every user",
+			inputLimit:    10,
+			expectedIDs:   []string{fixture.FixtureUserTwoID, fixture.FixtureUserOneID},
+			expectedTotal: 2,
+		},
+		{
+			name:          "success - second page",
+			inputLimit:    1,
+			inputOffset:   1,
+			expectedIDs:   []string{fixture.FixtureUserOneID},
+			expectedTotal: 2,
+		},
+		{
+			name:          "success - search ignores case",
+			inputFilter:   &model.UserFilter{Query: "JOHNNY"},
+			inputLimit:    10,
+			expectedIDs:   []string{fixture.FixtureUserOneID},
+			expectedTotal: 1,
+		},
+		{
+			name:          "success - search by email",
+			inputFilter:   &model.UserFilter{Query: "huy.ho@"},
+			inputLimit:    10,
+			expectedIDs:   []string{fixture.FixtureUserTwoID},
+			expectedTotal: 1,
+		},
+		{
+			name:          "success - wildcards matched literally",
+			inputFilter:   &model.UserFilter{Query: "%"},
+			inputLimit:    10,
+			expectedIDs:   []string{},
+			expectedTotal: 0,
+		},
+		{
+			name:          "success - disabled users",
+			inputFilter:   &model.UserFilter{Disabled: &disabled},
+			inputLimit:    10,
+			expectedIDs:   []string{fixture.FixtureUserOneID},
+			expectedTotal: 1,
+		},
+		{
+			name:          "success - enabled users matching the search",
+			inputFilter:   &model.UserFilter{Query: "ho", Disabled: &enabled},
+			inputLimit:    10,
+			expectedIDs:   []string{fixture.FixtureUserTwoID},
+			expectedTotal: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			assert.NoError(t, db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserOneID).
+				Update("disabled_at", fixture.FixtureTimestamp).Error)
+			repo := NewUser(db)
+
+			users, total, err := repo.ListUsers(ctx, tc.inputFilter, tc.inputLimit, tc.inputOffset)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTotal, total)
+			ids := make([]string, 0, len(users))
+			for _, u := range users {
+				ids = append(ids, u.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestUser_SetDisabledAt(t *testing.T) {
+	t.Parallel()
+
+	disabledAt := fixture.FixtureTimestamp.Add(time.Hour)
+
+	testCases := []struct {
+		name            string
+		inputUserID     string
+		inputDisabledAt *time.Time
+		expectedErr     error
+	}{
+		{
+			name:            "success - user disabled",
+			inputUserID:     fixture.FixtureUserOneID,
+			inputDisabledAt: &disabledAt,
+		},
+		{
+			name:        "success - user enabled",
+			inputUserID: fixture.FixtureUserOneID,
+		},
+		{
+			name:            "error - user not found",
+			inputUserID:     "00000000-0000-4000-8000-000000000000",
+			inputDisabledAt: &disabledAt,
+			expectedErr:     dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			assert.NoError(t, db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserOneID).
+				Update("disabled_at", fixture.FixtureTimestamp).Error)
+			repo := NewUser(db)
+
+			err := repo.SetDisabledAt(ctx, tc.inputUserID, tc.inputDisabledAt)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			var user model.User
+			assert.NoError(t, db.Where(whereIDClause, tc.inputUserID).First(&user).Error)
+			if tc.inputDisabledAt == nil {
+				assert.Nil(t, user.DisabledAt)
+			} else if assert.NotNil(t, user.DisabledAt) {
+				assert.True(t, tc.inputDisabledAt.Equal(*user.DisabledAt))
+			}
+		})
+	}
+}
+
+func TestUser_CountEnabledAdmins(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setup         func(t *testing.T, db *gorm.DB)
+		expectedCount int64
+	}{
+		{
+			name:          "success - no administrator",
+			setup:         func(t *testing.T, db *gorm.DB) {},
+			expectedCount: 0,
+		},
+		{
+			name: "success - enabled administrators counted",
+			setup: func(t *testing.T, db *gorm.DB) {
+				assert.NoError(t, db.Model(&model.User{}).Where("1 = 1").
+					Update("roles", model.Roles{model.RoleUser, model.RoleAdmin}).Error)
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "success - disabled administrator left out",
+			setup: func(t *testing.T, db *gorm.DB) {
+				assert.NoError(t, db.Model(&model.User{}).Where("1 = 1").
+					Update("roles", model.Roles{model.RoleAdmin}).Error)
+				assert.NoError(t, db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserOneID).
+					Update("disabled_at", fixture.FixtureTimestamp).Error)
+			},
+			expectedCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			tc.setup(t, db)
+			repo := NewUser(db)
+
+			count, err := repo.CountEnabledAdmins(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestUser_DeleteUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputUserID string
+		expectedErr error
+	}{
+		{
+			name:        "success - user removed permanently",
+			inputUserID: fixture.FixtureUserOneID,
+		},
+		{
+			name:        "error - user not found",
+			inputUserID: "00000000-0000-4000-8000-000000000000",
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			repo := NewUser(db)
+
+			err := repo.DeleteUser(ctx, tc.inputUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			var count int64
+			db.Unscoped().Model(&model.User{}).Where(whereIDClause, tc.inputUserID).Count(&count)
+			assert.Equal(t, int64(0), count, "Deleted user should be removed permanently")
+
+			db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserTwoID).Count(&count)
+			assert.Equal(t, int64(1), count, "Other users should be kept")
+		})
+	}
+}
+
+// TestUser_PurgeDeletedUsers tests the PurgeDeletedUsers method of the User repository.
+// It verifies that only users soft-deleted before the cutoff are removed permanently.
+func TestUser_PurgeDeletedUsers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		inputBefore   time.Time
+		expectedCount int64
+		verifyFunc    func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name: "success - purge user deleted before cutoff",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				err := db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserTwoID).
+					Update("deleted_at", fixture.FixtureTimestamp).Error
+				assert.NoError(t, err)
+				return db
+			},
+			inputBefore:   fixture.FixtureTimestamp.Add(time.Hour),
+			expectedCount: 1,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Unscoped().Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserTwoID).Count(&count)
+				assert.Equal(t, int64(0), count, "Deleted user should be removed permanently")
+
+				db.Model(&model.User{}).Where(whereIDClause, fixture.FixtureUserOneID).Count(&count)
+				assert.Equal(t, int64(1), count, "Active user should be kept")
+			},
+		},
+		{
+			name: "success - keep user deleted within retention",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				err := db.Where(whereIDClause, fixture.FixtureUserTwoID).Delete(&model.User{}).Error
+				assert.NoError(t, err)
+				return db
+			},
+			inputBefore:   fixture.FixtureTimestamp,
+			expectedCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			db := tc.setupDB(t)
+			userRepo := NewUser(db)
+
+			count, err := userRepo.PurgeDeletedUsers(ctx, tc.inputBefore)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+			if tc.verifyFunc != nil {
+				tc.verifyFunc(t, db)
+			}
+		})
+	}
+}
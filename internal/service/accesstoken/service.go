@@ -0,0 +1,168 @@
+// Package accesstoken implements personal access tokens, named and scoped API tokens
+// that let scripts and integrations call the API without the password of their user.
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// TokenPrefix starts every personal access token, which tells them apart from JWTs
+	// in the Authorization header and makes leaked tokens easy to scan for.
+	TokenPrefix = "bpat_"
+
+	// tokenLength is the length of the random part of a token, about 238 bits of randomness.
+	tokenLength = 40
+
+	// lastUsedResolution is how stale the last use time of a token may get. It is only
+	// written when older, which spares a database write on every request.
+	lastUsedResolution = time.Minute
+)
+
+// Service-level errors returned by personal access token operations.
+var (
+	// ErrInvalidToken is returned when authenticating with a token that is unknown or expired,
+	// or whose user is disabled or deleted.
+	ErrInvalidToken = errors.New("invalid personal access token")
+	// ErrExpiryInPast is returned when creating a token that would already be expired.
+	ErrExpiryInPast = errors.New("expiry must be in the future")
+)
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	CreateToken(ctx context.Context, userID, name string, scopes model.Scopes, expiresAt *time.Time) (*model.PersonalAccessToken, error)
+	GetTokens(ctx context.Context, userID string) ([]*model.PersonalAccessToken, error)
+	DeleteToken(ctx context.Context, tokenID, userID string) error
+	Authenticate(ctx context.Context, token string) (*model.PersonalAccessToken, error)
+}
+
+type accessTokenSvc struct {
+	repo   accesstoken.Repository
+	keyGen stringutils.KeyGenerator
+	now    func() time.Time
+}
+
+// NewService creates a personal access token service.
+//
+// Parameters:
+//   - repo: Repository storing the hashes of the tokens
+//   - keyGen: Generator for the random part of the tokens
+func NewService(repo accesstoken.Repository, keyGen stringutils.KeyGenerator) Service {
+	return &accessTokenSvc{repo: repo, keyGen: keyGen, now: time.Now}
+}
+
+// CreateToken creates a personal access token for a user. Only its hash is stored,
+// so the returned Token field is the only time the token can be read.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user the token acts for
+//   - name: A label telling the token apart from the other tokens of the user
+//   - scopes: The routes the token may call
+//   - expiresAt: When the token stops working, nil for a token that does not expire
+//
+// Returns:
+//   - *model.PersonalAccessToken: The created token, including the token itself
+//   - error: ErrExpiryInPast, or any error during generation or persistence
+func (s *accessTokenSvc) CreateToken(ctx context.Context, userID, name string, scopes model.Scopes, expiresAt *time.Time) (*model.PersonalAccessToken, error) {
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, ErrExpiryInPast
+	}
+
+	code, err := s.keyGen.GenerateCode(tokenLength)
+	if err != nil {
+		return nil, err
+	}
+	plain := TokenPrefix + code
+
+	created, err := s.repo.CreateToken(ctx, &model.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: token.HashToken(plain),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	created.Token = plain
+	return created, nil
+}
+
+// GetTokens lists the personal access tokens of a user with their last use, most recent first.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - []*model.PersonalAccessToken: The tokens, without the tokens themselves
+//   - error: Any database error
+func (s *accessTokenSvc) GetTokens(ctx context.Context, userID string) ([]*model.PersonalAccessToken, error) {
+	return s.repo.GetTokens(ctx, userID)
+}
+
+// DeleteToken revokes a personal access token of a user. Requests made with it are rejected right away.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - tokenID: The ID of the token
+//   - userID: The ID of the user revoking the token
+//
+// Returns:
+//   - error: ErrNotFoundType if the token doesn't exist or belongs to another user, or a database error
+func (s *accessTokenSvc) DeleteToken(ctx context.Context, tokenID, userID string) error {
+	return s.repo.DeleteToken(ctx, tokenID, userID)
+}
+
+// Authenticate resolves the personal access token sent with a request and records its use.
+// Failing to record the use is logged rather than failing the request.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - plain: The token, starting with TokenPrefix
+//
+// Returns:
+//   - *model.PersonalAccessToken: The token, with its user and scopes
+//   - error: ErrInvalidToken, or a database error
+func (s *accessTokenSvc) Authenticate(ctx context.Context, plain string) (*model.PersonalAccessToken, error) {
+	if !strings.HasPrefix(plain, TokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	pat, err := s.repo.GetTokenByHash(ctx, token.HashToken(plain))
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	now := s.now()
+	if pat.ExpiresAt != nil && !pat.ExpiresAt.After(now) {
+		return nil, ErrInvalidToken
+	}
+	// Tokens act for their user only while the user may sign in
+	if pat.User == nil || pat.User.DisabledAt != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if pat.LastUsedAt == nil || now.Sub(*pat.LastUsedAt) >= lastUsedResolution {
+		if err := s.repo.UpdateLastUsed(ctx, pat.ID, now); err != nil {
+			log.Error().Err(err).Str("token_id", pat.ID).Msg("Failed to record personal access token use")
+		} else {
+			pat.LastUsedAt = &now
+		}
+	}
+	return pat, nil
+}
@@ -0,0 +1,238 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	keyMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testUserID  = "user-123"
+	testTokenID = "token-123"
+	testCode    = "abcdefghijklmnopqrstuvwxyz0123456789ABCD"
+	testToken   = TokenPrefix + testCode
+)
+
+var (
+	testNow = time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	testErr = errors.New("database error")
+)
+
+type testMocks struct {
+	repo   *repoMocks.Repository
+	keyGen *keyMocks.KeyGenerator
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		repo:   repoMocks.NewRepository(t),
+		keyGen: keyMocks.NewKeyGenerator(t),
+	}
+	svc := NewService(m.repo, m.keyGen).(*accessTokenSvc)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+func TestAccessTokenSvc_CreateToken(t *testing.T) {
+	t.Parallel()
+
+	scopes := model.Scopes{model.ScopeBookmarksRead}
+	tomorrow := testNow.Add(24 * time.Hour)
+	yesterday := testNow.Add(-24 * time.Hour)
+
+	testCases := []struct {
+		name           string
+		inputExpiresAt *time.Time
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedToken  string
+	}{
+		{
+			name:           "Success - only the hash is stored",
+			inputExpiresAt: &tomorrow,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", tokenLength).Return(testCode, nil)
+				m.repo.On("CreateToken", ctx, &model.PersonalAccessToken{
+					UserID:    testUserID,
+					Name:      "CI",
+					TokenHash: token.HashToken(testToken),
+					Scopes:    scopes,
+					ExpiresAt: &tomorrow,
+				}).Return(func(_ context.Context, pat *model.PersonalAccessToken) (*model.PersonalAccessToken, error) {
+					pat.ID = testTokenID
+					return pat, nil
+				})
+			},
+			expectedToken: testToken,
+		},
+		{
+			name:           "Error - expiry in the past",
+			inputExpiresAt: &yesterday,
+			setupMock:      func(ctx context.Context, m *testMocks) {},
+			expectedErr:    ErrExpiryInPast,
+		},
+		{
+			name: "Error - repository error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", tokenLength).Return(testCode, nil)
+				m.repo.On("CreateToken", ctx, mock.Anything).Return(nil, testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.CreateToken(ctx, testUserID, "CI", scopes, tc.inputExpiresAt)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, testTokenID, got.ID)
+			assert.Equal(t, tc.expectedToken, got.Token)
+		})
+	}
+}
+
+func TestAccessTokenSvc_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	recently := testNow.Add(-10 * time.Second)
+	lastWeek := testNow.Add(-7 * 24 * time.Hour)
+
+	newToken := func(mutate func(pat *model.PersonalAccessToken)) *model.PersonalAccessToken {
+		pat := &model.PersonalAccessToken{
+			Base:   model.Base{ID: testTokenID},
+			UserID: testUserID,
+			User:   &model.User{Base: model.Base{ID: testUserID}},
+			Scopes: model.Scopes{model.ScopeBookmarksRead},
+		}
+		if mutate != nil {
+			mutate(pat)
+		}
+		return pat
+	}
+
+	testCases := []struct {
+		name           string
+		inputToken     string
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedLastAt *time.Time
+	}{
+		{
+			name:       "Success - first use is recorded",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).Return(newToken(nil), nil)
+				m.repo.On("UpdateLastUsed", ctx, testTokenID, testNow).Return(nil)
+			},
+			expectedLastAt: &testNow,
+		},
+		{
+			name:       "Success - recent use is not written again",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).
+					Return(newToken(func(pat *model.PersonalAccessToken) { pat.LastUsedAt = &recently }), nil)
+			},
+			expectedLastAt: &recently,
+		},
+		{
+			name:       "Success - failing to record the use is not fatal",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).
+					Return(newToken(func(pat *model.PersonalAccessToken) { pat.LastUsedAt = &lastWeek }), nil)
+				m.repo.On("UpdateLastUsed", ctx, testTokenID, testNow).Return(testErr)
+			},
+			expectedLastAt: &lastWeek,
+		},
+		{
+			name:        "Error - not a personal access token",
+			inputToken:  testCode,
+			setupMock:   func(ctx context.Context, m *testMocks) {},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name:       "Error - unknown token",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name:       "Error - expired token",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).
+					Return(newToken(func(pat *model.PersonalAccessToken) { pat.ExpiresAt = &lastWeek }), nil)
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name:       "Error - disabled user",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).
+					Return(newToken(func(pat *model.PersonalAccessToken) { pat.User.DisabledAt = &lastWeek }), nil)
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name:       "Error - user in the trash",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).
+					Return(newToken(func(pat *model.PersonalAccessToken) { pat.User = nil }), nil)
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name:       "Error - repository error",
+			inputToken: testToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetTokenByHash", ctx, token.HashToken(testToken)).Return(nil, testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.Authenticate(ctx, tc.inputToken)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, testTokenID, got.ID)
+			assert.Equal(t, tc.expectedLastAt, got.LastUsedAt)
+		})
+	}
+}
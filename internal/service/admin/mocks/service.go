@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// DeleteUser provides a mock function with given fields: ctx, userID
+func (_m *Service) DeleteUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForcePasswordReset provides a mock function with given fields: ctx, userID
+func (_m *Service) ForcePasswordReset(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForcePasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *Service) GetUser(ctx context.Context, userID string) (*model.UserOverview, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *model.UserOverview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.UserOverview, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UserOverview); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserOverview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter, req
+func (_m *Service) ListUsers(ctx context.Context, filter *model.UserFilter, req *pagination.Request) (*pagination.Response[*model.User], error) {
+	ret := _m.Called(ctx, filter, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 *pagination.Response[*model.User]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserFilter, *pagination.Request) (*pagination.Response[*model.User], error)); ok {
+		return rf(ctx, filter, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserFilter, *pagination.Request) *pagination.Response[*model.User]); ok {
+		r0 = rf(ctx, filter, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Response[*model.User])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UserFilter, *pagination.Request) error); ok {
+		r1 = rf(ctx, filter, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetUserDisabled provides a mock function with given fields: ctx, userID, disabled
+func (_m *Service) SetUserDisabled(ctx context.Context, userID string, disabled bool) error {
+	ret := _m.Called(ctx, userID, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDisabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, userID, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,180 @@
+// Package admin implements the user management of the administration API.
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/passwordreset"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+)
+
+// ErrLastAdmin is returned when disabling or deleting a user would leave no enabled administrator,
+// so that nobody could use the administration API anymore.
+var ErrLastAdmin = errors.New("user is the last enabled administrator")
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	ListUsers(ctx context.Context, filter *model.UserFilter, req *pagination.Request) (*pagination.Response[*model.User], error)
+	GetUser(ctx context.Context, userID string) (*model.UserOverview, error)
+	SetUserDisabled(ctx context.Context, userID string, disabled bool) error
+	ForcePasswordReset(ctx context.Context, userID string) error
+	DeleteUser(ctx context.Context, userID string) error
+}
+
+type adminSvc struct {
+	users        repository.User
+	bookmarkRepo bookmark.Repository
+	tokens       token.Service
+	resets       passwordreset.Service
+	now          func() time.Time
+}
+
+// NewService creates the user management service of administrators.
+//
+// Parameters:
+//   - users: Repository of the users
+//   - bookmarkRepo: Repository counting the bookmarks of the users
+//   - tokens: Service revoking the tokens of disabled and deleted users
+//   - resets: Service emailing the reset links of forced password resets
+func NewService(users repository.User, bookmarkRepo bookmark.Repository, tokens token.Service,
+	resets passwordreset.Service) Service {
+	return &adminSvc{
+		users:        users,
+		bookmarkRepo: bookmarkRepo,
+		tokens:       tokens,
+		resets:       resets,
+		now:          time.Now,
+	}
+}
+
+// ListUsers retrieves a page of the users matching filter, the most recently created first.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - filter: Optional filter, nil to list every user
+//   - req: Pointer to Pagination request with Page and Limit
+//
+// Returns:
+//   - *pagination.Response: Standard paginated response wrapper
+//   - error: Database error, if any
+func (s *adminSvc) ListUsers(ctx context.Context, filter *model.UserFilter, req *pagination.Request) (*pagination.Response[*model.User], error) {
+	limit := req.GetLimit()
+	offset := req.GetOffset()
+
+	users, total, err := s.users.ListUsers(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pagination.Response[*model.User]{
+		Data:     users,
+		Metadata: pagination.CalculateMetadata(total, req.Page, limit),
+	}, nil
+}
+
+// GetUser retrieves a user with the number of bookmarks it owns.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - *model.UserOverview: The user and its bookmark count
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or a database error
+func (s *adminSvc) GetUser(ctx context.Context, userID string) (*model.UserOverview, error) {
+	user, err := s.users.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.bookmarkRepo.CountBookmarks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UserOverview{User: *user, BookmarkCount: count}, nil
+}
+
+// SetUserDisabled disables or enables the account of a user. A disabled user cannot log in, and
+// every token issued to it is revoked, so the authentication middleware rejects its requests and
+// its refresh tokens stop working right away. Enabling the account lets the user log in again.
+// The last enabled administrator cannot be disabled.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//   - disabled: Whether the account is disabled or enabled
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, ErrLastAdmin, or a database or Redis error
+func (s *adminSvc) SetUserDisabled(ctx context.Context, userID string, disabled bool) error {
+	if !disabled {
+		return s.users.SetDisabledAt(ctx, userID, nil)
+	}
+
+	if err := s.checkNotLastAdmin(ctx, userID); err != nil {
+		return err
+	}
+	now := s.now()
+	if err := s.users.SetDisabledAt(ctx, userID, &now); err != nil {
+		return err
+	}
+	return s.tokens.RevokeAll(ctx, userID)
+}
+
+// ForcePasswordReset makes a user choose a new password, see passwordreset.Service.ForceReset.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or a database, generation or Redis error
+func (s *adminSvc) ForcePasswordReset(ctx context.Context, userID string) error {
+	return s.resets.ForceReset(ctx, userID)
+}
+
+// DeleteUser permanently removes a user together with its bookmarks, and revokes its tokens.
+// The last enabled administrator cannot be deleted.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, ErrLastAdmin, or a database or Redis error
+func (s *adminSvc) DeleteUser(ctx context.Context, userID string) error {
+	if err := s.checkNotLastAdmin(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.users.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+	return s.tokens.RevokeAll(ctx, userID)
+}
+
+// checkNotLastAdmin returns ErrLastAdmin when the user is the only enabled administrator.
+// The check is not atomic: administrators disabling each other at the same time may still
+// leave none enabled.
+func (s *adminSvc) checkNotLastAdmin(ctx context.Context, userID string) error {
+	user, err := s.users.GetUserById(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.Roles.Has(model.RoleAdmin) || user.DisabledAt != nil {
+		return nil
+	}
+
+	count, err := s.users.CountEnabledAdmins(ctx)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
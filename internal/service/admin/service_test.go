@@ -0,0 +1,297 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	bookmarkMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	resetMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/passwordreset/mocks"
+	tokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/token/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const testUserID = "user-123"
+
+var (
+	testNow   = time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	testErr   = errors.New("database error")
+	testUser  = &model.User{Base: model.Base{ID: testUserID}, Roles: model.Roles{model.RoleUser}}
+	testAdmin = &model.User{Base: model.Base{ID: testUserID}, Roles: model.Roles{model.RoleUser, model.RoleAdmin}}
+)
+
+type testMocks struct {
+	users        *repoMocks.User
+	bookmarkRepo *bookmarkMocks.Repository
+	tokens       *tokenMocks.Service
+	resets       *resetMocks.Service
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		users:        repoMocks.NewUser(t),
+		bookmarkRepo: bookmarkMocks.NewRepository(t),
+		tokens:       tokenMocks.NewService(t),
+		resets:       resetMocks.NewService(t),
+	}
+	svc := NewService(m.users, m.bookmarkRepo, m.tokens, m.resets).(*adminSvc)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+func TestAdminSvc_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	filter := &model.UserFilter{Query: "john"}
+	users := []*model.User{{Base: model.Base{ID: testUserID}, Username: "john"}}
+
+	testCases := []struct {
+		name           string
+		inputReq       *pagination.Request
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedOutput *pagination.Response[*model.User]
+	}{
+		{
+			name:     "Success - page of users",
+			inputReq: &pagination.Request{Page: 2, Limit: 5},
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("ListUsers", ctx, filter, 5, 5).Return(users, int64(6), nil)
+			},
+			expectedOutput: &pagination.Response[*model.User]{
+				Data:     users,
+				Metadata: pagination.Metadata{CurrentPage: 2, PageSize: 5, FirstPage: 1, LastPage: 2, TotalRecords: 6},
+			},
+		},
+		{
+			name:     "Error - repository error",
+			inputReq: &pagination.Request{},
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("ListUsers", ctx, filter, pagination.DefaultLimit, 0).Return(nil, int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.ListUsers(ctx, filter, tc.inputReq)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestAdminSvc_GetUser(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{Base: model.Base{ID: testUserID}, Username: "john"}
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedOutput *model.UserOverview
+	}{
+		{
+			name: "Success - user with bookmark count",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(user, nil)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(42), nil)
+			},
+			expectedOutput: &model.UserOverview{User: *user, BookmarkCount: 42},
+		},
+		{
+			name: "Error - user not found",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - count error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(user, nil)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.GetUser(ctx, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestAdminSvc_SetUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		inputDisabled bool
+		setupMock     func(ctx context.Context, m *testMocks)
+		expectedErr   error
+	}{
+		{
+			name:          "Success - user disabled and tokens revoked",
+			inputDisabled: true,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(testUser, nil)
+				m.users.On("SetDisabledAt", ctx, testUserID, &testNow).Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+			},
+		},
+		{
+			name:          "Success - administrator disabled while another is enabled",
+			inputDisabled: true,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(testAdmin, nil)
+				m.users.On("CountEnabledAdmins", ctx).Return(int64(2), nil)
+				m.users.On("SetDisabledAt", ctx, testUserID, &testNow).Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+			},
+		},
+		{
+			name:          "Success - user enabled",
+			inputDisabled: false,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("SetDisabledAt", ctx, testUserID, (*time.Time)(nil)).Return(nil)
+			},
+		},
+		{
+			name:          "Error - user not found",
+			inputDisabled: true,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name:          "Error - last enabled administrator",
+			inputDisabled: true,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(testAdmin, nil)
+				m.users.On("CountEnabledAdmins", ctx).Return(int64(1), nil)
+			},
+			expectedErr: ErrLastAdmin,
+		},
+		{
+			name:          "Error - revocation error",
+			inputDisabled: true,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(testUser, nil)
+				m.users.On("SetDisabledAt", ctx, testUserID, &testNow).Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(redis.ErrClosed)
+			},
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.SetUserDisabled(ctx, testUserID, tc.inputDisabled)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestAdminSvc_ForcePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, m := newTestService(t)
+	m.resets.On("ForceReset", ctx, testUserID).Return(dbutils.ErrNotFoundType)
+
+	err := svc.ForcePasswordReset(ctx, testUserID)
+
+	assert.ErrorIs(t, err, dbutils.ErrNotFoundType)
+}
+
+func TestAdminSvc_DeleteUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - user deleted and tokens revoked",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(testUser, nil)
+				m.users.On("DeleteUser", ctx, testUserID).Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+			},
+		},
+		{
+			name: "Success - disabled administrator deleted",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				disabled := *testAdmin
+				disabled.DisabledAt = &testNow
+				m.users.On("GetUserById", ctx, testUserID).Return(&disabled, nil)
+				m.users.On("DeleteUser", ctx, testUserID).Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+			},
+		},
+		{
+			name: "Error - user not found",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - last enabled administrator",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(testAdmin, nil)
+				m.users.On("CountEnabledAdmins", ctx).Return(int64(1), nil)
+			},
+			expectedErr: ErrLastAdmin,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.DeleteUser(ctx, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
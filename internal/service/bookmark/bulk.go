@@ -0,0 +1,37 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+)
+
+// ErrEmptySelection is returned when a bulk operation neither lists IDs nor has a filter
+// with at least one field set. Without it, an empty selection would apply the action to
+// every active bookmark of the user; those are selected explicitly with Archived set to false.
+var ErrEmptySelection = errors.New("bulk operation selects no bookmarks")
+
+// BulkUpdate applies an action to many bookmarks of a user at once.
+// Either all the selected bookmarks are changed or none are.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - op: The action and the bookmarks it applies to
+//
+// Returns:
+//   - int64: Number of rows changed (bookmarks, or tag assignments for tag and untag)
+//   - error: ErrEmptySelection, ErrNotFoundType if one of the IDs is not owned by the user, or a database error
+func (s *BookmarkSvc) BulkUpdate(ctx context.Context, userID string, op *model.BulkOperation) (int64, error) {
+	if len(op.IDs) == 0 && (op.Filter == nil || op.Filter.IsEmpty()) {
+		return 0, ErrEmptySelection
+	}
+
+	normalized := *op
+	normalized.Tags = normalizeTags(op.Tags)
+	normalized.Folder = strings.Trim(strings.TrimSpace(op.Folder), "/")
+
+	return s.repo.BulkUpdate(ctx, userID, &normalized)
+}
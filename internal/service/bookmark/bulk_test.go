@@ -0,0 +1,95 @@
+package bookmark
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkSvc_BulkUpdate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		inputOp          *model.BulkOperation
+		setupMock        func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedAffected int64
+		expectedErr      error
+	}{
+		{
+			name:    "Success - Tags And Folder Are Normalized",
+			inputOp: &model.BulkOperation{Action: model.BulkActionTag, IDs: []string{testBookmarkID}, Tags: []string{" go ", "go", ""}, Folder: " /Work/ "},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("BulkUpdate", ctx, testUserID, &model.BulkOperation{
+					Action: model.BulkActionTag,
+					IDs:    []string{testBookmarkID},
+					Tags:   []string{"go"},
+					Folder: "Work",
+				}).Return(int64(1), nil)
+			},
+			expectedAffected: 1,
+		},
+		{
+			name:    "Success - Filter",
+			inputOp: &model.BulkOperation{Action: model.BulkActionArchive, Filter: &model.BookmarkFilter{LinkStatus: model.LinkStatusBroken}},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("BulkUpdate", ctx, testUserID, &model.BulkOperation{
+					Action: model.BulkActionArchive,
+					Filter: &model.BookmarkFilter{LinkStatus: model.LinkStatusBroken},
+					Tags:   []string{},
+				}).Return(int64(12), nil)
+			},
+			expectedAffected: 12,
+		},
+		{
+			name:        "Error - Empty Selection",
+			inputOp:     &model.BulkOperation{Action: model.BulkActionDelete},
+			setupMock:   func(mockRepo *repoMocks.Repository, ctx context.Context) {},
+			expectedErr: ErrEmptySelection,
+		},
+		{
+			name:        "Error - Empty Filter",
+			inputOp:     &model.BulkOperation{Action: model.BulkActionDelete, Filter: &model.BookmarkFilter{}},
+			setupMock:   func(mockRepo *repoMocks.Repository, ctx context.Context) {},
+			expectedErr: ErrEmptySelection,
+		},
+		{
+			name:    "Error - Not Owned",
+			inputOp: &model.BulkOperation{Action: model.BulkActionDelete, IDs: []string{testBookmarkID}},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("BulkUpdate", ctx, testUserID, &model.BulkOperation{
+					Action: model.BulkActionDelete,
+					IDs:    []string{testBookmarkID},
+					Tags:   []string{},
+				}).Return(int64(0), dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			affected, err := svc.BulkUpdate(ctx, testUserID, tc.inputOp)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedAffected, affected)
+		})
+	}
+}
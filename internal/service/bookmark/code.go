@@ -0,0 +1,122 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+)
+
+// defaultCodeLength is the length of generated bookmark codes unless configured with WithCodeLength.
+// maxCodeRetries is the number of generated codes tried before giving up.
+const (
+	defaultCodeLength = 9
+	maxCodeRetries    = 5
+)
+
+// MaxCodeLength is the longest bookmark code the database can store.
+const MaxCodeLength = 32
+
+// Service-level errors returned for caller-chosen codes.
+var (
+	// ErrCodeReserved is returned when a code is a reserved word.
+	ErrCodeReserved = errors.New("code is reserved")
+	// ErrCodeTaken is returned when a code already redirects to a short link or another bookmark.
+	ErrCodeTaken = errors.New("code is already taken")
+)
+
+// reservedCodes are the words that cannot be chosen as codes, because they name routes
+// or could be mistaken for them. They are compared case-insensitively.
+var reservedCodes = map[string]bool{
+	"admin":        true,
+	"api":          true,
+	"auth":         true,
+	"bookmarks":    true,
+	"docs":         true,
+	"gen-pass":     true,
+	"health-check": true,
+	"links":        true,
+	"login":        true,
+	"logout":       true,
+	"public":       true,
+	"redirect":     true,
+	"register":     true,
+	"self":         true,
+	"shares":       true,
+	"shorten":      true,
+	"swagger":      true,
+	"users":        true,
+	"v1":           true,
+}
+
+// checkCode verifies that a code may be given to a bookmark. Bookmark codes share the redirect
+// namespace with short links, which are resolved first, so a code already used by a short link
+// would never reach the bookmark. Codes of other bookmarks are left to the unique constraint.
+func (s *BookmarkSvc) checkCode(ctx context.Context, code string) error {
+	if reservedCodes[strings.ToLower(code)] {
+		return ErrCodeReserved
+	}
+	if s.shortLinks == nil {
+		return nil
+	}
+
+	exists, err := s.shortLinks.Exists(ctx, code)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCodeTaken
+	}
+	return nil
+}
+
+// assignCode generates codes until store accepts one, like shortenUrl.ShortenUrl does for short links.
+// Codes refused by checkCode are skipped, and store reports a code already used by another
+// bookmark with dbutils.ErrDuplicationType.
+func (s *BookmarkSvc) assignCode(ctx context.Context, store func(code string) error) error {
+	for range maxCodeRetries {
+		code, err := s.codeGen.GenerateCode(s.codeLength)
+		if err != nil {
+			return err
+		}
+
+		err = s.checkCode(ctx, code)
+		if errors.Is(err, ErrCodeReserved) || errors.Is(err, ErrCodeTaken) {
+			continue // collision detected, retry with new code
+		}
+		if err != nil {
+			return err
+		}
+
+		err = store(code)
+		if !errors.Is(err, dbutils.ErrDuplicationType) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("failed to generate unique code after %d attempts", maxCodeRetries)
+}
+
+// RegenerateCode gives a bookmark a new generated code. The previous code stops redirecting.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - *model.Bookmark: The bookmark with its new code
+//   - error: ErrNotFoundType if not found or not owned by the user, or any error during generation or persistence
+func (s *BookmarkSvc) RegenerateCode(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error) {
+	err := s.assignCode(ctx, func(code string) error {
+		return s.repo.SetCode(ctx, bookmarkID, userID, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+}
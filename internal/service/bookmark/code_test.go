@@ -0,0 +1,183 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	urlMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkSvc_CreateBookmark_Code(t *testing.T) {
+	t.Parallel()
+
+	created := &model.Bookmark{Base: model.Base{ID: testBookmarkID}, Code: testCode}
+
+	testCases := []struct {
+		name           string
+		inputCode      string
+		setupMock      func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name:      "Success - Chosen Code",
+			inputCode: "golang",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context) {
+				mockLinks.On("Exists", ctx, "golang").Return(false, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.MatchedBy(func(b *model.Bookmark) bool { return b.Code == "golang" })).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, Code: "golang"}, nil)
+			},
+			expectedOutput: &model.Bookmark{Base: model.Base{ID: testBookmarkID}, Code: "golang"},
+		},
+		{
+			name:      "Error - Reserved Code",
+			inputCode: "Swagger",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context) {
+			},
+			expectedErr: ErrCodeReserved,
+		},
+		{
+			name:      "Error - Code Of A Short Link",
+			inputCode: "golang",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context) {
+				mockLinks.On("Exists", ctx, "golang").Return(true, nil)
+			},
+			expectedErr: ErrCodeTaken,
+		},
+		{
+			name:      "Error - Code Of Another Bookmark",
+			inputCode: "golang",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context) {
+				mockLinks.On("Exists", ctx, "golang").Return(false, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.Anything).Return(nil, dbutils.ErrDuplicationType)
+			},
+			expectedErr: ErrCodeTaken,
+		},
+		{
+			name: "Success - Generated Code Retried On Collision",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", 6).Return("link01", nil).Once()
+				mockLinks.On("Exists", ctx, "link01").Return(true, nil)
+				mockCodeGen.On("GenerateCode", 6).Return("book01", nil).Once()
+				mockLinks.On("Exists", ctx, "book01").Return(false, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.MatchedBy(func(b *model.Bookmark) bool { return b.Code == "book01" })).
+					Return(nil, dbutils.ErrDuplicationType).Once()
+				mockCodeGen.On("GenerateCode", 6).Return(testCode, nil).Once()
+				mockLinks.On("Exists", ctx, testCode).Return(false, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.MatchedBy(func(b *model.Bookmark) bool { return b.Code == testCode })).
+					Return(created, nil).Once()
+			},
+			expectedOutput: created,
+		},
+		{
+			name: "Error - Retries Exhausted",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", 6).Return(testCode, nil).Times(maxCodeRetries)
+				mockLinks.On("Exists", ctx, testCode).Return(false, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.Anything).Return(nil, dbutils.ErrDuplicationType).Times(maxCodeRetries)
+			},
+			expectedErr: errors.New("failed to generate unique code after 5 attempts"),
+		},
+		{
+			name: "Error - Short Link Lookup Failed",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockLinks *urlMocks.UrlStorage, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", 6).Return(testCode, nil)
+				mockLinks.On("Exists", ctx, testCode).Return(false, errors.New("redis error"))
+			},
+			expectedErr: errors.New("redis error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockCodeGen := mocks.NewKeyGenerator(t)
+			mockLinks := urlMocks.NewUrlStorage(t)
+			tc.setupMock(mockRepo, mockCodeGen, mockLinks, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mockCodeGen, WithCodeLength(6), WithShortLinks(mockLinks))
+
+			got, err := svc.CreateBookmark(ctx, testBookmarkDesc, testBookmarkURL, tc.inputCode, testUserID, OnDuplicateAllow)
+
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestBookmarkSvc_RegenerateCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", defaultCodeLength).Return(testCode, nil)
+				mockRepo.On("SetCode", ctx, testBookmarkID, testUserID, testCode).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, Code: testCode}, nil)
+			},
+			expectedOutput: &model.Bookmark{Base: model.Base{ID: testBookmarkID}, Code: testCode},
+		},
+		{
+			name: "Success - Retried On Collision",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", defaultCodeLength).Return("taken1234", nil).Once()
+				mockRepo.On("SetCode", ctx, testBookmarkID, testUserID, "taken1234").Return(dbutils.ErrDuplicationType)
+				mockCodeGen.On("GenerateCode", defaultCodeLength).Return(testCode, nil).Once()
+				mockRepo.On("SetCode", ctx, testBookmarkID, testUserID, testCode).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, Code: testCode}, nil)
+			},
+			expectedOutput: &model.Bookmark{Base: model.Base{ID: testBookmarkID}, Code: testCode},
+		},
+		{
+			name: "Error - Not Found",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", defaultCodeLength).Return(testCode, nil)
+				mockRepo.On("SetCode", ctx, testBookmarkID, testUserID, testCode).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockCodeGen := mocks.NewKeyGenerator(t)
+			tc.setupMock(mockRepo, mockCodeGen, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mockCodeGen)
+
+			got, err := svc.RegenerateCode(ctx, testBookmarkID, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
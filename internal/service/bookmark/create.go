@@ -2,44 +2,135 @@ package bookmark
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 )
 
-const codeLength = 9
+// OnDuplicate tells CreateBookmark what to do when the user already bookmarked the URL.
+// URLs are compared in normalized form, see pkg/urlnorm.
+type OnDuplicate string
+
+const (
+	// OnDuplicateReject fails with a DuplicateBookmarkError. This is the default.
+	OnDuplicateReject OnDuplicate = "reject"
+	// OnDuplicateMerge returns the existing bookmark instead of creating a new one.
+	// The new description is kept only if the existing bookmark has none.
+	OnDuplicateMerge OnDuplicate = "merge"
+	// OnDuplicateAllow creates a new bookmark anyway.
+	OnDuplicateAllow OnDuplicate = "allow"
+)
+
+// DuplicateBookmarkError is returned by CreateBookmark when the URL is already bookmarked
+// and duplicates are rejected.
+type DuplicateBookmarkError struct {
+	// ExistingID is the ID of the bookmark that already points to the URL
+	ExistingID string
+}
+
+// Error implements error.
+func (e *DuplicateBookmarkError) Error() string {
+	return fmt.Sprintf("url is already bookmarked by %s", e.ExistingID)
+}
 
 // CreateBookmark implements the business logic for creating a new bookmark.
-// It generates a unique short code for the URL using the configured KeyGenerator
-// and persists the bookmark data to the repository.
+// It uses the caller-chosen code, or generates a unique short code for the URL using
+// the configured KeyGenerator, and persists the bookmark data to the repository.
+//
+// Unless onDuplicate is OnDuplicateAllow, the user's existing bookmarks are checked first.
+// The check is not atomic: two concurrent requests for the same URL may both succeed.
 //
 // Parameters:
 //   - ctx: Context for the operation
 //   - description: User-provided description
 //   - url: The target URL to shorten
+//   - code: The short code chosen by the caller, empty to generate one
 //   - userID: The ID of the owner
+//   - onDuplicate: What to do when the URL is already bookmarked (empty means OnDuplicateReject)
 //
 // Returns:
-//   - *model.Bookmark: The created bookmark with generated ID and code
-//   - error: Any error during generation or persistence
-func (s *BookmarkSvc) CreateBookmark(ctx context.Context, description, url, userID string) (*model.Bookmark, error) {
-	// create code
-	code, err := s.codeGen.GenerateCode(codeLength)
-	if err != nil {
-		return nil, err
+//   - *model.Bookmark: The created bookmark with generated ID and code, or the existing one when merged
+//   - error: *DuplicateBookmarkError when rejected as duplicate, quota.ErrBookmarkQuotaExceeded when the user
+//     owns as many bookmarks as allowed, ErrCodeReserved or ErrCodeTaken when the chosen code cannot be used,
+//     or any error during generation or persistence
+func (s *BookmarkSvc) CreateBookmark(ctx context.Context, description, url, code, userID string, onDuplicate OnDuplicate) (*model.Bookmark, error) {
+	if onDuplicate != OnDuplicateAllow {
+		existing, err := s.repo.FindBookmarkByURL(ctx, userID, url)
+		switch {
+		case err == nil && onDuplicate == OnDuplicateMerge:
+			return s.mergeBookmark(ctx, existing, description)
+		case err == nil:
+			return nil, &DuplicateBookmarkError{ExistingID: existing.ID}
+		case !errors.Is(err, dbutils.ErrNotFoundType):
+			return nil, err
+		}
 	}
 
-	// create bookmark
-	bookmark := &model.Bookmark{
+	return s.createBookmark(ctx, &model.Bookmark{
 		Description: description,
 		URL:         url,
 		Code:        code,
 		UserID:      userID,
+	})
+}
+
+// createBookmark persists the bookmark under its code, or under a freshly generated code when it has none.
+// It is shared by every flow that creates bookmarks, such as CreateBookmark and ImportBookmarks.
+// The bookmark quota of the owner is checked first, and bookmarks created without a description
+// are queued for page metadata enrichment.
+func (s *BookmarkSvc) createBookmark(ctx context.Context, bookmark *model.Bookmark) (*model.Bookmark, error) {
+	if s.quotas != nil {
+		if err := s.quotas.CheckBookmarks(ctx, bookmark.UserID, 1); err != nil {
+			return nil, err
+		}
 	}
 
-	bookmarkModel, err := s.repo.CreateBookmark(ctx, bookmark)
-	if err != nil {
-		return nil, err
+	var bookmarkModel *model.Bookmark
+	store := func(code string) error {
+		bookmark.Code = code
+		created, err := s.repo.CreateBookmark(ctx, bookmark)
+		bookmarkModel = created
+		return err
+	}
+
+	if bookmark.Code == "" {
+		if err := s.assignCode(ctx, store); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.checkCode(ctx, bookmark.Code); err != nil {
+			return nil, err
+		}
+		err := store(bookmark.Code)
+		if errors.Is(err, dbutils.ErrDuplicationType) {
+			return nil, ErrCodeTaken
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.enrichment != nil && bookmarkModel.Description == "" {
+		s.enrichment.Enqueue(bookmarkModel.ID, bookmarkModel.URL)
 	}
 
 	return bookmarkModel, nil
 }
+
+// mergeBookmark folds a new bookmark for an already bookmarked URL into the existing one.
+// Only a missing description is filled in; everything else of the existing bookmark wins.
+func (s *BookmarkSvc) mergeBookmark(ctx context.Context, existing *model.Bookmark, description string) (*model.Bookmark, error) {
+	if existing.Description != "" || description == "" {
+		return existing, nil
+	}
+
+	err := s.repo.PatchBookmark(ctx, existing.ID, existing.UserID, &model.BookmarkPatch{Description: &description}, 0)
+	if err != nil {
+		return nil, err
+	}
+	existing.Description = description
+	existing.Version++
+	return existing, nil
+}
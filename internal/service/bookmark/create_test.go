@@ -7,6 +7,9 @@ import (
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	quotaMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/quota/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,15 +31,18 @@ func TestBookmarkSvc_CreateBookmark(t *testing.T) {
 		inputDescription string
 		inputURL         string
 		inputUserID      string
+		inputOnDuplicate OnDuplicate
 		setupMock        func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context)
 		expectedErr      error
 		expectedOutput   *model.Bookmark
+		expectedQueued   []string
 	}{
 		{
 			name:             "Success",
 			inputDescription: testBookmarkDesc,
 			inputURL:         testBookmarkURL,
 			inputUserID:      testUserID,
+			inputOnDuplicate: OnDuplicateAllow,
 			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
 				mockCodeGen.On("GenerateCode", 9).Return(testCode, nil)
 				mockRepo.On("CreateBookmark", ctx, mock.Anything).
@@ -56,11 +62,36 @@ func TestBookmarkSvc_CreateBookmark(t *testing.T) {
 				UserID:      testUserID,
 			},
 		},
+		{
+			name:             "Success - Empty Description Is Queued For Enrichment",
+			inputDescription: "",
+			inputURL:         testBookmarkURL,
+			inputUserID:      testUserID,
+			inputOnDuplicate: OnDuplicateAllow,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", 9).Return(testCode, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.Anything).
+					Return(&model.Bookmark{
+						Base:   model.Base{ID: testBookmarkID},
+						URL:    testBookmarkURL,
+						Code:   testCode,
+						UserID: testUserID,
+					}, nil)
+			},
+			expectedOutput: &model.Bookmark{
+				Base:   model.Base{ID: testBookmarkID},
+				URL:    testBookmarkURL,
+				Code:   testCode,
+				UserID: testUserID,
+			},
+			expectedQueued: []string{testBookmarkID},
+		},
 		{
 			name:             "Error - Key Generation Failed",
 			inputDescription: testBookmarkDesc,
 			inputURL:         testBookmarkURL,
 			inputUserID:      testUserID,
+			inputOnDuplicate: OnDuplicateAllow,
 			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
 				mockCodeGen.On("GenerateCode", 9).Return("", errors.New("code gen error"))
 			},
@@ -71,12 +102,75 @@ func TestBookmarkSvc_CreateBookmark(t *testing.T) {
 			inputDescription: testBookmarkDesc,
 			inputURL:         testBookmarkURL,
 			inputUserID:      testUserID,
+			inputOnDuplicate: OnDuplicateAllow,
 			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
 				mockCodeGen.On("GenerateCode", 9).Return(testCode, nil)
 				mockRepo.On("CreateBookmark", ctx, mock.Anything).Return(nil, errors.New("db error"))
 			},
 			expectedErr: errors.New("db error"),
 		},
+		{
+			name:             "Success - No Duplicate",
+			inputDescription: testBookmarkDesc,
+			inputURL:         testBookmarkURL,
+			inputUserID:      testUserID,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).Return(nil, dbutils.ErrNotFoundType)
+				mockCodeGen.On("GenerateCode", 9).Return(testCode, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.Anything).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, Description: testBookmarkDesc}, nil)
+			},
+			expectedOutput: &model.Bookmark{Base: model.Base{ID: testBookmarkID}, Description: testBookmarkDesc},
+		},
+		{
+			name:             "Error - Duplicate Rejected",
+			inputDescription: testBookmarkDesc,
+			inputURL:         testBookmarkURL,
+			inputUserID:      testUserID,
+			inputOnDuplicate: OnDuplicateReject,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).
+					Return(&model.Bookmark{Base: model.Base{ID: "existing-id"}}, nil)
+			},
+			expectedErr: &DuplicateBookmarkError{ExistingID: "existing-id"},
+		},
+		{
+			name:             "Success - Duplicate Merged Into Empty Description",
+			inputDescription: testBookmarkDesc,
+			inputURL:         testBookmarkURL,
+			inputUserID:      testUserID,
+			inputOnDuplicate: OnDuplicateMerge,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).
+					Return(&model.Bookmark{Base: model.Base{ID: "existing-id"}, UserID: testUserID, Version: 1}, nil)
+				description := testBookmarkDesc
+				mockRepo.On("PatchBookmark", ctx, "existing-id", testUserID, &model.BookmarkPatch{Description: &description}, 0).
+					Return(nil)
+			},
+			expectedOutput: &model.Bookmark{Base: model.Base{ID: "existing-id"}, UserID: testUserID, Description: testBookmarkDesc, Version: 2},
+		},
+		{
+			name:             "Success - Duplicate Merged Keeps Existing Description",
+			inputDescription: testBookmarkDesc,
+			inputURL:         testBookmarkURL,
+			inputUserID:      testUserID,
+			inputOnDuplicate: OnDuplicateMerge,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).
+					Return(&model.Bookmark{Base: model.Base{ID: "existing-id"}, Description: "Existing"}, nil)
+			},
+			expectedOutput: &model.Bookmark{Base: model.Base{ID: "existing-id"}, Description: "Existing"},
+		},
+		{
+			name:             "Error - Duplicate Lookup Failed",
+			inputDescription: testBookmarkDesc,
+			inputURL:         testBookmarkURL,
+			inputUserID:      testUserID,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).Return(nil, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -90,21 +184,69 @@ func TestBookmarkSvc_CreateBookmark(t *testing.T) {
 			tc.setupMock(mockRepo, mockCodeGen, ctx)
 
 			// Create service
-			svc := NewBookmarkSvc(mockRepo, mockCodeGen)
+			queue := &fakeEnrichmentQueue{}
+			svc := NewBookmarkSvc(mockRepo, mockCodeGen, WithEnrichmentQueue(queue))
 
 			// Execute
-			got, err := svc.CreateBookmark(ctx, tc.inputDescription, tc.inputURL, tc.inputUserID)
+			got, err := svc.CreateBookmark(ctx, tc.inputDescription, tc.inputURL, "", tc.inputUserID, tc.inputOnDuplicate)
 
 			// Assert
 			if tc.expectedErr != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedErr.Error(), err.Error())
 				assert.Nil(t, got)
+				assert.Empty(t, queue.ids)
 				return
 			}
 
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expectedOutput, got)
+			assert.Equal(t, tc.expectedQueued, queue.ids)
+		})
+	}
+}
+
+func TestBookmarkSvc_CreateBookmark_Quota(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockQuotas *quotaMocks.Service, ctx context.Context)
+		expectedErr error
+	}{
+		{
+			name: "Success - within quota",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockQuotas *quotaMocks.Service, ctx context.Context) {
+				mockQuotas.On("CheckBookmarks", ctx, testUserID, int64(1)).Return(nil)
+				mockCodeGen.On("GenerateCode", 9).Return(testCode, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.Anything).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, Description: testBookmarkDesc}, nil)
+			},
+		},
+		{
+			name: "Error - quota exceeded",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, mockQuotas *quotaMocks.Service, ctx context.Context) {
+				mockQuotas.On("CheckBookmarks", ctx, testUserID, int64(1)).Return(quota.ErrBookmarkQuotaExceeded)
+			},
+			expectedErr: quota.ErrBookmarkQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockCodeGen := mocks.NewKeyGenerator(t)
+			mockQuotas := quotaMocks.NewService(t)
+			tc.setupMock(mockRepo, mockCodeGen, mockQuotas, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mockCodeGen, WithQuotas(mockQuotas))
+
+			_, err := svc.CreateBookmark(ctx, testBookmarkDesc, testBookmarkURL, "", testUserID, OnDuplicateAllow)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
 		})
 	}
 }
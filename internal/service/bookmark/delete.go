@@ -11,9 +11,11 @@ import (
 //   - ctx: Context for the operation
 //   - bookmarkID: The ID of the bookmark to delete
 //   - userID: The ID of the user requesting the deletion (for ownership validation)
+//   - version: The version the bookmark must have to be deleted, 0 to delete unconditionally
 //
 // Returns:
-//   - error: nil on success, or an error from the repository layer
-func (s *BookmarkSvc) DeleteBookmark(ctx context.Context, bookmarkID, userID string) error {
-	return s.repo.DeleteBookmark(ctx, bookmarkID, userID)
+//   - error: nil on success, ErrVersionMismatch if the bookmark was modified since version,
+//     or an error from the repository layer
+func (s *BookmarkSvc) DeleteBookmark(ctx context.Context, bookmarkID, userID string, version int) error {
+	return s.repo.DeleteBookmark(ctx, bookmarkID, userID, version)
 }
@@ -27,7 +27,7 @@ func TestBookmarkSvc_DeleteBookmark(t *testing.T) {
 			bookmarkID: "bookmark-uuid-123",
 			userID:     "user-uuid-456",
 			setupMock: func(m *mocks.Repository) {
-				m.On("DeleteBookmark", mock.Anything, "bookmark-uuid-123", "user-uuid-456").
+				m.On("DeleteBookmark", mock.Anything, "bookmark-uuid-123", "user-uuid-456", 0).
 					Return(nil)
 			},
 			expectedErr: nil,
@@ -37,7 +37,7 @@ func TestBookmarkSvc_DeleteBookmark(t *testing.T) {
 			bookmarkID: "nonexistent-id",
 			userID:     "user-uuid-456",
 			setupMock: func(m *mocks.Repository) {
-				m.On("DeleteBookmark", mock.Anything, "nonexistent-id", "user-uuid-456").
+				m.On("DeleteBookmark", mock.Anything, "nonexistent-id", "user-uuid-456", 0).
 					Return(dbutils.ErrNotFoundType)
 			},
 			expectedErr: dbutils.ErrNotFoundType,
@@ -47,7 +47,7 @@ func TestBookmarkSvc_DeleteBookmark(t *testing.T) {
 			bookmarkID: "bookmark-uuid-123",
 			userID:     "user-uuid-456",
 			setupMock: func(m *mocks.Repository) {
-				m.On("DeleteBookmark", mock.Anything, "bookmark-uuid-123", "user-uuid-456").
+				m.On("DeleteBookmark", mock.Anything, "bookmark-uuid-123", "user-uuid-456", 0).
 					Return(errors.New("database connection error"))
 			},
 			expectedErr: errors.New("database connection error"),
@@ -66,7 +66,7 @@ func TestBookmarkSvc_DeleteBookmark(t *testing.T) {
 			svc := bookmark.NewBookmarkSvc(mockRepo, nil)
 
 			// Execute
-			err := svc.DeleteBookmark(context.Background(), tc.bookmarkID, tc.userID)
+			err := svc.DeleteBookmark(context.Background(), tc.bookmarkID, tc.userID, 0)
 
 			// Assert
 			if tc.expectedErr != nil {
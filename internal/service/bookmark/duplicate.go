@@ -0,0 +1,42 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+)
+
+// DuplicateGroup is a set of bookmarks of the same user pointing to the same page.
+type DuplicateGroup struct {
+	// NormalizedURL is the URL shared by the bookmarks, in normalized form
+	NormalizedURL string `json:"normalized_url"`
+	// Bookmarks are the duplicates, oldest first
+	Bookmarks []*model.Bookmark `json:"bookmarks"`
+}
+
+// GetDuplicates lists the groups of bookmarks that point to the same normalized URL.
+// Groups are ordered by URL; bookmarks within a group are ordered by creation time.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - []*DuplicateGroup: The groups with at least two bookmarks, empty when there are none
+//   - error: Any repository error
+func (s *BookmarkSvc) GetDuplicates(ctx context.Context, userID string) ([]*DuplicateGroup, error) {
+	bookmarks, err := s.repo.GetDuplicateBookmarks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*DuplicateGroup, 0)
+	for _, bookmark := range bookmarks {
+		if len(groups) == 0 || groups[len(groups)-1].NormalizedURL != bookmark.NormalizedURL {
+			groups = append(groups, &DuplicateGroup{NormalizedURL: bookmark.NormalizedURL})
+		}
+		last := groups[len(groups)-1]
+		last.Bookmarks = append(last.Bookmarks, bookmark)
+	}
+	return groups, nil
+}
@@ -0,0 +1,74 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkSvc_GetDuplicates(t *testing.T) {
+	t.Parallel()
+
+	first := &model.Bookmark{Base: model.Base{ID: "bookmark-1"}, NormalizedURL: "https://a.example/"}
+	second := &model.Bookmark{Base: model.Base{ID: "bookmark-2"}, NormalizedURL: "https://a.example/"}
+	third := &model.Bookmark{Base: model.Base{ID: "bookmark-3"}, NormalizedURL: "https://b.example/"}
+	fourth := &model.Bookmark{Base: model.Base{ID: "bookmark-4"}, NormalizedURL: "https://b.example/"}
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, mockRepo *repoMocks.Repository)
+		expectedErr    error
+		expectedOutput []*DuplicateGroup
+	}{
+		{
+			name: "success - bookmarks are grouped by normalized URL",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.Repository) {
+				mockRepo.On("GetDuplicateBookmarks", ctx, testUserID).
+					Return([]*model.Bookmark{first, second, third, fourth}, nil)
+			},
+			expectedOutput: []*DuplicateGroup{
+				{NormalizedURL: "https://a.example/", Bookmarks: []*model.Bookmark{first, second}},
+				{NormalizedURL: "https://b.example/", Bookmarks: []*model.Bookmark{third, fourth}},
+			},
+		},
+		{
+			name: "success - no duplicates",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.Repository) {
+				mockRepo.On("GetDuplicateBookmarks", ctx, testUserID).Return([]*model.Bookmark{}, nil)
+			},
+			expectedOutput: []*DuplicateGroup{},
+		},
+		{
+			name: "error - repository failure",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.Repository) {
+				mockRepo.On("GetDuplicateBookmarks", ctx, testUserID).Return(nil, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(ctx, mockRepo)
+			svc := NewBookmarkSvc(mockRepo, nil)
+
+			got, err := svc.GetDuplicates(ctx, testUserID)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package bookmark
+
+import (
+	"context"
+	"sync"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagemeta"
+	"github.com/rs/zerolog/log"
+)
+
+// Limits applied to fetched metadata, matching the column sizes of the bookmarks table.
+const (
+	maxMetaTitleLen       = 255
+	maxMetaDescriptionLen = 1024
+	maxMetaURLLen         = 2048
+)
+
+// EnrichmentQueue accepts bookmarks whose page metadata should be fetched in the background.
+type EnrichmentQueue interface {
+	// Enqueue schedules the bookmark for enrichment. It never blocks
+	// and reports false when the bookmark was dropped because the queue is full.
+	Enqueue(bookmarkID, url string) bool
+}
+
+// enrichmentJob identifies a bookmark waiting for enrichment.
+type enrichmentJob struct {
+	bookmarkID string
+	url        string
+}
+
+// Enricher is a background worker pool that fetches the pages of new bookmarks
+// and stores their metadata. It implements EnrichmentQueue for the service and
+// scheduler.Job so that it is started together with the server.
+//
+// The queue lives in memory: jobs still queued when the process stops are lost,
+// which only leaves those bookmarks without metadata.
+type Enricher struct {
+	repo    bookmark.Repository
+	fetcher pagemeta.Fetcher
+	jobs    chan enrichmentJob
+	workers int
+}
+
+// NewEnricher creates an Enricher.
+//
+// Parameters:
+//   - repo: Repository the metadata is written to
+//   - fetcher: Fetcher used to download the pages
+//   - workers: Number of pages fetched concurrently
+//   - queueSize: Number of bookmarks that can wait for a worker before new ones are dropped
+func NewEnricher(repo bookmark.Repository, fetcher pagemeta.Fetcher, workers, queueSize int) *Enricher {
+	return &Enricher{
+		repo:    repo,
+		fetcher: fetcher,
+		jobs:    make(chan enrichmentJob, queueSize),
+		workers: workers,
+	}
+}
+
+// Enqueue implements EnrichmentQueue.
+func (e *Enricher) Enqueue(bookmarkID, url string) bool {
+	select {
+	case e.jobs <- enrichmentJob{bookmarkID: bookmarkID, url: url}:
+		return true
+	default:
+		log.Warn().Str("bookmark_id", bookmarkID).Msg("Enrichment queue full, bookmark dropped")
+		return false
+	}
+}
+
+// Start runs the workers and blocks until ctx is cancelled.
+func (e *Enricher) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range e.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-e.jobs:
+					if err := e.enrich(ctx, job); err != nil {
+						log.Warn().Err(err).Str("bookmark_id", job.bookmarkID).Msg("Failed to enrich bookmark")
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// enrich fetches the page of a single bookmark and stores its metadata.
+func (e *Enricher) enrich(ctx context.Context, job enrichmentJob) error {
+	meta, err := e.fetcher.Fetch(ctx, job.url)
+	if err != nil {
+		return err
+	}
+
+	return e.repo.UpdatePageMetadata(ctx, job.bookmarkID, &model.PageMetadata{
+		Title:           truncate(meta.Title, maxMetaTitleLen),
+		MetaDescription: truncate(meta.Description, maxMetaDescriptionLen),
+		FaviconURL:      limitURL(meta.FaviconURL),
+		CanonicalURL:    limitURL(meta.CanonicalURL),
+		ReadingTime:     readingTime(meta.WordCount),
+	})
+}
+
+// limitURL drops URLs that do not fit their column; a truncated URL would be broken.
+func limitURL(url string) string {
+	if len(url) > maxMetaURLLen {
+		return ""
+	}
+	return url
+}
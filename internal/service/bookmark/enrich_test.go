@@ -0,0 +1,164 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagemeta"
+	metaMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/pagemeta/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeEnrichmentQueue records the bookmarks queued by the service.
+type fakeEnrichmentQueue struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (q *fakeEnrichmentQueue) Enqueue(bookmarkID, _ string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.ids = append(q.ids, bookmarkID)
+	return true
+}
+
+func TestEnricher_enrich(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, mockRepo *repoMocks.Repository, mockFetcher *metaMocks.Fetcher)
+		expectedErr error
+	}{
+		{
+			name: "success - metadata is stored within column limits",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.Repository, mockFetcher *metaMocks.Fetcher) {
+				mockFetcher.On("Fetch", ctx, testBookmarkURL).Return(&pagemeta.Metadata{
+					Title:        strings.Repeat("t", 300),
+					Description:  "A page",
+					FaviconURL:   testBookmarkURL + "/favicon.ico",
+					CanonicalURL: testBookmarkURL + "/" + strings.Repeat("a", 2048),
+					WordCount:    401,
+				}, nil)
+				mockRepo.On("UpdatePageMetadata", ctx, testBookmarkID, &model.PageMetadata{
+					Title:           strings.Repeat("t", 255),
+					MetaDescription: "A page",
+					FaviconURL:      testBookmarkURL + "/favicon.ico",
+					CanonicalURL:    "",
+					ReadingTime:     3,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error - fetch failed",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.Repository, mockFetcher *metaMocks.Fetcher) {
+				mockFetcher.On("Fetch", ctx, testBookmarkURL).Return(nil, pagemeta.ErrBlockedAddress)
+			},
+			expectedErr: pagemeta.ErrBlockedAddress,
+		},
+		{
+			name: "error - update failed",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.Repository, mockFetcher *metaMocks.Fetcher) {
+				mockFetcher.On("Fetch", ctx, testBookmarkURL).Return(&pagemeta.Metadata{}, nil)
+				mockRepo.On("UpdatePageMetadata", ctx, testBookmarkID, mock.Anything).Return(errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockFetcher := metaMocks.NewFetcher(t)
+			tc.setupMock(ctx, mockRepo, mockFetcher)
+
+			enricher := NewEnricher(mockRepo, mockFetcher, 1, 1)
+			err := enricher.enrich(ctx, enrichmentJob{bookmarkID: testBookmarkID, url: testBookmarkURL})
+
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEnricher_Enqueue(t *testing.T) {
+	t.Parallel()
+
+	enricher := NewEnricher(repoMocks.NewRepository(t), metaMocks.NewFetcher(t), 1, 1)
+
+	assert.True(t, enricher.Enqueue(testBookmarkID, testBookmarkURL))
+	assert.False(t, enricher.Enqueue("bookmark-2", testBookmarkURL), "queue is full")
+}
+
+func TestEnricher_Start(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head>
+			<title>Served Page</title>
+			<meta name="description" content="Served from httptest">
+			<link rel="canonical" href="/canonical">
+		</head></html>`))
+	}))
+	t.Cleanup(server.Close)
+
+	db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+	repo := bookmark.NewRepository(db)
+	created, err := repo.CreateBookmark(t.Context(), &model.Bookmark{
+		URL:    server.URL + "/page",
+		Code:   "enrich001",
+		UserID: fixture.FixtureUserOneID,
+	})
+	assert.NoError(t, err)
+
+	enricher := NewEnricher(repo, pagemeta.NewFetcher(pagemeta.Options{AllowPrivateNetworks: true}), 2, 10)
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		enricher.Start(ctx)
+		close(done)
+	}()
+
+	assert.True(t, enricher.Enqueue(created.ID, created.URL))
+
+	assert.Eventually(t, func() bool {
+		got, err := repo.GetBookmarkByID(t.Context(), created.ID, fixture.FixtureUserOneID)
+		return err == nil && got.Title != ""
+	}, 5*time.Second, 20*time.Millisecond)
+
+	got, err := repo.GetBookmarkByID(t.Context(), created.ID, fixture.FixtureUserOneID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.PageMetadata{
+		Title:           "Served Page",
+		MetaDescription: "Served from httptest",
+		FaviconURL:      server.URL + "/favicon.ico",
+		CanonicalURL:    server.URL + "/canonical",
+	}, got.PageMetadata)
+	assert.Equal(t, "Served Page", got.Description)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("enricher did not stop after the context was cancelled")
+	}
+}
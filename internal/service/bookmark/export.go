@@ -0,0 +1,25 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+)
+
+// exportBatchSize is the number of bookmarks loaded from the database at a time during export.
+const exportBatchSize = 200
+
+// ExportBookmarks streams all bookmarks of the user to fn in batches, grouped by folder.
+// Loading the collection batch by batch keeps memory use flat for large collections,
+// so callers can write each batch to the response as soon as it arrives.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - fn: Callback receiving each batch; returning an error stops the export
+//
+// Returns:
+//   - error: Database error or the error returned by fn
+func (s *BookmarkSvc) ExportBookmarks(ctx context.Context, userID string, fn func([]*model.Bookmark) error) error {
+	return s.repo.IterateBookmarks(ctx, userID, exportBatchSize, fn)
+}
@@ -0,0 +1,190 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	neturl "net/url"
+	"strings"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netscape"
+)
+
+// Limits applied to imported data, matching the column sizes of the bookmarks table.
+const (
+	maxImportDescriptionLen = 255
+	maxImportURLLen         = 2048
+	maxImportFolderLen      = 512
+	maxImportTagLen         = 64
+)
+
+// FolderMode tells how the folders of an imported bookmark file are stored.
+type FolderMode string
+
+const (
+	// FolderModeFolder keeps the folder hierarchy as the bookmark's folder path, e.g. "Work/Go".
+	FolderModeFolder FolderMode = "folder"
+	// FolderModeTag turns every folder name on the path into a tag.
+	FolderModeTag FolderMode = "tag"
+)
+
+// DuplicateMode tells how an imported URL that the user already bookmarked is handled.
+type DuplicateMode string
+
+const (
+	// DuplicateModeSkip leaves the existing bookmark untouched and ignores the imported one.
+	DuplicateModeSkip DuplicateMode = "skip"
+	// DuplicateModeOverwrite replaces the description, folder and tags of the existing bookmark.
+	DuplicateModeOverwrite DuplicateMode = "overwrite"
+	// DuplicateModeAllow creates a new bookmark even though the URL is already bookmarked.
+	DuplicateModeAllow DuplicateMode = "allow"
+)
+
+// ImportOptions configures ImportBookmarks. Zero values select FolderModeFolder and DuplicateModeSkip.
+type ImportOptions struct {
+	FolderMode    FolderMode
+	DuplicateMode DuplicateMode
+}
+
+// ImportResult summarizes the outcome of an import.
+type ImportResult struct {
+	// Created is the number of new bookmarks
+	Created int
+	// Updated is the number of existing bookmarks overwritten by the import
+	Updated int
+	// Skipped is the number of duplicates that were left untouched
+	Skipped int
+	// Invalid is the number of entries ignored because their URL is not an http(s) URL
+	Invalid int
+}
+
+// ImportBookmarks creates bookmarks for the user from entries read out of a Netscape bookmark file.
+// Entries are processed in order; a duplicate inside the file is therefore handled against the
+// bookmark created for its first occurrence. URLs are compared in normalized form (see pkg/urlnorm).
+// Bookmarklets and other non-http(s) links are counted as invalid and skipped, since they cannot
+// be shortened.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - items: The entries to import
+//   - opts: Folder and duplicate handling
+//
+// Returns:
+//   - *ImportResult: Counts of created, updated, skipped and invalid entries
+//   - error: The first database error, or quota.ErrBookmarkQuotaExceeded once the user owns as many
+//     bookmarks as allowed; entries processed before it are kept
+func (s *BookmarkSvc) ImportBookmarks(ctx context.Context, userID string, items []netscape.Bookmark, opts ImportOptions) (*ImportResult, error) {
+	if opts.FolderMode == "" {
+		opts.FolderMode = FolderModeFolder
+	}
+	if opts.DuplicateMode == "" {
+		opts.DuplicateMode = DuplicateModeSkip
+	}
+
+	result := &ImportResult{}
+	for _, item := range items {
+		if !isImportableURL(item.URL) {
+			result.Invalid++
+			continue
+		}
+
+		bookmark := importedBookmark(item, userID, opts.FolderMode)
+
+		if opts.DuplicateMode != DuplicateModeAllow {
+			existing, err := s.repo.FindBookmarkByURL(ctx, userID, item.URL)
+			switch {
+			case err == nil:
+				if opts.DuplicateMode == DuplicateModeSkip {
+					result.Skipped++
+					continue
+				}
+				bookmark.ID = existing.ID
+				if err := s.repo.OverwriteBookmark(ctx, bookmark); err != nil {
+					return result, err
+				}
+				result.Updated++
+				continue
+			case !errors.Is(err, dbutils.ErrNotFoundType):
+				return result, err
+			}
+		}
+
+		if _, err := s.createBookmark(ctx, bookmark); err != nil {
+			return result, err
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
+
+// importedBookmark converts a Netscape entry into a bookmark owned by userID.
+// The link title becomes the description, falling back to the <DD> text when the title is empty.
+func importedBookmark(item netscape.Bookmark, userID string, folderMode FolderMode) *model.Bookmark {
+	description := item.Title
+	if description == "" {
+		description = item.Description
+	}
+
+	tags := item.Tags
+	folder := ""
+	if folderMode == FolderModeTag {
+		tags = append(append([]string(nil), tags...), item.Folder...)
+	} else {
+		folder = truncate(strings.Join(item.Folder, "/"), maxImportFolderLen)
+	}
+
+	bookmark := &model.Bookmark{
+		Description: truncate(description, maxImportDescriptionLen),
+		URL:         item.URL,
+		Folder:      folder,
+		Tags:        model.NewBookmarkTags(normalizeTags(tags)),
+		UserID:      userID,
+	}
+	// Keep the original creation time so imported bookmarks sort like they did in the browser
+	if !item.AddDate.IsZero() {
+		bookmark.CreatedAt = item.AddDate
+	}
+	return bookmark
+}
+
+// isImportableURL reports whether rawURL is an absolute http(s) URL that fits the url column.
+func isImportableURL(rawURL string) bool {
+	if len(rawURL) > maxImportURLLen {
+		return false
+	}
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// normalizeTags trims and truncates tag names, dropping empty names and duplicates.
+func normalizeTags(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = truncate(strings.TrimSpace(name), maxImportTagLen)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		tags = append(tags, name)
+	}
+	return tags
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,155 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netscape"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkSvc_ImportBookmarks(t *testing.T) {
+	t.Parallel()
+
+	addDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	item := netscape.Bookmark{
+		URL:     testBookmarkURL,
+		Title:   testBookmarkDesc,
+		Folder:  []string{"Bookmarks bar", "Work"},
+		Tags:    []string{"go", " go ", ""},
+		AddDate: addDate,
+	}
+
+	testCases := []struct {
+		name           string
+		inputItems     []netscape.Bookmark
+		inputOpts      ImportOptions
+		setupMock      func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context)
+		expectedErr    error
+		expectedOutput *ImportResult
+	}{
+		{
+			name:       "Success - create with folder path",
+			inputItems: []netscape.Bookmark{item},
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).Return(nil, dbutils.ErrNotFoundType)
+				mockCodeGen.On("GenerateCode", defaultCodeLength).Return(testCode, nil)
+				mockRepo.On("CreateBookmark", ctx, &model.Bookmark{
+					Base:        model.Base{CreatedAt: addDate},
+					Description: testBookmarkDesc,
+					URL:         testBookmarkURL,
+					Code:        testCode,
+					Folder:      "Bookmarks bar/Work",
+					Tags:        []model.BookmarkTag{{Name: "go"}},
+					UserID:      testUserID,
+				}).Return(&model.Bookmark{}, nil)
+			},
+			expectedOutput: &ImportResult{Created: 1},
+		},
+		{
+			name:       "Success - folders become tags",
+			inputItems: []netscape.Bookmark{item},
+			inputOpts:  ImportOptions{FolderMode: FolderModeTag, DuplicateMode: DuplicateModeAllow},
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", defaultCodeLength).Return(testCode, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.MatchedBy(func(b *model.Bookmark) bool {
+					return b.Folder == "" && assert.ObjectsAreEqual([]string{"go", "Bookmarks bar", "Work"}, b.TagNames())
+				})).Return(&model.Bookmark{}, nil)
+			},
+			expectedOutput: &ImportResult{Created: 1},
+		},
+		{
+			name:       "Success - skip duplicate and invalid URLs",
+			inputItems: []netscape.Bookmark{item, {URL: "javascript:alert(1)"}, {URL: "place:sort=8"}},
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}}, nil)
+			},
+			expectedOutput: &ImportResult{Skipped: 1, Invalid: 2},
+		},
+		{
+			name:       "Success - overwrite duplicate",
+			inputItems: []netscape.Bookmark{item},
+			inputOpts:  ImportOptions{DuplicateMode: DuplicateModeOverwrite},
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}}, nil)
+				mockRepo.On("OverwriteBookmark", ctx, mock.MatchedBy(func(b *model.Bookmark) bool {
+					return b.ID == testBookmarkID && b.UserID == testUserID && b.Folder == "Bookmarks bar/Work"
+				})).Return(nil)
+			},
+			expectedOutput: &ImportResult{Updated: 1},
+		},
+		{
+			name:       "Error - duplicate lookup failed",
+			inputItems: []netscape.Bookmark{item},
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockRepo.On("FindBookmarkByURL", ctx, testUserID, testBookmarkURL).Return(nil, errors.New("db error"))
+			},
+			expectedErr:    errors.New("db error"),
+			expectedOutput: &ImportResult{},
+		},
+		{
+			name:       "Error - create failed keeps partial result",
+			inputItems: []netscape.Bookmark{{URL: "ftp://example.com"}, item},
+			inputOpts:  ImportOptions{DuplicateMode: DuplicateModeAllow},
+			setupMock: func(mockRepo *repoMocks.Repository, mockCodeGen *mocks.KeyGenerator, ctx context.Context) {
+				mockCodeGen.On("GenerateCode", defaultCodeLength).Return(testCode, nil)
+				mockRepo.On("CreateBookmark", ctx, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedErr:    errors.New("db error"),
+			expectedOutput: &ImportResult{Invalid: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockCodeGen := mocks.NewKeyGenerator(t)
+			tc.setupMock(mockRepo, mockCodeGen, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mockCodeGen)
+
+			got, err := svc.ImportBookmarks(ctx, testUserID, tc.inputItems, tc.inputOpts)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestBookmarkSvc_ExportBookmarks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockRepo := repoMocks.NewRepository(t)
+	mockRepo.On("IterateBookmarks", ctx, testUserID, exportBatchSize, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(3).(func([]*model.Bookmark) error)
+			_ = fn([]*model.Bookmark{{Base: model.Base{ID: testBookmarkID}}})
+		}).
+		Return(nil)
+
+	svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+	var exported []string
+	err := svc.ExportBookmarks(ctx, testUserID, func(bookmarks []*model.Bookmark) error {
+		for _, b := range bookmarks {
+			exported = append(exported, b.ID)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{testBookmarkID}, exported)
+}
@@ -0,0 +1,109 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/linkcheck"
+	"github.com/rs/zerolog/log"
+)
+
+// maxCheckErrorLen matches the size of the check_error column.
+const maxCheckErrorLen = 255
+
+// ErrNoRedirect is returned when accepting the redirect of a bookmark
+// whose last link check found no permanent redirect.
+var ErrNoRedirect = errors.New("bookmark has no permanent redirect")
+
+// LinkChecker checks whether URLs are still reachable.
+// It is implemented by *linkcheck.Checker.
+type LinkChecker interface {
+	CheckAll(ctx context.Context, urls []string, fn func(i int, result linkcheck.Result))
+}
+
+// CheckLinks checks the URLs of up to batchSize bookmarks that have not been checked
+// for staleAfter and records the outcome on each bookmark.
+// It is intended to be called periodically by the link check job and does nothing
+// when the service has no LinkChecker.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - staleAfter: How long a check result is considered current
+//   - batchSize: Maximum number of bookmarks checked in this run
+//
+// Returns:
+//   - int: Number of bookmarks checked
+//   - error: Database error while selecting the bookmarks, if any
+func (s *BookmarkSvc) CheckLinks(ctx context.Context, staleAfter time.Duration, batchSize int) (int, error) {
+	if s.links == nil {
+		return 0, nil
+	}
+
+	bookmarks, err := s.repo.GetBookmarksDueForCheck(ctx, time.Now().Add(-staleAfter), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	urls := make([]string, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		urls[i] = bookmark.URL
+	}
+
+	s.links.CheckAll(ctx, urls, func(i int, result linkcheck.Result) {
+		bookmark := bookmarks[i]
+		if err := s.repo.UpdateLinkCheck(ctx, bookmark.ID, toLinkCheck(bookmark.URL, result)); err != nil {
+			log.Warn().Err(err).Str("bookmark_id", bookmark.ID).Msg("Failed to store link check")
+		}
+	})
+
+	return len(bookmarks), nil
+}
+
+// toLinkCheck converts the result of checking url into what is stored on the bookmark.
+func toLinkCheck(url string, result linkcheck.Result) *model.LinkCheck {
+	now := time.Now()
+	check := &model.LinkCheck{
+		StatusCode:    result.StatusCode,
+		LastCheckedAt: &now,
+	}
+	if result.Err != nil {
+		check.StatusCode = 0
+		check.CheckError = truncate(result.Err.Error(), maxCheckErrorLen)
+		return check
+	}
+	// Temporary redirects are expected to change back, so only permanent ones are offered to the user
+	if result.Permanent && result.FinalURL != url {
+		check.FinalURL = limitURL(result.FinalURL)
+	}
+	return check
+}
+
+// AcceptRedirect replaces the URL of a bookmark with the target of its permanent redirect,
+// as found by the last link check.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - *model.Bookmark: The bookmark with its new URL
+//   - error: ErrNotFoundType if not found or not owned by the user, ErrNoRedirect if there
+//     is no redirect to accept, or a database error
+func (s *BookmarkSvc) AcceptRedirect(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error) {
+	bookmark, err := s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if bookmark.FinalURL == "" {
+		return nil, ErrNoRedirect
+	}
+
+	if err := s.repo.AcceptRedirect(ctx, bookmarkID, userID, bookmark.FinalURL); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+}
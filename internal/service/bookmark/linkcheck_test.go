@@ -0,0 +1,199 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/linkcheck"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkSvc_CheckLinks(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	staleAfter := 24 * time.Hour
+	checkedBefore := mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-staleAfter + time.Minute))
+	})
+	// linkCheck matches a stored check by its outcome, ignoring the check time
+	linkCheck := func(statusCode int, finalURL string, failed bool) any {
+		return mock.MatchedBy(func(check *model.LinkCheck) bool {
+			return check.StatusCode == statusCode && check.FinalURL == finalURL &&
+				(check.CheckError != "") == failed && check.LastCheckedAt != nil
+		})
+	}
+
+	testCases := []struct {
+		name           string
+		withChecker    bool
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput int
+	}{
+		{
+			name:        "Success - every outcome is recorded",
+			withChecker: true,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarksDueForCheck", ctx, checkedBefore, 10).Return([]*model.Bookmark{
+					{Base: model.Base{ID: "bm-ok"}, URL: server.URL + "/ok"},
+					{Base: model.Base{ID: "bm-gone"}, URL: server.URL + "/gone"},
+					{Base: model.Base{ID: "bm-moved"}, URL: server.URL + "/moved"},
+					{Base: model.Base{ID: "bm-temporary"}, URL: server.URL + "/temporary"},
+					{Base: model.Base{ID: "bm-unreachable"}, URL: "ftp://example.com/file"},
+				}, nil)
+				mockRepo.On("UpdateLinkCheck", ctx, "bm-ok", linkCheck(http.StatusOK, "", false)).Return(nil)
+				mockRepo.On("UpdateLinkCheck", ctx, "bm-gone", linkCheck(http.StatusNotFound, "", false)).Return(nil)
+				mockRepo.On("UpdateLinkCheck", ctx, "bm-moved", linkCheck(http.StatusOK, server.URL+"/ok", false)).Return(nil)
+				mockRepo.On("UpdateLinkCheck", ctx, "bm-temporary", linkCheck(http.StatusOK, "", false)).Return(nil)
+				// A failed update is logged and does not stop the run
+				mockRepo.On("UpdateLinkCheck", ctx, "bm-unreachable", linkCheck(0, "", true)).Return(dbutils.ErrNotFoundType)
+			},
+			expectedOutput: 5,
+		},
+		{
+			name:        "Error - Repository Failed",
+			withChecker: true,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarksDueForCheck", ctx, checkedBefore, 10).Return(nil, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+		{
+			name:      "Success - no checker configured",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			var opts []Option
+			if tc.withChecker {
+				opts = append(opts, WithLinkChecker(linkcheck.NewChecker(server.Client(), linkcheck.Options{})))
+			}
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t), opts...)
+
+			got, err := svc.CheckLinks(ctx, staleAfter, 10)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestBookmarkSvc_AcceptRedirect(t *testing.T) {
+	t.Parallel()
+
+	const testFinalURL = "https://example.com/moved"
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{
+					Base:      model.Base{ID: testBookmarkID},
+					URL:       testBookmarkURL,
+					LinkCheck: model.LinkCheck{StatusCode: http.StatusOK, FinalURL: testFinalURL},
+				}, nil).Once()
+				mockRepo.On("AcceptRedirect", ctx, testBookmarkID, testUserID, testFinalURL).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{
+					Base:      model.Base{ID: testBookmarkID},
+					URL:       testFinalURL,
+					LinkCheck: model.LinkCheck{StatusCode: http.StatusOK},
+				}, nil).Once()
+			},
+			expectedOutput: &model.Bookmark{
+				Base:      model.Base{ID: testBookmarkID},
+				URL:       testFinalURL,
+				LinkCheck: model.LinkCheck{StatusCode: http.StatusOK},
+			},
+		},
+		{
+			name: "Error - No Redirect",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{
+					Base: model.Base{ID: testBookmarkID},
+					URL:  testBookmarkURL,
+				}, nil)
+			},
+			expectedErr: ErrNoRedirect,
+		},
+		{
+			name: "Error - Not Found",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - Redirect Changed Concurrently",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{
+					Base:      model.Base{ID: testBookmarkID},
+					LinkCheck: model.LinkCheck{FinalURL: testFinalURL},
+				}, nil)
+				mockRepo.On("AcceptRedirect", ctx, testBookmarkID, testUserID, testFinalURL).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			got, err := svc.AcceptRedirect(ctx, testBookmarkID, testUserID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
@@ -5,10 +5,19 @@ package mocks
 import (
 	context "context"
 
-	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	bookmark "github.com/HadesHo3820/ebvn-golang-course/internal/service/bookmark"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+
+	netscape "github.com/HadesHo3820/ebvn-golang-course/pkg/netscape"
+
 	pagination "github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -16,9 +25,95 @@ type Service struct {
 	mock.Mock
 }
 
-// CreateBookmark provides a mock function with given fields: ctx, description, url, userID
-func (_m *Service) CreateBookmark(ctx context.Context, description string, url string, userID string) (*model.Bookmark, error) {
-	ret := _m.Called(ctx, description, url, userID)
+// AcceptRedirect provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) AcceptRedirect(ctx context.Context, bookmarkID string, userID string) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptRedirect")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkUpdate provides a mock function with given fields: ctx, userID, op
+func (_m *Service) BulkUpdate(ctx context.Context, userID string, op *model.BulkOperation) (int64, error) {
+	ret := _m.Called(ctx, userID, op)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BulkOperation) (int64, error)); ok {
+		return rf(ctx, userID, op)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BulkOperation) int64); ok {
+		r0 = rf(ctx, userID, op)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.BulkOperation) error); ok {
+		r1 = rf(ctx, userID, op)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckLinks provides a mock function with given fields: ctx, staleAfter, batchSize
+func (_m *Service) CheckLinks(ctx context.Context, staleAfter time.Duration, batchSize int) (int, error) {
+	ret := _m.Called(ctx, staleAfter, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLinks")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int) (int, error)); ok {
+		return rf(ctx, staleAfter, batchSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int) int); ok {
+		r0 = rf(ctx, staleAfter, batchSize)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, int) error); ok {
+		r1 = rf(ctx, staleAfter, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBookmark provides a mock function with given fields: ctx, description, url, code, userID, onDuplicate
+func (_m *Service) CreateBookmark(ctx context.Context, description string, url string, code string, userID string, onDuplicate bookmark.OnDuplicate) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, description, url, code, userID, onDuplicate)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateBookmark")
@@ -26,19 +121,19 @@ func (_m *Service) CreateBookmark(ctx context.Context, description string, url s
 
 	var r0 *model.Bookmark
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.Bookmark, error)); ok {
-		return rf(ctx, description, url, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, bookmark.OnDuplicate) (*model.Bookmark, error)); ok {
+		return rf(ctx, description, url, code, userID, onDuplicate)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Bookmark); ok {
-		r0 = rf(ctx, description, url, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, bookmark.OnDuplicate) *model.Bookmark); ok {
+		r0 = rf(ctx, description, url, code, userID, onDuplicate)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Bookmark)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, description, url, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, bookmark.OnDuplicate) error); ok {
+		r1 = rf(ctx, description, url, code, userID, onDuplicate)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,17 +141,17 @@ func (_m *Service) CreateBookmark(ctx context.Context, description string, url s
 	return r0, r1
 }
 
-// DeleteBookmark provides a mock function with given fields: ctx, bookmarkID, userID
-func (_m *Service) DeleteBookmark(ctx context.Context, bookmarkID string, userID string) error {
-	ret := _m.Called(ctx, bookmarkID, userID)
+// DeleteBookmark provides a mock function with given fields: ctx, bookmarkID, userID, version
+func (_m *Service) DeleteBookmark(ctx context.Context, bookmarkID string, userID string, version int) error {
+	ret := _m.Called(ctx, bookmarkID, userID, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteBookmark")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, bookmarkID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -64,14 +159,191 @@ func (_m *Service) DeleteBookmark(ctx context.Context, bookmarkID string, userID
 	return r0
 }
 
-// GetBookmarks provides a mock function with given fields: ctx, userID, req
-func (_m *Service) GetBookmarks(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
-	ret := _m.Called(ctx, userID, req)
+// ExportBookmarks provides a mock function with given fields: ctx, userID, fn
+func (_m *Service) ExportBookmarks(ctx context.Context, userID string, fn func([]*model.Bookmark) error) error {
+	ret := _m.Called(ctx, userID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportBookmarks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func([]*model.Bookmark) error) error); ok {
+		r0 = rf(ctx, userID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBookmarkByID provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) GetBookmarkByID(ctx context.Context, bookmarkID string, userID string) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookmarkByID")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookmarks provides a mock function with given fields: ctx, userID, filter, req
+func (_m *Service) GetBookmarks(ctx context.Context, userID string, filter *model.BookmarkFilter, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+	ret := _m.Called(ctx, userID, filter, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBookmarks")
 	}
 
+	var r0 *pagination.Response[*model.Bookmark]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BookmarkFilter, *pagination.Request) (*pagination.Response[*model.Bookmark], error)); ok {
+		return rf(ctx, userID, filter, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.BookmarkFilter, *pagination.Request) *pagination.Response[*model.Bookmark]); ok {
+		r0 = rf(ctx, userID, filter, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Response[*model.Bookmark])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *model.BookmarkFilter, *pagination.Request) error); ok {
+		r1 = rf(ctx, userID, filter, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicates provides a mock function with given fields: ctx, userID
+func (_m *Service) GetDuplicates(ctx context.Context, userID string) ([]*bookmark.DuplicateGroup, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicates")
+	}
+
+	var r0 []*bookmark.DuplicateGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*bookmark.DuplicateGroup, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*bookmark.DuplicateGroup); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bookmark.DuplicateGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, bookmarkID, userID, req
+func (_m *Service) GetHistory(ctx context.Context, bookmarkID string, userID string, req *pagination.Request) (*pagination.Response[*model.BookmarkRevision], error) {
+	ret := _m.Called(ctx, bookmarkID, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 *pagination.Response[*model.BookmarkRevision]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *pagination.Request) (*pagination.Response[*model.BookmarkRevision], error)); ok {
+		return rf(ctx, bookmarkID, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *pagination.Request) *pagination.Response[*model.BookmarkRevision]); ok {
+		r0 = rf(ctx, bookmarkID, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Response[*model.BookmarkRevision])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *pagination.Request) error); ok {
+		r1 = rf(ctx, bookmarkID, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSnapshot provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) GetSnapshot(ctx context.Context, bookmarkID string, userID string) (*model.BookmarkSnapshot, io.ReadCloser, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshot")
+	}
+
+	var r0 *model.BookmarkSnapshot
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.BookmarkSnapshot, io.ReadCloser, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.BookmarkSnapshot); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) io.ReadCloser); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, bookmarkID, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTrash provides a mock function with given fields: ctx, userID, req
+func (_m *Service) GetTrash(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
 	var r0 *pagination.Response[*model.Bookmark]
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Request) (*pagination.Response[*model.Bookmark], error)); ok {
@@ -94,17 +366,361 @@ func (_m *Service) GetBookmarks(ctx context.Context, userID string, req *paginat
 	return r0, r1
 }
 
-// UpdateBookmark provides a mock function with given fields: ctx, bookmarkID, userID, description, url
-func (_m *Service) UpdateBookmark(ctx context.Context, bookmarkID string, userID string, description string, url string) error {
-	ret := _m.Called(ctx, bookmarkID, userID, description, url)
+// ImportBookmarks provides a mock function with given fields: ctx, userID, items, opts
+func (_m *Service) ImportBookmarks(ctx context.Context, userID string, items []netscape.Bookmark, opts bookmark.ImportOptions) (*bookmark.ImportResult, error) {
+	ret := _m.Called(ctx, userID, items, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportBookmarks")
+	}
+
+	var r0 *bookmark.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []netscape.Bookmark, bookmark.ImportOptions) (*bookmark.ImportResult, error)); ok {
+		return rf(ctx, userID, items, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []netscape.Bookmark, bookmark.ImportOptions) *bookmark.ImportResult); ok {
+		r0 = rf(ctx, userID, items, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bookmark.ImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []netscape.Bookmark, bookmark.ImportOptions) error); ok {
+		r1 = rf(ctx, userID, items, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkRead provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) MarkRead(ctx context.Context, bookmarkID string, userID string) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkUnread provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) MarkUnread(ctx context.Context, bookmarkID string, userID string) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUnread")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchBookmark provides a mock function with given fields: ctx, bookmarkID, userID, patch, version
+func (_m *Service) PatchBookmark(ctx context.Context, bookmarkID string, userID string, patch *model.BookmarkPatch, version int) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID, patch, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchBookmark")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.BookmarkPatch, int) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID, patch, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.BookmarkPatch, int) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID, patch, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.BookmarkPatch, int) error); ok {
+		r1 = rf(ctx, bookmarkID, userID, patch, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeSnapshots provides a mock function with given fields: ctx, batchSize
+func (_m *Service) PurgeSnapshots(ctx context.Context, batchSize int) (int, error) {
+	ret := _m.Called(ctx, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeSnapshots")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, batchSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, batchSize)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, retention
+func (_m *Service) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, error)); ok {
+		return rf(ctx, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateCode provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) RegenerateCode(ctx context.Context, bookmarkID string, userID string) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateCode")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenderNotes provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) RenderNotes(ctx context.Context, bookmarkID string, userID string) (*bookmark.RenderedNotes, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderNotes")
+	}
+
+	var r0 *bookmark.RenderedNotes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*bookmark.RenderedNotes, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *bookmark.RenderedNotes); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bookmark.RenderedNotes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestSnapshot provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) RequestSnapshot(ctx context.Context, bookmarkID string, userID string) (*model.BookmarkSnapshot, error) {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestSnapshot")
+	}
+
+	var r0 *model.BookmarkSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.BookmarkSnapshot, error)); ok {
+		return rf(ctx, bookmarkID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.BookmarkSnapshot); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreBookmark provides a mock function with given fields: ctx, bookmarkID, userID
+func (_m *Service) RestoreBookmark(ctx context.Context, bookmarkID string, userID string) error {
+	ret := _m.Called(ctx, bookmarkID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, bookmarkID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevertBookmark provides a mock function with given fields: ctx, bookmarkID, userID, revision
+func (_m *Service) RevertBookmark(ctx context.Context, bookmarkID string, userID string, revision int) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertBookmark")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, bookmarkID, userID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ToggleState provides a mock function with given fields: ctx, bookmarkID, userID, state
+func (_m *Service) ToggleState(ctx context.Context, bookmarkID string, userID string, state model.BookmarkState) (*model.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, userID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ToggleState")
+	}
+
+	var r0 *model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.BookmarkState) (*model.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, userID, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.BookmarkState) *model.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, userID, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, model.BookmarkState) error); ok {
+		r1 = rf(ctx, bookmarkID, userID, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBookmark provides a mock function with given fields: ctx, bookmarkID, userID, description, url, version
+func (_m *Service) UpdateBookmark(ctx context.Context, bookmarkID string, userID string, description string, url string, version int) error {
+	ret := _m.Called(ctx, bookmarkID, userID, description, url, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateBookmark")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, bookmarkID, userID, description, url)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int) error); ok {
+		r0 = rf(ctx, bookmarkID, userID, description, url, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -7,23 +7,24 @@ import (
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
 )
 
-// GetBookmarks retrieves a paginated list of bookmarks for the specified user.
+// GetBookmarks retrieves a paginated list of bookmarks for the specified user, narrowed down by filter.
 // It handles the calculation of offset/limit from the request, fetches data from the repository,
 // and constructs the final paginated response with metadata.
 //
 // Parameters:
 //   - ctx: Context for the operation
 //   - userID: The ID of the owner
+//   - filter: Optional filter, nil to list every bookmark
 //   - req: Pointer to Pagination request with Page and Limit
 //
 // Returns:
 //   - *pagination.Response: Standard paginated response wrapper
 //   - error: Database or internal error
-func (s *BookmarkSvc) GetBookmarks(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+func (s *BookmarkSvc) GetBookmarks(ctx context.Context, userID string, filter *model.BookmarkFilter, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
 	limit := req.GetLimit()
 	offset := req.GetOffset()
 
-	bookmarks, total, err := s.repo.GetBookmarks(ctx, userID, limit, offset)
+	bookmarks, total, err := s.repo.GetBookmarks(ctx, userID, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -35,3 +36,17 @@ func (s *BookmarkSvc) GetBookmarks(ctx context.Context, userID string, req *pagi
 		Metadata: meta,
 	}, nil
 }
+
+// GetBookmarkByID retrieves a single bookmark owned by the specified user.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to retrieve
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - *model.Bookmark: The matching bookmark
+//   - error: ErrNotFoundType if not found or not owned by the user, or a database error
+func (s *BookmarkSvc) GetBookmarkByID(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error) {
+	return s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+}
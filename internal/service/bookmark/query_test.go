@@ -7,6 +7,7 @@ import (
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,7 @@ func TestBookmarkSvc_GetBookmarks(t *testing.T) {
 	testLimit := 10
 	testOffset := 0
 	testTotal := int64(20)
+	testFilter := &model.BookmarkFilter{LinkStatus: model.LinkStatusBroken}
 
 	testCases := []struct {
 		name           string
@@ -36,7 +38,7 @@ func TestBookmarkSvc_GetBookmarks(t *testing.T) {
 				Limit: testLimit,
 			},
 			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
-				mockRepo.On("GetBookmarks", ctx, testUserID, testLimit, testOffset).
+				mockRepo.On("GetBookmarks", ctx, testUserID, testFilter, testLimit, testOffset).
 					Return([]*model.Bookmark{
 						{Base: model.Base{ID: "bm-1"}},
 						{Base: model.Base{ID: "bm-2"}},
@@ -64,7 +66,7 @@ func TestBookmarkSvc_GetBookmarks(t *testing.T) {
 				Limit: testLimit,
 			},
 			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
-				mockRepo.On("GetBookmarks", ctx, testUserID, testLimit, testOffset).
+				mockRepo.On("GetBookmarks", ctx, testUserID, testFilter, testLimit, testOffset).
 					Return(nil, int64(0), errors.New("db error"))
 			},
 			expectedErr: errors.New("db error"),
@@ -85,7 +87,7 @@ func TestBookmarkSvc_GetBookmarks(t *testing.T) {
 			svc := NewBookmarkSvc(mockRepo, mockCodeGen)
 
 			// Execute
-			got, err := svc.GetBookmarks(ctx, tc.inputUserID, tc.inputReq)
+			got, err := svc.GetBookmarks(ctx, tc.inputUserID, testFilter, tc.inputReq)
 
 			// Assert
 			if tc.expectedErr != nil {
@@ -100,3 +102,58 @@ func TestBookmarkSvc_GetBookmarks(t *testing.T) {
 		})
 	}
 }
+
+func TestBookmarkSvc_GetBookmarkByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, UserID: testUserID}, nil)
+			},
+			expectedOutput: &model.Bookmark{Base: model.Base{ID: testBookmarkID}, UserID: testUserID},
+		},
+		{
+			name: "Error - Repository Not Found",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			// Setup mocks
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			// Create service
+			svc := NewBookmarkSvc(mockRepo, nil)
+
+			// Execute
+			got, err := svc.GetBookmarkByID(ctx, testBookmarkID, testUserID)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
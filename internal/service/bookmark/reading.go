@@ -0,0 +1,86 @@
+package bookmark
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/markdown"
+)
+
+// wordsPerMinute is the reading speed used to estimate how long a page takes to read.
+const wordsPerMinute = 200
+
+// RenderedNotes holds the notes of a bookmark in both their source and rendered form.
+type RenderedNotes struct {
+	// Markdown is the notes as written by the user
+	Markdown string `json:"markdown"`
+	// HTML is the sanitized rendering of the notes
+	HTML string `json:"html"`
+}
+
+// MarkRead marks a bookmark as read. A bookmark that already is read keeps its original read time.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - *model.Bookmark: The bookmark with its read time
+//   - error: ErrNotFoundType if not found or not owned by the user, or a database error
+func (s *BookmarkSvc) MarkRead(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error) {
+	now := time.Now().UTC()
+	return s.setReadAt(ctx, bookmarkID, userID, &now)
+}
+
+// MarkUnread puts a bookmark back on the reading list by clearing its read time.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - *model.Bookmark: The unread bookmark
+//   - error: ErrNotFoundType if not found or not owned by the user, or a database error
+func (s *BookmarkSvc) MarkUnread(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error) {
+	return s.setReadAt(ctx, bookmarkID, userID, nil)
+}
+
+// setReadAt stores the read time of a bookmark and reloads it.
+func (s *BookmarkSvc) setReadAt(ctx context.Context, bookmarkID, userID string, readAt *time.Time) (*model.Bookmark, error) {
+	if err := s.repo.SetReadAt(ctx, bookmarkID, userID, readAt); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+}
+
+// RenderNotes renders the Markdown notes of a bookmark to sanitized HTML.
+// Anyone who may read the bookmark may read its notes.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//   - userID: The ID of the user requesting the notes (for permission validation)
+//
+// Returns:
+//   - *RenderedNotes: The notes and their HTML rendering, both empty if the bookmark has no notes
+//   - error: ErrNotFoundType if the bookmark doesn't exist or user may not read it, or a database error
+func (s *BookmarkSvc) RenderNotes(ctx context.Context, bookmarkID, userID string) (*RenderedNotes, error) {
+	bookmark, err := s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenderedNotes{
+		Markdown: bookmark.Notes,
+		HTML:     markdown.ToHTML(bookmark.Notes),
+	}, nil
+}
+
+// readingTime estimates the minutes needed to read words, rounded up. It is 0 when there is nothing to read.
+func readingTime(words int) int {
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
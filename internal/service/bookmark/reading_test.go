@@ -0,0 +1,168 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkSvc_MarkRead(t *testing.T) {
+	t.Parallel()
+
+	readAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		markRead       bool
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name:     "Success - mark read",
+			markRead: true,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("SetReadAt", ctx, testBookmarkID, testUserID, mock.MatchedBy(func(t *time.Time) bool {
+					return t != nil && time.Since(*t) < time.Minute
+				})).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{ReadAt: &readAt}, nil)
+			},
+			expectedOutput: &model.Bookmark{ReadAt: &readAt},
+		},
+		{
+			name:     "Success - mark unread",
+			markRead: false,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("SetReadAt", ctx, testBookmarkID, testUserID, (*time.Time)(nil)).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+			},
+			expectedOutput: &model.Bookmark{},
+		},
+		{
+			name:     "Error - Not Found",
+			markRead: true,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("SetReadAt", ctx, testBookmarkID, testUserID, mock.Anything).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			var got *model.Bookmark
+			var err error
+			if tc.markRead {
+				got, err = svc.MarkRead(ctx, testBookmarkID, testUserID)
+			} else {
+				got, err = svc.MarkUnread(ctx, testBookmarkID, testUserID)
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestBookmarkSvc_RenderNotes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *RenderedNotes
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(&model.Bookmark{Notes: "Read **twice**<script>x</script>"}, nil)
+			},
+			expectedOutput: &RenderedNotes{
+				Markdown: "Read **twice**<script>x</script>",
+				HTML:     "<p>Read <strong>twice</strong>x</p>",
+			},
+		},
+		{
+			name: "Success - no notes",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+			},
+			expectedOutput: &RenderedNotes{},
+		},
+		{
+			name: "Error - Database Error",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(nil, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			got, err := svc.RenderNotes(ctx, testBookmarkID, testUserID)
+
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{name: "no words", input: 0, expected: 0},
+		{name: "a few words", input: 15, expected: 1},
+		{name: "exactly one minute", input: 200, expected: 1},
+		{name: "rounded up", input: 201, expected: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, readingTime(tc.input))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+)
+
+// ErrRevisionNotFound is returned when reverting a bookmark to a revision it does not have.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// GetHistory retrieves a paginated list of the revisions of a bookmark, most recent first.
+// Anyone who may read the bookmark may read its history.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//   - userID: The ID of the user requesting the history (for permission validation)
+//   - req: Pointer to Pagination request with Page and Limit
+//
+// Returns:
+//   - *pagination.Response: Standard paginated response wrapper
+//   - error: ErrNotFoundType if the bookmark doesn't exist or user may not read it, or a database error
+func (s *BookmarkSvc) GetHistory(ctx context.Context, bookmarkID, userID string, req *pagination.Request) (*pagination.Response[*model.BookmarkRevision], error) {
+	if _, err := s.repo.GetBookmarkByID(ctx, bookmarkID, userID); err != nil {
+		return nil, err
+	}
+
+	limit := req.GetLimit()
+	offset := req.GetOffset()
+
+	revisions, total, err := s.repo.GetRevisions(ctx, bookmarkID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := pagination.CalculateMetadata(total, req.Page, limit)
+
+	return &pagination.Response[*model.BookmarkRevision]{
+		Data:     revisions,
+		Metadata: meta,
+	}, nil
+}
+
+// RevertBookmark restores the description and URL a bookmark had before the given revision.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to revert
+//   - userID: The ID of the user reverting the bookmark (for permission validation)
+//   - revision: The revision to restore
+//
+// Returns:
+//   - *model.Bookmark: The bookmark after the revert
+//   - error: ErrNotFoundType if the bookmark doesn't exist or user may not edit it,
+//     ErrRevisionNotFound if the bookmark has no such revision, or a database error
+func (s *BookmarkSvc) RevertBookmark(ctx context.Context, bookmarkID, userID string, revision int) (*model.Bookmark, error) {
+	if _, err := s.repo.GetBookmarkByID(ctx, bookmarkID, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetRevision(ctx, bookmarkID, revision); err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.repo.RevertBookmark(ctx, bookmarkID, userID, revision); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+}
@@ -0,0 +1,149 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkSvc_GetHistory(t *testing.T) {
+	t.Parallel()
+
+	revisions := []*model.BookmarkRevision{
+		{BookmarkID: testBookmarkID, Revision: 2, URL: testBookmarkURL},
+		{BookmarkID: testBookmarkID, Revision: 1, URL: testBookmarkURL},
+	}
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *pagination.Response[*model.BookmarkRevision]
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+				mockRepo.On("GetRevisions", ctx, testBookmarkID, 10, 0).Return(revisions, int64(2), nil)
+			},
+			expectedOutput: &pagination.Response[*model.BookmarkRevision]{
+				Data:     revisions,
+				Metadata: pagination.CalculateMetadata(2, 1, 10),
+			},
+		},
+		{
+			name: "Error - Bookmark Not Found",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - Database Error",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+				mockRepo.On("GetRevisions", ctx, testBookmarkID, 10, 0).Return(nil, int64(0), errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			got, err := svc.GetHistory(ctx, testBookmarkID, testUserID, &pagination.Request{Page: 1})
+
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestBookmarkSvc_RevertBookmark(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{URL: "https://new.example.com"}, nil).Once()
+				mockRepo.On("GetRevision", ctx, testBookmarkID, 1).Return(&model.BookmarkRevision{URL: testBookmarkURL}, nil)
+				mockRepo.On("RevertBookmark", ctx, testBookmarkID, testUserID, 1).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{URL: testBookmarkURL}, nil).Once()
+			},
+			expectedOutput: &model.Bookmark{URL: testBookmarkURL},
+		},
+		{
+			name: "Error - Bookmark Not Found",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - Revision Not Found",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+				mockRepo.On("GetRevision", ctx, testBookmarkID, 1).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrRevisionNotFound,
+		},
+		{
+			name: "Error - Revert Failed",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+				mockRepo.On("GetRevision", ctx, testBookmarkID, 1).Return(&model.BookmarkRevision{}, nil)
+				mockRepo.On("RevertBookmark", ctx, testBookmarkID, testUserID, 1).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			got, err := svc.RevertBookmark(ctx, testBookmarkID, testUserID, 1)
+
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
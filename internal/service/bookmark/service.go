@@ -2,26 +2,116 @@ package bookmark
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/blobstore"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netscape"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
 )
 
 //go:generate mockery --name Service --filename service.go
 type Service interface {
-	CreateBookmark(ctx context.Context, description, url, userID string) (*model.Bookmark, error)
-	GetBookmarks(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error)
-	UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string) error
-	DeleteBookmark(ctx context.Context, bookmarkID, userID string) error
+	CreateBookmark(ctx context.Context, description, url, code, userID string, onDuplicate OnDuplicate) (*model.Bookmark, error)
+	GetBookmarks(ctx context.Context, userID string, filter *model.BookmarkFilter, req *pagination.Request) (*pagination.Response[*model.Bookmark], error)
+	GetDuplicates(ctx context.Context, userID string) ([]*DuplicateGroup, error)
+	GetBookmarkByID(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error)
+	UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string, version int) error
+	PatchBookmark(ctx context.Context, bookmarkID, userID string, patch *model.BookmarkPatch, version int) (*model.Bookmark, error)
+	DeleteBookmark(ctx context.Context, bookmarkID, userID string, version int) error
+	GetTrash(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error)
+	RestoreBookmark(ctx context.Context, bookmarkID, userID string) error
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	ImportBookmarks(ctx context.Context, userID string, items []netscape.Bookmark, opts ImportOptions) (*ImportResult, error)
+	ExportBookmarks(ctx context.Context, userID string, fn func([]*model.Bookmark) error) error
+	CheckLinks(ctx context.Context, staleAfter time.Duration, batchSize int) (int, error)
+	AcceptRedirect(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error)
+	ToggleState(ctx context.Context, bookmarkID, userID string, state model.BookmarkState) (*model.Bookmark, error)
+	RegenerateCode(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error)
+	BulkUpdate(ctx context.Context, userID string, op *model.BulkOperation) (int64, error)
+	GetHistory(ctx context.Context, bookmarkID, userID string, req *pagination.Request) (*pagination.Response[*model.BookmarkRevision], error)
+	RevertBookmark(ctx context.Context, bookmarkID, userID string, revision int) (*model.Bookmark, error)
+	MarkRead(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error)
+	MarkUnread(ctx context.Context, bookmarkID, userID string) (*model.Bookmark, error)
+	RenderNotes(ctx context.Context, bookmarkID, userID string) (*RenderedNotes, error)
+	RequestSnapshot(ctx context.Context, bookmarkID, userID string) (*model.BookmarkSnapshot, error)
+	GetSnapshot(ctx context.Context, bookmarkID, userID string) (*model.BookmarkSnapshot, io.ReadCloser, error)
+	PurgeSnapshots(ctx context.Context, batchSize int) (int, error)
 }
 
 type BookmarkSvc struct {
-	repo    bookmark.Repository
-	codeGen stringutils.KeyGenerator
+	repo       bookmark.Repository
+	codeGen    stringutils.KeyGenerator
+	codeLength int
+	shortLinks repository.UrlStorage
+	enrichment EnrichmentQueue
+	links      LinkChecker
+	quotas     quota.Service
+
+	snapshots     SnapshotQueue
+	snapshotStore blobstore.Store
+}
+
+// Option configures optional collaborators of the bookmark service.
+type Option func(*BookmarkSvc)
+
+// WithCodeLength sets the length of generated bookmark codes. Non-positive lengths keep the default.
+func WithCodeLength(length int) Option {
+	return func(s *BookmarkSvc) {
+		if length > 0 {
+			s.codeLength = length
+		}
+	}
+}
+
+// WithShortLinks makes the service refuse bookmark codes already used by a short link in storage,
+// since both are resolved by the same redirect endpoint.
+func WithShortLinks(storage repository.UrlStorage) Option {
+	return func(s *BookmarkSvc) {
+		s.shortLinks = storage
+	}
+}
+
+// WithEnrichmentQueue makes the service queue new bookmarks without a description
+// so that their page metadata is fetched in the background.
+func WithEnrichmentQueue(queue EnrichmentQueue) Option {
+	return func(s *BookmarkSvc) {
+		s.enrichment = queue
+	}
+}
+
+// WithLinkChecker enables CheckLinks, which verifies bookmark URLs with checker.
+func WithLinkChecker(checker LinkChecker) Option {
+	return func(s *BookmarkSvc) {
+		s.links = checker
+	}
+}
+
+// WithQuotas limits the number of bookmarks and the snapshot storage of each user.
+// Without it, both are unlimited.
+func WithQuotas(quotas quota.Service) Option {
+	return func(s *BookmarkSvc) {
+		s.quotas = quotas
+	}
+}
+
+// WithSnapshots enables offline snapshots: captures are queued on queue and read back from store.
+func WithSnapshots(queue SnapshotQueue, store blobstore.Store) Option {
+	return func(s *BookmarkSvc) {
+		s.snapshots = queue
+		s.snapshotStore = store
+	}
 }
 
-func NewBookmarkSvc(repo bookmark.Repository, codeGen stringutils.KeyGenerator) Service {
-	return &BookmarkSvc{repo: repo, codeGen: codeGen}
+func NewBookmarkSvc(repo bookmark.Repository, codeGen stringutils.KeyGenerator, opts ...Option) Service {
+	s := &BookmarkSvc{repo: repo, codeGen: codeGen, codeLength: defaultCodeLength}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
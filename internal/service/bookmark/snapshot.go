@@ -0,0 +1,303 @@
+package bookmark
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/blobstore"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/snapshot"
+	"github.com/rs/zerolog/log"
+)
+
+// maxSnapshotErrorLen matches the size of the error column of the bookmark_snapshots table.
+const maxSnapshotErrorLen = 255
+
+var (
+	// ErrSnapshotsDisabled is returned when the server was started without snapshot support.
+	ErrSnapshotsDisabled = errors.New("snapshots are disabled")
+	// ErrSnapshotQuotaExceeded is returned when the user's snapshots use up their storage quota.
+	ErrSnapshotQuotaExceeded = errors.New("snapshot storage quota exceeded")
+	// ErrSnapshotQueueFull is returned when too many snapshots are waiting for a worker.
+	ErrSnapshotQueueFull = errors.New("snapshot queue is full")
+	// ErrSnapshotNotFound is returned when no snapshot was ever requested for the bookmark.
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// SnapshotQueue accepts bookmarks whose page should be archived in the background.
+type SnapshotQueue interface {
+	// Enqueue schedules the capture of url for the bookmark. It never blocks
+	// and reports false when the bookmark was dropped because the queue is full.
+	Enqueue(bookmarkID, userID, url string) bool
+}
+
+// snapshotJob identifies a bookmark waiting for its page to be archived.
+type snapshotJob struct {
+	bookmarkID string
+	userID     string
+	url        string
+}
+
+// Snapshotter is a background worker pool that captures the pages of bookmarks and
+// stores them in the blob store. It implements SnapshotQueue for the service and
+// scheduler.Job so that it is started together with the server.
+//
+// Like the Enricher, the queue lives in memory: jobs still queued when the process
+// stops are lost and their snapshots stay pending until they are requested again.
+type Snapshotter struct {
+	repo     bookmark.Repository
+	capturer snapshot.Capturer
+	store    blobstore.Store
+	quotas   quota.Service
+	jobs     chan snapshotJob
+	workers  int
+}
+
+// NewSnapshotter creates a Snapshotter.
+//
+// Parameters:
+//   - repo: Repository the snapshot states are written to
+//   - capturer: Capturer used to download the pages
+//   - store: Blob store the snapshots are written to
+//   - quotas: Service providing the snapshot storage available to each user, nil for unlimited storage
+//   - workers: Number of pages captured concurrently
+//   - queueSize: Number of bookmarks that can wait for a worker before new ones are refused
+func NewSnapshotter(repo bookmark.Repository, capturer snapshot.Capturer, store blobstore.Store, quotas quota.Service, workers, queueSize int) *Snapshotter {
+	return &Snapshotter{
+		repo:     repo,
+		capturer: capturer,
+		store:    store,
+		quotas:   quotas,
+		jobs:     make(chan snapshotJob, queueSize),
+		workers:  workers,
+	}
+}
+
+// Enqueue implements SnapshotQueue.
+func (s *Snapshotter) Enqueue(bookmarkID, userID, url string) bool {
+	select {
+	case s.jobs <- snapshotJob{bookmarkID: bookmarkID, userID: userID, url: url}:
+		return true
+	default:
+		log.Warn().Str("bookmark_id", bookmarkID).Msg("Snapshot queue full, bookmark dropped")
+		return false
+	}
+}
+
+// Start runs the workers and blocks until ctx is cancelled.
+func (s *Snapshotter) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.jobs:
+					if err := s.capture(ctx, job); err != nil {
+						log.Warn().Err(err).Str("bookmark_id", job.bookmarkID).Msg("Failed to capture snapshot")
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// capture archives the page of a single bookmark. Whatever happens, the snapshot leaves
+// the pending state: it is either ready with the new copy or failed with the reason,
+// in which case the previous copy, if any, is kept.
+//
+// The quota is checked against the size of the captured page, replacing the previous copy.
+// Captures of the same user running concurrently may exceed it by the size of one page.
+func (s *Snapshotter) capture(ctx context.Context, job snapshotJob) error {
+	page, err := s.capturer.Capture(ctx, job.url)
+	if err != nil {
+		return s.fail(ctx, job, err.Error(), err)
+	}
+
+	limit, err := snapshotLimit(ctx, s.quotas, job.userID)
+	if err != nil {
+		return s.fail(ctx, job, "storage error", err)
+	}
+	if limit > 0 {
+		current, err := s.repo.GetSnapshot(ctx, job.bookmarkID)
+		if err != nil {
+			return s.fail(ctx, job, "storage error", err)
+		}
+		usage, err := s.repo.GetSnapshotUsage(ctx, job.userID)
+		if err != nil {
+			return s.fail(ctx, job, "storage error", err)
+		}
+		if usage-current.Size+int64(len(page)) > limit {
+			return s.fail(ctx, job, ErrSnapshotQuotaExceeded.Error(), ErrSnapshotQuotaExceeded)
+		}
+	}
+
+	size, err := s.store.Put(ctx, model.SnapshotKey(job.bookmarkID), bytes.NewReader(page))
+	if err != nil {
+		return s.fail(ctx, job, "storage error", err)
+	}
+
+	return s.repo.CompleteSnapshot(ctx, job.bookmarkID, size, time.Now().UTC())
+}
+
+// snapshotLimit returns the snapshot storage available to a user in bytes, 0 if unlimited.
+func snapshotLimit(ctx context.Context, quotas quota.Service, userID string) (int64, error) {
+	if quotas == nil {
+		return 0, nil
+	}
+	limits, err := quotas.GetLimits(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return limits.SnapshotBytes, nil
+}
+
+// fail records reason as the outcome of the capture and returns cause.
+func (s *Snapshotter) fail(ctx context.Context, job snapshotJob, reason string, cause error) error {
+	if err := s.repo.FailSnapshot(ctx, job.bookmarkID, truncate(reason, maxSnapshotErrorLen)); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+// RequestSnapshot queues the capture of the page a bookmark points to.
+// Only the owner may archive a bookmark, and only while their snapshots fit in their quota.
+// A bookmark that already has a snapshot keeps serving it until the new capture succeeds.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to archive
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - *model.BookmarkSnapshot: The pending snapshot
+//   - error: ErrSnapshotsDisabled, ErrSnapshotQuotaExceeded, ErrSnapshotQueueFull,
+//     ErrNotFoundType if not found or not owned by the user, or a database error
+func (s *BookmarkSvc) RequestSnapshot(ctx context.Context, bookmarkID, userID string) (*model.BookmarkSnapshot, error) {
+	if s.snapshots == nil {
+		return nil, ErrSnapshotsDisabled
+	}
+
+	limit, err := snapshotLimit(ctx, s.quotas, userID)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 {
+		usage, err := s.repo.GetSnapshotUsage(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if usage >= limit {
+			return nil, ErrSnapshotQuotaExceeded
+		}
+	}
+
+	pending, err := s.repo.RequestSnapshot(ctx, bookmarkID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.snapshots.Enqueue(bookmarkID, userID, bookmark.URL) {
+		if err := s.repo.FailSnapshot(ctx, bookmarkID, ErrSnapshotQueueFull.Error()); err != nil {
+			return nil, err
+		}
+		return nil, ErrSnapshotQueueFull
+	}
+
+	return pending, nil
+}
+
+// GetSnapshot retrieves the snapshot of a bookmark together with its stored copy.
+// Anyone who may read the bookmark may read its snapshot.
+//
+// The returned reader is nil while no capture has succeeded yet: the snapshot
+// then tells whether one is still pending or why it failed. Otherwise the caller
+// must close it.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark
+//   - userID: The ID of the user requesting the snapshot (for permission validation)
+//
+// Returns:
+//   - *model.BookmarkSnapshot: The snapshot state
+//   - io.ReadCloser: The stored HTML, or nil if there is none yet
+//   - error: ErrSnapshotsDisabled, ErrSnapshotNotFound, ErrNotFoundType if the bookmark
+//     doesn't exist or the user may not read it, or a database or storage error
+func (s *BookmarkSvc) GetSnapshot(ctx context.Context, bookmarkID, userID string) (*model.BookmarkSnapshot, io.ReadCloser, error) {
+	if s.snapshots == nil {
+		return nil, nil, ErrSnapshotsDisabled
+	}
+
+	if _, err := s.repo.GetBookmarkByID(ctx, bookmarkID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	state, err := s.repo.GetSnapshot(ctx, bookmarkID)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			return nil, nil, ErrSnapshotNotFound
+		}
+		return nil, nil, err
+	}
+
+	if state.CapturedAt == nil {
+		return state, nil, nil
+	}
+
+	content, err := s.snapshotStore.Open(ctx, model.SnapshotKey(bookmarkID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return state, content, nil
+}
+
+// PurgeSnapshots deletes the snapshots left behind by bookmarks that no longer exist,
+// blob first so that a failure leaves the row to retry with on the next run.
+// It is intended to be called periodically by the snapshot cleanup job.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - batchSize: Maximum number of snapshots deleted in one run
+//
+// Returns:
+//   - int: Number of snapshots deleted
+//   - error: Database or storage error, if any
+func (s *BookmarkSvc) PurgeSnapshots(ctx context.Context, batchSize int) (int, error) {
+	if s.snapshots == nil {
+		return 0, nil
+	}
+
+	orphans, err := s.repo.GetOrphanedSnapshots(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, orphan := range orphans {
+		if err := s.snapshotStore.Delete(ctx, model.SnapshotKey(orphan.BookmarkID)); err != nil {
+			return i, err
+		}
+		if err := s.repo.DeleteSnapshot(ctx, orphan.BookmarkID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(orphans), nil
+}
@@ -0,0 +1,412 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	quotaMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/quota/mocks"
+	blobMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/blobstore/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	snapshotMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/snapshot/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSnapshotQuota = 1000
+
+// newSnapshotQuotas returns a quota service giving every user limit bytes of snapshot storage.
+func newSnapshotQuotas(t *testing.T, limit int64) *quotaMocks.Service {
+	quotas := quotaMocks.NewService(t)
+	quotas.On("GetLimits", mock.Anything, testUserID).Return(&model.QuotaLimits{SnapshotBytes: limit}, nil).Maybe()
+	return quotas
+}
+
+// fakeSnapshotQueue records the bookmarks queued by the service, or refuses them when full.
+type fakeSnapshotQueue struct {
+	mu   sync.Mutex
+	full bool
+	jobs []snapshotJob
+}
+
+func (q *fakeSnapshotQueue) Enqueue(bookmarkID, userID, url string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.full {
+		return false
+	}
+	q.jobs = append(q.jobs, snapshotJob{bookmarkID: bookmarkID, userID: userID, url: url})
+	return true
+}
+
+func TestBookmarkSvc_RequestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	pending := &model.BookmarkSnapshot{BookmarkID: testBookmarkID, UserID: testUserID, Status: model.SnapshotPending}
+
+	testCases := []struct {
+		name           string
+		disabled       bool
+		queueFull      bool
+		quota          int64
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.BookmarkSnapshot
+		expectedJobs   []snapshotJob
+	}{
+		{
+			name:  "Success",
+			quota: testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(testSnapshotQuota-1), nil)
+				mockRepo.On("RequestSnapshot", ctx, testBookmarkID, testUserID).Return(pending, nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{URL: testBookmarkURL}, nil)
+			},
+			expectedOutput: pending,
+			expectedJobs:   []snapshotJob{{bookmarkID: testBookmarkID, userID: testUserID, url: testBookmarkURL}},
+		},
+		{
+			name:  "Success - unlimited storage",
+			quota: 0,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("RequestSnapshot", ctx, testBookmarkID, testUserID).Return(pending, nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{URL: testBookmarkURL}, nil)
+			},
+			expectedOutput: pending,
+			expectedJobs:   []snapshotJob{{bookmarkID: testBookmarkID, userID: testUserID, url: testBookmarkURL}},
+		},
+		{
+			name:        "Error - snapshots disabled",
+			disabled:    true,
+			setupMock:   func(mockRepo *repoMocks.Repository, ctx context.Context) {},
+			expectedErr: ErrSnapshotsDisabled,
+		},
+		{
+			name:  "Error - quota used up",
+			quota: testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(testSnapshotQuota), nil)
+			},
+			expectedErr: ErrSnapshotQuotaExceeded,
+		},
+		{
+			name:  "Error - Not Found",
+			quota: testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(0), nil)
+				mockRepo.On("RequestSnapshot", ctx, testBookmarkID, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name:      "Error - queue full",
+			queueFull: true,
+			quota:     testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(0), nil)
+				mockRepo.On("RequestSnapshot", ctx, testBookmarkID, testUserID).Return(pending, nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{URL: testBookmarkURL}, nil)
+				mockRepo.On("FailSnapshot", ctx, testBookmarkID, ErrSnapshotQueueFull.Error()).Return(nil)
+			},
+			expectedErr: ErrSnapshotQueueFull,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			queue := &fakeSnapshotQueue{full: tc.queueFull}
+			opts := []Option{WithQuotas(newSnapshotQuotas(t, tc.quota))}
+			if !tc.disabled {
+				opts = append(opts, WithSnapshots(queue, blobMocks.NewStore(t)))
+			}
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t), opts...)
+
+			got, err := svc.RequestSnapshot(ctx, testBookmarkID, testUserID)
+
+			assert.Equal(t, tc.expectedJobs, queue.jobs)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestBookmarkSvc_GetSnapshot(t *testing.T) {
+	t.Parallel()
+
+	capturedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ready := &model.BookmarkSnapshot{BookmarkID: testBookmarkID, Status: model.SnapshotReady, Size: 4, CapturedAt: &capturedAt}
+	pending := &model.BookmarkSnapshot{BookmarkID: testBookmarkID, Status: model.SnapshotPending}
+
+	testCases := []struct {
+		name            string
+		disabled        bool
+		setupMock       func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context)
+		expectedErr     error
+		expectedOutput  *model.BookmarkSnapshot
+		expectedContent string
+	}{
+		{
+			name: "Success - stored copy",
+			setupMock: func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+				mockRepo.On("GetSnapshot", ctx, testBookmarkID).Return(ready, nil)
+				mockStore.On("Open", ctx, model.SnapshotKey(testBookmarkID)).Return(io.NopCloser(strings.NewReader("html")), nil)
+			},
+			expectedOutput:  ready,
+			expectedContent: "html",
+		},
+		{
+			name: "Success - first capture pending",
+			setupMock: func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+				mockRepo.On("GetSnapshot", ctx, testBookmarkID).Return(pending, nil)
+			},
+			expectedOutput: pending,
+		},
+		{
+			name:        "Error - snapshots disabled",
+			disabled:    true,
+			setupMock:   func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {},
+			expectedErr: ErrSnapshotsDisabled,
+		},
+		{
+			name: "Error - bookmark not found",
+			setupMock: func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - never requested",
+			setupMock: func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{}, nil)
+				mockRepo.On("GetSnapshot", ctx, testBookmarkID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrSnapshotNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockStore := blobMocks.NewStore(t)
+			tc.setupMock(mockRepo, mockStore, ctx)
+
+			var opts []Option
+			if !tc.disabled {
+				opts = append(opts, WithSnapshots(&fakeSnapshotQueue{}, mockStore))
+			}
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t), opts...)
+
+			got, content, err := svc.GetSnapshot(ctx, testBookmarkID, testUserID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, got)
+				assert.Nil(t, content)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+			if tc.expectedContent == "" {
+				assert.Nil(t, content)
+				return
+			}
+			data, err := io.ReadAll(content)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedContent, string(data))
+		})
+	}
+}
+
+func TestBookmarkSvc_PurgeSnapshots(t *testing.T) {
+	t.Parallel()
+
+	orphans := []*model.BookmarkSnapshot{{BookmarkID: "orphan-1"}, {BookmarkID: "orphan-2"}}
+	errStore := errors.New("disk full")
+
+	testCases := []struct {
+		name          string
+		disabled      bool
+		setupMock     func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context)
+		expectedErr   error
+		expectedCount int
+	}{
+		{
+			name: "Success - blobs and rows deleted",
+			setupMock: func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {
+				mockRepo.On("GetOrphanedSnapshots", ctx, 10).Return(orphans, nil)
+				for _, orphan := range orphans {
+					mockStore.On("Delete", ctx, model.SnapshotKey(orphan.BookmarkID)).Return(nil).Once()
+					mockRepo.On("DeleteSnapshot", ctx, orphan.BookmarkID).Return(nil).Once()
+				}
+			},
+			expectedCount: 2,
+		},
+		{
+			name:          "Success - snapshots disabled",
+			disabled:      true,
+			setupMock:     func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {},
+			expectedCount: 0,
+		},
+		{
+			name: "Error - row kept when the blob cannot be deleted",
+			setupMock: func(mockRepo *repoMocks.Repository, mockStore *blobMocks.Store, ctx context.Context) {
+				mockRepo.On("GetOrphanedSnapshots", ctx, 10).Return(orphans, nil)
+				mockStore.On("Delete", ctx, model.SnapshotKey("orphan-1")).Return(nil).Once()
+				mockRepo.On("DeleteSnapshot", ctx, "orphan-1").Return(nil).Once()
+				mockStore.On("Delete", ctx, model.SnapshotKey("orphan-2")).Return(errStore).Once()
+			},
+			expectedErr:   errStore,
+			expectedCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockStore := blobMocks.NewStore(t)
+			tc.setupMock(mockRepo, mockStore, ctx)
+
+			var opts []Option
+			if !tc.disabled {
+				opts = append(opts, WithSnapshots(&fakeSnapshotQueue{}, mockStore))
+			}
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t), opts...)
+
+			count, err := svc.PurgeSnapshots(ctx, 10)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestSnapshotter_capture(t *testing.T) {
+	t.Parallel()
+
+	page := []byte("<html>snapshot</html>")
+	errCapture := errors.New("snapshot: unexpected status 404")
+	errStore := errors.New("disk full")
+
+	testCases := []struct {
+		name        string
+		quota       int64
+		setupMock   func(mockRepo *repoMocks.Repository, mockCapturer *snapshotMocks.Capturer, mockStore *blobMocks.Store, ctx context.Context)
+		expectedErr error
+	}{
+		{
+			name:  "success - stored and marked ready",
+			quota: testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCapturer *snapshotMocks.Capturer, mockStore *blobMocks.Store, ctx context.Context) {
+				mockCapturer.On("Capture", ctx, testBookmarkURL).Return(page, nil)
+				mockRepo.On("GetSnapshot", ctx, testBookmarkID).Return(&model.BookmarkSnapshot{Size: 500}, nil)
+				// 900 used, of which 500 by the replaced copy: 400 + 21 fits in the quota
+				mockRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(900), nil)
+				mockStore.On("Put", ctx, model.SnapshotKey(testBookmarkID), mock.Anything).Return(int64(len(page)), nil)
+				mockRepo.On("CompleteSnapshot", ctx, testBookmarkID, int64(len(page)), mock.MatchedBy(func(t time.Time) bool {
+					return time.Since(t) < time.Minute
+				})).Return(nil)
+			},
+		},
+		{
+			name:  "success - unlimited storage",
+			quota: 0,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCapturer *snapshotMocks.Capturer, mockStore *blobMocks.Store, ctx context.Context) {
+				mockCapturer.On("Capture", ctx, testBookmarkURL).Return(page, nil)
+				mockStore.On("Put", ctx, model.SnapshotKey(testBookmarkID), mock.Anything).Return(int64(len(page)), nil)
+				mockRepo.On("CompleteSnapshot", ctx, testBookmarkID, int64(len(page)), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "error - capture failed",
+			setupMock: func(mockRepo *repoMocks.Repository, mockCapturer *snapshotMocks.Capturer, mockStore *blobMocks.Store, ctx context.Context) {
+				mockCapturer.On("Capture", ctx, testBookmarkURL).Return(nil, errCapture)
+				mockRepo.On("FailSnapshot", ctx, testBookmarkID, errCapture.Error()).Return(nil)
+			},
+			expectedErr: errCapture,
+		},
+		{
+			name:  "error - quota exceeded",
+			quota: testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCapturer *snapshotMocks.Capturer, mockStore *blobMocks.Store, ctx context.Context) {
+				mockCapturer.On("Capture", ctx, testBookmarkURL).Return(page, nil)
+				mockRepo.On("GetSnapshot", ctx, testBookmarkID).Return(&model.BookmarkSnapshot{}, nil)
+				mockRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(990), nil)
+				mockRepo.On("FailSnapshot", ctx, testBookmarkID, ErrSnapshotQuotaExceeded.Error()).Return(nil)
+			},
+			expectedErr: ErrSnapshotQuotaExceeded,
+		},
+		{
+			name:  "error - current snapshot not read",
+			quota: testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCapturer *snapshotMocks.Capturer, mockStore *blobMocks.Store, ctx context.Context) {
+				mockCapturer.On("Capture", ctx, testBookmarkURL).Return(page, nil)
+				mockRepo.On("GetSnapshot", ctx, testBookmarkID).Return(nil, errStore)
+				mockRepo.On("FailSnapshot", ctx, testBookmarkID, "storage error").Return(nil)
+			},
+			expectedErr: errStore,
+		},
+		{
+			name:  "error - storage failed",
+			quota: testSnapshotQuota,
+			setupMock: func(mockRepo *repoMocks.Repository, mockCapturer *snapshotMocks.Capturer, mockStore *blobMocks.Store, ctx context.Context) {
+				mockCapturer.On("Capture", ctx, testBookmarkURL).Return(page, nil)
+				mockRepo.On("GetSnapshot", ctx, testBookmarkID).Return(&model.BookmarkSnapshot{}, nil)
+				mockRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(0), nil)
+				mockStore.On("Put", ctx, model.SnapshotKey(testBookmarkID), mock.Anything).Return(int64(0), errStore)
+				mockRepo.On("FailSnapshot", ctx, testBookmarkID, "storage error").Return(nil)
+			},
+			expectedErr: errStore,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockCapturer := snapshotMocks.NewCapturer(t)
+			mockStore := blobMocks.NewStore(t)
+			tc.setupMock(mockRepo, mockCapturer, mockStore, ctx)
+
+			snapshotter := NewSnapshotter(mockRepo, mockCapturer, mockStore, newSnapshotQuotas(t, tc.quota), 1, 1)
+			err := snapshotter.capture(ctx, snapshotJob{bookmarkID: testBookmarkID, userID: testUserID, url: testBookmarkURL})
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
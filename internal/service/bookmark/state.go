@@ -0,0 +1,26 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+)
+
+// ToggleState flips the favorite, pinned or archived state of a bookmark.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the owner
+//   - state: The state to flip
+//
+// Returns:
+//   - *model.Bookmark: The bookmark with its new state
+//   - error: ErrNotFoundType if not found or not owned by the user, or a database error
+func (s *BookmarkSvc) ToggleState(ctx context.Context, bookmarkID, userID string, state model.BookmarkState) (*model.Bookmark, error) {
+	if err := s.repo.ToggleBookmarkState(ctx, bookmarkID, userID, state); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+}
@@ -0,0 +1,77 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkSvc_ToggleState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("ToggleBookmarkState", ctx, testBookmarkID, testUserID, model.BookmarkStatePinned).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(&model.Bookmark{
+					Base:   model.Base{ID: testBookmarkID},
+					Pinned: true,
+				}, nil)
+			},
+			expectedOutput: &model.Bookmark{
+				Base:   model.Base{ID: testBookmarkID},
+				Pinned: true,
+			},
+		},
+		{
+			name: "Error - Not Found",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("ToggleBookmarkState", ctx, testBookmarkID, testUserID, model.BookmarkStatePinned).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - Reload Failed",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("ToggleBookmarkState", ctx, testBookmarkID, testUserID, model.BookmarkStatePinned).Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).Return(nil, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			got, err := svc.ToggleState(ctx, testBookmarkID, testUserID, model.BookmarkStatePinned)
+
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
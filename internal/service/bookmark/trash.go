@@ -0,0 +1,71 @@
+package bookmark
+
+import (
+	"context"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+)
+
+// GetTrash retrieves a paginated list of soft-deleted bookmarks for the specified user.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the owner
+//   - req: Pointer to Pagination request with Page and Limit
+//
+// Returns:
+//   - *pagination.Response: Standard paginated response wrapper
+//   - error: Database or internal error
+func (s *BookmarkSvc) GetTrash(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+	limit := req.GetLimit()
+	offset := req.GetOffset()
+
+	bookmarks, total, err := s.repo.GetTrashedBookmarks(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := pagination.CalculateMetadata(total, req.Page, limit)
+
+	return &pagination.Response[*model.Bookmark]{
+		Data:     bookmarks,
+		Metadata: meta,
+	}, nil
+}
+
+// RestoreBookmark moves a bookmark out of the trash, making it visible again.
+// Trashed bookmarks do not count towards the bookmark quota, so the quota is checked
+// as if the bookmark were created again.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to restore
+//   - userID: The ID of the owner
+//
+// Returns:
+//   - error: ErrNotFoundType if the bookmark is not in the user's trash,
+//     quota.ErrBookmarkQuotaExceeded if the user already owns all the bookmarks allowed, or a database error
+func (s *BookmarkSvc) RestoreBookmark(ctx context.Context, bookmarkID, userID string) error {
+	if s.quotas != nil {
+		if err := s.quotas.CheckBookmarks(ctx, userID, 1); err != nil {
+			return err
+		}
+	}
+	return s.repo.RestoreBookmark(ctx, bookmarkID, userID)
+}
+
+// PurgeTrash permanently removes bookmarks that have been in the trash for longer than retention.
+// It is intended to be called periodically by the trash retention job.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - retention: How long a trashed bookmark is kept before it is removed permanently
+//
+// Returns:
+//   - int64: Number of bookmarks removed
+//   - error: Database error, if any
+func (s *BookmarkSvc) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.repo.PurgeTrashedBookmarks(ctx, time.Now().Add(-retention))
+}
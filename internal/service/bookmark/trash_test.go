@@ -0,0 +1,215 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	quotaMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/quota/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBookmarkSvc_GetTrash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		inputReq       *pagination.Request
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *pagination.Response[*model.Bookmark]
+	}{
+		{
+			name:     "Success",
+			inputReq: &pagination.Request{Page: 2, Limit: 5},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetTrashedBookmarks", ctx, testUserID, 5, 5).
+					Return([]*model.Bookmark{{Base: model.Base{ID: "bm-6"}}}, int64(6), nil)
+			},
+			expectedOutput: &pagination.Response[*model.Bookmark]{
+				Data: []*model.Bookmark{{Base: model.Base{ID: "bm-6"}}},
+				Metadata: pagination.Metadata{
+					CurrentPage:  2,
+					PageSize:     5,
+					FirstPage:    1,
+					LastPage:     2,
+					TotalRecords: 6,
+				},
+			},
+		},
+		{
+			name:     "Error - Repository Failed",
+			inputReq: &pagination.Request{},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetTrashedBookmarks", ctx, testUserID, pagination.DefaultLimit, 0).
+					Return(nil, int64(0), errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			got, err := svc.GetTrash(ctx, testUserID, tc.inputReq)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestBookmarkSvc_RestoreBookmark(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("RestoreBookmark", ctx, testBookmarkID, testUserID).Return(nil)
+			},
+		},
+		{
+			name: "Error - Not In Trash",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("RestoreBookmark", ctx, testBookmarkID, testUserID).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			err := svc.RestoreBookmark(ctx, testBookmarkID, testUserID)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+// TestBookmarkSvc_RestoreBookmark_Quota tests that restored bookmarks count towards the bookmark quota,
+// since trashed ones do not.
+func TestBookmarkSvc_RestoreBookmark_Quota(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mockRepo *repoMocks.Repository, mockQuotas *quotaMocks.Service, ctx context.Context)
+		expectedErr error
+	}{
+		{
+			name: "Success - within quota",
+			setupMock: func(mockRepo *repoMocks.Repository, mockQuotas *quotaMocks.Service, ctx context.Context) {
+				mockQuotas.On("CheckBookmarks", ctx, testUserID, int64(1)).Return(nil)
+				mockRepo.On("RestoreBookmark", ctx, testBookmarkID, testUserID).Return(nil)
+			},
+		},
+		{
+			name: "Error - quota exceeded",
+			setupMock: func(mockRepo *repoMocks.Repository, mockQuotas *quotaMocks.Service, ctx context.Context) {
+				mockQuotas.On("CheckBookmarks", ctx, testUserID, int64(1)).Return(quota.ErrBookmarkQuotaExceeded)
+			},
+			expectedErr: quota.ErrBookmarkQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			mockQuotas := quotaMocks.NewService(t)
+			tc.setupMock(mockRepo, mockQuotas, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t), WithQuotas(mockQuotas))
+
+			err := svc.RestoreBookmark(ctx, testBookmarkID, testUserID)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestBookmarkSvc_PurgeTrash(t *testing.T) {
+	t.Parallel()
+
+	retention := 7 * 24 * time.Hour
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput int64
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("PurgeTrashedBookmarks", ctx, mock.MatchedBy(func(before time.Time) bool {
+					return before.Before(time.Now().Add(-retention + time.Minute))
+				})).Return(int64(3), nil)
+			},
+			expectedOutput: 3,
+		},
+		{
+			name: "Error - Repository Failed",
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("PurgeTrashedBookmarks", ctx, mock.Anything).Return(int64(0), errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewBookmarkSvc(mockRepo, mocks.NewKeyGenerator(t))
+
+			count, err := svc.PurgeTrash(ctx, retention)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, count)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package bookmark
 
 import (
 	"context"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 )
 
 // UpdateBookmark implements the business logic for updating an existing bookmark.
@@ -14,9 +17,46 @@ import (
 //   - userID: The ID of the user requesting the update (for ownership validation)
 //   - description: The new description for the bookmark
 //   - url: The new URL for the bookmark
+//   - version: The version the bookmark must have for the update to apply, 0 to update unconditionally
 //
 // Returns:
-//   - error: nil on success, or an error from the repository layer
-func (s *BookmarkSvc) UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string) error {
-	return s.repo.UpdateBookmark(ctx, bookmarkID, userID, description, url)
+//   - error: nil on success, ErrVersionMismatch if the bookmark was modified since version,
+//     or an error from the repository layer
+func (s *BookmarkSvc) UpdateBookmark(ctx context.Context, bookmarkID, userID, description, url string, version int) error {
+	return s.repo.UpdateBookmark(ctx, bookmarkID, userID, description, url, version)
+}
+
+// PatchBookmark implements the business logic for partially updating a bookmark.
+// Only the fields set in the patch are changed. The updated bookmark is read back
+// and returned so callers can respond with the full resource.
+// An empty patch does not write anything and simply returns the current bookmark,
+// provided it still has the expected version.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - bookmarkID: The ID of the bookmark to update
+//   - userID: The ID of the user requesting the update (for ownership validation)
+//   - patch: The fields to change
+//   - version: The version the bookmark must have for the patch to apply, 0 to patch unconditionally
+//
+// Returns:
+//   - *model.Bookmark: The bookmark after the patch has been applied
+//   - error: ErrNotFoundType if not found or not owned by the user,
+//     ErrVersionMismatch if the bookmark was modified since version, or a database error
+func (s *BookmarkSvc) PatchBookmark(ctx context.Context, bookmarkID, userID string, patch *model.BookmarkPatch, version int) (*model.Bookmark, error) {
+	if !patch.IsEmpty() {
+		if err := s.repo.PatchBookmark(ctx, bookmarkID, userID, patch, version); err != nil {
+			return nil, err
+		}
+		return s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+	}
+
+	bookmark, err := s.repo.GetBookmarkByID(ctx, bookmarkID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && bookmark.Version != version {
+		return nil, dbutils.ErrVersionMismatch
+	}
+	return bookmark, nil
 }
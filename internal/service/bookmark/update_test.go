@@ -5,10 +5,12 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestBookmarkSvc_UpdateBookmark(t *testing.T) {
@@ -30,7 +32,7 @@ func TestBookmarkSvc_UpdateBookmark(t *testing.T) {
 			inputDescription: testBookmarkDesc,
 			inputURL:         testBookmarkURL,
 			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
-				mockRepo.On("UpdateBookmark", ctx, testBookmarkID, testUserID, testBookmarkDesc, testBookmarkURL).
+				mockRepo.On("UpdateBookmark", ctx, testBookmarkID, testUserID, testBookmarkDesc, testBookmarkURL, 0).
 					Return(nil)
 			},
 			expectedErr: nil,
@@ -42,7 +44,7 @@ func TestBookmarkSvc_UpdateBookmark(t *testing.T) {
 			inputDescription: testBookmarkDesc,
 			inputURL:         testBookmarkURL,
 			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
-				mockRepo.On("UpdateBookmark", ctx, testBookmarkID, testUserID, testBookmarkDesc, testBookmarkURL).
+				mockRepo.On("UpdateBookmark", ctx, testBookmarkID, testUserID, testBookmarkDesc, testBookmarkURL, 0).
 					Return(dbutils.ErrNotFoundType)
 			},
 			expectedErr: dbutils.ErrNotFoundType,
@@ -54,7 +56,7 @@ func TestBookmarkSvc_UpdateBookmark(t *testing.T) {
 			inputDescription: testBookmarkDesc,
 			inputURL:         testBookmarkURL,
 			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
-				mockRepo.On("UpdateBookmark", ctx, testBookmarkID, testUserID, testBookmarkDesc, testBookmarkURL).
+				mockRepo.On("UpdateBookmark", ctx, testBookmarkID, testUserID, testBookmarkDesc, testBookmarkURL, 0).
 					Return(errors.New("db error"))
 			},
 			expectedErr: errors.New("db error"),
@@ -75,7 +77,7 @@ func TestBookmarkSvc_UpdateBookmark(t *testing.T) {
 			svc := NewBookmarkSvc(mockRepo, mockCodeGen)
 
 			// Execute
-			err := svc.UpdateBookmark(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputDescription, tc.inputURL)
+			err := svc.UpdateBookmark(ctx, tc.inputBookmarkID, tc.inputUserID, tc.inputDescription, tc.inputURL, 0)
 
 			// Assert
 			if tc.expectedErr != nil {
@@ -88,3 +90,124 @@ func TestBookmarkSvc_UpdateBookmark(t *testing.T) {
 		})
 	}
 }
+
+func TestBookmarkSvc_PatchBookmark(t *testing.T) {
+	t.Parallel()
+
+	newDescription := "Patched"
+	patchedBookmark := &model.Bookmark{
+		Base:        model.Base{ID: testBookmarkID},
+		Description: newDescription,
+		URL:         testBookmarkURL,
+		UserID:      testUserID,
+		Version:     2,
+	}
+
+	testCases := []struct {
+		name           string
+		inputPatch     *model.BookmarkPatch
+		inputVersion   int
+		setupMock      func(mockRepo *repoMocks.Repository, ctx context.Context)
+		expectedErr    error
+		expectedOutput *model.Bookmark
+	}{
+		{
+			name:       "Success",
+			inputPatch: &model.BookmarkPatch{Description: &newDescription},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("PatchBookmark", ctx, testBookmarkID, testUserID, &model.BookmarkPatch{Description: &newDescription}, 0).
+					Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(patchedBookmark, nil)
+			},
+			expectedOutput: patchedBookmark,
+		},
+		{
+			name:       "Success - empty patch only reads the bookmark",
+			inputPatch: &model.BookmarkPatch{},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(patchedBookmark, nil)
+			},
+			expectedOutput: patchedBookmark,
+		},
+		{
+			name:         "Success - empty patch with the current version",
+			inputPatch:   &model.BookmarkPatch{},
+			inputVersion: 2,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(patchedBookmark, nil)
+			},
+			expectedOutput: patchedBookmark,
+		},
+		{
+			name:         "Error - empty patch with a stale version",
+			inputPatch:   &model.BookmarkPatch{},
+			inputVersion: 1,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(patchedBookmark, nil)
+			},
+			expectedErr: dbutils.ErrVersionMismatch,
+		},
+		{
+			name:         "Error - Version Mismatch",
+			inputPatch:   &model.BookmarkPatch{Description: &newDescription},
+			inputVersion: 1,
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("PatchBookmark", ctx, testBookmarkID, testUserID, mock.Anything, 1).
+					Return(dbutils.ErrVersionMismatch)
+			},
+			expectedErr: dbutils.ErrVersionMismatch,
+		},
+		{
+			name:       "Error - Repository Not Found",
+			inputPatch: &model.BookmarkPatch{Description: &newDescription},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("PatchBookmark", ctx, testBookmarkID, testUserID, mock.Anything, 0).
+					Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name:       "Error - Reading Back Failed",
+			inputPatch: &model.BookmarkPatch{Description: &newDescription},
+			setupMock: func(mockRepo *repoMocks.Repository, ctx context.Context) {
+				mockRepo.On("PatchBookmark", ctx, testBookmarkID, testUserID, mock.Anything, 0).
+					Return(nil)
+				mockRepo.On("GetBookmarkByID", ctx, testBookmarkID, testUserID).
+					Return(nil, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			// Setup mocks
+			mockRepo := repoMocks.NewRepository(t)
+			tc.setupMock(mockRepo, ctx)
+
+			// Create service
+			svc := NewBookmarkSvc(mockRepo, nil)
+
+			// Execute
+			got, err := svc.PatchBookmark(ctx, testBookmarkID, testUserID, tc.inputPatch, tc.inputVersion)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package emailverification
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
+	mailerMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/mailer/mocks"
+	keyMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testUserID            = "user-123"
+	testEmail             = "john@example.com"
+	testVerificationToken = "verification-token"
+	testTTL               = 24 * time.Hour
+)
+
+var (
+	testNow = time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	testErr = errors.New("database error")
+)
+
+type testMocks struct {
+	users  *repoMocks.User
+	store  *repoMocks.EmailVerificationStore
+	keyGen *keyMocks.KeyGenerator
+	mailer *mailerMocks.Mailer
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		users:  repoMocks.NewUser(t),
+		store:  repoMocks.NewEmailVerificationStore(t),
+		keyGen: keyMocks.NewKeyGenerator(t),
+		mailer: mailerMocks.NewMailer(t),
+	}
+	svc := NewService(m.users, m.store, m.keyGen, m.mailer, Config{
+		TTL:       testTTL,
+		VerifyURL: "https://api.example.com/v1/users/verify",
+	}).(*verificationSvc)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+// expectSend sets up the mocks for a verification link emailed to email.
+func expectSend(ctx context.Context, m *testMocks, email string, sendErr error) {
+	m.keyGen.On("GenerateCode", verificationTokenLength).Return(testVerificationToken, nil)
+	m.store.On("SaveVerificationToken", ctx, token.HashToken(testVerificationToken),
+		&model.EmailVerification{UserID: testUserID, Email: email}, testTTL).Return(nil)
+	m.mailer.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+		return msg.To == email &&
+			strings.Contains(msg.Body, "https://api.example.com/v1/users/verify?token="+testVerificationToken)
+	})).Return(sendErr)
+}
+
+func TestVerificationSvc_SendVerification(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{Base: model.Base{ID: testUserID}, Email: testEmail, DisplayName: "John"}
+
+	testCases := []struct {
+		name        string
+		email       string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name:  "Success - current email",
+			email: testEmail,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectSend(ctx, m, testEmail, nil)
+			},
+		},
+		{
+			name:  "Success - new email",
+			email: "new@example.com",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectSend(ctx, m, "new@example.com", nil)
+			},
+		},
+		{
+			name:  "Success - mail failure is not reported",
+			email: testEmail,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectSend(ctx, m, testEmail, errors.New("smtp down"))
+			},
+		},
+		{
+			name:  "Error - store error",
+			email: testEmail,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", verificationTokenLength).Return(testVerificationToken, nil)
+				m.store.On("SaveVerificationToken", ctx, token.HashToken(testVerificationToken), mock.Anything, testTTL).
+					Return(redis.ErrClosed)
+			},
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.SendVerification(ctx, user, tc.email)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestVerificationSvc_ResendVerification(t *testing.T) {
+	t.Parallel()
+
+	verifiedAt := testNow.Add(-time.Hour)
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - link sent to unverified email",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).
+					Return(&model.User{Base: model.Base{ID: testUserID}, Email: testEmail}, nil)
+				expectSend(ctx, m, testEmail, nil)
+			},
+		},
+		{
+			name: "Success - verified email is skipped",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).
+					Return(&model.User{Base: model.Base{ID: testUserID}, Email: testEmail, EmailVerifiedAt: &verifiedAt}, nil)
+			},
+		},
+		{
+			name: "Success - unknown email is not reported",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(nil, dbutils.ErrNotFoundType)
+			},
+		},
+		{
+			name: "Error - repository error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(nil, testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.ResendVerification(ctx, testEmail)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestVerificationSvc_Verify(t *testing.T) {
+	t.Parallel()
+
+	hashed := token.HashToken(testVerificationToken)
+	verification := &model.EmailVerification{UserID: testUserID, Email: testEmail}
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - email verified",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeVerificationToken", ctx, hashed).Return(verification, nil)
+				m.users.On("VerifyEmail", ctx, testUserID, testEmail, testNow).Return(nil)
+			},
+		},
+		{
+			name: "Error - unknown or used token",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeVerificationToken", ctx, hashed).Return(nil, redis.Nil)
+			},
+			expectedErr: ErrInvalidVerificationToken,
+		},
+		{
+			name: "Error - email no longer pending",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeVerificationToken", ctx, hashed).Return(verification, nil)
+				m.users.On("VerifyEmail", ctx, testUserID, testEmail, testNow).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrInvalidVerificationToken,
+		},
+		{
+			name: "Error - email taken meanwhile",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeVerificationToken", ctx, hashed).Return(verification, nil)
+				m.users.On("VerifyEmail", ctx, testUserID, testEmail, testNow).Return(dbutils.ErrDuplicationType)
+			},
+			expectedErr: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.Verify(ctx, testVerificationToken)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -41,9 +41,9 @@ func (_m *ShortenUrl) GetUrl(ctx context.Context, code string) (string, error) {
 	return r0, r1
 }
 
-// ShortenUrl provides a mock function with given fields: ctx, url, exp
-func (_m *ShortenUrl) ShortenUrl(ctx context.Context, url string, exp int) (string, error) {
-	ret := _m.Called(ctx, url, exp)
+// ShortenUrl provides a mock function with given fields: ctx, userID, clientIP, url, exp
+func (_m *ShortenUrl) ShortenUrl(ctx context.Context, userID string, clientIP string, url string, exp int) (string, error) {
+	ret := _m.Called(ctx, userID, clientIP, url, exp)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ShortenUrl")
@@ -51,17 +51,17 @@ func (_m *ShortenUrl) ShortenUrl(ctx context.Context, url string, exp int) (stri
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) (string, error)); ok {
-		return rf(ctx, url, exp)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) (string, error)); ok {
+		return rf(ctx, userID, clientIP, url, exp)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) string); ok {
-		r0 = rf(ctx, url, exp)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) string); ok {
+		r0 = rf(ctx, userID, clientIP, url, exp)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
-		r1 = rf(ctx, url, exp)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int) error); ok {
+		r1 = rf(ctx, userID, clientIP, url, exp)
 	} else {
 		r1 = ret.Error(1)
 	}
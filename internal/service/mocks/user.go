@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
-	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	jwt "github.com/golang-jwt/jwt/v5"
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+
+	time "time"
 )
 
 // User is an autogenerated mock type for the User type
@@ -14,6 +18,54 @@ type User struct {
 	mock.Mock
 }
 
+// BootstrapAdmin provides a mock function with given fields: ctx, username, email, password
+func (_m *User) BootstrapAdmin(ctx context.Context, username string, email string, password string) error {
+	ret := _m.Called(ctx, username, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BootstrapAdmin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, username, email, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangePassword provides a mock function with given fields: ctx, userID, currentPassword, newPassword
+func (_m *User) ChangePassword(ctx context.Context, userID string, currentPassword string, newPassword string) (*model.TokenPair, error) {
+	ret := _m.Called(ctx, userID, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 *model.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.TokenPair, error)); ok {
+		return rf(ctx, userID, currentPassword, newPassword)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.TokenPair); ok {
+		r0 = rf(ctx, userID, currentPassword, newPassword)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, currentPassword, newPassword)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: ctx, username, password, displayName, email
 func (_m *User) CreateUser(ctx context.Context, username string, password string, displayName string, email string) (*model.User, error) {
 	ret := _m.Called(ctx, username, password, displayName, email)
@@ -75,22 +127,24 @@ func (_m *User) GetUserByID(ctx context.Context, userId string) (*model.User, er
 }
 
 // Login provides a mock function with given fields: ctx, username, password
-func (_m *User) Login(ctx context.Context, username string, password string) (string, error) {
+func (_m *User) Login(ctx context.Context, username string, password string) (*model.TokenPair, error) {
 	ret := _m.Called(ctx, username, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 *model.TokenPair
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.TokenPair, error)); ok {
 		return rf(ctx, username, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.TokenPair); ok {
 		r0 = rf(ctx, username, password)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenPair)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
@@ -102,6 +156,100 @@ func (_m *User) Login(ctx context.Context, username string, password string) (st
 	return r0, r1
 }
 
+// Logout provides a mock function with given fields: ctx, claims, refreshToken
+func (_m *User) Logout(ctx context.Context, claims jwt.MapClaims, refreshToken string) error {
+	ret := _m.Called(ctx, claims, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, jwt.MapClaims, string) error); ok {
+		r0 = rf(ctx, claims, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeDeletedUsers provides a mock function with given fields: ctx, retention
+func (_m *User) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, error)); ok {
+		return rf(ctx, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshToken provides a mock function with given fields: ctx, refreshToken
+func (_m *User) RefreshToken(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 *model.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.TokenPair, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAllTokens provides a mock function with given fields: ctx, userID
+func (_m *User) RevokeAllTokens(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAllTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUser provides a mock function with given fields: ctx, userID, displayName, email
 func (_m *User) UpdateUser(ctx context.Context, userID string, displayName string, email string) error {
 	ret := _m.Called(ctx, userID, displayName, email)
@@ -0,0 +1,296 @@
+// Package oidclogin signs users in through the OpenID Connect provider of the company.
+// Users are sent to the provider with the authorization code flow and PKCE. On their return,
+// the identity asserted by the provider is linked to a local user, created on first login,
+// who then receives the same tokens as after a password login.
+package oidclogin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// stateLength and nonceLength are the lengths of the state and nonce of a login,
+	// 32 alphanumeric characters carry about 190 bits.
+	stateLength = 32
+	nonceLength = 32
+	// codeVerifierLength is the length of PKCE code verifiers, which must have 43 to 128 characters.
+	codeVerifierLength = 64
+
+	// minUsernameLength and maxUsernameLength are the bounds of usernames enforced at registration.
+	minUsernameLength = 2
+	maxUsernameLength = 20
+	// usernameSuffixLength is the length of the random suffix added to taken usernames.
+	usernameSuffixLength = 4
+	// usernameAttempts is how many usernames are tried when creating a user.
+	usernameAttempts = 5
+	// fallbackUsername is used when the identity has no usable username or email local-part.
+	fallbackUsername = "user"
+)
+
+// Service-level errors returned when completing a login.
+var (
+	// ErrInvalidState is returned when the state of a callback is unknown, expired or already used.
+	ErrInvalidState = errors.New("invalid login state")
+	// ErrEmailNotVerified is returned when a new identity has no email address verified by the provider,
+	// so it can neither be linked to an existing user nor get an account of its own.
+	ErrEmailNotVerified = errors.New("email address is not verified by the identity provider")
+	// ErrAccountLinked is returned when the user with the email address of the identity
+	// is already linked to another identity at the provider.
+	ErrAccountLinked = errors.New("account is linked to another identity")
+	// ErrAccountDisabled is returned when an administrator disabled the account.
+	ErrAccountDisabled = errors.New("account is disabled")
+)
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	StartLogin(ctx context.Context) (string, error)
+	CompleteLogin(ctx context.Context, state, code string) (*model.TokenPair, error)
+}
+
+// Config holds the settings of the logins.
+type Config struct {
+	// StateTTL is how long users have to sign in at the provider.
+	StateTTL time.Duration
+}
+
+type loginSvc struct {
+	provider     oidc.Provider
+	store        repository.OIDCLoginStore
+	users        repository.User
+	tokens       token.Service
+	accessTokens accesstoken.Repository
+	keyGen       stringutils.KeyGenerator
+	cfg          Config
+	now          func() time.Time
+}
+
+// NewService creates an OpenID Connect login service.
+//
+// Parameters:
+//   - provider: Client of the identity provider
+//   - store: Storage of the logins waiting for the callback of the provider
+//   - users: Repository of the users
+//   - tokens: Service issuing the tokens of signed-in users
+//   - accessTokens: Repository of the personal access tokens, deleted when an unverified user is claimed
+//   - keyGen: Generator of the states, nonces and code verifiers
+//   - cfg: Settings of the logins
+func NewService(provider oidc.Provider, store repository.OIDCLoginStore, users repository.User,
+	tokens token.Service, accessTokens accesstoken.Repository, keyGen stringutils.KeyGenerator, cfg Config) Service {
+	return &loginSvc{
+		provider:     provider,
+		store:        store,
+		users:        users,
+		tokens:       tokens,
+		accessTokens: accessTokens,
+		keyGen:       keyGen,
+		cfg:          cfg,
+		now:          time.Now,
+	}
+}
+
+// StartLogin begins a login and returns the URL of the provider to redirect the user to.
+// The code verifier and the nonce of the login are kept under the hash of its state until
+// the user comes back with it, or StateTTL passed.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//
+// Returns:
+//   - string: The authorization URL at the provider
+//   - error: oidc.ErrDiscovery if the provider cannot be reached, or a generation or Redis error
+func (s *loginSvc) StartLogin(ctx context.Context) (string, error) {
+	state, err := s.keyGen.GenerateCode(stateLength)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := s.keyGen.GenerateCode(nonceLength)
+	if err != nil {
+		return "", err
+	}
+	codeVerifier, err := s.keyGen.GenerateCode(codeVerifierLength)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := s.provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		return "", err
+	}
+	login := &model.OIDCLogin{CodeVerifier: codeVerifier, Nonce: nonce}
+	if err := s.store.SaveLogin(ctx, token.HashToken(state), login, s.cfg.StateTTL); err != nil {
+		return "", err
+	}
+	return authURL, nil
+}
+
+// CompleteLogin finishes the login with the given state: the authorization code is redeemed
+// at the provider, and the identity it asserts is linked to a local user, who receives tokens.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - state: The state the provider sent back
+//   - code: The authorization code the provider issued
+//
+// Returns:
+//   - *model.TokenPair: A short-lived access token and a refresh token
+//   - error: ErrInvalidState if the login cannot be completed, oidc.ErrTokenExchange or oidc.ErrInvalidIDToken
+//     if the provider did not sign the user in, ErrEmailNotVerified, ErrAccountLinked or ErrAccountDisabled
+//     if the identity cannot be signed in, or a database, Redis or token issuing error
+func (s *loginSvc) CompleteLogin(ctx context.Context, state, code string) (*model.TokenPair, error) {
+	login, err := s.store.ConsumeLogin(ctx, token.HashToken(state))
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidState
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.linkUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	if user.DisabledAt != nil {
+		return nil, ErrAccountDisabled
+	}
+
+	return s.tokens.Issue(ctx, user.ID, user.Roles)
+}
+
+// linkUser returns the local user of an identity. Identities are found by their subject,
+// which the provider never reassigns. A new identity is linked to the user with the same
+// email address, provided the provider verified it; without such a user, one is created.
+func (s *loginSvc) linkUser(ctx context.Context, identity *oidc.Identity) (*model.User, error) {
+	user, err := s.users.GetUserByOIDCSubject(ctx, identity.Subject)
+	if !errors.Is(err, dbutils.ErrNotFoundType) {
+		return user, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	user, err = s.users.GetUserByEmail(ctx, identity.Email)
+	if errors.Is(err, dbutils.ErrNotFoundType) {
+		return s.createUser(ctx, identity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.OIDCSubject != nil {
+		return nil, ErrAccountLinked
+	}
+
+	if user.EmailVerifiedAt == nil {
+		if err := s.claimUnverifiedUser(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.users.SetOIDCSubject(ctx, user.ID, identity.Subject)
+	// The user was linked to another identity meanwhile
+	if errors.Is(err, dbutils.ErrNotFoundType) {
+		return nil, ErrAccountLinked
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// claimUnverifiedUser hands a user whose email address was never confirmed over to the owner
+// of the address, as verified by the provider. Anyone may have registered with the address,
+// so the password and every token of the user, personal access tokens included, are revoked
+// before the address is confirmed; the owner can still choose a password with the forgotten
+// password flow.
+func (s *loginSvc) claimUnverifiedUser(ctx context.Context, user *model.User) error {
+	// An empty hash never matches a password
+	if err := s.users.UpdatePassword(ctx, user.ID, ""); err != nil {
+		return err
+	}
+	if err := s.tokens.RevokeAll(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.accessTokens.DeleteUserTokens(ctx, user.ID); err != nil {
+		return err
+	}
+
+	verifiedAt := s.now()
+	if err := s.users.VerifyEmail(ctx, user.ID, user.Email, verifiedAt); err != nil {
+		return err
+	}
+	user.Password = ""
+	user.EmailVerifiedAt = &verifiedAt
+	return nil
+}
+
+// createUser creates the user of a new identity, with its verified email address and without password.
+// The username is taken from the identity; when it is taken, a random suffix is added.
+func (s *loginSvc) createUser(ctx context.Context, identity *oidc.Identity) (*model.User, error) {
+	base := identityUsername(identity)
+	displayName := identity.Name
+	if displayName == "" {
+		displayName = base
+	}
+	verifiedAt := s.now()
+	subject := identity.Subject
+
+	username := base
+	for attempt := 1; ; attempt++ {
+		user, err := s.users.CreateUser(ctx, &model.User{
+			Username:        username,
+			DisplayName:     displayName,
+			Email:           identity.Email,
+			EmailVerifiedAt: &verifiedAt,
+			Roles:           model.Roles{model.RoleUser},
+			OIDCSubject:     &subject,
+		})
+		if !errors.Is(err, dbutils.ErrDuplicationType) || attempt == usernameAttempts {
+			return user, err
+		}
+
+		suffix, err := s.keyGen.GenerateCode(usernameSuffixLength)
+		if err != nil {
+			return nil, err
+		}
+		username = base[:min(len(base), maxUsernameLength-usernameSuffixLength-1)] + "_" + suffix
+	}
+}
+
+// identityUsername derives a username from the preferred username of an identity, or else
+// from the local-part of its email address, keeping the characters usual in usernames.
+func identityUsername(identity *oidc.Identity) string {
+	candidate := identity.PreferredUsername
+	if candidate == "" {
+		candidate, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	username := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		default:
+			return -1
+		}
+	}, candidate)
+	if len(username) < minUsernameLength {
+		return fallbackUsername
+	}
+	return username[:min(len(username), maxUsernameLength)]
+}
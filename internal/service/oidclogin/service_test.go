@@ -0,0 +1,358 @@
+package oidclogin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	accessTokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken/mocks"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	tokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/token/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	oidcMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/oidc/mocks"
+	keyMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testUserID       = "user-123"
+	testSubject      = "subject-1"
+	testEmail        = "jane@example.com"
+	testState        = "state-state-state-state-state-st"
+	testNonce        = "nonce-nonce-nonce-nonce-nonce-no"
+	testCodeVerifier = "verifier-verifier-verifier-verifier-verifier-verifier-verifier-v"
+	testCode         = "authorization-code"
+	testAuthURL      = "https://idp.example.com/authorize?state=" + testState
+	testStateTTL     = 10 * time.Minute
+)
+
+var (
+	testNow   = time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	testErr   = errors.New("database error")
+	testPair  = &model.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900}
+	testLogin = &model.OIDCLogin{CodeVerifier: testCodeVerifier, Nonce: testNonce}
+)
+
+type testMocks struct {
+	provider     *oidcMocks.Provider
+	store        *repoMocks.OIDCLoginStore
+	users        *repoMocks.User
+	tokens       *tokenMocks.Service
+	accessTokens *accessTokenMocks.Repository
+	keyGen       *keyMocks.KeyGenerator
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		provider:     oidcMocks.NewProvider(t),
+		store:        repoMocks.NewOIDCLoginStore(t),
+		users:        repoMocks.NewUser(t),
+		tokens:       tokenMocks.NewService(t),
+		accessTokens: accessTokenMocks.NewRepository(t),
+		keyGen:       keyMocks.NewKeyGenerator(t),
+	}
+	svc := NewService(m.provider, m.store, m.users, m.tokens, m.accessTokens, m.keyGen, Config{StateTTL: testStateTTL}).(*loginSvc)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+func testIdentity() *oidc.Identity {
+	return &oidc.Identity{
+		Subject:           testSubject,
+		Email:             testEmail,
+		EmailVerified:     true,
+		Name:              "Jane Doe",
+		PreferredUsername: "jane",
+	}
+}
+
+func TestLoginSvc_StartLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedURL string
+		expectedErr error
+	}{
+		{
+			name: "Success - login stored under the hash of the state",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", stateLength).Return(testState, nil).Once()
+				m.keyGen.On("GenerateCode", nonceLength).Return(testNonce, nil).Once()
+				m.keyGen.On("GenerateCode", codeVerifierLength).Return(testCodeVerifier, nil).Once()
+				m.provider.On("AuthCodeURL", ctx, testState, testNonce, oidc.CodeChallenge(testCodeVerifier)).
+					Return(testAuthURL, nil)
+				m.store.On("SaveLogin", ctx, token.HashToken(testState), testLogin, testStateTTL).Return(nil)
+			},
+			expectedURL: testAuthURL,
+		},
+		{
+			name: "Error - provider unreachable",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", mock.Anything).Return("code", nil)
+				m.provider.On("AuthCodeURL", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return("", oidc.ErrDiscovery)
+			},
+			expectedErr: oidc.ErrDiscovery,
+		},
+		{
+			name: "Error - store error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", mock.Anything).Return("code", nil)
+				m.provider.On("AuthCodeURL", ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(testAuthURL, nil)
+				m.store.On("SaveLogin", ctx, mock.Anything, mock.Anything, testStateTTL).Return(redis.ErrClosed)
+			},
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			authURL, err := svc.StartLogin(ctx)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedURL, authURL)
+		})
+	}
+}
+
+func TestLoginSvc_CompleteLogin(t *testing.T) {
+	t.Parallel()
+
+	hashed := token.HashToken(testState)
+	verifiedAt := testNow.Add(-time.Hour)
+	otherSubject := "subject-2"
+
+	// expectExchange sets up the mocks for a login the provider completes with identity.
+	expectExchange := func(ctx context.Context, m *testMocks, identity *oidc.Identity) {
+		m.store.On("ConsumeLogin", ctx, hashed).Return(testLogin, nil)
+		m.provider.On("Exchange", ctx, testCode, testCodeVerifier, testNonce).Return(identity, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		setupMock    func(ctx context.Context, m *testMocks)
+		expectedPair *model.TokenPair
+		expectedErr  error
+	}{
+		{
+			name: "Success - linked user",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).
+					Return(&model.User{Base: model.Base{ID: testUserID}, Roles: model.Roles{model.RoleUser, model.RoleAdmin}}, nil)
+				m.tokens.On("Issue", ctx, testUserID, model.Roles{model.RoleUser, model.RoleAdmin}).Return(testPair, nil)
+			},
+			expectedPair: testPair,
+		},
+		{
+			name: "Success - user with verified email linked",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(&model.User{
+					Base: model.Base{ID: testUserID}, Email: testEmail, EmailVerifiedAt: &verifiedAt,
+					Roles: model.Roles{model.RoleUser},
+				}, nil)
+				m.users.On("SetOIDCSubject", ctx, testUserID, testSubject).Return(nil)
+				m.tokens.On("Issue", ctx, testUserID, model.Roles{model.RoleUser}).Return(testPair, nil)
+			},
+			expectedPair: testPair,
+		},
+		{
+			name: "Success - user with unverified email claimed",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(&model.User{
+					Base: model.Base{ID: testUserID}, Email: testEmail, Password: "hash",
+					Roles: model.Roles{model.RoleUser},
+				}, nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				m.accessTokens.On("DeleteUserTokens", ctx, testUserID).Return(nil)
+				m.users.On("VerifyEmail", ctx, testUserID, testEmail, testNow).Return(nil)
+				m.users.On("SetOIDCSubject", ctx, testUserID, testSubject).Return(nil)
+				m.tokens.On("Issue", ctx, testUserID, model.Roles{model.RoleUser}).Return(testPair, nil)
+			},
+			expectedPair: testPair,
+		},
+		{
+			name: "Success - user created",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool {
+					return u.Username == "jane" && u.DisplayName == "Jane Doe" && u.Email == testEmail &&
+						u.Password == "" && u.EmailVerifiedAt.Equal(testNow) &&
+						u.OIDCSubject != nil && *u.OIDCSubject == testSubject
+				})).Return(&model.User{Base: model.Base{ID: testUserID}, Roles: model.Roles{model.RoleUser}}, nil)
+				m.tokens.On("Issue", ctx, testUserID, model.Roles{model.RoleUser}).Return(testPair, nil)
+			},
+			expectedPair: testPair,
+		},
+		{
+			name: "Success - taken username gets a suffix",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool { return u.Username == "jane" })).
+					Return(nil, dbutils.ErrDuplicationType)
+				m.keyGen.On("GenerateCode", usernameSuffixLength).Return("x7k2", nil)
+				m.users.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool { return u.Username == "jane_x7k2" })).
+					Return(&model.User{Base: model.Base{ID: testUserID}, Roles: model.Roles{model.RoleUser}}, nil)
+				m.tokens.On("Issue", ctx, testUserID, model.Roles{model.RoleUser}).Return(testPair, nil)
+			},
+			expectedPair: testPair,
+		},
+		{
+			name: "Error - unknown or used state",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeLogin", ctx, hashed).Return(nil, redis.Nil)
+			},
+			expectedErr: ErrInvalidState,
+		},
+		{
+			name: "Error - invalid ID token",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeLogin", ctx, hashed).Return(testLogin, nil)
+				m.provider.On("Exchange", ctx, testCode, testCodeVerifier, testNonce).Return(nil, oidc.ErrInvalidIDToken)
+			},
+			expectedErr: oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "Error - email not verified by the provider",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				identity := testIdentity()
+				identity.EmailVerified = false
+				expectExchange(ctx, m, identity)
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrEmailNotVerified,
+		},
+		{
+			name: "Error - user linked to another identity",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(&model.User{
+					Base: model.Base{ID: testUserID}, Email: testEmail, EmailVerifiedAt: &verifiedAt, OIDCSubject: &otherSubject,
+				}, nil)
+			},
+			expectedErr: ErrAccountLinked,
+		},
+		{
+			name: "Error - user linked to another identity meanwhile",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(&model.User{
+					Base: model.Base{ID: testUserID}, Email: testEmail, EmailVerifiedAt: &verifiedAt,
+				}, nil)
+				m.users.On("SetOIDCSubject", ctx, testUserID, testSubject).Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrAccountLinked,
+		},
+		{
+			name: "Error - personal access tokens of the claimed user not deleted",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, dbutils.ErrNotFoundType)
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(&model.User{
+					Base: model.Base{ID: testUserID}, Email: testEmail, Password: "hash",
+				}, nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				m.accessTokens.On("DeleteUserTokens", ctx, testUserID).Return(testErr)
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "Error - account disabled",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).
+					Return(&model.User{Base: model.Base{ID: testUserID}, DisabledAt: &verifiedAt}, nil)
+			},
+			expectedErr: ErrAccountDisabled,
+		},
+		{
+			name: "Error - repository error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				expectExchange(ctx, m, testIdentity())
+				m.users.On("GetUserByOIDCSubject", ctx, testSubject).Return(nil, testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			pair, err := svc.CompleteLogin(ctx, testState, testCode)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedPair, pair)
+		})
+	}
+}
+
+func TestIdentityUsername(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		identity *oidc.Identity
+		expected string
+	}{
+		{
+			name:     "preferred username",
+			identity: &oidc.Identity{PreferredUsername: "jane.doe", Email: "jd@example.com"},
+			expected: "jane.doe",
+		},
+		{
+			name:     "email local-part",
+			identity: &oidc.Identity{Email: "jane+work@example.com"},
+			expected: "janework",
+		},
+		{
+			name:     "truncated",
+			identity: &oidc.Identity{PreferredUsername: "a-very-long-username-indeed"},
+			expected: "a-very-long-username",
+		},
+		{
+			name:     "fallback",
+			identity: &oidc.Identity{PreferredUsername: "Ж", Email: "@example.com"},
+			expected: fallbackUsername,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, identityUsername(tc.identity))
+		})
+	}
+}
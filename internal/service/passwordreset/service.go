@@ -0,0 +1,213 @@
+// Package passwordreset lets users who forgot their password set a new one.
+// A single-use reset token is emailed to the address of the account; only its hash is stored.
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/utils"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+// resetTokenLength is the length of reset tokens. 43 alphanumeric characters carry about 256 bits.
+const resetTokenLength = 43
+
+// ErrInvalidResetToken is returned when a reset token is unknown, expired or already used.
+var ErrInvalidResetToken = errors.New("invalid reset token")
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	RequestReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	ForceReset(ctx context.Context, userID string) error
+}
+
+// Config holds the settings of the reset tokens.
+type Config struct {
+	// TTL is how long a reset token can be used.
+	TTL time.Duration
+	// ResetURL is the page the emailed link points to. The token is added as the "token" query parameter.
+	ResetURL string
+}
+
+type resetSvc struct {
+	users        repository.User
+	store        repository.PasswordResetStore
+	tokens       token.Service
+	accessTokens accesstoken.Repository
+	keyGen       stringutils.KeyGenerator
+	hash         utils.PasswordHashing
+	mailer       mailer.Mailer
+	cfg          Config
+}
+
+// NewService creates a password reset service.
+//
+// Parameters:
+//   - users: Repository of the users
+//   - store: Storage of the reset tokens
+//   - tokens: Service revoking the tokens of a user whose password was reset
+//   - accessTokens: Repository of the personal access tokens, deleted when the password is reset
+//   - keyGen: Generator of the random reset tokens
+//   - hash: The password hashing implementation
+//   - m: Mailer sending the reset links
+//   - cfg: Settings of the reset tokens
+func NewService(users repository.User, store repository.PasswordResetStore, tokens token.Service,
+	accessTokens accesstoken.Repository, keyGen stringutils.KeyGenerator, hash utils.PasswordHashing,
+	m mailer.Mailer, cfg Config) Service {
+	return &resetSvc{
+		users:        users,
+		store:        store,
+		tokens:       tokens,
+		accessTokens: accessTokens,
+		keyGen:       keyGen,
+		hash:         hash,
+		mailer:       m,
+		cfg:          cfg,
+	}
+}
+
+// RequestReset emails a reset link to the user with the given address.
+// Whether the address belongs to an account must not be observable by the caller,
+// so unknown addresses and mail delivery failures are not reported as errors.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - email: The email address of the account
+//
+// Returns:
+//   - error: Database, generation or Redis error, if any
+func (s *resetSvc) RequestReset(ctx context.Context, email string) error {
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, dbutils.ErrNotFoundType) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.sendResetLink(ctx, user, "Someone asked to reset the password of your account.",
+		"If you did not ask for it, you can ignore this email.")
+}
+
+// ForceReset makes a user choose a new password, e.g. after the password leaked. The current
+// password stops working, every access, refresh and personal access token of the user is revoked, and a reset
+// link is emailed to the user. Like for RequestReset, mail delivery failures are only logged:
+// the user can still ask for another link with the forgotten password flow.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or a database, generation or Redis error
+func (s *resetSvc) ForceReset(ctx context.Context, userID string) error {
+	user, err := s.users.GetUserById(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// An empty hash never matches a password
+	if err := s.users.UpdatePassword(ctx, userID, ""); err != nil {
+		return err
+	}
+	if err := s.revokeCredentials(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.sendResetLink(ctx, user, "An administrator asked you to choose a new password for your account.",
+		"Until you do, you cannot sign in.")
+}
+
+// sendResetLink issues a reset token for a user and emails the link to it, explaining why with reason.
+func (s *resetSvc) sendResetLink(ctx context.Context, user *model.User, reason, note string) error {
+	resetToken, err := s.keyGen.GenerateCode(resetTokenLength)
+	if err != nil {
+		return err
+	}
+	if err := s.store.SaveResetToken(ctx, token.HashToken(resetToken), user.ID, s.cfg.TTL); err != nil {
+		return err
+	}
+
+	link, err := s.resetLink(resetToken)
+	if err != nil {
+		return err
+	}
+	msg := &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\n%s "+
+			"Open the link below within %s to choose a new password:\n\n%s\n\n"+
+			"%s\n", user.DisplayName, reason, s.cfg.TTL, link, note),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Error().Err(err).Str("userID", user.ID).Msg("failed to send password reset email")
+	}
+	return nil
+}
+
+// ResetPassword sets a new password for the user a reset token was issued to.
+// The token is consumed, and every access, refresh and personal access token of the user is revoked.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - resetToken: The token received by email
+//   - newPassword: The plain-text new password
+//
+// Returns:
+//   - error: ErrInvalidResetToken if the token cannot be used, or hashing, database or Redis error
+func (s *resetSvc) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	userID, err := s.store.ConsumeResetToken(ctx, token.HashToken(resetToken))
+	if errors.Is(err, redis.Nil) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	hashPwd, err := s.hash.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	err = s.users.UpdatePassword(ctx, userID, hashPwd)
+	if errors.Is(err, dbutils.ErrNotFoundType) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.revokeCredentials(ctx, userID)
+}
+
+// revokeCredentials revokes every access, refresh and personal access token of a user.
+func (s *resetSvc) revokeCredentials(ctx context.Context, userID string) error {
+	if err := s.tokens.RevokeAll(ctx, userID); err != nil {
+		return err
+	}
+	return s.accessTokens.DeleteUserTokens(ctx, userID)
+}
+
+// resetLink adds the reset token to the configured reset URL.
+func (s *resetSvc) resetLink(resetToken string) (string, error) {
+	u, err := url.Parse(s.cfg.ResetURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("token", resetToken)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,273 @@
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	accessTokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken/mocks"
+	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	tokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/token/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
+	mailerMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/mailer/mocks"
+	keyMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	hashMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/utils/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testUserID     = "user-123"
+	testEmail      = "john@example.com"
+	testResetToken = "reset-token"
+	testTTL        = time.Hour
+)
+
+var testErr = errors.New("database error")
+
+type testMocks struct {
+	users        *repoMocks.User
+	store        *repoMocks.PasswordResetStore
+	tokens       *tokenMocks.Service
+	accessTokens *accessTokenMocks.Repository
+	keyGen       *keyMocks.KeyGenerator
+	hash         *hashMocks.PasswordHashing
+	mailer       *mailerMocks.Mailer
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		users:        repoMocks.NewUser(t),
+		store:        repoMocks.NewPasswordResetStore(t),
+		tokens:       tokenMocks.NewService(t),
+		accessTokens: accessTokenMocks.NewRepository(t),
+		keyGen:       keyMocks.NewKeyGenerator(t),
+		hash:         hashMocks.NewPasswordHashing(t),
+		mailer:       mailerMocks.NewMailer(t),
+	}
+	svc := NewService(m.users, m.store, m.tokens, m.accessTokens, m.keyGen, m.hash, m.mailer, Config{
+		TTL:      testTTL,
+		ResetURL: "https://app.example.com/reset-password?lang=en",
+	})
+	return svc, m
+}
+
+func TestResetSvc_RequestReset(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{Base: model.Base{ID: testUserID}, Email: testEmail, DisplayName: "John"}
+	isResetMail := mock.MatchedBy(func(msg *mailer.Message) bool {
+		return msg.To == testEmail &&
+			strings.Contains(msg.Body, "https://app.example.com/reset-password?lang=en&token="+testResetToken)
+	})
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - reset link sent",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(user, nil)
+				m.keyGen.On("GenerateCode", resetTokenLength).Return(testResetToken, nil)
+				m.store.On("SaveResetToken", ctx, token.HashToken(testResetToken), testUserID, testTTL).Return(nil)
+				m.mailer.On("Send", ctx, isResetMail).Return(nil)
+			},
+		},
+		{
+			name: "Success - unknown email is not reported",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(nil, dbutils.ErrNotFoundType)
+			},
+		},
+		{
+			name: "Success - mail failure is not reported",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(user, nil)
+				m.keyGen.On("GenerateCode", resetTokenLength).Return(testResetToken, nil)
+				m.store.On("SaveResetToken", ctx, token.HashToken(testResetToken), testUserID, testTTL).Return(nil)
+				m.mailer.On("Send", ctx, isResetMail).Return(errors.New("smtp down"))
+			},
+		},
+		{
+			name: "Error - repository error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(nil, testErr)
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "Error - store error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserByEmail", ctx, testEmail).Return(user, nil)
+				m.keyGen.On("GenerateCode", resetTokenLength).Return(testResetToken, nil)
+				m.store.On("SaveResetToken", ctx, token.HashToken(testResetToken), testUserID, testTTL).Return(redis.ErrClosed)
+			},
+			expectedErr: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.RequestReset(ctx, testEmail)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestResetSvc_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	hashed := token.HashToken(testResetToken)
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - password replaced and tokens revoked",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeResetToken", ctx, hashed).Return(testUserID, nil)
+				m.hash.On("Hash", "NewPassword1!").Return("new-hash", nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "new-hash").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				m.accessTokens.On("DeleteUserTokens", ctx, testUserID).Return(nil)
+			},
+		},
+		{
+			name: "Error - unknown or used token",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeResetToken", ctx, hashed).Return("", redis.Nil)
+			},
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			name: "Error - user deleted since the request",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeResetToken", ctx, hashed).Return(testUserID, nil)
+				m.hash.On("Hash", "NewPassword1!").Return("new-hash", nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "new-hash").Return(dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			name: "Error - revocation error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeResetToken", ctx, hashed).Return(testUserID, nil)
+				m.hash.On("Hash", "NewPassword1!").Return("new-hash", nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "new-hash").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(redis.ErrClosed)
+			},
+			expectedErr: redis.ErrClosed,
+		},
+		{
+			name: "Error - personal access tokens not deleted",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("ConsumeResetToken", ctx, hashed).Return(testUserID, nil)
+				m.hash.On("Hash", "NewPassword1!").Return("new-hash", nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "new-hash").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				m.accessTokens.On("DeleteUserTokens", ctx, testUserID).Return(testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.ResetPassword(ctx, testResetToken, "NewPassword1!")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestResetSvc_ForceReset(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{Base: model.Base{ID: testUserID}, Email: testEmail, DisplayName: "John"}
+	isResetMail := mock.MatchedBy(func(msg *mailer.Message) bool {
+		return msg.To == testEmail && strings.Contains(msg.Body, "An administrator asked you") &&
+			strings.Contains(msg.Body, "https://app.example.com/reset-password?lang=en&token="+testResetToken)
+	})
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - password cleared, tokens revoked and link sent",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(user, nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				m.accessTokens.On("DeleteUserTokens", ctx, testUserID).Return(nil)
+				m.keyGen.On("GenerateCode", resetTokenLength).Return(testResetToken, nil)
+				m.store.On("SaveResetToken", ctx, token.HashToken(testResetToken), testUserID, testTTL).Return(nil)
+				m.mailer.On("Send", ctx, isResetMail).Return(nil)
+			},
+		},
+		{
+			name: "Error - user not found",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "Error - revocation error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(user, nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(redis.ErrClosed)
+			},
+			expectedErr: redis.ErrClosed,
+		},
+		{
+			name: "Error - personal access tokens not deleted",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.users.On("GetUserById", ctx, testUserID).Return(user, nil)
+				m.users.On("UpdatePassword", ctx, testUserID, "").Return(nil)
+				m.tokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				m.accessTokens.On("DeleteUserTokens", ctx, testUserID).Return(testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.ForceReset(ctx, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// CheckBookmarks provides a mock function with given fields: ctx, userID, n
+func (_m *Service) CheckBookmarks(ctx context.Context, userID string, n int64) error {
+	ret := _m.Called(ctx, userID, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckBookmarks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, userID, n)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsumeAnonymousShortLink provides a mock function with given fields: ctx, clientIP
+func (_m *Service) ConsumeAnonymousShortLink(ctx context.Context, clientIP string) error {
+	ret := _m.Called(ctx, clientIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeAnonymousShortLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, clientIP)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsumeShortLink provides a mock function with given fields: ctx, userID
+func (_m *Service) ConsumeShortLink(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeShortLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLimits provides a mock function with given fields: ctx, userID
+func (_m *Service) GetLimits(ctx context.Context, userID string) (*model.QuotaLimits, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimits")
+	}
+
+	var r0 *model.QuotaLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.QuotaLimits, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.QuotaLimits); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.QuotaLimits)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuota provides a mock function with given fields: ctx, userID
+func (_m *Service) GetQuota(ctx context.Context, userID string) (*model.Quota, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuota")
+	}
+
+	var r0 *model.Quota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Quota, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Quota); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Quota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseAnonymousShortLink provides a mock function with given fields: ctx, clientIP
+func (_m *Service) ReleaseAnonymousShortLink(ctx context.Context, clientIP string) error {
+	ret := _m.Called(ctx, clientIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseAnonymousShortLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, clientIP)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseShortLink provides a mock function with given fields: ctx, userID
+func (_m *Service) ReleaseShortLink(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseShortLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,247 @@
+// Package quota enforces the per-user limits on bookmarks, short links and snapshot storage.
+// Default limits come from the configuration and can be overridden for single users.
+// Short links created without a token are limited per client address instead.
+package quota
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+)
+
+// Service-level errors returned when a limit is reached.
+var (
+	// ErrBookmarkQuotaExceeded is returned when a user would own more bookmarks than allowed.
+	ErrBookmarkQuotaExceeded = errors.New("bookmark quota exceeded")
+	// ErrShortLinkQuotaExceeded is returned when a user already created all the short links allowed today.
+	ErrShortLinkQuotaExceeded = errors.New("daily short link quota exceeded")
+)
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	GetLimits(ctx context.Context, userID string) (*model.QuotaLimits, error)
+	GetQuota(ctx context.Context, userID string) (*model.Quota, error)
+	CheckBookmarks(ctx context.Context, userID string, n int64) error
+	ConsumeShortLink(ctx context.Context, userID string) error
+	ReleaseShortLink(ctx context.Context, userID string) error
+	ConsumeAnonymousShortLink(ctx context.Context, clientIP string) error
+	ReleaseAnonymousShortLink(ctx context.Context, clientIP string) error
+}
+
+type quotaSvc struct {
+	repo         quota.Repository
+	bookmarkRepo bookmark.Repository
+	urlStorage   repository.UrlStorage
+	defaults     model.QuotaLimits
+	anonymous    int64
+	now          func() time.Time
+}
+
+// NewService creates a quota service.
+//
+// Parameters:
+//   - repo: Repository storing the per-user overrides
+//   - bookmarkRepo: Repository the bookmark and snapshot usage is read from
+//   - urlStorage: Storage counting the short links created each day
+//   - defaults: Limits of users without overrides, 0 meaning unlimited
+//   - anonymousShortLinksPerDay: Short links each client address may create per day without a token, 0 meaning unlimited
+func NewService(repo quota.Repository, bookmarkRepo bookmark.Repository, urlStorage repository.UrlStorage,
+	defaults model.QuotaLimits, anonymousShortLinksPerDay int64) Service {
+	return &quotaSvc{
+		repo:         repo,
+		bookmarkRepo: bookmarkRepo,
+		urlStorage:   urlStorage,
+		defaults:     defaults,
+		anonymous:    anonymousShortLinksPerDay,
+		now:          time.Now,
+	}
+}
+
+// GetLimits returns the limits of a user: the defaults with the user's overrides applied.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - *model.QuotaLimits: The limits, 0 meaning unlimited
+//   - error: Database error, if any
+func (s *quotaSvc) GetLimits(ctx context.Context, userID string) (*model.QuotaLimits, error) {
+	limits := s.defaults
+
+	override, err := s.repo.GetUserQuota(ctx, userID)
+	switch {
+	case err == nil:
+		limits = override.Apply(limits)
+	case !errors.Is(err, dbutils.ErrNotFoundType):
+		return nil, err
+	}
+
+	return &limits, nil
+}
+
+// GetQuota returns the limits of a user together with their current usage.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - *model.Quota: The limits, the usage and when the daily short link count starts over
+//   - error: Database or Redis error, if any
+func (s *quotaSvc) GetQuota(ctx context.Context, userID string) (*model.Quota, error) {
+	limits, err := s.GetLimits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := s.bookmarkRepo.CountBookmarks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotBytes, err := s.bookmarkRepo.GetSnapshotUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	shortLinks, err := s.urlStorage.GetLinkCount(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Quota{
+		Limits: *limits,
+		Usage: model.QuotaUsage{
+			Bookmarks:       bookmarks,
+			ShortLinksToday: shortLinks,
+			SnapshotBytes:   snapshotBytes,
+		},
+		ShortLinksResetAt: NextReset(now),
+	}, nil
+}
+
+// CheckBookmarks verifies that a user may create n more bookmarks.
+// The check is not atomic: concurrent requests of the same user may exceed the limit slightly.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//   - n: The number of bookmarks about to be created
+//
+// Returns:
+//   - error: ErrBookmarkQuotaExceeded if the user would own more bookmarks than allowed, or a database error
+func (s *quotaSvc) CheckBookmarks(ctx context.Context, userID string, n int64) error {
+	limits, err := s.GetLimits(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if limits.Bookmarks == 0 {
+		return nil
+	}
+
+	count, err := s.bookmarkRepo.CountBookmarks(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count+n > limits.Bookmarks {
+		return ErrBookmarkQuotaExceeded
+	}
+
+	return nil
+}
+
+// ConsumeShortLink counts a short link against the daily quota of a user.
+// The count is incremented before it is checked, so concurrent requests never exceed the limit;
+// a refused link is taken back right away.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - error: ErrShortLinkQuotaExceeded if the user already created all the links allowed today,
+//     or a database or Redis error
+func (s *quotaSvc) ConsumeShortLink(ctx context.Context, userID string) error {
+	limits, err := s.GetLimits(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.consumeShortLink(ctx, userID, limits.ShortLinksPerDay)
+}
+
+// ReleaseShortLink gives back a short link counted by ConsumeShortLink that was not created after all.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - error: Redis error, if any
+func (s *quotaSvc) ReleaseShortLink(ctx context.Context, userID string) error {
+	return s.urlStorage.DecrLinkCount(ctx, userID, s.now())
+}
+
+// ConsumeAnonymousShortLink counts a short link created without a token against the daily
+// quota shared by all anonymous callers from the same client address.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - clientIP: The address of the caller
+//
+// Returns:
+//   - error: ErrShortLinkQuotaExceeded if the address already created all the links allowed today,
+//     or a Redis error
+func (s *quotaSvc) ConsumeAnonymousShortLink(ctx context.Context, clientIP string) error {
+	return s.consumeShortLink(ctx, anonymousCounter(clientIP), s.anonymous)
+}
+
+// ReleaseAnonymousShortLink gives back a short link counted by ConsumeAnonymousShortLink
+// that was not created after all.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - clientIP: The address of the caller
+//
+// Returns:
+//   - error: Redis error, if any
+func (s *quotaSvc) ReleaseAnonymousShortLink(ctx context.Context, clientIP string) error {
+	return s.urlStorage.DecrLinkCount(ctx, anonymousCounter(clientIP), s.now())
+}
+
+// consumeShortLink increments the daily link count of counter and takes the link back
+// when the count goes over limit, 0 meaning unlimited.
+func (s *quotaSvc) consumeShortLink(ctx context.Context, counter string, limit int64) error {
+	now := s.now()
+	count, err := s.urlStorage.IncrLinkCount(ctx, counter, now)
+	if err != nil {
+		return err
+	}
+
+	if limit > 0 && count > limit {
+		if err := s.urlStorage.DecrLinkCount(ctx, counter, now); err != nil {
+			return errors.Join(ErrShortLinkQuotaExceeded, err)
+		}
+		return ErrShortLinkQuotaExceeded
+	}
+
+	return nil
+}
+
+// anonymousCounter returns the name the links of anonymous callers from an address are counted under.
+// User IDs are UUIDs, so the prefix keeps these counts apart from the ones of users.
+func anonymousCounter(clientIP string) string {
+	return "anonymous:" + clientIP
+}
+
+// NextReset returns when the daily short link count following t starts over, i.e. the next UTC midnight.
+func NextReset(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
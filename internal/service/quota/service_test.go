@@ -0,0 +1,330 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	bookmarkMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	urlMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	quotaMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/quota/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testUserID              = "user-123"
+	testClientIP            = "203.0.113.7"
+	testAnonymousShortLinks = 3
+)
+
+var (
+	testNow      = time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	testDefaults = model.QuotaLimits{Bookmarks: 100, ShortLinksPerDay: 10, SnapshotBytes: 1000}
+	testErr      = errors.New("database error")
+)
+
+// testMocks bundles the collaborators of the quota service.
+type testMocks struct {
+	repo         *quotaMocks.Repository
+	bookmarkRepo *bookmarkMocks.Repository
+	urlStorage   *urlMocks.UrlStorage
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		repo:         quotaMocks.NewRepository(t),
+		bookmarkRepo: bookmarkMocks.NewRepository(t),
+		urlStorage:   urlMocks.NewUrlStorage(t),
+	}
+	svc := NewService(m.repo, m.bookmarkRepo, m.urlStorage, testDefaults, testAnonymousShortLinks).(*quotaSvc)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestQuotaSvc_GetLimits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedOutput *model.QuotaLimits
+	}{
+		{
+			name: "Success - defaults without overrides",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedOutput: &testDefaults,
+		},
+		{
+			name: "Success - overrides applied",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(&model.UserQuota{
+					UserID:           testUserID,
+					MaxBookmarks:     int64Ptr(0),
+					MaxSnapshotBytes: int64Ptr(5000),
+				}, nil)
+			},
+			expectedOutput: &model.QuotaLimits{Bookmarks: 0, ShortLinksPerDay: 10, SnapshotBytes: 5000},
+		},
+		{
+			name: "Error - repository error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			limits, err := svc.GetLimits(ctx, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, limits)
+		})
+	}
+}
+
+func TestQuotaSvc_GetQuota(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedOutput *model.Quota
+	}{
+		{
+			name: "Success",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(42), nil)
+				m.bookmarkRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(512), nil)
+				m.urlStorage.On("GetLinkCount", ctx, testUserID, testNow).Return(int64(3), nil)
+			},
+			expectedOutput: &model.Quota{
+				Limits:            testDefaults,
+				Usage:             model.QuotaUsage{Bookmarks: 42, ShortLinksToday: 3, SnapshotBytes: 512},
+				ShortLinksResetAt: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Error - counting bookmarks fails",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "Error - reading link count fails",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(42), nil)
+				m.bookmarkRepo.On("GetSnapshotUsage", ctx, testUserID).Return(int64(512), nil)
+				m.urlStorage.On("GetLinkCount", ctx, testUserID, testNow).Return(int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			quota, err := svc.GetQuota(ctx, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, quota)
+		})
+	}
+}
+
+func TestQuotaSvc_CheckBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		inputN      int64
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name:   "Success - below the limit",
+			inputN: 1,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(99), nil)
+			},
+		},
+		{
+			name:   "Success - unlimited user is not counted",
+			inputN: 1000,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(&model.UserQuota{MaxBookmarks: int64Ptr(0)}, nil)
+			},
+		},
+		{
+			name:   "Error - limit reached",
+			inputN: 1,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(100), nil)
+			},
+			expectedErr: ErrBookmarkQuotaExceeded,
+		},
+		{
+			name:   "Error - batch does not fit",
+			inputN: 10,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.bookmarkRepo.On("CountBookmarks", ctx, testUserID).Return(int64(95), nil)
+			},
+			expectedErr: ErrBookmarkQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.CheckBookmarks(ctx, testUserID, tc.inputN)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestQuotaSvc_ConsumeShortLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - last link of the day",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.urlStorage.On("IncrLinkCount", ctx, testUserID, testNow).Return(int64(10), nil)
+			},
+		},
+		{
+			name: "Success - unlimited user is still counted",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(&model.UserQuota{MaxShortLinksPerDay: int64Ptr(0)}, nil)
+				m.urlStorage.On("IncrLinkCount", ctx, testUserID, testNow).Return(int64(500), nil)
+			},
+		},
+		{
+			name: "Error - limit reached, link taken back",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.urlStorage.On("IncrLinkCount", ctx, testUserID, testNow).Return(int64(11), nil)
+				m.urlStorage.On("DecrLinkCount", ctx, testUserID, testNow).Return(nil)
+			},
+			expectedErr: ErrShortLinkQuotaExceeded,
+		},
+		{
+			name: "Error - counter unavailable",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetUserQuota", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+				m.urlStorage.On("IncrLinkCount", ctx, testUserID, testNow).Return(int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.ConsumeShortLink(ctx, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestQuotaSvc_ConsumeAnonymousShortLink(t *testing.T) {
+	t.Parallel()
+
+	const counter = "anonymous:" + testClientIP
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success - last link of the day",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.urlStorage.On("IncrLinkCount", ctx, counter, testNow).Return(int64(testAnonymousShortLinks), nil)
+			},
+		},
+		{
+			name: "Error - limit reached, link taken back",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.urlStorage.On("IncrLinkCount", ctx, counter, testNow).Return(int64(testAnonymousShortLinks+1), nil)
+				m.urlStorage.On("DecrLinkCount", ctx, counter, testNow).Return(nil)
+			},
+			expectedErr: ErrShortLinkQuotaExceeded,
+		},
+		{
+			name: "Error - counter unavailable",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.urlStorage.On("IncrLinkCount", ctx, counter, testNow).Return(int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.ConsumeAnonymousShortLink(ctx, testClientIP)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestNextReset(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), NextReset(testNow))
+	assert.Equal(t, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), NextReset(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)))
+}
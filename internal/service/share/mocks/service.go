@@ -0,0 +1,229 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// CreatePublicLink provides a mock function with given fields: ctx, ownerID, targetType, target
+func (_m *Service) CreatePublicLink(ctx context.Context, ownerID string, targetType model.ShareTargetType, target string) (*model.Share, error) {
+	ret := _m.Called(ctx, ownerID, targetType, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePublicLink")
+	}
+
+	var r0 *model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.ShareTargetType, string) (*model.Share, error)); ok {
+		return rf(ctx, ownerID, targetType, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.ShareTargetType, string) *model.Share); ok {
+		r0 = rf(ctx, ownerID, targetType, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.ShareTargetType, string) error); ok {
+		r1 = rf(ctx, ownerID, targetType, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteShare provides a mock function with given fields: ctx, shareID, ownerID
+func (_m *Service) DeleteShare(ctx context.Context, shareID string, ownerID string) error {
+	ret := _m.Called(ctx, shareID, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteShare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, shareID, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPublicBookmarks provides a mock function with given fields: ctx, token, req
+func (_m *Service) GetPublicBookmarks(ctx context.Context, token string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+	ret := _m.Called(ctx, token, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicBookmarks")
+	}
+
+	var r0 *pagination.Response[*model.Bookmark]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Request) (*pagination.Response[*model.Bookmark], error)); ok {
+		return rf(ctx, token, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Request) *pagination.Response[*model.Bookmark]); ok {
+		r0 = rf(ctx, token, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Response[*model.Bookmark])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *pagination.Request) error); ok {
+		r1 = rf(ctx, token, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceivedShares provides a mock function with given fields: ctx, userID
+func (_m *Service) GetReceivedShares(ctx context.Context, userID string) ([]*model.Share, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceivedShares")
+	}
+
+	var r0 []*model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Share, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Share); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSharedBookmarks provides a mock function with given fields: ctx, userID, req
+func (_m *Service) GetSharedBookmarks(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedBookmarks")
+	}
+
+	var r0 *pagination.Response[*model.Bookmark]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Request) (*pagination.Response[*model.Bookmark], error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Request) *pagination.Response[*model.Bookmark]); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Response[*model.Bookmark])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *pagination.Request) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetShares provides a mock function with given fields: ctx, ownerID
+func (_m *Service) GetShares(ctx context.Context, ownerID string) ([]*model.Share, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShares")
+	}
+
+	var r0 []*model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Share, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Share); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareWithUser provides a mock function with given fields: ctx, ownerID, targetType, target, username, permission
+func (_m *Service) ShareWithUser(ctx context.Context, ownerID string, targetType model.ShareTargetType, target string, username string, permission model.SharePermission) (*model.Share, error) {
+	ret := _m.Called(ctx, ownerID, targetType, target, username, permission)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareWithUser")
+	}
+
+	var r0 *model.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.ShareTargetType, string, string, model.SharePermission) (*model.Share, error)); ok {
+		return rf(ctx, ownerID, targetType, target, username, permission)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.ShareTargetType, string, string, model.SharePermission) *model.Share); ok {
+		r0 = rf(ctx, ownerID, targetType, target, username, permission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.ShareTargetType, string, string, model.SharePermission) error); ok {
+		r1 = rf(ctx, ownerID, targetType, target, username, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
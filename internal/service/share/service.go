@@ -0,0 +1,247 @@
+// Package share implements sharing bookmarks, tags and folders with other users
+// and through public read-only links.
+package share
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/share"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
+)
+
+// tokenLength is the length of public link tokens. With 62 possible characters,
+// 32 characters carry about 190 bits of randomness, which cannot be guessed.
+const tokenLength = 32
+
+// Service-level errors returned by share operations.
+var (
+	// ErrGranteeNotFound is returned when sharing with a username that does not exist.
+	ErrGranteeNotFound = errors.New("grantee not found")
+	// ErrShareWithSelf is returned when users try to share with themselves.
+	ErrShareWithSelf = errors.New("cannot share with yourself")
+)
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	ShareWithUser(ctx context.Context, ownerID string, targetType model.ShareTargetType, target, username string, permission model.SharePermission) (*model.Share, error)
+	CreatePublicLink(ctx context.Context, ownerID string, targetType model.ShareTargetType, target string) (*model.Share, error)
+	GetShares(ctx context.Context, ownerID string) ([]*model.Share, error)
+	GetReceivedShares(ctx context.Context, userID string) ([]*model.Share, error)
+	DeleteShare(ctx context.Context, shareID, ownerID string) error
+	GetSharedBookmarks(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error)
+	GetPublicBookmarks(ctx context.Context, token string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error)
+}
+
+type shareSvc struct {
+	repo         share.Repository
+	bookmarkRepo bookmark.Repository
+	userRepo     repository.User
+	keyGen       stringutils.KeyGenerator
+}
+
+// NewService creates a share service.
+//
+// Parameters:
+//   - repo: Repository storing the shares
+//   - bookmarkRepo: Repository reading the shared bookmarks
+//   - userRepo: Repository resolving grantees by username
+//   - keyGen: Generator for public link tokens
+func NewService(repo share.Repository, bookmarkRepo bookmark.Repository, userRepo repository.User, keyGen stringutils.KeyGenerator) Service {
+	return &shareSvc{repo: repo, bookmarkRepo: bookmarkRepo, userRepo: userRepo, keyGen: keyGen}
+}
+
+// ShareWithUser gives another user access to a bookmark, a tag or a folder of the owner.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - ownerID: The ID of the user sharing their bookmarks
+//   - targetType: Whether a bookmark, a tag or a folder is shared
+//   - target: The bookmark ID, tag name or folder path
+//   - username: The username of the grantee
+//   - permission: What the grantee may do with the bookmarks
+//
+// Returns:
+//   - *model.Share: The created share
+//   - error: ErrGranteeNotFound, ErrShareWithSelf, ErrNotFoundType if the bookmark is not the owner's,
+//     ErrDuplicationType if the collection is already shared with the grantee, or a database error
+func (s *shareSvc) ShareWithUser(ctx context.Context, ownerID string, targetType model.ShareTargetType, target, username string, permission model.SharePermission) (*model.Share, error) {
+	grantee, err := s.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			return nil, ErrGranteeNotFound
+		}
+		return nil, err
+	}
+	if grantee.ID == ownerID {
+		return nil, ErrShareWithSelf
+	}
+
+	target, err = s.checkTarget(ctx, ownerID, targetType, target)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := s.repo.CreateShare(ctx, &model.Share{
+		OwnerID:    ownerID,
+		TargetType: targetType,
+		Target:     target,
+		GranteeID:  &grantee.ID,
+		Permission: permission,
+	})
+	if err != nil {
+		return nil, err
+	}
+	share.Grantee = grantee
+	return share, nil
+}
+
+// CreatePublicLink creates a read-only link to a bookmark, a tag or a folder of the owner
+// that anyone holding its token can open without logging in.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - ownerID: The ID of the user sharing their bookmarks
+//   - targetType: Whether a bookmark, a tag or a folder is shared
+//   - target: The bookmark ID, tag name or folder path
+//
+// Returns:
+//   - *model.Share: The created share, including its token
+//   - error: ErrNotFoundType if the bookmark is not the owner's, or any error during generation or persistence
+func (s *shareSvc) CreatePublicLink(ctx context.Context, ownerID string, targetType model.ShareTargetType, target string) (*model.Share, error) {
+	target, err := s.checkTarget(ctx, ownerID, targetType, target)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.keyGen.GenerateCode(tokenLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateShare(ctx, &model.Share{
+		OwnerID:    ownerID,
+		TargetType: targetType,
+		Target:     target,
+		Permission: model.SharePermissionViewer,
+		Token:      &token,
+	})
+}
+
+// checkTarget normalizes the target of a new share. A shared bookmark must belong to the owner;
+// tags and folders are not checked since they may be filled later.
+func (s *shareSvc) checkTarget(ctx context.Context, ownerID string, targetType model.ShareTargetType, target string) (string, error) {
+	switch targetType {
+	case model.ShareTargetBookmark:
+		bookmark, err := s.bookmarkRepo.GetBookmarkByID(ctx, target, ownerID)
+		if err != nil {
+			return "", err
+		}
+		// Bookmarks shared with the owner can be read, but not shared further
+		if bookmark.UserID != ownerID {
+			return "", dbutils.ErrNotFoundType
+		}
+	case model.ShareTargetFolder:
+		target = strings.Trim(target, "/")
+	case model.ShareTargetTag:
+		target = strings.TrimSpace(target)
+	}
+	return target, nil
+}
+
+// GetShares retrieves every share created by a user, with the grantee of user shares.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - ownerID: The ID of the owner
+//
+// Returns:
+//   - []*model.Share: The shares
+//   - error: Database error, if any
+func (s *shareSvc) GetShares(ctx context.Context, ownerID string) ([]*model.Share, error) {
+	return s.repo.GetShares(ctx, ownerID)
+}
+
+// GetReceivedShares retrieves every share granted to a user, with the owner of each share.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the grantee
+//
+// Returns:
+//   - []*model.Share: The shares
+//   - error: Database error, if any
+func (s *shareSvc) GetReceivedShares(ctx context.Context, userID string) ([]*model.Share, error) {
+	return s.repo.GetReceivedShares(ctx, userID)
+}
+
+// DeleteShare revokes a share created by the user.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - shareID: The ID of the share to revoke
+//   - ownerID: The ID of the owner
+//
+// Returns:
+//   - error: ErrNotFoundType if not found or not owned by the user, or a database error
+func (s *shareSvc) DeleteShare(ctx context.Context, shareID, ownerID string) error {
+	return s.repo.DeleteShare(ctx, shareID, ownerID)
+}
+
+// GetSharedBookmarks retrieves a paginated list of the bookmarks other users shared with the user.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the grantee
+//   - req: Pointer to Pagination request with Page and Limit
+//
+// Returns:
+//   - *pagination.Response: Standard paginated response wrapper
+//   - error: Database or internal error
+func (s *shareSvc) GetSharedBookmarks(ctx context.Context, userID string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+	limit := req.GetLimit()
+
+	bookmarks, total, err := s.bookmarkRepo.GetSharedBookmarks(ctx, userID, limit, req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pagination.Response[*model.Bookmark]{
+		Data:     bookmarks,
+		Metadata: pagination.CalculateMetadata(total, req.Page, limit),
+	}, nil
+}
+
+// GetPublicBookmarks retrieves a paginated list of the bookmarks behind a public link.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - token: The token of the public link
+//   - req: Pointer to Pagination request with Page and Limit
+//
+// Returns:
+//   - *pagination.Response: Standard paginated response wrapper
+//   - error: ErrNotFoundType if the link does not exist (or was revoked), or a database error
+func (s *shareSvc) GetPublicBookmarks(ctx context.Context, token string, req *pagination.Request) (*pagination.Response[*model.Bookmark], error) {
+	share, err := s.repo.GetShareByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.GetLimit()
+	bookmarks, total, err := s.bookmarkRepo.GetBookmarksByShare(ctx, share.ID, limit, req.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pagination.Response[*model.Bookmark]{
+		Data:     bookmarks,
+		Metadata: pagination.CalculateMetadata(total, req.Page, limit),
+	}, nil
+}
@@ -0,0 +1,288 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	bookmarkMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	userMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	shareMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/share/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/pagination"
+	keyMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testOwnerID    = "user-123"
+	testGranteeID  = "user-456"
+	testUsername   = "grantee"
+	testBookmarkID = "bookmark-1"
+	testToken      = "abcdefghijklmnopqrstuvwxyz012345"
+)
+
+// testMocks bundles the collaborators of the share service.
+type testMocks struct {
+	repo         *shareMocks.Repository
+	bookmarkRepo *bookmarkMocks.Repository
+	userRepo     *userMocks.User
+	keyGen       *keyMocks.KeyGenerator
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		repo:         shareMocks.NewRepository(t),
+		bookmarkRepo: bookmarkMocks.NewRepository(t),
+		userRepo:     userMocks.NewUser(t),
+		keyGen:       keyMocks.NewKeyGenerator(t),
+	}
+	return NewService(m.repo, m.bookmarkRepo, m.userRepo, m.keyGen), m
+}
+
+func TestShareSvc_ShareWithUser(t *testing.T) {
+	t.Parallel()
+
+	grantee := &model.User{Base: model.Base{ID: testGranteeID}, Username: testUsername}
+	granteeID := testGranteeID
+
+	testCases := []struct {
+		name            string
+		inputTargetType model.ShareTargetType
+		inputTarget     string
+		setupMock       func(ctx context.Context, m *testMocks)
+		expectedErr     error
+		expectedOutput  *model.Share
+	}{
+		{
+			name:            "Success - folder path is trimmed",
+			inputTargetType: model.ShareTargetFolder,
+			inputTarget:     "/Work/Go/",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.userRepo.On("GetUserByUsername", ctx, testUsername).Return(grantee, nil)
+				m.repo.On("CreateShare", ctx, &model.Share{
+					OwnerID:    testOwnerID,
+					TargetType: model.ShareTargetFolder,
+					Target:     "Work/Go",
+					GranteeID:  &granteeID,
+					Permission: model.SharePermissionEditor,
+				}).Return(func(_ context.Context, share *model.Share) (*model.Share, error) {
+					return share, nil
+				})
+			},
+			expectedOutput: &model.Share{
+				OwnerID:    testOwnerID,
+				TargetType: model.ShareTargetFolder,
+				Target:     "Work/Go",
+				GranteeID:  &granteeID,
+				Grantee:    grantee,
+				Permission: model.SharePermissionEditor,
+			},
+		},
+		{
+			name:            "Success - own bookmark",
+			inputTargetType: model.ShareTargetBookmark,
+			inputTarget:     testBookmarkID,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.userRepo.On("GetUserByUsername", ctx, testUsername).Return(grantee, nil)
+				m.bookmarkRepo.On("GetBookmarkByID", ctx, testBookmarkID, testOwnerID).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, UserID: testOwnerID}, nil)
+				m.repo.On("CreateShare", ctx, mock.Anything).Return(func(_ context.Context, share *model.Share) (*model.Share, error) {
+					return share, nil
+				})
+			},
+			expectedOutput: &model.Share{
+				OwnerID:    testOwnerID,
+				TargetType: model.ShareTargetBookmark,
+				Target:     testBookmarkID,
+				GranteeID:  &granteeID,
+				Grantee:    grantee,
+				Permission: model.SharePermissionEditor,
+			},
+		},
+		{
+			name:            "Error - Bookmark Shared With The Owner",
+			inputTargetType: model.ShareTargetBookmark,
+			inputTarget:     testBookmarkID,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.userRepo.On("GetUserByUsername", ctx, testUsername).Return(grantee, nil)
+				m.bookmarkRepo.On("GetBookmarkByID", ctx, testBookmarkID, testOwnerID).
+					Return(&model.Bookmark{Base: model.Base{ID: testBookmarkID}, UserID: "someone-else"}, nil)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name:            "Error - Grantee Not Found",
+			inputTargetType: model.ShareTargetTag,
+			inputTarget:     "golang",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.userRepo.On("GetUserByUsername", ctx, testUsername).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrGranteeNotFound,
+		},
+		{
+			name:            "Error - Share With Self",
+			inputTargetType: model.ShareTargetTag,
+			inputTarget:     "golang",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.userRepo.On("GetUserByUsername", ctx, testUsername).
+					Return(&model.User{Base: model.Base{ID: testOwnerID}}, nil)
+			},
+			expectedErr: ErrShareWithSelf,
+		},
+		{
+			name:            "Error - Already Shared",
+			inputTargetType: model.ShareTargetTag,
+			inputTarget:     "golang",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.userRepo.On("GetUserByUsername", ctx, testUsername).Return(grantee, nil)
+				m.repo.On("CreateShare", ctx, mock.Anything).Return(nil, dbutils.ErrDuplicationType)
+			},
+			expectedErr: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.ShareWithUser(ctx, testOwnerID, tc.inputTargetType, tc.inputTarget, testUsername, model.SharePermissionEditor)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestShareSvc_CreatePublicLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", tokenLength).Return(testToken, nil)
+				m.repo.On("CreateShare", ctx, mock.MatchedBy(func(share *model.Share) bool {
+					return share.GranteeID == nil && *share.Token == testToken &&
+						share.Permission == model.SharePermissionViewer && share.Target == "golang"
+				})).Return(func(_ context.Context, share *model.Share) (*model.Share, error) {
+					return share, nil
+				})
+			},
+		},
+		{
+			name: "Error - Generate Token Failed",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.keyGen.On("GenerateCode", tokenLength).Return("", errors.New("entropy error"))
+			},
+			expectedErr: errors.New("entropy error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.CreatePublicLink(ctx, testOwnerID, model.ShareTargetTag, " golang ")
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testToken, *got.Token)
+		})
+	}
+}
+
+func TestShareSvc_GetPublicBookmarks(t *testing.T) {
+	t.Parallel()
+
+	req := &pagination.Request{Page: 1, Limit: 10}
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedOutput *pagination.Response[*model.Bookmark]
+	}{
+		{
+			name: "Success",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetShareByToken", ctx, testToken).Return(&model.Share{Base: model.Base{ID: "share-1"}}, nil)
+				m.bookmarkRepo.On("GetBookmarksByShare", ctx, "share-1", 10, 0).
+					Return([]*model.Bookmark{{Base: model.Base{ID: testBookmarkID}}}, int64(1), nil)
+			},
+			expectedOutput: &pagination.Response[*model.Bookmark]{
+				Data: []*model.Bookmark{{Base: model.Base{ID: testBookmarkID}}},
+				Metadata: pagination.Metadata{
+					CurrentPage:  1,
+					PageSize:     10,
+					FirstPage:    1,
+					LastPage:     1,
+					TotalRecords: 1,
+				},
+			},
+		},
+		{
+			name: "Error - Unknown Token",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.repo.On("GetShareByToken", ctx, testToken).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.GetPublicBookmarks(ctx, testToken, req)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestShareSvc_GetSharedBookmarks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, m := newTestService(t)
+	m.bookmarkRepo.On("GetSharedBookmarks", ctx, testGranteeID, 5, 5).Return(nil, int64(0), errors.New("db error"))
+
+	got, err := svc.GetSharedBookmarks(ctx, testGranteeID, &pagination.Request{Page: 2, Limit: 5})
+
+	assert.EqualError(t, err, "db error")
+	assert.Nil(t, got)
+}
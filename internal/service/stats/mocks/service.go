@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// GetStats provides a mock function with given fields: ctx, userID, top
+func (_m *Service) GetStats(ctx context.Context, userID string, top int) (*model.BookmarkStats, error) {
+	ret := _m.Called(ctx, userID, top)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 *model.BookmarkStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*model.BookmarkStats, error)); ok {
+		return rf(ctx, userID, top)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *model.BookmarkStats); ok {
+		r0 = rf(ctx, userID, top)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BookmarkStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, top)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
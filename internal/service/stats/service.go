@@ -0,0 +1,82 @@
+// Package stats computes the bookmark statistics of a user.
+// The statistics are aggregated by the database and cached in Redis for a short while.
+package stats
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	GetStats(ctx context.Context, userID string, top int) (*model.BookmarkStats, error)
+}
+
+type statsSvc struct {
+	bookmarkRepo bookmark.Repository
+	cache        repository.StatsCache
+	ttl          time.Duration
+	now          func() time.Time
+}
+
+// NewService creates a statistics service.
+//
+// Parameters:
+//   - bookmarkRepo: Repository the statistics are aggregated by
+//   - cache: Cache the statistics are kept in
+//   - ttl: How long the statistics are cached, 0 disables the cache
+func NewService(bookmarkRepo bookmark.Repository, cache repository.StatsCache, ttl time.Duration) Service {
+	return &statsSvc{
+		bookmarkRepo: bookmarkRepo,
+		cache:        cache,
+		ttl:          ttl,
+		now:          time.Now,
+	}
+}
+
+// GetStats returns the bookmark statistics of a user, from the cache when they were computed recently.
+// The cache is best effort: when Redis fails the statistics are computed from the database.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//   - top: Number of domains and clicked bookmarks listed at most
+//
+// Returns:
+//   - *model.BookmarkStats: The statistics
+//   - error: Database error, if any
+func (s *statsSvc) GetStats(ctx context.Context, userID string, top int) (*model.BookmarkStats, error) {
+	key := userID + ":" + strconv.Itoa(top)
+
+	if s.ttl > 0 {
+		stats, err := s.cache.GetStats(ctx, key)
+		if err == nil {
+			return stats, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			log.Warn().Err(err).Str("user_id", userID).Msg("Failed to read cached stats")
+		}
+	}
+
+	stats, err := s.bookmarkRepo.GetStats(ctx, userID, top)
+	if err != nil {
+		return nil, err
+	}
+	stats.GeneratedAt = s.now().UTC()
+
+	if s.ttl > 0 {
+		if err := s.cache.SetStats(ctx, key, stats, s.ttl); err != nil {
+			log.Warn().Err(err).Str("user_id", userID).Msg("Failed to cache stats")
+		}
+	}
+
+	return stats, nil
+}
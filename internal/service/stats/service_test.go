@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	bookmarkMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
+	cacheMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testUserID = "user-123"
+	testKey    = "user-123:10"
+	testTTL    = time.Minute
+)
+
+var (
+	testNow = time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	testErr = errors.New("database error")
+)
+
+func TestStatsSvc_GetStats(t *testing.T) {
+	t.Parallel()
+
+	cached := &model.BookmarkStats{Total: 5, GeneratedAt: testNow.Add(-time.Minute)}
+
+	testCases := []struct {
+		name           string
+		ttl            time.Duration
+		setupMock      func(ctx context.Context, repo *bookmarkMocks.Repository, cache *cacheMocks.StatsCache)
+		expectedErr    error
+		expectedOutput *model.BookmarkStats
+	}{
+		{
+			name: "Success - served from cache",
+			ttl:  testTTL,
+			setupMock: func(ctx context.Context, repo *bookmarkMocks.Repository, cache *cacheMocks.StatsCache) {
+				cache.On("GetStats", ctx, testKey).Return(cached, nil)
+			},
+			expectedOutput: cached,
+		},
+		{
+			name: "Success - computed and cached on miss",
+			ttl:  testTTL,
+			setupMock: func(ctx context.Context, repo *bookmarkMocks.Repository, cache *cacheMocks.StatsCache) {
+				cache.On("GetStats", ctx, testKey).Return(nil, redis.Nil)
+				repo.On("GetStats", ctx, testUserID, 10).Return(&model.BookmarkStats{Total: 3}, nil)
+				cache.On("SetStats", ctx, testKey, &model.BookmarkStats{Total: 3, GeneratedAt: testNow}, testTTL).Return(nil)
+			},
+			expectedOutput: &model.BookmarkStats{Total: 3, GeneratedAt: testNow},
+		},
+		{
+			name: "Success - cache errors are ignored",
+			ttl:  testTTL,
+			setupMock: func(ctx context.Context, repo *bookmarkMocks.Repository, cache *cacheMocks.StatsCache) {
+				cache.On("GetStats", ctx, testKey).Return(nil, redis.ErrClosed)
+				repo.On("GetStats", ctx, testUserID, 10).Return(&model.BookmarkStats{Total: 3}, nil)
+				cache.On("SetStats", ctx, testKey, &model.BookmarkStats{Total: 3, GeneratedAt: testNow}, testTTL).Return(redis.ErrClosed)
+			},
+			expectedOutput: &model.BookmarkStats{Total: 3, GeneratedAt: testNow},
+		},
+		{
+			name: "Success - cache disabled",
+			setupMock: func(ctx context.Context, repo *bookmarkMocks.Repository, cache *cacheMocks.StatsCache) {
+				repo.On("GetStats", ctx, testUserID, 10).Return(&model.BookmarkStats{Total: 3}, nil)
+			},
+			expectedOutput: &model.BookmarkStats{Total: 3, GeneratedAt: testNow},
+		},
+		{
+			name: "Error - repository error",
+			ttl:  testTTL,
+			setupMock: func(ctx context.Context, repo *bookmarkMocks.Repository, cache *cacheMocks.StatsCache) {
+				cache.On("GetStats", ctx, testKey).Return(nil, redis.Nil)
+				repo.On("GetStats", ctx, testUserID, 10).Return(nil, testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			repo := bookmarkMocks.NewRepository(t)
+			cache := cacheMocks.NewStatsCache(t)
+			tc.setupMock(ctx, repo, cache)
+
+			svc := NewService(repo, cache, tc.ttl).(*statsSvc)
+			svc.now = func() time.Time { return testNow }
+
+			got, err := svc.GetStats(ctx, testUserID, 10)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
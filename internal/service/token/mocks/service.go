@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/HadesHo3820/ebvn-golang-course/internal/model"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// Issue provides a mock function with given fields: ctx, userID, roles
+func (_m *Service) Issue(ctx context.Context, userID string, roles model.Roles) (*model.TokenPair, error) {
+	ret := _m.Called(ctx, userID, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 *model.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Roles) (*model.TokenPair, error)); ok {
+		return rf(ctx, userID, roles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.Roles) *model.TokenPair); ok {
+		r0 = rf(ctx, userID, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.Roles) error); ok {
+		r1 = rf(ctx, userID, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *Service) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *model.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.TokenPair, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, claims, refreshToken
+func (_m *Service) Revoke(ctx context.Context, claims jwt.MapClaims, refreshToken string) error {
+	ret := _m.Called(ctx, claims, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, jwt.MapClaims, string) error); ok {
+		r0 = rf(ctx, claims, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAll provides a mock function with given fields: ctx, userID
+func (_m *Service) RevokeAll(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Validate provides a mock function with given fields: ctx, claims
+func (_m *Service) Validate(ctx context.Context, claims jwt.MapClaims) error {
+	ret := _m.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, jwt.MapClaims) error); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,275 @@
+// Package token issues the credentials of signed-in users: short-lived JWT access tokens
+// and opaque refresh tokens, which are rotated on each use. It also revokes them, either
+// one login at a time or every token of a user at once.
+package token
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// refreshTokenLength is the length of refresh tokens. 43 alphanumeric characters carry about 256 bits.
+const refreshTokenLength = 43
+
+// Service-level errors returned when refreshing tokens.
+var (
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when a refresh token is used a second time.
+	// The token may have been stolen, so every token of its family is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrTokenRevoked is returned when an access token was revoked before its expiry.
+	ErrTokenRevoked = errors.New("token revoked")
+)
+
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	Issue(ctx context.Context, userID string, roles model.Roles) (*model.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+	Validate(ctx context.Context, claims jwt.MapClaims) error
+	Revoke(ctx context.Context, claims jwt.MapClaims, refreshToken string) error
+	RevokeAll(ctx context.Context, userID string) error
+}
+
+// Config holds the lifetimes of the issued tokens.
+type Config struct {
+	// AccessTTL is how long an access token is valid.
+	AccessTTL time.Duration
+	// RefreshTTL is how long a refresh token is valid. Each refresh starts the period over.
+	RefreshTTL time.Duration
+}
+
+type tokenSvc struct {
+	jwtGen      jwtutils.JWTGenerator
+	store       repository.RefreshTokenStore
+	revocations repository.TokenRevocationStore
+	keyGen      stringutils.KeyGenerator
+	cfg         Config
+	now         func() time.Time
+}
+
+// NewService creates a token service.
+//
+// Parameters:
+//   - jwtGen: Generator signing the access tokens
+//   - store: Storage of the refresh tokens
+//   - revocations: Storage of the revoked access tokens and of the token generations
+//   - keyGen: Generator of the random refresh tokens
+//   - cfg: Lifetimes of the tokens
+func NewService(jwtGen jwtutils.JWTGenerator, store repository.RefreshTokenStore, revocations repository.TokenRevocationStore,
+	keyGen stringutils.KeyGenerator, cfg Config) Service {
+	return &tokenSvc{
+		jwtGen:      jwtGen,
+		store:       store,
+		revocations: revocations,
+		keyGen:      keyGen,
+		cfg:         cfg,
+		now:         time.Now,
+	}
+}
+
+// Issue creates the tokens of a user who just signed in. The refresh token starts a new family.
+// The roles are embedded in the access tokens of the family until it ends, so a change of the
+// roles of the user takes effect at the next login, or right away by revoking the user's tokens.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//   - roles: The roles of the user
+//
+// Returns:
+//   - *model.TokenPair: The access and refresh tokens
+//   - error: Signing, generation or Redis error, if any
+func (s *tokenSvc) Issue(ctx context.Context, userID string, roles model.Roles) (*model.TokenPair, error) {
+	gen, err := s.revocations.GetTokenGeneration(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, &model.RefreshToken{UserID: userID, FamilyID: uuid.NewString(), Generation: gen, Roles: roles})
+}
+
+// Refresh exchanges a refresh token for new tokens. The refresh token is rotated: it cannot be
+// used again, and the new one belongs to the same family. Using it again revokes the family,
+// logging out both the legitimate client and whoever replayed the token.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - refreshToken: The refresh token received with the previous tokens
+//
+// Returns:
+//   - *model.TokenPair: The new access and refresh tokens
+//   - error: ErrInvalidRefreshToken if the token is unknown, expired or revoked (also through
+//     RevokeAll), ErrRefreshTokenReused if it was already used, or a signing, generation or Redis error
+func (s *tokenSvc) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	stored, uses, err := s.store.UseRefreshToken(ctx, HashToken(refreshToken))
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if uses > 1 {
+		if err := s.store.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, errors.Join(ErrRefreshTokenReused, err)
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	gen, err := s.revocations.GetTokenGeneration(ctx, stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Generation < gen {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return s.issue(ctx, stored)
+}
+
+// Validate checks that an access token, whose signature and expiry were already verified,
+// was not revoked since it was issued.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - claims: The claims of the access token
+//
+// Returns:
+//   - error: ErrTokenRevoked if the token was revoked, or a Redis error
+func (s *tokenSvc) Validate(ctx context.Context, claims jwt.MapClaims) error {
+	userID, _ := claims["sub"].(string)
+	gen, err := s.revocations.GetTokenGeneration(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if claimInt64(claims, "gen") < gen {
+		return ErrTokenRevoked
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return nil
+	}
+	denied, err := s.revocations.IsTokenDenied(ctx, jti)
+	if err != nil {
+		return err
+	}
+	if denied {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// Revoke logs out a single login: the access token is denylisted until it expires and,
+// when given, the family of the refresh token is revoked. Refresh tokens of other users
+// and unknown refresh tokens are ignored.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - claims: The claims of the access token
+//   - refreshToken: The refresh token of the same login, or "" to leave it valid
+//
+// Returns:
+//   - error: Redis error, if any
+func (s *tokenSvc) Revoke(ctx context.Context, claims jwt.MapClaims, refreshToken string) error {
+	jti, _ := claims["jti"].(string)
+	ttl := time.Unix(claimInt64(claims, "exp"), 0).Sub(s.now())
+	if jti != "" && ttl > 0 {
+		if err := s.revocations.DenyToken(ctx, jti, ttl); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	stored, err := s.store.GetRefreshToken(ctx, HashToken(refreshToken))
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if userID, _ := claims["sub"].(string); stored.UserID != userID {
+		return nil
+	}
+	return s.store.RevokeFamily(ctx, stored.FamilyID)
+}
+
+// RevokeAll revokes every access and refresh token issued to a user so far,
+// by moving the user to the next token generation.
+//
+// Parameters:
+//   - ctx: Context for the operation
+//   - userID: The ID of the user
+//
+// Returns:
+//   - error: Redis error, if any
+func (s *tokenSvc) RevokeAll(ctx context.Context, userID string) error {
+	_, err := s.revocations.IncrTokenGeneration(ctx, userID)
+	return err
+}
+
+// issue signs an access token for the owner of the refresh token record,
+// and stores a new refresh token under it.
+func (s *tokenSvc) issue(ctx context.Context, refresh *model.RefreshToken) (*model.TokenPair, error) {
+	now := s.now()
+	accessToken, err := s.jwtGen.GenerateToken(jwt.MapClaims{
+		"sub":   refresh.UserID,
+		"jti":   uuid.NewString(),
+		"gen":   refresh.Generation,
+		"roles": []string(refresh.Roles),
+		"iat":   now.Unix(),
+		"exp":   now.Add(s.cfg.AccessTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.keyGen.GenerateCode(refreshTokenLength)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveRefreshToken(ctx, HashToken(refreshToken), refresh, s.cfg.RefreshTTL); err != nil {
+		return nil, err
+	}
+
+	return &model.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.cfg.AccessTTL / time.Second),
+	}, nil
+}
+
+// claimInt64 returns a numeric claim as an integer. Claims parsed from a token are float64,
+// while claims built in code may be integers. Missing or non-numeric claims count as 0.
+func claimInt64(claims jwt.MapClaims, name string) int64 {
+	switch v := claims[name].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	}
+	return 0
+}
+
+// HashToken returns the hex-encoded SHA-256 of a token. Refresh tokens and the other
+// secrets handed out by links (such as password reset tokens) are random and long, so a
+// fast unsalted hash is enough to keep them from being usable when read from storage.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
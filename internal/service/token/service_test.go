@@ -0,0 +1,368 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	storeMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	jwtMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils/mocks"
+	keyMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testUserID       = "user-123"
+	testFamilyID     = "family-1"
+	testAccessToken  = "access-token"
+	testRefreshToken = "refresh-token"
+	testNewRefresh   = "new-refresh-token"
+)
+
+var (
+	testNow    = time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC)
+	testConfig = Config{AccessTTL: 15 * time.Minute, RefreshTTL: 720 * time.Hour}
+	testErr    = errors.New("redis error")
+	testRoles  = model.Roles{model.RoleUser, model.RoleAdmin}
+)
+
+// testMocks bundles the collaborators of the token service.
+type testMocks struct {
+	jwtGen      *jwtMocks.JWTGenerator
+	store       *storeMocks.RefreshTokenStore
+	revocations *storeMocks.TokenRevocationStore
+	keyGen      *keyMocks.KeyGenerator
+}
+
+func newTestService(t *testing.T) (Service, *testMocks) {
+	m := &testMocks{
+		jwtGen:      jwtMocks.NewJWTGenerator(t),
+		store:       storeMocks.NewRefreshTokenStore(t),
+		revocations: storeMocks.NewTokenRevocationStore(t),
+		keyGen:      keyMocks.NewKeyGenerator(t),
+	}
+	svc := NewService(m.jwtGen, m.store, m.revocations, m.keyGen, testConfig).(*tokenSvc)
+	svc.now = func() time.Time { return testNow }
+	return svc, m
+}
+
+// expectIssue sets up the mocks for issuing tokens of generation gen whose refresh token
+// is stored as family, which may be a mock argument matcher.
+func expectIssue(ctx context.Context, m *testMocks, gen int64, family any) {
+	m.jwtGen.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+		jti, _ := claims["jti"].(string)
+		return claims["sub"] == testUserID && jti != "" && claims["gen"] == gen &&
+			assert.ObjectsAreEqual(claims["roles"], []string(testRoles)) &&
+			claims["iat"] == testNow.Unix() && claims["exp"] == testNow.Add(testConfig.AccessTTL).Unix()
+	})).Return(testAccessToken, nil)
+	m.keyGen.On("GenerateCode", refreshTokenLength).Return(testNewRefresh, nil)
+	m.store.On("SaveRefreshToken", ctx, HashToken(testNewRefresh), family, testConfig.RefreshTTL).Return(nil)
+}
+
+func TestTokenSvc_Issue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedOutput *model.TokenPair
+	}{
+		{
+			name: "Success - new family",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(2), nil)
+				expectIssue(ctx, m, 2, mock.MatchedBy(func(r *model.RefreshToken) bool {
+					return r.UserID == testUserID && r.FamilyID != "" && r.Generation == 2 &&
+						assert.ObjectsAreEqual(r.Roles, testRoles)
+				}))
+			},
+			expectedOutput: &model.TokenPair{AccessToken: testAccessToken, RefreshToken: testNewRefresh, ExpiresIn: 900},
+		},
+		{
+			name: "Error - store error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(0), nil)
+				m.jwtGen.On("GenerateToken", mock.Anything).Return(testAccessToken, nil)
+				m.keyGen.On("GenerateCode", refreshTokenLength).Return(testNewRefresh, nil)
+				m.store.On("SaveRefreshToken", ctx, mock.Anything, mock.Anything, testConfig.RefreshTTL).Return(testErr)
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "Error - signing error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(0), nil)
+				m.jwtGen.On("GenerateToken", mock.Anything).Return("", testErr)
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "Error - generation error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.Issue(ctx, testUserID, testRoles)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestTokenSvc_Refresh(t *testing.T) {
+	t.Parallel()
+
+	family := &model.RefreshToken{UserID: testUserID, FamilyID: testFamilyID, Generation: 1, Roles: testRoles}
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, m *testMocks)
+		expectedErr    error
+		expectedOutput *model.TokenPair
+	}{
+		{
+			name: "Success - rotated within the family",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("UseRefreshToken", ctx, HashToken(testRefreshToken)).Return(family, int64(1), nil)
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(1), nil)
+				expectIssue(ctx, m, 1, family)
+			},
+			expectedOutput: &model.TokenPair{AccessToken: testAccessToken, RefreshToken: testNewRefresh, ExpiresIn: 900},
+		},
+		{
+			name: "Error - family of an older generation",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("UseRefreshToken", ctx, HashToken(testRefreshToken)).Return(family, int64(1), nil)
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(2), nil)
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "Error - reused token revokes the family",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("UseRefreshToken", ctx, HashToken(testRefreshToken)).Return(family, int64(2), nil)
+				m.store.On("RevokeFamily", ctx, testFamilyID).Return(nil)
+			},
+			expectedErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "Error - unknown, expired or revoked token",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("UseRefreshToken", ctx, HashToken(testRefreshToken)).Return(nil, int64(0), redis.Nil)
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "Error - store error",
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.store.On("UseRefreshToken", ctx, HashToken(testRefreshToken)).Return(nil, int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			got, err := svc.Refresh(ctx, testRefreshToken)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedOutput, got)
+		})
+	}
+}
+
+func TestTokenSvc_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		claims      jwt.MapClaims
+		setupMock   func(ctx context.Context, m *testMocks)
+		expectedErr error
+	}{
+		{
+			name:   "Success - current generation, not denied",
+			claims: jwt.MapClaims{"sub": testUserID, "jti": "jti-1", "gen": float64(1)},
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(1), nil)
+				m.revocations.On("IsTokenDenied", ctx, "jti-1").Return(false, nil)
+			},
+		},
+		{
+			name:   "Success - token without jti",
+			claims: jwt.MapClaims{"sub": testUserID},
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(0), nil)
+			},
+		},
+		{
+			name:   "Error - denied token",
+			claims: jwt.MapClaims{"sub": testUserID, "jti": "jti-1", "gen": float64(1)},
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(1), nil)
+				m.revocations.On("IsTokenDenied", ctx, "jti-1").Return(true, nil)
+			},
+			expectedErr: ErrTokenRevoked,
+		},
+		{
+			name:   "Error - older generation",
+			claims: jwt.MapClaims{"sub": testUserID, "jti": "jti-1", "gen": float64(1)},
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(2), nil)
+			},
+			expectedErr: ErrTokenRevoked,
+		},
+		{
+			name:   "Error - store error",
+			claims: jwt.MapClaims{"sub": testUserID, "jti": "jti-1"},
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("GetTokenGeneration", ctx, testUserID).Return(int64(0), testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.Validate(ctx, tc.claims)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestTokenSvc_Revoke(t *testing.T) {
+	t.Parallel()
+
+	claims := jwt.MapClaims{"sub": testUserID, "jti": "jti-1", "exp": float64(testNow.Add(10 * time.Minute).Unix())}
+
+	testCases := []struct {
+		name         string
+		claims       jwt.MapClaims
+		refreshToken string
+		setupMock    func(ctx context.Context, m *testMocks)
+		expectedErr  error
+	}{
+		{
+			name:   "Success - access token denied for its remaining lifetime",
+			claims: claims,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("DenyToken", ctx, "jti-1", 10*time.Minute).Return(nil)
+			},
+		},
+		{
+			name:         "Success - refresh token family revoked",
+			claims:       claims,
+			refreshToken: testRefreshToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("DenyToken", ctx, "jti-1", 10*time.Minute).Return(nil)
+				m.store.On("GetRefreshToken", ctx, HashToken(testRefreshToken)).
+					Return(&model.RefreshToken{UserID: testUserID, FamilyID: testFamilyID}, nil)
+				m.store.On("RevokeFamily", ctx, testFamilyID).Return(nil)
+			},
+		},
+		{
+			name:         "Success - refresh token of another user ignored",
+			claims:       claims,
+			refreshToken: testRefreshToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("DenyToken", ctx, "jti-1", 10*time.Minute).Return(nil)
+				m.store.On("GetRefreshToken", ctx, HashToken(testRefreshToken)).
+					Return(&model.RefreshToken{UserID: "other-user", FamilyID: testFamilyID}, nil)
+			},
+		},
+		{
+			name:         "Success - unknown refresh token ignored",
+			claims:       claims,
+			refreshToken: testRefreshToken,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("DenyToken", ctx, "jti-1", 10*time.Minute).Return(nil)
+				m.store.On("GetRefreshToken", ctx, HashToken(testRefreshToken)).Return(nil, redis.Nil)
+			},
+		},
+		{
+			name:      "Success - expired token not denied",
+			claims:    jwt.MapClaims{"sub": testUserID, "jti": "jti-1", "exp": float64(testNow.Add(-time.Minute).Unix())},
+			setupMock: func(ctx context.Context, m *testMocks) {},
+		},
+		{
+			name:   "Error - store error",
+			claims: claims,
+			setupMock: func(ctx context.Context, m *testMocks) {
+				m.revocations.On("DenyToken", ctx, "jti-1", 10*time.Minute).Return(testErr)
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			tc.setupMock(ctx, m)
+
+			err := svc.Revoke(ctx, tc.claims, tc.refreshToken)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestTokenSvc_RevokeAll(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		storeErr    error
+		expectedErr error
+	}{
+		{name: "Success - next generation"},
+		{name: "Error - store error", storeErr: testErr, expectedErr: testErr},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			svc, m := newTestService(t)
+			m.revocations.On("IncrTokenGeneration", ctx, testUserID).Return(int64(1), tc.storeErr)
+
+			err := svc.RevokeAll(ctx, testUserID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"fmt"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
 	"github.com/redis/go-redis/v9"
 )
@@ -27,25 +30,35 @@ const (
 //go:generate mockery --name ShortenUrl --filename shorten_url.go
 type ShortenUrl interface {
 	// ShortenUrl generates a unique short code for the given URL
-	// and stores the mapping in the repository.
-	ShortenUrl(ctx context.Context, url string, exp int) (string, error)
+	// and stores the mapping in the repository. Links created by an
+	// authenticated user count against their daily quota; userID is empty
+	// for anonymous callers, whose links count against the daily quota
+	// of their address clientIP.
+	ShortenUrl(ctx context.Context, userID, clientIP, url string, exp int) (string, error)
 
-	// GetUrl retrieves the original URL associated with the given short code.
-	// Returns ErrCodeNotFound if the code does not exist in the repository.
+	// GetUrl retrieves the original URL associated with the given short code,
+	// which may also be the code of a bookmark. Returns ErrCodeNotFound if the
+	// code does not exist in the repository.
 	GetUrl(ctx context.Context, code string) (string, error)
 }
 
 // shortenUrl is the concrete implementation of the ShortenUrl interface.
 // It uses a UrlStorage repository for persisting URL-to-code mappings.
 type shortenUrl struct {
-	repo   repository.UrlStorage
-	keyGen stringutils.KeyGenerator
+	repo      repository.UrlStorage
+	keyGen    stringutils.KeyGenerator
+	quotas    quota.Service
+	bookmarks bookmark.Repository
 }
 
 // NewShortenUrl creates a new instance of the ShortenUrl service.
 // It requires a UrlStorage repository for storing shortened URL mappings.
-func NewShortenUrl(repo repository.UrlStorage, keyGen stringutils.KeyGenerator) ShortenUrl {
-	return &shortenUrl{repo: repo, keyGen: keyGen}
+// The daily short link quotas of authenticated users and of anonymous client
+// addresses are enforced by quotas; with a nil quotas, links are not limited. Codes unknown to the repository
+// are looked up as bookmark codes in bookmarks, counting the click; with a
+// nil bookmarks, only short links are resolved.
+func NewShortenUrl(repo repository.UrlStorage, keyGen stringutils.KeyGenerator, quotas quota.Service, bookmarks bookmark.Repository) ShortenUrl {
+	return &shortenUrl{repo: repo, keyGen: keyGen, quotas: quotas, bookmarks: bookmarks}
 }
 
 // ShortenUrl generates a unique alphanumeric code for the given URL,
@@ -59,10 +72,41 @@ func NewShortenUrl(repo repository.UrlStorage, keyGen stringutils.KeyGenerator)
 // The generated code is urlCodeLength characters long and uses a
 // cryptographically secure random number generator.
 //
+// The link is counted against the daily quota of the user, or of the client
+// address for anonymous callers, before it is stored, and given back if it
+// cannot be stored.
+//
 // Returns:
 //   - The generated short code on success.
+//   - quota.ErrShortLinkQuotaExceeded if the user or address already created all the links allowed today.
 //   - An error if code generation fails, storage fails, or max retries exceeded.
-func (s *shortenUrl) ShortenUrl(ctx context.Context, url string, exp int) (string, error) {
+func (s *shortenUrl) ShortenUrl(ctx context.Context, userID, clientIP, url string, exp int) (string, error) {
+	if s.quotas == nil {
+		return s.storeUrl(ctx, url, exp)
+	}
+
+	counter, consume, release := userID, s.quotas.ConsumeShortLink, s.quotas.ReleaseShortLink
+	if userID == "" {
+		counter, consume, release = clientIP, s.quotas.ConsumeAnonymousShortLink, s.quotas.ReleaseAnonymousShortLink
+	}
+
+	if err := consume(ctx, counter); err != nil {
+		return "", err
+	}
+
+	code, err := s.storeUrl(ctx, url, exp)
+	if err != nil {
+		if releaseErr := release(ctx, counter); releaseErr != nil {
+			return "", errors.Join(err, releaseErr)
+		}
+		return "", err
+	}
+
+	return code, nil
+}
+
+// storeUrl stores the URL under a freshly generated code, retrying on collisions.
+func (s *shortenUrl) storeUrl(ctx context.Context, url string, exp int) (string, error) {
 	for range maxRetries {
 		// generate random code
 		urlCode, err := s.keyGen.GenerateCode(urlCodeLength)
@@ -93,6 +137,8 @@ var ErrCodeNotFound = errors.New("code not found")
 // GetUrl retrieves the original URL for a given short code.
 // It queries the repository and translates redis.Nil errors to ErrCodeNotFound
 // for a cleaner abstraction that doesn't leak storage implementation details.
+// A code that is not a short link is resolved as the code of a bookmark,
+// and the click is counted in the bookmark statistics.
 //
 // Returns:
 //   - The original URL if the code exists.
@@ -101,7 +147,15 @@ var ErrCodeNotFound = errors.New("code not found")
 func (s *shortenUrl) GetUrl(ctx context.Context, code string) (string, error) {
 	url, err := s.repo.GetUrl(ctx, code)
 	// redis.Nil is returned when the key does not exist
-	if errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) {
+		return url, err
+	}
+	if s.bookmarks == nil {
+		return "", ErrCodeNotFound
+	}
+
+	url, err = s.bookmarks.RecordClick(ctx, code)
+	if errors.Is(err, dbutils.ErrNotFoundType) {
 		return "", ErrCodeNotFound
 	}
 	return url, err
@@ -7,11 +7,15 @@ import (
 	"errors"
 	"testing"
 
+	bookmarkMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark/mocks"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/quota"
+	quotaMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/quota/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	mockKeyGen "github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils/mocks"
 )
 
@@ -147,10 +151,10 @@ func TestShortenUrl_ShortenUrl(t *testing.T) {
 			// Setup
 			mockRepo := tc.setupMockRepo(ctx, tc.urlInput, tc.exp)
 			mockKeyGen := tc.setupMockKeyGen()
-			service := NewShortenUrl(mockRepo, mockKeyGen)
+			service := NewShortenUrl(mockRepo, mockKeyGen, nil, nil)
 
 			// Execute
-			code, err := service.ShortenUrl(ctx, tc.urlInput, tc.exp)
+			code, err := service.ShortenUrl(ctx, "", "", tc.urlInput, tc.exp)
 
 			// Assert
 			assert.Equal(t, tc.expectCode, code)
@@ -159,6 +163,100 @@ func TestShortenUrl_ShortenUrl(t *testing.T) {
 	}
 }
 
+// TestShortenUrl_ShortenUrl_Quota validates that links are counted against the daily quota
+// of the user, or of the client address for anonymous callers, and given back when they
+// cannot be stored.
+func TestShortenUrl_ShortenUrl_Quota(t *testing.T) {
+	t.Parallel()
+
+	const (
+		userID   = "user-123"
+		clientIP = "203.0.113.7"
+		url      = "https://example.com"
+	)
+
+	testCases := []struct {
+		name        string
+		userID      string
+		setupMocks  func(ctx context.Context, repo *mocks.UrlStorage, keyGen *mockKeyGen.KeyGenerator, quotas *quotaMocks.Service)
+		expectCode  string
+		expectedErr error
+	}{
+		{
+			name:   "link counted and stored",
+			userID: userID,
+			setupMocks: func(ctx context.Context, repo *mocks.UrlStorage, keyGen *mockKeyGen.KeyGenerator, quotas *quotaMocks.Service) {
+				quotas.On("ConsumeShortLink", ctx, userID).Return(nil).Once()
+				keyGen.On("GenerateCode", urlCodeLength).Return("1234567", nil).Once()
+				repo.On("StoreUrlIfNotExists", ctx, "1234567", url, 0).Return(true, nil).Once()
+			},
+			expectCode: "1234567",
+		},
+		{
+			name:   "quota exceeded",
+			userID: userID,
+			setupMocks: func(ctx context.Context, repo *mocks.UrlStorage, keyGen *mockKeyGen.KeyGenerator, quotas *quotaMocks.Service) {
+				quotas.On("ConsumeShortLink", ctx, userID).Return(quota.ErrShortLinkQuotaExceeded).Once()
+			},
+			expectedErr: quota.ErrShortLinkQuotaExceeded,
+		},
+		{
+			name:   "link given back when storage fails",
+			userID: userID,
+			setupMocks: func(ctx context.Context, repo *mocks.UrlStorage, keyGen *mockKeyGen.KeyGenerator, quotas *quotaMocks.Service) {
+				quotas.On("ConsumeShortLink", ctx, userID).Return(nil).Once()
+				keyGen.On("GenerateCode", urlCodeLength).Return("1234567", nil).Once()
+				repo.On("StoreUrlIfNotExists", ctx, "1234567", url, 0).Return(false, testErr).Once()
+				quotas.On("ReleaseShortLink", ctx, userID).Return(nil).Once()
+			},
+			expectedErr: testErr,
+		},
+		{
+			name: "anonymous link counted by address",
+			setupMocks: func(ctx context.Context, repo *mocks.UrlStorage, keyGen *mockKeyGen.KeyGenerator, quotas *quotaMocks.Service) {
+				quotas.On("ConsumeAnonymousShortLink", ctx, clientIP).Return(nil).Once()
+				keyGen.On("GenerateCode", urlCodeLength).Return("1234567", nil).Once()
+				repo.On("StoreUrlIfNotExists", ctx, "1234567", url, 0).Return(true, nil).Once()
+			},
+			expectCode: "1234567",
+		},
+		{
+			name: "anonymous quota exceeded",
+			setupMocks: func(ctx context.Context, repo *mocks.UrlStorage, keyGen *mockKeyGen.KeyGenerator, quotas *quotaMocks.Service) {
+				quotas.On("ConsumeAnonymousShortLink", ctx, clientIP).Return(quota.ErrShortLinkQuotaExceeded).Once()
+			},
+			expectedErr: quota.ErrShortLinkQuotaExceeded,
+		},
+		{
+			name: "anonymous link given back when storage fails",
+			setupMocks: func(ctx context.Context, repo *mocks.UrlStorage, keyGen *mockKeyGen.KeyGenerator, quotas *quotaMocks.Service) {
+				quotas.On("ConsumeAnonymousShortLink", ctx, clientIP).Return(nil).Once()
+				keyGen.On("GenerateCode", urlCodeLength).Return("1234567", nil).Once()
+				repo.On("StoreUrlIfNotExists", ctx, "1234567", url, 0).Return(false, testErr).Once()
+				quotas.On("ReleaseAnonymousShortLink", ctx, clientIP).Return(nil).Once()
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			repo := mocks.NewUrlStorage(t)
+			keyGen := mockKeyGen.NewKeyGenerator(t)
+			quotas := quotaMocks.NewService(t)
+			tc.setupMocks(ctx, repo, keyGen, quotas)
+
+			code, err := NewShortenUrl(repo, keyGen, quotas, nil).ShortenUrl(ctx, tc.userID, clientIP, url, 0)
+
+			assert.Equal(t, tc.expectCode, code)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
 // TestShortenUrl_GetUrl validates the GetUrl method of the ShortenUrl service.
 // It uses table-driven tests to cover various scenarios including success,
 // code not found, and repository errors.
@@ -219,7 +317,7 @@ func TestShortenUrl_GetUrl(t *testing.T) {
 			// Setup - GetUrl doesn't need KeyGenerator, so we pass nil
 			mockRepo := tc.setupMockRepo(ctx, tc.code)
 			mockKeyGen := mockKeyGen.NewKeyGenerator(t)
-			service := NewShortenUrl(mockRepo, mockKeyGen)
+			service := NewShortenUrl(mockRepo, mockKeyGen, nil, nil)
 
 			// Execute
 			url, err := service.GetUrl(ctx, tc.code)
@@ -230,3 +328,56 @@ func TestShortenUrl_GetUrl(t *testing.T) {
 		})
 	}
 }
+
+// TestShortenUrl_GetUrl_BookmarkCode validates that codes unknown to the URL storage
+// are resolved as bookmark codes and the click is recorded.
+func TestShortenUrl_GetUrl_BookmarkCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, bookmarks *bookmarkMocks.Repository)
+		expectedUrl string
+		expectedErr error
+	}{
+		{
+			name: "success - bookmark code",
+			setupMock: func(ctx context.Context, bookmarks *bookmarkMocks.Repository) {
+				bookmarks.On("RecordClick", ctx, "abc123456").Return("https://example.com", nil).Once()
+			},
+			expectedUrl: "https://example.com",
+		},
+		{
+			name: "code not found - neither link nor bookmark",
+			setupMock: func(ctx context.Context, bookmarks *bookmarkMocks.Repository) {
+				bookmarks.On("RecordClick", ctx, "abc123456").Return("", dbutils.ErrNotFoundType).Once()
+			},
+			expectedErr: ErrCodeNotFound,
+		},
+		{
+			name: "repository error - propagates error",
+			setupMock: func(ctx context.Context, bookmarks *bookmarkMocks.Repository) {
+				bookmarks.On("RecordClick", ctx, "abc123456").Return("", testErr).Once()
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+
+			mockRepo := mocks.NewUrlStorage(t)
+			mockRepo.On("GetUrl", ctx, "abc123456").Return("", redis.Nil).Once()
+			bookmarks := bookmarkMocks.NewRepository(t)
+			tc.setupMock(ctx, bookmarks)
+			service := NewShortenUrl(mockRepo, mockKeyGen.NewKeyGenerator(t), nil, bookmarks)
+
+			url, err := service.GetUrl(ctx, "abc123456")
+
+			assert.Equal(t, tc.expectedUrl, url)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
@@ -6,17 +6,18 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/repository"
-	"github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/utils"
 	"github.com/golang-jwt/jwt/v5"
-)
-
-const (
-	tokenLast = 24 * time.Hour
+	"github.com/rs/zerolog/log"
 )
 
 // User defines the interface for user-related business operations.
@@ -26,17 +27,47 @@ const (
 type User interface {
 	// CreateUser registers a new user with the provided credentials and profile information.
 	CreateUser(ctx context.Context, username, password, displayName, email string) (*model.User, error)
-	Login(ctx context.Context, username, password string) (string, error)
+	Login(ctx context.Context, username, password string) (*model.TokenPair, error)
+	// RefreshToken exchanges a refresh token for new tokens, see token.Service.Refresh.
+	RefreshToken(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+	// Logout revokes the access token of the caller and, when given, its refresh token.
+	Logout(ctx context.Context, claims jwt.MapClaims, refreshToken string) error
+	// RevokeAllTokens revokes every access, refresh and personal access token issued to a user.
+	RevokeAllTokens(ctx context.Context, userID string) error
+	// ChangePassword replaces the password of a user who knows the current one, logs the user out everywhere
+	// and revokes the personal access tokens of the user.
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*model.TokenPair, error)
 	GetUserByID(ctx context.Context, userId string) (*model.User, error)
 	UpdateUser(ctx context.Context, userID, displayName, email string) error
+	// PurgeDeletedUsers permanently removes users that have been soft-deleted for longer than retention.
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error)
+	// BootstrapAdmin grants the admin role to a user, creating the user when it does not exist.
+	BootstrapAdmin(ctx context.Context, username, email, password string) error
 }
 
 // user is the concrete implementation of the User interface.
 // It coordinates between the repository layer and applies business rules.
 type user struct {
-	repo            repository.User
-	jwtGen          jwtutils.JWTGenerator
-	passwordHashing utils.PasswordHashing
+	repo                 repository.User
+	tokens               token.Service
+	accessTokens         accesstoken.Repository
+	passwordHashing      utils.PasswordHashing
+	verifier             emailverification.Service
+	requireVerifiedEmail bool
+}
+
+// UserOption configures optional behaviour of the User service.
+type UserOption func(*user)
+
+// WithEmailVerification makes the service email a verification link to the address given at
+// registration, and keep a new address pending until it is confirmed the same way. When
+// requireVerified is set, users can only log in once their address is confirmed.
+// Without it, addresses are used as given and never verified.
+func WithEmailVerification(verifier emailverification.Service, requireVerified bool) UserOption {
+	return func(u *user) {
+		u.verifier = verifier
+		u.requireVerifiedEmail = requireVerified
+	}
 }
 
 // NewUser creates and returns a new User service instance.
@@ -44,15 +75,26 @@ type user struct {
 //
 // Parameters:
 //   - repo: A repository.User implementation for database operations
+//   - tokens: The service issuing the tokens of signed-in users
+//   - accessTokens: Repository of the personal access tokens, revoked with the other tokens of a user
+//   - hash: The password hashing implementation
+//   - opts: Optional behaviour, such as email verification
 //
 // Returns:
 //   - User: An implementation of the User service interface
-func NewUser(repo repository.User, jwtGen jwtutils.JWTGenerator, hash utils.PasswordHashing) User {
-	return &user{repo: repo, jwtGen: jwtGen, passwordHashing: hash}
+func NewUser(repo repository.User, tokens token.Service, accessTokens accesstoken.Repository, hash utils.PasswordHashing,
+	opts ...UserOption) User {
+	u := &user{repo: repo, tokens: tokens, accessTokens: accessTokens, passwordHashing: hash}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
 }
 
 // CreateUser handles user registration by hashing the password and persisting the user.
 // It applies security measures (password hashing) before delegating to the repository.
+// With email verification, a verification link is then emailed to the user; failing to send it
+// does not fail the registration, since the user can ask for another link.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and deadline control
@@ -80,6 +122,12 @@ func (u *user) CreateUser(ctx context.Context, username, password, displayName,
 	if err != nil {
 		return nil, err
 	}
+
+	if u.verifier != nil {
+		if err := u.verifier.SendVerification(ctx, res, res.Email); err != nil {
+			log.Error().Err(err).Str("userID", res.ID).Msg("failed to start email verification")
+		}
+	}
 	return res, nil
 }
 
@@ -90,10 +138,23 @@ var (
 
 	// ErrClientNoUpdate is returned when UpdateUser is called with no fields to update.
 	ErrClientNoUpdate = errors.New("no update")
+
+	// ErrClientWrongPassword is returned when the current password given to ChangePassword is wrong.
+	ErrClientWrongPassword = errors.New("current password is incorrect")
+
+	// ErrClientEmailNotVerified is returned by Login when verified emails are required and the user's is not.
+	ErrClientEmailNotVerified = errors.New("email address is not verified")
+
+	// ErrClientAccountDisabled is returned by Login when an administrator disabled the account.
+	ErrClientAccountDisabled = errors.New("account is disabled")
+
+	// ErrBootstrapAdminIncomplete is returned by BootstrapAdmin when the admin must be created
+	// but no email or password was given.
+	ErrBootstrapAdminIncomplete = errors.New("email and password are required to create the admin user")
 )
 
-// Login authenticates a user and returns a JWT token.
-// It verifies the username exists and the password is correct before generating a token.
+// Login authenticates a user and returns their tokens.
+// It verifies the username exists and the password is correct before issuing the tokens.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and deadline control
@@ -101,34 +162,122 @@ var (
 //   - password: The plain-text password to verify
 //
 // Returns:
-//   - string: A JWT token valid for 24 hours if authentication succeeds
-//   - error: ErrClientErr if credentials are invalid, or other errors from repo/JWT generation
-func (u *user) Login(ctx context.Context, username, password string) (string, error) {
+//   - *model.TokenPair: A short-lived access token and a refresh token if authentication succeeds
+//   - error: ErrClientErr if credentials are invalid, ErrClientAccountDisabled if the account is
+//     disabled, ErrClientEmailNotVerified if the email address must be verified first, or other
+//     errors from repo/token issuing
+func (u *user) Login(ctx context.Context, username, password string) (*model.TokenPair, error) {
 	// check if user exist
 	chosenUser, err := u.repo.GetUserByUsername(ctx, username)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// check if password is valid
 	check := u.passwordHashing.CompareHashAndPassword(chosenUser.Password, password)
 	if !check {
-		return "", ErrClientErr
+		return nil, ErrClientErr
 	}
 
-	// create token
-	jwtContent := jwt.MapClaims{
-		"sub": chosenUser.ID,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(tokenLast).Unix(),
+	if chosenUser.DisabledAt != nil {
+		return nil, ErrClientAccountDisabled
+	}
+	if u.requireVerifiedEmail && chosenUser.EmailVerifiedAt == nil {
+		return nil, ErrClientEmailNotVerified
 	}
-	token, err := u.jwtGen.GenerateToken(jwtContent)
+
+	// create tokens
+	return u.tokens.Issue(ctx, chosenUser.ID, chosenUser.Roles)
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - refreshToken: The refresh token received at login or at the previous refresh
+//
+// Returns:
+//   - *model.TokenPair: The new tokens
+//   - error: token.ErrInvalidRefreshToken or token.ErrRefreshTokenReused if the token cannot be used,
+//     or other errors from token issuing
+func (u *user) RefreshToken(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	return u.tokens.Refresh(ctx, refreshToken)
+}
+
+// Logout ends the login of the caller, see token.Service.Revoke.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - claims: The claims of the access token used for the request
+//   - refreshToken: The refresh token of the same login, or "" to keep it valid
+//
+// Returns:
+//   - error: Errors from the token storage
+func (u *user) Logout(ctx context.Context, claims jwt.MapClaims, refreshToken string) error {
+	return u.tokens.Revoke(ctx, claims, refreshToken)
+}
+
+// RevokeAllTokens logs a user out everywhere, e.g. after the account was compromised.
+// The personal access tokens of the user are revoked as well.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//
+// Returns:
+//   - error: dbutils.ErrNotFoundType if the user does not exist, or errors from the token storage
+func (u *user) RevokeAllTokens(ctx context.Context, userID string) error {
+	if _, err := u.repo.GetUserById(ctx, userID); err != nil {
+		return err
+	}
+	return u.revokeCredentials(ctx, userID)
+}
+
+// revokeCredentials revokes every access, refresh and personal access token of a user.
+func (u *user) revokeCredentials(ctx context.Context, userID string) error {
+	if err := u.tokens.RevokeAll(ctx, userID); err != nil {
+		return err
+	}
+	return u.accessTokens.DeleteUserTokens(ctx, userID)
+}
+
+// ChangePassword replaces the password of a user after checking the current one.
+// Every access, refresh and personal access token issued before is revoked, so that neither
+// a session opened with the old password nor a token created in it survives the change;
+// the caller receives new tokens instead.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - userID: The unique identifier of the user
+//   - currentPassword: The plain-text current password
+//   - newPassword: The plain-text new password
+//
+// Returns:
+//   - *model.TokenPair: New tokens for the caller
+//   - error: ErrClientWrongPassword if the current password is wrong, dbutils.ErrNotFoundType
+//     if the user does not exist, or errors from hashing, the repository or the token storage
+func (u *user) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*model.TokenPair, error) {
+	chosenUser, err := u.repo.GetUserById(ctx, userID)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if !u.passwordHashing.CompareHashAndPassword(chosenUser.Password, currentPassword) {
+		return nil, ErrClientWrongPassword
+	}
+
+	hashPwd, err := u.passwordHashing.Hash(newPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdatePassword(ctx, userID, hashPwd); err != nil {
+		return nil, err
 	}
 
-	// return token
-	return token, nil
+	if err := u.revokeCredentials(ctx, userID); err != nil {
+		return nil, err
+	}
+	return u.tokens.Issue(ctx, userID, chosenUser.Roles)
 }
 
 // GetUserByID retrieves a user's details by their unique ID.
@@ -146,6 +295,8 @@ func (u *user) GetUserByID(ctx context.Context, userId string) (*model.User, err
 
 // UpdateUser updates a user's profile information.
 // At least one field (displayName or email) must be provided.
+// With email verification, a new email address is kept pending and a verification link is
+// emailed to it; the address only replaces the current one once confirmed.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and deadline control
@@ -159,5 +310,89 @@ func (u *user) UpdateUser(ctx context.Context, userID, displayName, email string
 	if displayName == "" && email == "" {
 		return ErrClientNoUpdate
 	}
-	return u.repo.UpdateUser(ctx, userID, displayName, email)
+	if u.verifier == nil {
+		return u.repo.UpdateUser(ctx, userID, displayName, email)
+	}
+
+	if displayName != "" {
+		if err := u.repo.UpdateUser(ctx, userID, displayName, ""); err != nil {
+			return err
+		}
+	}
+	if email == "" {
+		return nil
+	}
+
+	chosenUser, err := u.repo.GetUserById(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if email == chosenUser.Email {
+		return nil
+	}
+	if err := u.repo.SetPendingEmail(ctx, userID, email); err != nil {
+		return err
+	}
+	return u.verifier.SendVerification(ctx, chosenUser, email)
+}
+
+// PurgeDeletedUsers permanently removes users that have been soft-deleted for longer than retention.
+// It is intended to be called periodically by the trash retention job.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - retention: How long a deleted user is kept before it is removed permanently
+//
+// Returns:
+//   - int64: Number of users removed
+//   - error: An error from the repository layer
+func (u *user) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	return u.repo.PurgeDeletedUsers(ctx, time.Now().Add(-retention))
+}
+
+// BootstrapAdmin makes sure a deployment has a first administrator. It is meant to run at startup
+// from configuration, and does nothing when the user already holds the admin role.
+// An existing user is granted the role, keeping its password; the role shows in its tokens from
+// its next login. A missing user is created with the given email and password, the email being
+// considered verified since it comes from the operator.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and deadline control
+//   - username: Login identifier of the admin
+//   - email: Email address used when the user has to be created
+//   - password: Plain-text password used when the user has to be created
+//
+// Returns:
+//   - error: ErrBootstrapAdminIncomplete if the user is missing and email or password is empty,
+//     or an error from hashing or the repository layer
+func (u *user) BootstrapAdmin(ctx context.Context, username, email, password string) error {
+	existing, err := u.repo.GetUserByUsername(ctx, username)
+	if err == nil {
+		if existing.Roles.Has(model.RoleAdmin) {
+			return nil
+		}
+		roles := append(slices.Clone(existing.Roles), model.RoleAdmin)
+		return u.repo.UpdateRoles(ctx, existing.ID, roles)
+	}
+	if !errors.Is(err, dbutils.ErrNotFoundType) {
+		return err
+	}
+
+	if email == "" || password == "" {
+		return ErrBootstrapAdminIncomplete
+	}
+	hashPwd, err := u.passwordHashing.Hash(password)
+	if err != nil {
+		return err
+	}
+	verifiedAt := time.Now()
+	_, err = u.repo.CreateUser(ctx, &model.User{
+		Username:        username,
+		Password:        hashPwd,
+		DisplayName:     username,
+		Email:           email,
+		EmailVerifiedAt: &verifiedAt,
+		Roles:           model.Roles{model.RoleUser, model.RoleAdmin},
+	})
+	return err
 }
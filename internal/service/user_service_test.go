@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	accessTokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/accesstoken/mocks"
 	repoMocks "github.com/HadesHo3820/ebvn-golang-course/internal/repository/mocks"
+	verificationMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/emailverification/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/service/token"
+	tokenMocks "github.com/HadesHo3820/ebvn-golang-course/internal/service/token/mocks"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/dbutils"
-	jwtMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils/mocks"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/utils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/utils/mocks"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -130,12 +132,12 @@ func TestUser_CreateUser(t *testing.T) {
 
 			// Setup mocks
 			mockRepo := repoMocks.NewUser(t)
-			mockJWT := jwtMocks.NewJWTGenerator(t)
+			mockTokens := tokenMocks.NewService(t)
 			tc.setupMock(mockRepo, ctx)
 			mockPasswordHashing := tc.setupMockPasswordHashing(t)
 
 			// Create service
-			svc := NewUser(mockRepo, mockJWT, mockPasswordHashing)
+			svc := NewUser(mockRepo, mockTokens, accessTokenMocks.NewRepository(t), mockPasswordHashing)
 
 			// Execute
 			output, err := svc.CreateUser(ctx, tc.inputUsername, tc.inputPassword, tc.inputDisplay, tc.inputEmail)
@@ -156,12 +158,15 @@ func TestUser_CreateUser(t *testing.T) {
 	}
 }
 
+// testTokenPair is the pair of tokens issued by the mocked token service.
+var testTokenPair = &model.TokenPair{AccessToken: "valid.jwt.token", RefreshToken: "refresh-token", ExpiresIn: 900}
+
 // TestUser_Login tests the Login method of the User service.
-// It uses table-driven tests with mocked repository and JWT generator to verify:
-//   - Successful login returns a valid token
+// It uses table-driven tests with mocked repository and token service to verify:
+//   - Successful login returns the issued tokens
 //   - Error when user is not found
 //   - Error when password is invalid
-//   - Error when JWT generation fails
+//   - Error when token issuing fails
 func TestUser_Login(t *testing.T) {
 	t.Parallel()
 
@@ -170,37 +175,36 @@ func TestUser_Login(t *testing.T) {
 	hashedPassword, _ := passwordHashing.Hash("correctpassword")
 
 	testCases := []struct {
-		name          string
-		inputUsername string
-		inputPassword string
-		setupMock     func(ctx context.Context, mockRepo *repoMocks.User, mockJWT *jwtMocks.JWTGenerator, mockPasswordHashing *mocks.PasswordHashing)
-		expectedErr   error
-		expectedToken string
+		name           string
+		inputUsername  string
+		inputPassword  string
+		setupMock      func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockPasswordHashing *mocks.PasswordHashing)
+		expectedErr    error
+		expectedTokens *model.TokenPair
 	}{
 		{
 			name:          "success - valid login",
 			inputUsername: testUserUsername,
 			inputPassword: "correctpassword",
-			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockJWT *jwtMocks.JWTGenerator, mockPasswordHashing *mocks.PasswordHashing) {
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockPasswordHashing *mocks.PasswordHashing) {
 				mockRepo.On("GetUserByUsername", ctx, testUserUsername).Return(&model.User{
 					Base: model.Base{
 						ID: testUserID,
 					},
 					Username: "testuser",
 					Password: hashedPassword,
+					Roles:    model.Roles{model.RoleUser, model.RoleAdmin},
 				}, nil)
 				mockPasswordHashing.On("CompareHashAndPassword", hashedPassword, "correctpassword").Return(true)
-				mockJWT.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
-					return claims["sub"] == testUserID
-				})).Return("valid.jwt.token", nil)
+				mockTokens.On("Issue", ctx, testUserID, model.Roles{model.RoleUser, model.RoleAdmin}).Return(testTokenPair, nil)
 			},
-			expectedToken: "valid.jwt.token",
+			expectedTokens: testTokenPair,
 		},
 		{
 			name:          "error - user not found",
 			inputUsername: "nonexistent",
 			inputPassword: "password",
-			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockJWT *jwtMocks.JWTGenerator, mockPasswordHashing *mocks.PasswordHashing) {
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockPasswordHashing *mocks.PasswordHashing) {
 				mockRepo.On("GetUserByUsername", ctx, "nonexistent").Return(nil, dbutils.ErrNotFoundType)
 			},
 			expectedErr: dbutils.ErrNotFoundType,
@@ -209,7 +213,7 @@ func TestUser_Login(t *testing.T) {
 			name:          "error - invalid password",
 			inputUsername: testUserUsername,
 			inputPassword: "wrongpassword",
-			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockJWT *jwtMocks.JWTGenerator, mockPasswordHashing *mocks.PasswordHashing) {
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockPasswordHashing *mocks.PasswordHashing) {
 				mockRepo.On("GetUserByUsername", ctx, "testuser").Return(&model.User{
 					Base: model.Base{
 						ID: testUserID,
@@ -222,10 +226,28 @@ func TestUser_Login(t *testing.T) {
 			expectedErr: ErrClientErr,
 		},
 		{
-			name:          "error - JWT generation fails",
+			name:          "error - account disabled",
 			inputUsername: testUserUsername,
 			inputPassword: "correctpassword",
-			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockJWT *jwtMocks.JWTGenerator, mockPasswordHashing *mocks.PasswordHashing) {
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockPasswordHashing *mocks.PasswordHashing) {
+				disabledAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				mockRepo.On("GetUserByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: testUserID,
+					},
+					Username:   "testuser",
+					Password:   hashedPassword,
+					DisabledAt: &disabledAt,
+				}, nil)
+				mockPasswordHashing.On("CompareHashAndPassword", hashedPassword, "correctpassword").Return(true)
+			},
+			expectedErr: ErrClientAccountDisabled,
+		},
+		{
+			name:          "error - token issuing fails",
+			inputUsername: testUserUsername,
+			inputPassword: "correctpassword",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockPasswordHashing *mocks.PasswordHashing) {
 				mockRepo.On("GetUserByUsername", ctx, "testuser").Return(&model.User{
 					Base: model.Base{
 						ID: testUserID,
@@ -234,7 +256,7 @@ func TestUser_Login(t *testing.T) {
 					Password: hashedPassword,
 				}, nil)
 				mockPasswordHashing.On("CompareHashAndPassword", hashedPassword, "correctpassword").Return(true)
-				mockJWT.On("GenerateToken", mock.Anything).Return("", errors.New("jwt error"))
+				mockTokens.On("Issue", ctx, testUserID, model.Roles(nil)).Return(nil, errors.New("jwt error"))
 			},
 			expectedErr: errors.New("jwt error"),
 		},
@@ -247,25 +269,25 @@ func TestUser_Login(t *testing.T) {
 
 			// Setup mocks
 			mockRepo := repoMocks.NewUser(t)
-			mockJWT := jwtMocks.NewJWTGenerator(t)
+			mockTokens := tokenMocks.NewService(t)
 			mockPasswordHashing := mocks.NewPasswordHashing(t)
-			tc.setupMock(ctx, mockRepo, mockJWT, mockPasswordHashing)
+			tc.setupMock(ctx, mockRepo, mockTokens, mockPasswordHashing)
 
 			// Create service
-			svc := NewUser(mockRepo, mockJWT, mockPasswordHashing)
+			svc := NewUser(mockRepo, mockTokens, accessTokenMocks.NewRepository(t), mockPasswordHashing)
 
 			// Execute
-			token, err := svc.Login(ctx, tc.inputUsername, tc.inputPassword)
+			tokens, err := svc.Login(ctx, tc.inputUsername, tc.inputPassword)
 
 			// Assert
 			if tc.expectedErr != nil {
 				assert.Error(t, err)
-				assert.Empty(t, token)
+				assert.Nil(t, tokens)
 				return
 			}
 
 			assert.NoError(t, err)
-			assert.Equal(t, tc.expectedToken, token)
+			assert.Equal(t, tc.expectedTokens, tokens)
 		})
 	}
 }
@@ -329,12 +351,12 @@ func TestUser_GetUserByID(t *testing.T) {
 
 			//Setup mocks
 			mockRepo := repoMocks.NewUser(t)
-			mockJWT := jwtMocks.NewJWTGenerator(t)
+			mockTokens := tokenMocks.NewService(t)
 			mockPasswordHashing := mocks.NewPasswordHashing(t)
 			tc.setupMock(mockRepo, ctx)
 
 			// Create service
-			svc := NewUser(mockRepo, mockJWT, mockPasswordHashing)
+			svc := NewUser(mockRepo, mockTokens, accessTokenMocks.NewRepository(t), mockPasswordHashing)
 
 			// Execute
 			output, err := svc.GetUserByID(ctx, tc.inputUserID)
@@ -423,12 +445,12 @@ func TestUser_UpdateUser(t *testing.T) {
 
 			// Setup mocks
 			mockRepo := repoMocks.NewUser(t)
-			mockJWT := jwtMocks.NewJWTGenerator(t)
+			mockTokens := tokenMocks.NewService(t)
 			mockPasswordHashing := mocks.NewPasswordHashing(t)
 			tc.setupMock(mockRepo, ctx)
 
 			// Create service
-			svc := NewUser(mockRepo, mockJWT, mockPasswordHashing)
+			svc := NewUser(mockRepo, mockTokens, accessTokenMocks.NewRepository(t), mockPasswordHashing)
 
 			// Execute
 			err := svc.UpdateUser(ctx, tc.inputUserID, tc.inputDisplayName, tc.inputEmail)
@@ -443,3 +465,504 @@ func TestUser_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+// TestUser_PurgeDeletedUsers tests the PurgeDeletedUsers method of the User service.
+// It verifies that the retention window is converted into a cutoff in the past
+// and that repository results are passed through.
+func TestUser_PurgeDeletedUsers(t *testing.T) {
+	t.Parallel()
+
+	retention := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name           string
+		setupMock      func(mockRepo *repoMocks.User, ctx context.Context)
+		expectedErr    error
+		expectedOutput int64
+	}{
+		{
+			name: "success - purge deleted users",
+			setupMock: func(mockRepo *repoMocks.User, ctx context.Context) {
+				mockRepo.On("PurgeDeletedUsers", ctx, mock.MatchedBy(func(before time.Time) bool {
+					return before.Before(time.Now().Add(-retention + time.Minute))
+				})).Return(int64(2), nil)
+			},
+			expectedOutput: 2,
+		},
+		{
+			name: "error - repository error",
+			setupMock: func(mockRepo *repoMocks.User, ctx context.Context) {
+				mockRepo.On("PurgeDeletedUsers", ctx, mock.Anything).Return(int64(0), errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewUser(t)
+			tc.setupMock(mockRepo, ctx)
+
+			svc := NewUser(mockRepo, tokenMocks.NewService(t), accessTokenMocks.NewRepository(t), mocks.NewPasswordHashing(t))
+
+			count, err := svc.PurgeDeletedUsers(ctx, retention)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, count)
+		})
+	}
+}
+
+// TestUser_RefreshToken tests that the RefreshToken method delegates to the token service.
+func TestUser_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(ctx context.Context, mockTokens *tokenMocks.Service)
+		expectedErr    error
+		expectedTokens *model.TokenPair
+	}{
+		{
+			name: "success - tokens rotated",
+			setupMock: func(ctx context.Context, mockTokens *tokenMocks.Service) {
+				mockTokens.On("Refresh", ctx, "refresh-token").Return(testTokenPair, nil)
+			},
+			expectedTokens: testTokenPair,
+		},
+		{
+			name: "error - reused refresh token",
+			setupMock: func(ctx context.Context, mockTokens *tokenMocks.Service) {
+				mockTokens.On("Refresh", ctx, "refresh-token").Return(nil, token.ErrRefreshTokenReused)
+			},
+			expectedErr: token.ErrRefreshTokenReused,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockTokens := tokenMocks.NewService(t)
+			tc.setupMock(ctx, mockTokens)
+			svc := NewUser(repoMocks.NewUser(t), mockTokens, accessTokenMocks.NewRepository(t), mocks.NewPasswordHashing(t))
+
+			tokens, err := svc.RefreshToken(ctx, "refresh-token")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedTokens, tokens)
+		})
+	}
+}
+
+func TestUser_RevokeAllTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockAccessTokens *accessTokenMocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success - tokens revoked",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockAccessTokens *accessTokenMocks.Repository) {
+				mockRepo.On("GetUserById", ctx, "user-123").Return(&model.User{Base: model.Base{ID: "user-123"}}, nil)
+				mockTokens.On("RevokeAll", ctx, "user-123").Return(nil)
+				mockAccessTokens.On("DeleteUserTokens", ctx, "user-123").Return(nil)
+			},
+		},
+		{
+			name: "error - personal access tokens not deleted",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockAccessTokens *accessTokenMocks.Repository) {
+				mockRepo.On("GetUserById", ctx, "user-123").Return(&model.User{Base: model.Base{ID: "user-123"}}, nil)
+				mockTokens.On("RevokeAll", ctx, "user-123").Return(nil)
+				mockAccessTokens.On("DeleteUserTokens", ctx, "user-123").Return(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "error - user not found",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service, mockAccessTokens *accessTokenMocks.Repository) {
+				mockRepo.On("GetUserById", ctx, "user-123").Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewUser(t)
+			mockTokens := tokenMocks.NewService(t)
+			mockAccessTokens := accessTokenMocks.NewRepository(t)
+			tc.setupMock(ctx, mockRepo, mockTokens, mockAccessTokens)
+			svc := NewUser(mockRepo, mockTokens, mockAccessTokens, mocks.NewPasswordHashing(t))
+
+			err := svc.RevokeAllTokens(ctx, "user-123")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestUser_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	storedUser := &model.User{Base: model.Base{ID: testUserID}, Username: testUserUsername, Password: "old-hash",
+		Roles: model.Roles{model.RoleUser}}
+
+	testCases := []struct {
+		name      string
+		setupMock func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service,
+			mockAccessTokens *accessTokenMocks.Repository, mockPasswordHashing *mocks.PasswordHashing)
+		expectedErr    error
+		expectedTokens *model.TokenPair
+	}{
+		{
+			name: "success - password changed and tokens revoked",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service,
+				mockAccessTokens *accessTokenMocks.Repository, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(storedUser, nil)
+				mockPasswordHashing.On("CompareHashAndPassword", "old-hash", "OldPassword1!").Return(true)
+				mockPasswordHashing.On("Hash", "NewPassword1!").Return("new-hash", nil)
+				mockRepo.On("UpdatePassword", ctx, testUserID, "new-hash").Return(nil)
+				mockTokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				mockAccessTokens.On("DeleteUserTokens", ctx, testUserID).Return(nil)
+				mockTokens.On("Issue", ctx, testUserID, model.Roles{model.RoleUser}).Return(testTokenPair, nil)
+			},
+			expectedTokens: testTokenPair,
+		},
+		{
+			name: "error - wrong current password",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service,
+				mockAccessTokens *accessTokenMocks.Repository, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(storedUser, nil)
+				mockPasswordHashing.On("CompareHashAndPassword", "old-hash", "OldPassword1!").Return(false)
+			},
+			expectedErr: ErrClientWrongPassword,
+		},
+		{
+			name: "error - user not found",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service,
+				mockAccessTokens *accessTokenMocks.Repository, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "error - revocation fails",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service,
+				mockAccessTokens *accessTokenMocks.Repository, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(storedUser, nil)
+				mockPasswordHashing.On("CompareHashAndPassword", "old-hash", "OldPassword1!").Return(true)
+				mockPasswordHashing.On("Hash", "NewPassword1!").Return("new-hash", nil)
+				mockRepo.On("UpdatePassword", ctx, testUserID, "new-hash").Return(nil)
+				mockTokens.On("RevokeAll", ctx, testUserID).Return(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "error - personal access tokens not deleted",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockTokens *tokenMocks.Service,
+				mockAccessTokens *accessTokenMocks.Repository, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(storedUser, nil)
+				mockPasswordHashing.On("CompareHashAndPassword", "old-hash", "OldPassword1!").Return(true)
+				mockPasswordHashing.On("Hash", "NewPassword1!").Return("new-hash", nil)
+				mockRepo.On("UpdatePassword", ctx, testUserID, "new-hash").Return(nil)
+				mockTokens.On("RevokeAll", ctx, testUserID).Return(nil)
+				mockAccessTokens.On("DeleteUserTokens", ctx, testUserID).Return(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewUser(t)
+			mockTokens := tokenMocks.NewService(t)
+			mockPasswordHashing := mocks.NewPasswordHashing(t)
+			mockAccessTokens := accessTokenMocks.NewRepository(t)
+			tc.setupMock(ctx, mockRepo, mockTokens, mockAccessTokens, mockPasswordHashing)
+			svc := NewUser(mockRepo, mockTokens, mockAccessTokens, mockPasswordHashing)
+
+			tokens, err := svc.ChangePassword(ctx, testUserID, "OldPassword1!", "NewPassword1!")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedTokens, tokens)
+		})
+	}
+}
+
+// TestUser_CreateUser_WithEmailVerification tests that a verification link is sent at registration,
+// and that failing to send it does not fail the registration.
+func TestUser_CreateUser_WithEmailVerification(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		sendErr error
+	}{
+		{
+			name: "success - verification sent",
+		},
+		{
+			name:    "success - verification failure is ignored",
+			sendErr: errors.New("redis error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			created := &model.User{Base: model.Base{ID: testUserID}, Username: testUserUsername, Email: testUserEmail}
+			mockRepo := repoMocks.NewUser(t)
+			mockPasswordHashing := mocks.NewPasswordHashing(t)
+			mockVerifier := verificationMocks.NewService(t)
+			mockPasswordHashing.On("Hash", "password123").Return(testHashedPassword, nil)
+			mockRepo.On("CreateUser", ctx, mock.Anything).Return(created, nil)
+			mockVerifier.On("SendVerification", ctx, created, testUserEmail).Return(tc.sendErr)
+
+			svc := NewUser(mockRepo, tokenMocks.NewService(t), accessTokenMocks.NewRepository(t), mockPasswordHashing, WithEmailVerification(mockVerifier, false))
+
+			res, err := svc.CreateUser(ctx, testUserUsername, "password123", testUserDisplayName, testUserEmail)
+
+			assert.NoError(t, err)
+			assert.Equal(t, created, res)
+		})
+	}
+}
+
+// TestUser_Login_RequireVerifiedEmail tests that users with an unverified email address
+// cannot log in when verified emails are required.
+func TestUser_Login_RequireVerifiedEmail(t *testing.T) {
+	t.Parallel()
+
+	verifiedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		requireVerified bool
+		emailVerifiedAt *time.Time
+		expectedErr     error
+	}{
+		{
+			name:            "success - verified email",
+			requireVerified: true,
+			emailVerifiedAt: &verifiedAt,
+		},
+		{
+			name: "success - unverified email allowed",
+		},
+		{
+			name:            "error - unverified email",
+			requireVerified: true,
+			expectedErr:     ErrClientEmailNotVerified,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewUser(t)
+			mockTokens := tokenMocks.NewService(t)
+			mockPasswordHashing := mocks.NewPasswordHashing(t)
+			mockRepo.On("GetUserByUsername", ctx, testUserUsername).Return(&model.User{
+				Base:            model.Base{ID: testUserID},
+				Password:        testHashedPassword,
+				EmailVerifiedAt: tc.emailVerifiedAt,
+			}, nil)
+			mockPasswordHashing.On("CompareHashAndPassword", testHashedPassword, "password123").Return(true)
+			if tc.expectedErr == nil {
+				mockTokens.On("Issue", ctx, testUserID, model.Roles(nil)).Return(testTokenPair, nil)
+			}
+
+			svc := NewUser(mockRepo, mockTokens, accessTokenMocks.NewRepository(t), mockPasswordHashing,
+				WithEmailVerification(verificationMocks.NewService(t), tc.requireVerified))
+
+			tokens, err := svc.Login(ctx, testUserUsername, "password123")
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				assert.Equal(t, testTokenPair, tokens)
+			}
+		})
+	}
+}
+
+// TestUser_UpdateUser_WithEmailVerification tests that a new email address is kept pending
+// and a verification link is sent to it, while the display name is updated right away.
+func TestUser_UpdateUser_WithEmailVerification(t *testing.T) {
+	t.Parallel()
+
+	current := &model.User{Base: model.Base{ID: testUserID}, Email: testUserEmail}
+
+	testCases := []struct {
+		name             string
+		inputDisplayName string
+		inputEmail       string
+		setupMock        func(ctx context.Context, mockRepo *repoMocks.User, mockVerifier *verificationMocks.Service)
+		expectedErr      error
+	}{
+		{
+			name:             "success - display name updated, email pending",
+			inputDisplayName: newUserDisplayName,
+			inputEmail:       newUserEmail,
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockVerifier *verificationMocks.Service) {
+				mockRepo.On("UpdateUser", ctx, testUserID, newUserDisplayName, "").Return(nil)
+				mockRepo.On("GetUserById", ctx, testUserID).Return(current, nil)
+				mockRepo.On("SetPendingEmail", ctx, testUserID, newUserEmail).Return(nil)
+				mockVerifier.On("SendVerification", ctx, current, newUserEmail).Return(nil)
+			},
+		},
+		{
+			name:             "success - display name only",
+			inputDisplayName: newUserDisplayName,
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockVerifier *verificationMocks.Service) {
+				mockRepo.On("UpdateUser", ctx, testUserID, newUserDisplayName, "").Return(nil)
+			},
+		},
+		{
+			name:       "success - unchanged email",
+			inputEmail: testUserEmail,
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockVerifier *verificationMocks.Service) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(current, nil)
+			},
+		},
+		{
+			name:       "error - user not found",
+			inputEmail: newUserEmail,
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockVerifier *verificationMocks.Service) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: dbutils.ErrNotFoundType,
+		},
+		{
+			name:       "error - verification fails",
+			inputEmail: newUserEmail,
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockVerifier *verificationMocks.Service) {
+				mockRepo.On("GetUserById", ctx, testUserID).Return(current, nil)
+				mockRepo.On("SetPendingEmail", ctx, testUserID, newUserEmail).Return(nil)
+				mockVerifier.On("SendVerification", ctx, current, newUserEmail).Return(errors.New("redis error"))
+			},
+			expectedErr: errors.New("redis error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewUser(t)
+			mockVerifier := verificationMocks.NewService(t)
+			tc.setupMock(ctx, mockRepo, mockVerifier)
+
+			svc := NewUser(mockRepo, tokenMocks.NewService(t), accessTokenMocks.NewRepository(t), mocks.NewPasswordHashing(t), WithEmailVerification(mockVerifier, false))
+
+			err := svc.UpdateUser(ctx, testUserID, tc.inputDisplayName, tc.inputEmail)
+
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUser_BootstrapAdmin(t *testing.T) {
+	t.Parallel()
+
+	const adminPassword = "RootPassword1!"
+
+	testCases := []struct {
+		name          string
+		inputEmail    string
+		inputPassword string
+		setupMock     func(ctx context.Context, mockRepo *repoMocks.User, mockPasswordHashing *mocks.PasswordHashing)
+		expectedErr   error
+	}{
+		{
+			name:          "success - missing user created as admin",
+			inputEmail:    testUserEmail,
+			inputPassword: adminPassword,
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserByUsername", ctx, testUserUsername).Return(nil, dbutils.ErrNotFoundType)
+				mockPasswordHashing.On("Hash", adminPassword).Return("admin-hash", nil)
+				mockRepo.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool {
+					return u.Username == testUserUsername && u.Password == "admin-hash" && u.Email == testUserEmail &&
+						u.EmailVerifiedAt != nil && assert.ObjectsAreEqual(model.Roles{model.RoleUser, model.RoleAdmin}, u.Roles)
+				})).Return(&model.User{Base: model.Base{ID: testUserID}}, nil)
+			},
+		},
+		{
+			name: "success - existing user promoted",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserByUsername", ctx, testUserUsername).
+					Return(&model.User{Base: model.Base{ID: testUserID}, Roles: model.Roles{model.RoleUser}}, nil)
+				mockRepo.On("UpdateRoles", ctx, testUserID, model.Roles{model.RoleUser, model.RoleAdmin}).Return(nil)
+			},
+		},
+		{
+			name: "success - existing admin left as is",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserByUsername", ctx, testUserUsername).
+					Return(&model.User{Base: model.Base{ID: testUserID}, Roles: model.Roles{model.RoleUser, model.RoleAdmin}}, nil)
+			},
+		},
+		{
+			name:       "error - missing user without password",
+			inputEmail: testUserEmail,
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserByUsername", ctx, testUserUsername).Return(nil, dbutils.ErrNotFoundType)
+			},
+			expectedErr: ErrBootstrapAdminIncomplete,
+		},
+		{
+			name: "error - repository error",
+			setupMock: func(ctx context.Context, mockRepo *repoMocks.User, mockPasswordHashing *mocks.PasswordHashing) {
+				mockRepo.On("GetUserByUsername", ctx, testUserUsername).Return(nil, errors.New("database error"))
+			},
+			expectedErr: errors.New("database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			mockRepo := repoMocks.NewUser(t)
+			mockPasswordHashing := mocks.NewPasswordHashing(t)
+			tc.setupMock(ctx, mockRepo, mockPasswordHashing)
+
+			svc := NewUser(mockRepo, tokenMocks.NewService(t), accessTokenMocks.NewRepository(t), mockPasswordHashing)
+
+			err := svc.BootstrapAdmin(ctx, testUserUsername, tc.inputEmail, tc.inputPassword)
+
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
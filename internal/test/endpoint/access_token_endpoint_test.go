@@ -0,0 +1,144 @@
+package endpoint
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccessTokenEndpoint validates the /v1/self/tokens endpoints and authentication with
+// personal access tokens: a token is shown once, works on the routes of its scopes only,
+// records its last use, stops working once revoked and while its user is disabled.
+func TestAccessTokenEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client, userID, _ := newAdminTestClient(t, t.TempDir())
+	userAuth := map[string]string{"Authorization": testValidAuthToken}
+
+	status, res := client.do(http.MethodPost, "/v1/self/tokens", map[string]any{
+		"name":   "Backup script",
+		"scopes": []string{"bookmarks:read"},
+	}, userAuth)
+	require.Equal(t, http.StatusOK, status)
+	tokenID, _ := res["id"].(string)
+	pat, _ := res["token"].(string)
+	require.True(t, strings.HasPrefix(pat, "bpat_"), pat)
+	patAuth := map[string]string{"Authorization": "Bearer " + pat}
+
+	// The token reads bookmarks, but cannot change them nor reach the account endpoints
+	status, _ = client.do(http.MethodGet, "/v1/bookmarks", nil, patAuth)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, res = client.do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Go", "url": "https://go.dev"}, patAuth)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "Token lacks the bookmarks:write scope", res["error"])
+
+	status, _ = client.do(http.MethodPost, "/v1/links/shorten", map[string]any{"url": "https://go.dev"}, patAuth)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, _ = client.do(http.MethodGet, "/v1/self/info", nil, patAuth)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, _ = client.do(http.MethodPost, "/v1/self/tokens", map[string]any{"name": "Escalated", "scopes": []string{"bookmarks:write"}}, patAuth)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// The listing shows the last use but not the token
+	status, res = client.do(http.MethodGet, "/v1/self/tokens", nil, userAuth)
+	require.Equal(t, http.StatusOK, status)
+	data, _ := res["data"].([]any)
+	require.Len(t, data, 1)
+	listed := data[0].(map[string]any)
+	assert.Equal(t, tokenID, listed["id"])
+	assert.Equal(t, "Backup script", listed["name"])
+	assert.NotNil(t, listed["last_used_at"])
+	assert.NotContains(t, listed, "token")
+
+	// Revoked tokens are rejected; only their owner can revoke them
+	status, res = client.do(http.MethodPost, "/v1/self/tokens", map[string]any{
+		"name":   "Old script",
+		"scopes": []string{"bookmarks:read"},
+	}, userAuth)
+	require.Equal(t, http.StatusOK, status)
+	revokedID, _ := res["id"].(string)
+	revokedAuth := map[string]string{"Authorization": "Bearer " + res["token"].(string)}
+
+	status, _ = client.do(http.MethodDelete, "/v1/self/tokens/"+revokedID, nil, map[string]string{"Authorization": testAdminAuthToken})
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = client.do(http.MethodDelete, "/v1/self/tokens/"+revokedID, nil, userAuth)
+	require.Equal(t, http.StatusOK, status)
+
+	status, res = client.do(http.MethodGet, "/v1/bookmarks", nil, revokedAuth)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "Invalid token", res["error"])
+
+	// Tokens of disabled users are rejected until the user is enabled again
+	status, _ = client.admin(http.MethodPost, "/v1/admin/users/"+userID+"/disable")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = client.do(http.MethodGet, "/v1/bookmarks", nil, patAuth)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = client.admin(http.MethodPost, "/v1/admin/users/"+userID+"/enable")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = client.do(http.MethodGet, "/v1/bookmarks", nil, patAuth)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// TestAccessTokenEndpoint_CredentialChanges validates that personal access tokens stop working
+// when the password of their user changes or every token of the user is revoked.
+func TestAccessTokenEndpoint_CredentialChanges(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		change func(client *tokenTestClient, userID string) int
+	}{
+		{
+			name: "password changed",
+			change: func(client *tokenTestClient, _ string) int {
+				status, _ := client.do(http.MethodPut, "/v1/self/password",
+					map[string]string{"current_password": "Password1!", "new_password": "NewPassword1!"},
+					map[string]string{"Authorization": testValidAuthToken})
+				return status
+			},
+		},
+		{
+			name: "tokens revoked by an administrator",
+			change: func(client *tokenTestClient, userID string) int {
+				status, _ := client.admin(http.MethodPost, "/v1/admin/users/"+userID+"/tokens/revoke")
+				return status
+			},
+		},
+		{
+			name: "password reset forced by an administrator",
+			change: func(client *tokenTestClient, userID string) int {
+				status, _ := client.admin(http.MethodPost, "/v1/admin/users/"+userID+"/password/reset")
+				return status
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, userID, _ := newAdminTestClient(t, t.TempDir())
+			status, res := client.do(http.MethodPost, "/v1/self/tokens", map[string]any{
+				"name":   "Backup script",
+				"scopes": []string{"bookmarks:read"},
+			}, map[string]string{"Authorization": testValidAuthToken})
+			require.Equal(t, http.StatusOK, status)
+			patAuth := map[string]string{"Authorization": "Bearer " + res["token"].(string)}
+
+			status, _ = client.do(http.MethodGet, "/v1/bookmarks", nil, patAuth)
+			require.Equal(t, http.StatusOK, status)
+
+			require.Equal(t, http.StatusOK, tc.change(client, userID))
+
+			status, _ = client.do(http.MethodGet, "/v1/bookmarks", nil, patAuth)
+			assert.Equal(t, http.StatusUnauthorized, status)
+		})
+	}
+}
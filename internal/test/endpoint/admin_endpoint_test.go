@@ -0,0 +1,221 @@
+package endpoint
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newAdminTestClient creates a test engine writing emails to mailDir, signs up the default user
+// and authenticates testAdminAuthToken as an admin and testValidAuthToken as that user.
+// It returns the ID and refresh token of the user.
+func newAdminTestClient(t *testing.T, mailDir string) (*tokenTestClient, string, string) {
+	cfg := defaultTestConfig()
+	cfg.AccessTokenTTL = 15 * time.Minute
+	cfg.RefreshTokenTTL = time.Hour
+	cfg.MailDriver = mailer.DriverFile
+	cfg.MailDir = mailDir
+	cfg.PasswordResetTTL = time.Hour
+	cfg.PasswordResetURL = "https://app.example.com/reset-password"
+	testEngine := NewTestEngine(&TestEngineOpts{T: t, Fixture: &fixture.BookmarkCommonTestDB{}, Cfg: cfg})
+	testEngine.JwtGen.On("GenerateToken", mock.Anything).Return("valid.jwt.token", nil)
+	client := &tokenTestClient{t: t, testEngine: testEngine}
+	userID, refreshToken := client.signUp()
+
+	testEngine.JwtValidator.On("ValidateToken", "admin.jwt.token").Return(fixture.DefaultJWTClaims(
+		fixture.WithClaim("sub", fixture.FixtureUserOneID),
+		fixture.WithClaim("jti", "jti-admin"),
+		fixture.WithClaim("gen", float64(0)),
+		fixture.WithClaim("roles", []any{model.RoleUser, model.RoleAdmin}),
+	), nil).Maybe()
+	testEngine.JwtValidator.On("ValidateToken", "valid.jwt.token").Return(fixture.DefaultJWTClaims(
+		fixture.WithClaim("sub", userID),
+		fixture.WithClaim("jti", "jti-user"),
+		fixture.WithClaim("gen", float64(0)),
+		fixture.WithClaim("roles", []any{model.RoleUser}),
+	), nil).Maybe()
+
+	return client, userID, refreshToken
+}
+
+// admin sends a request authenticated as the admin.
+func (c *tokenTestClient) admin(method, path string) (int, map[string]any) {
+	return c.do(method, path, nil, map[string]string{"Authorization": testAdminAuthToken})
+}
+
+func (c *tokenTestClient) login(password string) (int, map[string]any) {
+	return c.do(http.MethodPost, "/v1/users/login", fixture.DefaultLoginBody(fixture.WithField("password", password)), nil)
+}
+
+// TestAdminEndpoint_Users validates the GET /v1/admin/users, GET /v1/admin/users/:id and
+// DELETE /v1/admin/users/:id endpoints: users can be searched and viewed with their bookmark
+// count, and a deleted user is gone together with its tokens.
+func TestAdminEndpoint_Users(t *testing.T) {
+	t.Parallel()
+
+	client, userID, _ := newAdminTestClient(t, t.TempDir())
+	userPath := "/v1/admin/users/" + userID
+
+	status, _ := client.do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Go", "url": "https://go.dev"},
+		map[string]string{"Authorization": testValidAuthToken})
+	require.Equal(t, http.StatusOK, status)
+
+	// Non-admins are refused
+	status, _ = client.do(http.MethodGet, "/v1/admin/users", nil, map[string]string{"Authorization": testValidAuthToken})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	status, res := client.admin(http.MethodGet, "/v1/admin/users?q=TESTUSER")
+	require.Equal(t, http.StatusOK, status)
+	data, _ := res["data"].([]any)
+	require.Len(t, data, 1)
+	assert.Equal(t, userID, data[0].(map[string]any)["id"])
+
+	status, res = client.admin(http.MethodGet, "/v1/admin/users?limit=2")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(3), res["metadata"].(map[string]any)["total_records"])
+	assert.Len(t, res["data"], 2)
+
+	status, res = client.admin(http.MethodGet, "/v1/admin/users?disabled=true")
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, res["data"])
+
+	status, res = client.admin(http.MethodGet, userPath)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "testuser", res["username"])
+	assert.Equal(t, float64(1), res["bookmark_count"])
+
+	status, res = client.admin(http.MethodDelete, userPath)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "User deleted", res["message"])
+
+	status, _ = client.admin(http.MethodGet, userPath)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = client.admin(http.MethodDelete, userPath)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = client.getSelfInfo()
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = client.login("Password1!")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+// TestAdminEndpoint_DisableUser validates the POST /v1/admin/users/:id/disable and
+// POST /v1/admin/users/:id/enable endpoints: a disabled user is logged out and cannot
+// log in until the account is enabled again.
+func TestAdminEndpoint_DisableUser(t *testing.T) {
+	t.Parallel()
+
+	client, userID, refreshToken := newAdminTestClient(t, t.TempDir())
+
+	status, _ := client.getSelfInfo()
+	require.Equal(t, http.StatusOK, status)
+
+	status, res := client.admin(http.MethodPost, "/v1/admin/users/"+userID+"/disable")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "User disabled", res["message"])
+
+	status, _ = client.getSelfInfo()
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = client.refresh(refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, res = client.login("Password1!")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "Account is disabled", res["message"])
+
+	status, res = client.admin(http.MethodGet, "/v1/admin/users?disabled=true")
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, res["data"], 1)
+
+	status, res = client.admin(http.MethodPost, "/v1/admin/users/"+userID+"/enable")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "User enabled", res["message"])
+
+	status, _ = client.login("Password1!")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// TestAdminEndpoint_AdminProtection validates that administrators can neither disable nor
+// delete their own account, and that the last enabled administrator is kept.
+func TestAdminEndpoint_AdminProtection(t *testing.T) {
+	t.Parallel()
+
+	client, userID, _ := newAdminTestClient(t, t.TempDir())
+	db := client.testEngine.DB
+	ownPath := "/v1/admin/users/" + fixture.FixtureUserOneID
+	userPath := "/v1/admin/users/" + userID
+
+	status, res := client.admin(http.MethodPost, ownPath+"/disable")
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "You cannot disable or delete your own account", res["message"])
+
+	status, _ = client.admin(http.MethodDelete, ownPath)
+	assert.Equal(t, http.StatusConflict, status)
+
+	// The user is the only administrator in the database
+	require.NoError(t, db.Model(&model.User{}).Where("id = ?", userID).
+		Update("roles", model.Roles{model.RoleUser, model.RoleAdmin}).Error)
+
+	status, res = client.admin(http.MethodPost, userPath+"/disable")
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "Cannot disable or delete the last enabled administrator", res["message"])
+
+	status, _ = client.admin(http.MethodDelete, userPath)
+	assert.Equal(t, http.StatusConflict, status)
+
+	// Once another administrator is enabled, the user can go
+	require.NoError(t, db.Model(&model.User{}).Where("id = ?", fixture.FixtureUserOneID).
+		Update("roles", model.Roles{model.RoleUser, model.RoleAdmin}).Error)
+
+	status, _ = client.admin(http.MethodPost, userPath+"/disable")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = client.admin(http.MethodDelete, userPath)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// TestAdminEndpoint_ForcePasswordReset validates the POST /v1/admin/users/:id/password/reset
+// endpoint: the current password stops working and the user sets a new one with the emailed link.
+func TestAdminEndpoint_ForcePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	mailDir := t.TempDir()
+	client, userID, refreshToken := newAdminTestClient(t, mailDir)
+
+	status, _ := client.admin(http.MethodPost, "/v1/admin/users/00000000-0000-4000-8000-000000000000/password/reset")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, res := client.admin(http.MethodPost, "/v1/admin/users/"+userID+"/password/reset")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Password reset email sent", res["message"])
+
+	status, _ = client.login("Password1!")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = client.refresh(refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	messages := readMails(t, mailDir, resetSubject)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "test@example.com", messages[0].To)
+	link, err := url.Parse(resetLinkPattern.FindString(messages[0].Body))
+	require.NoError(t, err)
+
+	status, _ = client.do(http.MethodPost, "/v1/users/password/reset",
+		map[string]string{"token": link.Query().Get("token"), "new_password": "NewPassword1!"}, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	status, _ = client.login("NewPassword1!")
+	assert.Equal(t, http.StatusOK, status)
+}
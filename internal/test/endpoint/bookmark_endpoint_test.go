@@ -3,13 +3,19 @@ package endpoint
 import (
 	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	bookmarkRepo "github.com/HadesHo3820/ebvn-golang-course/internal/repository/bookmark"
 	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
 	jwtMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/response"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -42,6 +48,21 @@ func TestBookmarkEndpoint_Create(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedFields: []string{"id", "description", "url", "code", "user_id", "created_at"},
 		},
+		{
+			name:      "success - create bookmark without description",
+			authToken: testValidAuthToken,
+			requestBody: map[string]any{
+				"description": "",
+				"url":         "https://integration-test.com/untitled",
+			},
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusOK,
+			expectedFields: []string{"id", "description", "url", "code", "user_id", "created_at"},
+		},
 		{
 			name:      "error - unauthorized (invalid token)",
 			authToken: "Bearer invalid",
@@ -505,3 +526,1065 @@ func TestBookmarkEndpoint_Delete(t *testing.T) {
 		})
 	}
 }
+
+// TestBookmarkEndpoint_GetBookmark validates the GET /v1/bookmarks/{id} endpoint.
+func TestBookmarkEndpoint_GetBookmark(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		authToken      string
+		bookmarkID     string
+		setupMock      func(*jwtMocks.JWTValidator) jwt.MapClaims
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:       "success - get own bookmark",
+			authToken:  testValidAuthToken,
+			bookmarkID: fixture.FixtureBookmarkOneID,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":          fixture.FixtureBookmarkOneID,
+				"code":        fixture.FixtureBookmarkOneCode,
+				"url":         fixture.FixtureBookmarkURL,
+				"description": fixture.FixtureBookmarkDescription,
+			},
+		},
+		{
+			name:       "error - get another user's bookmark",
+			authToken:  testValidAuthToken,
+			bookmarkID: fixture.FixtureBookmarkOneID, // Belongs to User One
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserTwoID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:       "error - unauthorized",
+			authToken:  "Bearer invalid",
+			bookmarkID: fixture.FixtureBookmarkOneID,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				m.On("ValidateToken", mock.Anything).Return(nil, jwt.ErrTokenInvalidClaims)
+				return nil
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create test engine with helper and Bookmark fixture
+			testEngine := NewTestEngine(&TestEngineOpts{
+				T:       t,
+				Fixture: &fixture.BookmarkCommonTestDB{},
+			})
+
+			// Setup mock expectations
+			tc.setupMock(testEngine.JwtValidator)
+
+			// Create request
+			req := httptest.NewRequest(http.MethodGet, "/v1/bookmarks/"+tc.bookmarkID, nil)
+			if tc.authToken != "" {
+				req.Header.Set("Authorization", tc.authToken)
+			}
+
+			rec := httptest.NewRecorder()
+			testEngine.Engine.ServeHTTP(rec, req)
+
+			// Assert status code
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			// Assert response body if expected
+			if tc.expectedBody != nil {
+				var body map[string]any
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				for key, expected := range tc.expectedBody {
+					assert.Equal(t, expected, body[key])
+				}
+			}
+		})
+	}
+}
+
+// TestBookmarkEndpoint_Patch validates the PATCH /v1/bookmarks/{id} endpoint.
+func TestBookmarkEndpoint_Patch(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		authToken      string
+		bookmarkID     string
+		requestBody    string
+		setupMock      func(*jwtMocks.JWTValidator) jwt.MapClaims
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:        "success - patch URL keeps description",
+			authToken:   testValidAuthToken,
+			bookmarkID:  fixture.FixtureBookmarkOneID,
+			requestBody: `{"url": "https://patched-example.com"}`,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"url":         "https://patched-example.com",
+				"description": fixture.FixtureBookmarkDescription,
+			},
+		},
+		{
+			name:        "success - null description clears it",
+			authToken:   testValidAuthToken,
+			bookmarkID:  fixture.FixtureBookmarkOneID,
+			requestBody: `{"description": null}`,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"url":         fixture.FixtureBookmarkURL,
+				"description": "",
+			},
+		},
+		{
+			name:        "error - patch another user's bookmark",
+			authToken:   testValidAuthToken,
+			bookmarkID:  fixture.FixtureBookmarkOneID, // Belongs to User One
+			requestBody: `{"description": "Malicious Update"}`,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserTwoID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found",
+			},
+		},
+		{
+			name:        "error - null URL",
+			authToken:   testValidAuthToken,
+			bookmarkID:  fixture.FixtureBookmarkOneID,
+			requestBody: `{"url": null}`,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "error - empty URL",
+			authToken:   testValidAuthToken,
+			bookmarkID:  fixture.FixtureBookmarkOneID,
+			requestBody: `{"url": ""}`,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"message": response.InputErrMessage,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create test engine with helper and Bookmark fixture
+			testEngine := NewTestEngine(&TestEngineOpts{
+				T:       t,
+				Fixture: &fixture.BookmarkCommonTestDB{},
+			})
+
+			// Setup mock expectations
+			tc.setupMock(testEngine.JwtValidator)
+
+			// Create request
+			req := httptest.NewRequest(http.MethodPatch, "/v1/bookmarks/"+tc.bookmarkID, strings.NewReader(tc.requestBody))
+			req.Header.Set(contentTypeHeader, "application/merge-patch+json")
+			if tc.authToken != "" {
+				req.Header.Set("Authorization", tc.authToken)
+			}
+
+			rec := httptest.NewRecorder()
+			testEngine.Engine.ServeHTTP(rec, req)
+
+			// Assert status code
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			// Assert response body if expected
+			if tc.expectedBody != nil {
+				var body map[string]any
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				for key, expected := range tc.expectedBody {
+					assert.Equal(t, expected, body[key])
+				}
+			}
+		})
+	}
+}
+
+// TestBookmarkEndpoint_Restore validates the POST /v1/bookmarks/{id}/restore endpoint.
+func TestBookmarkEndpoint_Restore(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		authToken      string
+		bookmarkID     string
+		setupMock      func(*jwtMocks.JWTValidator) jwt.MapClaims
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:       "success - restore own trashed bookmark",
+			authToken:  testValidAuthToken,
+			bookmarkID: fixture.FixtureBookmarkTrashedID,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Success",
+			},
+		},
+		{
+			name:       "error - bookmark is not in trash",
+			authToken:  testValidAuthToken,
+			bookmarkID: fixture.FixtureBookmarkOneID,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found in trash",
+			},
+		},
+		{
+			name:       "error - restore another user's bookmark",
+			authToken:  testValidAuthToken,
+			bookmarkID: fixture.FixtureBookmarkTrashedID, // Belongs to User One
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserTwoID))
+				m.On("ValidateToken", mock.Anything).Return(claims, nil)
+				return claims
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"message": "Bookmark not found in trash",
+			},
+		},
+		{
+			name:       "error - unauthorized",
+			authToken:  "Bearer invalid",
+			bookmarkID: fixture.FixtureBookmarkTrashedID,
+			setupMock: func(m *jwtMocks.JWTValidator) jwt.MapClaims {
+				m.On("ValidateToken", mock.Anything).Return(nil, jwt.ErrTokenInvalidClaims)
+				return nil
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testEngine := NewTestEngine(&TestEngineOpts{
+				T:       t,
+				Fixture: &fixture.BookmarkCommonTestDB{},
+			})
+
+			tc.setupMock(testEngine.JwtValidator)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/bookmarks/"+tc.bookmarkID+"/restore", nil)
+			if tc.authToken != "" {
+				req.Header.Set("Authorization", tc.authToken)
+			}
+
+			rec := httptest.NewRecorder()
+			testEngine.Engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedBody != nil {
+				var body map[string]any
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				for key, expected := range tc.expectedBody {
+					assert.Equal(t, expected, body[key])
+				}
+			}
+		})
+	}
+}
+
+// TestBookmarkEndpoint_TrashLifecycle walks a bookmark through delete, trash listing and restore,
+// checking that a trashed bookmark is hidden from regular reads until it is restored.
+func TestBookmarkEndpoint_TrashLifecycle(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+
+	trashIDs := func() []string {
+		code, body := do(http.MethodGet, "/v1/bookmarks/trash")
+		assert.Equal(t, http.StatusOK, code)
+
+		ids := []string{}
+		for _, item := range body["data"].([]any) {
+			bookmark := item.(map[string]any)
+			assert.NotEmpty(t, bookmark["deleted_at"])
+			ids = append(ids, bookmark["id"].(string))
+		}
+		return ids
+	}
+
+	bookmarkPath := "/v1/bookmarks/" + fixture.FixtureBookmarkOneID
+
+	// Only the fixture's trashed bookmark is in the trash initially
+	assert.Equal(t, []string{fixture.FixtureBookmarkTrashedID}, trashIDs())
+
+	// Delete moves the bookmark to the trash
+	code, _ := do(http.MethodDelete, bookmarkPath)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = do(http.MethodGet, bookmarkPath)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, []string{fixture.FixtureBookmarkOneID, fixture.FixtureBookmarkTrashedID}, trashIDs())
+
+	// Deleting it again does not find it
+	code, _ = do(http.MethodDelete, bookmarkPath)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// Restore brings it back
+	code, _ = do(http.MethodPost, bookmarkPath+"/restore")
+	assert.Equal(t, http.StatusOK, code)
+
+	code, body := do(http.MethodGet, bookmarkPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, fixture.FixtureBookmarkOneID, body["id"])
+	assert.Equal(t, []string{fixture.FixtureBookmarkTrashedID}, trashIDs())
+}
+
+// TestBookmarkEndpoint_ImportExport imports a browser bookmark file through
+// POST /v1/bookmarks/import and reads the collection back through GET /v1/bookmarks/export.
+func TestBookmarkEndpoint_ImportExport(t *testing.T) {
+	t.Parallel()
+
+	const importFile = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://example.com/long-url">Already bookmarked</A>
+    <DT><H3>Work</H3>
+    <DL><p>
+        <DT><A HREF="https://go.dev/" ADD_DATE="1700000000" TAGS="go">Go</A>
+        <DT><A HREF="javascript:void(0)">Bookmarklet</A>
+    </DL><p>
+</DL><p>
+`
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	// Import
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "bookmarks.html")
+	assert.NoError(t, err)
+	_, _ = part.Write([]byte(importFile))
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/bookmarks/import", body)
+	req.Header.Set("Authorization", testValidAuthToken)
+	req.Header.Set(contentTypeHeader, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	testEngine.Engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"created":1,"updated":0,"skipped":1,"invalid":1}`, rec.Body.String())
+
+	// Export as JSON
+	req = httptest.NewRequest(http.MethodGet, "/v1/bookmarks/export?format=json", nil)
+	req.Header.Set("Authorization", testValidAuthToken)
+	rec = httptest.NewRecorder()
+	testEngine.Engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var exported []map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &exported))
+	assert.Len(t, exported, 2)
+
+	// Root folder first, then "Work"
+	assert.Equal(t, fixture.FixtureBookmarkOneID, exported[0]["id"])
+	assert.Equal(t, "https://go.dev/", exported[1]["url"])
+	assert.Equal(t, "Go", exported[1]["description"])
+	assert.Equal(t, "Work", exported[1]["folder"])
+	assert.Equal(t, []any{"go"}, exported[1]["tags"])
+	assert.Equal(t, "2023-11-14T22:13:20Z", exported[1]["created_at"])
+
+	// Export as HTML
+	req = httptest.NewRequest(http.MethodGet, "/v1/bookmarks/export", nil)
+	req.Header.Set("Authorization", testValidAuthToken)
+	rec = httptest.NewRecorder()
+	testEngine.Engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `<DT><H3>Work</H3>`)
+	assert.Contains(t, rec.Body.String(), `<DT><A HREF="https://go.dev/" ADD_DATE="1700000000" TAGS="go">Go</A>`)
+}
+
+// TestBookmarkEndpoint_Duplicates creates bookmarks for spellings of an already bookmarked URL
+// with every on_duplicate mode and lists the resulting duplicate groups.
+func TestBookmarkEndpoint_Duplicates(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string, reqBody map[string]any) (int, map[string]any) {
+		var payload []byte
+		if reqBody != nil {
+			payload, _ = json.Marshal(reqBody)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Authorization", testValidAuthToken)
+		req.Header.Set("Content-Type", contentTypeHeader)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+
+	variant := "HTTPS://Example.com/long-url/?utm_source=newsletter#comments"
+
+	// No duplicates yet: the fixture's second copy of the URL is in the trash
+	code, body := do(http.MethodGet, "/v1/bookmarks/duplicates", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, body["data"])
+
+	// Duplicates are rejected by default, pointing at the existing bookmark
+	code, body = do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Again", "url": variant})
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, fixture.FixtureBookmarkOneID, body["existing_id"])
+
+	// Merge returns the existing bookmark, keeping its description
+	code, body = do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Again", "url": variant, "on_duplicate": "merge"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, fixture.FixtureBookmarkOneID, body["id"])
+	assert.Equal(t, fixture.FixtureBookmarkDescription, body["description"])
+
+	// Allow creates a second bookmark
+	code, body = do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Again", "url": variant, "on_duplicate": "allow"})
+	assert.Equal(t, http.StatusOK, code)
+	duplicateID := body["id"]
+	assert.NotEqual(t, fixture.FixtureBookmarkOneID, duplicateID)
+
+	code, body = do(http.MethodGet, "/v1/bookmarks/duplicates", nil)
+	assert.Equal(t, http.StatusOK, code)
+	groups := body["data"].([]any)
+	if assert.Len(t, groups, 1) {
+		group := groups[0].(map[string]any)
+		assert.Equal(t, fixture.FixtureBookmarkURL, group["normalized_url"])
+
+		ids := []any{}
+		for _, item := range group["bookmarks"].([]any) {
+			ids = append(ids, item.(map[string]any)["id"])
+		}
+		assert.Equal(t, []any{fixture.FixtureBookmarkOneID, duplicateID}, ids)
+	}
+}
+
+// TestBookmarkEndpoint_LinkCheck validates listing broken links and accepting a permanent redirect
+// found by the link checker, whose results are written to the database directly.
+func TestBookmarkEndpoint_LinkCheck(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+
+	movedURL := "https://example.com/new-home"
+	checkedAt := fixture.FixtureTimestamp
+	assert.NoError(t, testEngine.DB.Model(&model.Bookmark{}).Where("id = ?", fixture.FixtureBookmarkOneID).
+		Updates(&model.LinkCheck{StatusCode: http.StatusOK, FinalURL: movedURL, LastCheckedAt: &checkedAt}).Error)
+	broken := &model.Bookmark{
+		URL:       "https://example.com/gone",
+		Code:      "gone0001",
+		UserID:    fixture.FixtureUserOneID,
+		LinkCheck: model.LinkCheck{StatusCode: http.StatusNotFound, LastCheckedAt: &checkedAt},
+	}
+	assert.NoError(t, testEngine.DB.Create(broken).Error)
+
+	code, body := do(http.MethodGet, "/v1/bookmarks?status=broken")
+	assert.Equal(t, http.StatusOK, code)
+	if data := body["data"].([]any); assert.Len(t, data, 1) {
+		assert.Equal(t, broken.ID, data[0].(map[string]any)["id"])
+		assert.Equal(t, float64(http.StatusNotFound), data[0].(map[string]any)["status_code"])
+	}
+
+	code, body = do(http.MethodGet, "/v1/bookmarks?status=redirected")
+	assert.Equal(t, http.StatusOK, code)
+	if data := body["data"].([]any); assert.Len(t, data, 1) {
+		assert.Equal(t, movedURL, data[0].(map[string]any)["final_url"])
+	}
+
+	// A bookmark without a permanent redirect has nothing to accept
+	code, _ = do(http.MethodPost, "/v1/bookmarks/"+broken.ID+"/accept-redirect")
+	assert.Equal(t, http.StatusConflict, code)
+
+	// Bookmarks of other users cannot be changed
+	code, _ = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkTwoID+"/accept-redirect")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, body = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID+"/accept-redirect")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, movedURL, body["url"])
+	assert.NotContains(t, body, "final_url")
+
+	code, body = do(http.MethodGet, "/v1/bookmarks?status=redirected")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, body["data"])
+}
+
+// TestBookmarkEndpoint_States validates the favorite, pin and archive toggles and their list filters.
+func TestBookmarkEndpoint_States(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+	listIDs := func(query string) []string {
+		code, body := do(http.MethodGet, "/v1/bookmarks"+query)
+		assert.Equal(t, http.StatusOK, code)
+		ids := []string{}
+		for _, item := range body["data"].([]any) {
+			ids = append(ids, item.(map[string]any)["id"].(string))
+		}
+		return ids
+	}
+
+	newer := &model.Bookmark{URL: "https://example.com/newer", Code: "newer001", UserID: fixture.FixtureUserOneID}
+	assert.NoError(t, testEngine.DB.Create(newer).Error)
+	assert.Equal(t, []string{newer.ID, fixture.FixtureBookmarkOneID}, listIDs(""))
+
+	// Pinning the older bookmark moves it to the top
+	code, body := do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID+"/pin")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, body["pinned"])
+	assert.Equal(t, []string{fixture.FixtureBookmarkOneID, newer.ID}, listIDs(""))
+
+	code, body = do(http.MethodPost, "/v1/bookmarks/"+newer.ID+"/favorite")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, body["is_favorite"])
+	assert.Equal(t, []string{newer.ID}, listIDs("?favorite=true"))
+
+	// Archived bookmarks leave the default list
+	code, _ = do(http.MethodPost, "/v1/bookmarks/"+newer.ID+"/archive")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{fixture.FixtureBookmarkOneID}, listIDs(""))
+	assert.Equal(t, []string{newer.ID}, listIDs("?archived=true"))
+
+	// Toggling again unarchives
+	code, body = do(http.MethodPost, "/v1/bookmarks/"+newer.ID+"/archive")
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, body, "archived")
+	assert.Len(t, listIDs(""), 2)
+
+	// States are personal: other users' bookmarks cannot be toggled
+	code, _ = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkTwoID+"/favorite")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+// TestBookmarkEndpoint_Bulk validates the POST /v1/bookmarks/bulk endpoint.
+func TestBookmarkEndpoint_Bulk(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(body map[string]any) (int, map[string]any) {
+		reqBody, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/v1/bookmarks/bulk", bytes.NewReader(reqBody))
+		req.Header.Set("Authorization", testValidAuthToken)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var resBody map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resBody))
+		return rec.Code, resBody
+	}
+
+	extra := &model.Bookmark{URL: "https://example.com/extra", Code: "bulk0001", UserID: fixture.FixtureUserOneID}
+	assert.NoError(t, testEngine.DB.Create(extra).Error)
+	ids := []string{fixture.FixtureBookmarkOneID, extra.ID}
+
+	code, body := do(map[string]any{"action": "tag", "ids": ids, "tags": []string{"cleanup"}})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(2), body["affected"])
+
+	code, body = do(map[string]any{"action": "move", "ids": ids, "folder": "Old"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(2), body["affected"])
+
+	// An empty filter does not select every bookmark
+	code, _ = do(map[string]any{"action": "delete", "filter": map[string]any{}})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// A single foreign ID fails the whole operation
+	code, _ = do(map[string]any{"action": "delete", "ids": append(ids, fixture.FixtureBookmarkTwoID)})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, body = do(map[string]any{"action": "delete", "ids": ids})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(2), body["affected"])
+
+	var trashed []model.Bookmark
+	assert.NoError(t, testEngine.DB.Unscoped().Preload("Tags").Where("id IN ?", ids).Find(&trashed).Error)
+	for _, bookmark := range trashed {
+		assert.True(t, bookmark.DeletedAt.Valid)
+		assert.Equal(t, "Old", bookmark.Folder)
+		assert.Contains(t, bookmark.TagNames(), "cleanup")
+	}
+}
+
+// TestBookmarkEndpoint_History validates that edits are recorded and can be reverted.
+func TestBookmarkEndpoint_History(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string, body map[string]any) (int, map[string]any) {
+		var reqBody []byte
+		if body != nil {
+			reqBody, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(reqBody))
+		req.Header.Set("Authorization", testValidAuthToken)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var resp map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return rec.Code, resp
+	}
+	bookmarkPath := "/v1/bookmarks/" + fixture.FixtureBookmarkOneID
+
+	code, _ := do(http.MethodPut, bookmarkPath, map[string]any{"description": "Edited", "url": "https://example.com/edited"})
+	assert.Equal(t, http.StatusOK, code)
+
+	code, body := do(http.MethodGet, bookmarkPath+"/history", nil)
+	assert.Equal(t, http.StatusOK, code)
+	revisions := body["data"].([]any)
+	if assert.Len(t, revisions, 1) {
+		rev := revisions[0].(map[string]any)
+		assert.Equal(t, float64(1), rev["revision"])
+		assert.Equal(t, fixture.FixtureBookmarkURL, rev["url"])
+		assert.Equal(t, fixture.FixtureBookmarkDescription, rev["description"])
+		assert.Equal(t, fixture.FixtureUserOneID, rev["changed_by"])
+	}
+
+	// Reverting restores the replaced values and is itself recorded
+	code, body = do(http.MethodPost, bookmarkPath+"/revert/1", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, fixture.FixtureBookmarkURL, body["url"])
+	assert.Equal(t, fixture.FixtureBookmarkDescription, body["description"])
+
+	code, body = do(http.MethodGet, bookmarkPath+"/history", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body["data"], 2)
+
+	code, body = do(http.MethodPost, bookmarkPath+"/revert/9", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "Revision not found", body["message"])
+
+	// Other users' history is not visible
+	code, _ = do(http.MethodGet, "/v1/bookmarks/"+fixture.FixtureBookmarkTwoID+"/history", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+// TestBookmarkEndpoint_ReadingList validates the read status, the unread filter and the notes of bookmarks.
+func TestBookmarkEndpoint_ReadingList(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path, body string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var resp map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return rec.Code, resp
+	}
+	countUnread := func() int {
+		code, body := do(http.MethodGet, "/v1/bookmarks?unread=true", "")
+		assert.Equal(t, http.StatusOK, code)
+		return len(body["data"].([]any))
+	}
+	bookmarkPath := "/v1/bookmarks/" + fixture.FixtureBookmarkOneID
+
+	assert.Equal(t, 1, countUnread())
+
+	code, body := do(http.MethodPost, bookmarkPath+"/read", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, body["read_at"])
+	assert.Equal(t, 0, countUnread())
+
+	code, body = do(http.MethodPost, bookmarkPath+"/unread", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, body, "read_at")
+	assert.Equal(t, 1, countUnread())
+
+	// Notes are written with PATCH and rendered without any raw HTML
+	code, body = do(http.MethodPatch, bookmarkPath, `{"notes":"Read **twice** <img src=x onerror=alert(1)>"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Read **twice** <img src=x onerror=alert(1)>", body["notes"])
+
+	code, body = do(http.MethodGet, bookmarkPath+"/notes", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "<p>Read <strong>twice</strong> </p>", body["html"])
+
+	// Read status is personal: other users' bookmarks cannot be marked
+	code, _ = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkTwoID+"/read", "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+// TestBookmarkEndpoint_Snapshot validates requesting and serving the offline snapshot of a bookmark.
+// Background jobs are not started by the test engine, so the capture is simulated by
+// storing the snapshot in the configured directory.
+func TestBookmarkEndpoint_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultTestConfig()
+	cfg.SnapshotWorkers = 1
+	cfg.SnapshotQueueSize = 5
+	cfg.SnapshotDir = t.TempDir()
+	cfg.SnapshotQuotaBytes = 1 << 20
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+		Cfg:     cfg,
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+		return rec
+	}
+	snapshotPath := "/v1/bookmarks/" + fixture.FixtureBookmarkOneID + "/snapshot"
+
+	rec := do(http.MethodGet, snapshotPath)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message":"Snapshot not found"}`, rec.Body.String())
+
+	rec = do(http.MethodPost, snapshotPath)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var snapshot map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+	assert.Equal(t, "pending", snapshot["status"])
+
+	rec = do(http.MethodGet, snapshotPath)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	// Complete the capture as the worker would
+	page := "<html><body>Archived</body></html>"
+	assert.NoError(t, os.MkdirAll(filepath.Join(cfg.SnapshotDir, "snapshots"), 0o750))
+	assert.NoError(t, os.WriteFile(filepath.Join(cfg.SnapshotDir, filepath.FromSlash(model.SnapshotKey(fixture.FixtureBookmarkOneID))), []byte(page), 0o600))
+	assert.NoError(t, testEngine.DB.Model(&model.BookmarkSnapshot{}).
+		Where("bookmark_id = ?", fixture.FixtureBookmarkOneID).
+		Updates(map[string]any{"status": model.SnapshotReady, "size": len(page), "captured_at": fixture.FixtureTimestamp}).Error)
+
+	rec = do(http.MethodGet, snapshotPath)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, page, rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "sandbox")
+
+	// Only the owner may archive a bookmark
+	rec = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkTwoID+"/snapshot")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// TestBookmarkEndpoint_SnapshotDisabled validates that snapshot endpoints report when snapshots are disabled.
+func TestBookmarkEndpoint_SnapshotDisabled(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	for _, method := range []string{http.MethodPost, http.MethodGet} {
+		req := httptest.NewRequest(method, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID+"/snapshot", nil)
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.JSONEq(t, `{"message":"Snapshots are disabled"}`, rec.Body.String())
+	}
+}
+
+// TestBookmarkEndpoint_ConditionalRequests validates ETags, If-Match and If-None-Match on bookmarks.
+func TestBookmarkEndpoint_ConditionalRequests(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", testValidAuthToken)
+		req.Header.Set("Content-Type", "application/json")
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+		return rec
+	}
+	bookmarkPath := "/v1/bookmarks/" + fixture.FixtureBookmarkOneID
+
+	rec := do(http.MethodGet, bookmarkPath, "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	firstETag := rec.Header().Get("ETag")
+	assert.Equal(t, `"1"`, firstETag)
+
+	rec = do(http.MethodGet, bookmarkPath, "", map[string]string{"If-None-Match": firstETag})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	// Background jobs do not change the version: the link check and enrichment leave the ETag as is
+	checkedAt := fixture.FixtureTimestamp
+	repo := bookmarkRepo.NewRepository(testEngine.DB)
+	assert.NoError(t, repo.UpdateLinkCheck(t.Context(), fixture.FixtureBookmarkOneID,
+		&model.LinkCheck{StatusCode: http.StatusOK, LastCheckedAt: &checkedAt}))
+	assert.NoError(t, repo.UpdatePageMetadata(t.Context(), fixture.FixtureBookmarkOneID,
+		&model.PageMetadata{Title: "Example Domain"}))
+	rec = do(http.MethodGet, bookmarkPath, "", map[string]string{"If-None-Match": firstETag})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	// The first client updates the bookmark it read
+	rec = do(http.MethodPut, bookmarkPath, `{"description":"First","url":"https://example.com/first"}`,
+		map[string]string{"If-Match": firstETag})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// The second client read the same version and must not overwrite the first update
+	rec = do(http.MethodPut, bookmarkPath, `{"description":"Second","url":"https://example.com/second"}`,
+		map[string]string{"If-Match": firstETag})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.JSONEq(t, `{"message":"Bookmark has been modified"}`, rec.Body.String())
+
+	rec = do(http.MethodDelete, bookmarkPath, "", map[string]string{"If-Match": firstETag})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	rec = do(http.MethodGet, bookmarkPath, "", map[string]string{"If-None-Match": firstETag})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	secondETag := rec.Header().Get("ETag")
+	assert.Equal(t, `"2"`, secondETag)
+	assert.Contains(t, rec.Body.String(), `"description":"First"`)
+
+	rec = do(http.MethodPatch, bookmarkPath, `{"description":"Patched"}`, map[string]string{"If-Match": secondETag})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+
+	// Lists are tagged by content and change whenever one of their bookmarks does
+	rec = do(http.MethodGet, "/v1/bookmarks", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	listETag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, listETag)
+
+	rec = do(http.MethodGet, "/v1/bookmarks", "", map[string]string{"If-None-Match": listETag})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = do(http.MethodDelete, bookmarkPath, "", map[string]string{"If-Match": `"3"`})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do(http.MethodGet, "/v1/bookmarks", "", map[string]string{"If-None-Match": listETag})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// TestBookmarkEndpoint_Code validates caller-chosen codes, which share the redirect namespace
+// with short links, and regenerating the code of a bookmark.
+func TestBookmarkEndpoint_Code(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+		return rec
+	}
+	create := func(code, url string) *httptest.ResponseRecorder {
+		return do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Go", "url": url, "code": code})
+	}
+
+	// A chosen code redirects to the bookmark
+	rec := create("golang", "https://go.dev")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var created model.Bookmark
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "golang", created.Code)
+
+	rec = do(http.MethodGet, "/v1/links/redirect/golang", nil)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://go.dev", rec.Header().Get("Location"))
+
+	// Reserved words and codes already in use are refused
+	rec = create("Swagger", "https://swagger.io")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message":"Code is reserved"}`, rec.Body.String())
+
+	rec = create(fixture.FixtureBookmarkTwoCode, "https://example.org")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message":"Code is already taken"}`, rec.Body.String())
+
+	rec = do(http.MethodPost, "/v1/links/shorten", fixture.DefaultShortenURLBody())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var shortened struct {
+		Code string `json:"code"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &shortened))
+	rec = create(shortened.Code, "https://example.org")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	// A regenerated code replaces the previous one
+	rec = do(http.MethodPost, "/v1/bookmarks/"+created.ID+"/code", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var regenerated model.Bookmark
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &regenerated))
+	assert.Len(t, regenerated.Code, 9)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+	rec = do(http.MethodGet, "/v1/links/redirect/golang", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = do(http.MethodGet, "/v1/links/redirect/"+regenerated.Code, nil)
+	assert.Equal(t, http.StatusFound, rec.Code)
+
+	// Only the owner can regenerate a code
+	rec = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkTwoID+"/code", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,140 @@
+package endpoint
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// verificationSubject is the subject of the email verification emails.
+const verificationSubject = "Confirm your email address"
+
+// verifyLinkPattern finds the email verification link in an email body.
+var verifyLinkPattern = regexp.MustCompile(`https://api\.example\.com/v1/users/verify\?\S+`)
+
+// newVerificationTestClient creates a test client whose emails are written to the returned directory,
+// and which refuses the login of users with an unverified email address.
+func newVerificationTestClient(t *testing.T) (*tokenTestClient, string) {
+	mailDir := t.TempDir()
+	cfg := defaultTestConfig()
+	cfg.AccessTokenTTL = 15 * time.Minute
+	cfg.RefreshTokenTTL = time.Hour
+	cfg.MailDriver = mailer.DriverFile
+	cfg.MailDir = mailDir
+	cfg.EmailVerificationTTL = time.Hour
+	cfg.EmailVerificationURL = "https://api.example.com/v1/users/verify"
+	cfg.RequireVerifiedEmail = true
+	testEngine := NewTestEngine(&TestEngineOpts{T: t, Cfg: cfg})
+	testEngine.JwtGen.On("GenerateToken", mock.Anything).Return("valid.jwt.token", nil).Maybe()
+
+	return &tokenTestClient{t: t, testEngine: testEngine}, mailDir
+}
+
+// verifyLinkTo returns the path and query of the last verification link emailed to the address.
+func verifyLinkTo(t *testing.T, mailDir, to string) string {
+	var link string
+	for _, msg := range readMails(t, mailDir, verificationSubject) {
+		if msg.To == to {
+			link = verifyLinkPattern.FindString(msg.Body)
+		}
+	}
+	require.NotEmpty(t, link, "no verification link sent to %s", to)
+
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	return u.RequestURI()
+}
+
+// TestUserEndpoint_EmailVerification validates the GET /v1/users/verify and POST /v1/users/verify/resend
+// endpoints: the address given at registration must be confirmed before logging in, and the links
+// can be used once.
+func TestUserEndpoint_EmailVerification(t *testing.T) {
+	t.Parallel()
+
+	client, mailDir := newVerificationTestClient(t)
+	loginBody := fixture.DefaultLoginBody(fixture.WithField("password", "Password1!"))
+
+	status, _ := client.do(http.MethodPost, "/v1/users/register", fixture.DefaultRegisterBody(), nil)
+	require.Equal(t, http.StatusOK, status)
+	verifyPath := verifyLinkTo(t, mailDir, "test@example.com")
+
+	// Unverified users cannot log in
+	status, res := client.do(http.MethodPost, "/v1/users/login", loginBody, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "Email address is not verified", res["message"])
+
+	// Resending does not tell whether the account exists
+	unknownStatus, unknownRes := client.do(http.MethodPost, "/v1/users/verify/resend", map[string]string{"email": "nobody@example.com"}, nil)
+	status, res = client.do(http.MethodPost, "/v1/users/verify/resend", map[string]string{"email": "test@example.com"}, nil)
+	assert.Equal(t, http.StatusOK, unknownStatus)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, unknownRes, res)
+	assert.Len(t, readMails(t, mailDir, verificationSubject), 2)
+
+	status, res = client.do(http.MethodGet, verifyPath, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Email address verified", res["message"])
+
+	status, _ = client.do(http.MethodPost, "/v1/users/login", loginBody, nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	// The link can be used once
+	status, res = client.do(http.MethodGet, verifyPath, nil, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Invalid or expired verification token", res["message"])
+}
+
+// TestUserEndpoint_EmailChange validates that a new email address set with PUT /v1/self/info
+// stays pending until it is confirmed, and is refused if another user took it meanwhile.
+func TestUserEndpoint_EmailChange(t *testing.T) {
+	t.Parallel()
+
+	client, mailDir := newVerificationTestClient(t)
+	status, res := client.do(http.MethodPost, "/v1/users/register", fixture.DefaultRegisterBody(), nil)
+	require.Equal(t, http.StatusOK, status)
+	data, _ := res["data"].(map[string]any)
+	userID, _ := data["id"].(string)
+	client.testEngine.JwtValidator.On("ValidateToken", mock.Anything).
+		Return(fixture.DefaultJWTClaims(fixture.WithClaim("sub", userID)), nil)
+	authHeaders := map[string]string{"Authorization": testValidAuthToken}
+
+	selfEmail := func() (any, any) {
+		status, res := client.getSelfInfo()
+		require.Equal(t, http.StatusOK, status)
+		data, _ := res["data"].(map[string]any)
+		return data["email"], data["pending_email"]
+	}
+
+	status, _ = client.do(http.MethodPut, "/v1/self/info", map[string]string{"email": "new@example.com"}, authHeaders)
+	require.Equal(t, http.StatusOK, status)
+
+	email, pending := selfEmail()
+	assert.Equal(t, "test@example.com", email)
+	assert.Equal(t, "new@example.com", pending)
+
+	status, _ = client.do(http.MethodGet, verifyLinkTo(t, mailDir, "new@example.com"), nil, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	email, pending = selfEmail()
+	assert.Equal(t, "new@example.com", email)
+	assert.Nil(t, pending)
+
+	// An address taken by another user cannot be confirmed
+	status, _ = client.do(http.MethodPut, "/v1/self/info", map[string]string{"email": fixture.FixtureUserTwoEmail}, authHeaders)
+	require.Equal(t, http.StatusOK, status)
+
+	status, res = client.do(http.MethodGet, verifyLinkTo(t, mailDir, fixture.FixtureUserTwoEmail), nil, nil)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "Email already in use", res["message"])
+
+	email, _ = selfEmail()
+	assert.Equal(t, "new@example.com", email)
+}
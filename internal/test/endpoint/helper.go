@@ -7,6 +7,7 @@ import (
 	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils"
 	jwtMocks "github.com/HadesHo3820/ebvn-golang-course/pkg/jwtutils/mocks"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
 	redisPkg "github.com/HadesHo3820/ebvn-golang-course/pkg/redis"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/stringutils"
 	"github.com/HadesHo3820/ebvn-golang-course/pkg/utils"
@@ -19,6 +20,7 @@ func defaultTestConfig() *api.Config {
 	return &api.Config{
 		ServiceName: "test-service",
 		InstanceID:  "1234",
+		MailDriver:  mailer.DriverLog,
 	}
 }
 
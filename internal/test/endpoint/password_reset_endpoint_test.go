@@ -0,0 +1,130 @@
+package endpoint
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// resetSubject is the subject of the password reset emails.
+const resetSubject = "Reset your password"
+
+// resetLinkPattern finds the password reset link in an email body.
+var resetLinkPattern = regexp.MustCompile(`https://app\.example\.com/reset-password\?\S+`)
+
+// readMails returns the emails with the given subject written by the file mailer to dir, oldest first.
+func readMails(t *testing.T, dir, subject string) []*mailer.Message {
+	messages, err := mailer.ReadDir(dir)
+	require.NoError(t, err)
+
+	var res []*mailer.Message
+	for _, msg := range messages {
+		if msg.Subject == subject {
+			res = append(res, msg)
+		}
+	}
+	return res
+}
+
+// TestUserEndpoint_PasswordReset validates the POST /v1/users/password/forgot and
+// POST /v1/users/password/reset endpoints: the reset link is emailed only to existing
+// accounts although the response is the same, and its token can be used once.
+func TestUserEndpoint_PasswordReset(t *testing.T) {
+	t.Parallel()
+
+	mailDir := t.TempDir()
+	cfg := defaultTestConfig()
+	cfg.AccessTokenTTL = 15 * time.Minute
+	cfg.RefreshTokenTTL = time.Hour
+	cfg.MailDriver = mailer.DriverFile
+	cfg.MailDir = mailDir
+	cfg.PasswordResetTTL = time.Hour
+	cfg.PasswordResetURL = "https://app.example.com/reset-password"
+	testEngine := NewTestEngine(&TestEngineOpts{T: t, Cfg: cfg})
+	testEngine.JwtGen.On("GenerateToken", mock.Anything).Return("valid.jwt.token", nil)
+	client := &tokenTestClient{t: t, testEngine: testEngine}
+	_, refreshToken := client.signUp()
+
+	forgot := func(email string) (int, map[string]any) {
+		return client.do(http.MethodPost, "/v1/users/password/forgot", map[string]string{"email": email}, nil)
+	}
+	reset := func(token string) (int, map[string]any) {
+		return client.do(http.MethodPost, "/v1/users/password/reset",
+			map[string]string{"token": token, "new_password": "NewPassword1!"}, nil)
+	}
+
+	// Unknown addresses get the same response, but no email
+	unknownStatus, unknownRes := forgot("nobody@example.com")
+	require.Equal(t, http.StatusOK, unknownStatus)
+	assert.Empty(t, readMails(t, mailDir, resetSubject))
+
+	status, res := forgot("test@example.com")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, unknownRes, res)
+
+	messages := readMails(t, mailDir, resetSubject)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "test@example.com", messages[0].To)
+	link, err := url.Parse(resetLinkPattern.FindString(messages[0].Body))
+	require.NoError(t, err)
+	resetToken := link.Query().Get("token")
+	require.NotEmpty(t, resetToken)
+
+	// The token sets the new password and logs the user out
+	status, res = reset(resetToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Password has been reset", res["message"])
+
+	status, _ = client.refresh(refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = client.do(http.MethodPost, "/v1/users/login", fixture.DefaultLoginBody(fixture.WithField("password", "Password1!")), nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = client.do(http.MethodPost, "/v1/users/login", fixture.DefaultLoginBody(fixture.WithField("password", "NewPassword1!")), nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	// The token can be used once
+	status, res = reset(resetToken)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Invalid or expired reset token", res["message"])
+}
+
+// TestUserEndpoint_PasswordReset_MailFailure validates that POST /v1/users/password/forgot
+// answers an existing account exactly like an unknown address when the email cannot be sent.
+func TestUserEndpoint_PasswordReset_MailFailure(t *testing.T) {
+	t.Parallel()
+
+	mailDir := filepath.Join(t.TempDir(), "mail")
+	cfg := defaultTestConfig()
+	cfg.MailDriver = mailer.DriverFile
+	cfg.MailDir = mailDir
+	cfg.PasswordResetTTL = time.Hour
+	cfg.PasswordResetURL = "https://app.example.com/reset-password"
+	testEngine := NewTestEngine(&TestEngineOpts{T: t, Cfg: cfg})
+	client := &tokenTestClient{t: t, testEngine: testEngine}
+
+	// The file mailer cannot write to the directory once it is replaced by a file
+	require.NoError(t, os.RemoveAll(mailDir))
+	require.NoError(t, os.WriteFile(mailDir, nil, 0o600))
+
+	forgot := func(email string) (int, map[string]any) {
+		return client.do(http.MethodPost, "/v1/users/password/forgot", map[string]string{"email": email}, nil)
+	}
+
+	unknownStatus, unknownRes := forgot("nobody@example.com")
+	require.Equal(t, http.StatusOK, unknownStatus)
+
+	status, res := forgot(fixture.FixtureUserOneEmail)
+	assert.Equal(t, unknownStatus, status)
+	assert.Equal(t, unknownRes, res)
+}
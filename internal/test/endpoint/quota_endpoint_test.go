@@ -0,0 +1,108 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestQuotaEndpoint validates that the bookmark quota is enforced on creation and restore,
+// that the daily short link quota is enforced, also for anonymous callers, that per-user
+// overrides apply, and that GET /v1/self/quota reports the usage.
+func TestQuotaEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultTestConfig()
+	cfg.QuotaMaxBookmarks = 2
+	cfg.QuotaShortLinksPerDay = 1
+	cfg.QuotaAnonymousShortLinksPerDay = 1
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+		Cfg:     cfg,
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string, body any, authenticated bool) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if authenticated {
+			req.Header.Set("Authorization", testValidAuthToken)
+		}
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+		return rec
+	}
+	getQuota := func() *model.Quota {
+		rec := do(http.MethodGet, "/v1/self/quota", nil, true)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var res struct {
+			Data *model.Quota `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res.Data
+	}
+
+	// The fixture user owns one bookmark outside the trash
+	quota := getQuota()
+	assert.Equal(t, model.QuotaLimits{Bookmarks: 2, ShortLinksPerDay: 1}, quota.Limits)
+	assert.Equal(t, model.QuotaUsage{Bookmarks: 1}, quota.Usage)
+	assert.False(t, quota.ShortLinksResetAt.IsZero())
+
+	// Bookmarks
+	rec := do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Second", "url": "https://second.example.com"}, true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Third", "url": "https://third.example.com"}, true)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"message":"Bookmark quota exceeded"}`, rec.Body.String())
+
+	// Trashed bookmarks do not count, so restoring one is refused as well
+	rec = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkTrashedID+"/restore", nil, true)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"message":"Bookmark quota exceeded"}`, rec.Body.String())
+
+	// Short links: counted for signed-in users, and by address for anonymous callers
+	shorten := fixture.DefaultShortenURLBody()
+	rec = do(http.MethodPost, "/v1/links/shorten", shorten, true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do(http.MethodPost, "/v1/links/shorten", shorten, true)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, `{"message":"Daily short link quota exceeded"}`, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	rec = do(http.MethodPost, "/v1/links/shorten", shorten, false)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do(http.MethodPost, "/v1/links/shorten", shorten, false)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, `{"message":"Daily short link quota exceeded"}`, rec.Body.String())
+
+	quota = getQuota()
+	assert.Equal(t, model.QuotaUsage{Bookmarks: 2, ShortLinksToday: 1}, quota.Usage)
+
+	// A per-user override lifts the bookmark limit
+	unlimited := int64(0)
+	assert.NoError(t, testEngine.DB.Create(&model.UserQuota{UserID: fixture.FixtureUserOneID, MaxBookmarks: &unlimited}).Error)
+
+	rec = do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Third", "url": "https://third.example.com"}, true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	quota = getQuota()
+	assert.Equal(t, model.QuotaLimits{Bookmarks: 0, ShortLinksPerDay: 1}, quota.Limits)
+	assert.Equal(t, int64(3), quota.Usage.Bookmarks)
+}
@@ -0,0 +1,131 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestShareEndpoint_Flow validates sharing a folder with another user and through a public link,
+// then revoking both.
+func TestShareEndpoint_Flow(t *testing.T) {
+	t.Parallel()
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+	})
+	testEngine.JwtValidator.On("ValidateToken", "owner.jwt.token").
+		Return(fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID)), nil)
+	testEngine.JwtValidator.On("ValidateToken", "grantee.jwt.token").
+		Return(fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserTwoID)), nil)
+
+	do := func(method, path, token string, body any) (int, map[string]any) {
+		var reqBody bytes.Buffer
+		if body != nil {
+			assert.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reqBody)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+
+		var resBody map[string]any
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resBody))
+		return rec.Code, resBody
+	}
+
+	assert.NoError(t, testEngine.DB.Model(&model.Bookmark{}).Where("id = ?", fixture.FixtureBookmarkOneID).
+		Update("folder", "Work/Go").Error)
+
+	// The grantee cannot see the bookmark before it is shared
+	code, _ := do(http.MethodGet, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID, "grantee.jwt.token", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, body := do(http.MethodPost, "/v1/shares", "owner.jwt.token", map[string]any{
+		"target_type": "folder",
+		"target":      "Work",
+		"username":    fixture.FixtureUserTwoUsername,
+		"permission":  "editor",
+	})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, fixture.FixtureUserTwoUsername, body["grantee_username"])
+	shareID, _ := body["id"].(string)
+
+	code, _ = do(http.MethodPost, "/v1/shares", "owner.jwt.token", map[string]any{
+		"target_type": "folder",
+		"target":      "Work",
+		"username":    fixture.FixtureUserTwoUsername,
+		"permission":  "viewer",
+	})
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, body = do(http.MethodGet, "/v1/shares/received", "grantee.jwt.token", nil)
+	assert.Equal(t, http.StatusOK, code)
+	if data := body["data"].([]any); assert.Len(t, data, 1) {
+		assert.Equal(t, fixture.FixtureUserOneUsername, data[0].(map[string]any)["owner_username"])
+	}
+
+	code, body = do(http.MethodGet, "/v1/bookmarks/shared", "grantee.jwt.token", nil)
+	assert.Equal(t, http.StatusOK, code)
+	if data := body["data"].([]any); assert.Len(t, data, 1) {
+		assert.Equal(t, fixture.FixtureBookmarkOneID, data[0].(map[string]any)["id"])
+	}
+
+	// Editors can read and update the shared bookmark
+	code, _ = do(http.MethodGet, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID, "grantee.jwt.token", nil)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = do(http.MethodPatch, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID, "grantee.jwt.token",
+		map[string]any{"description": "Edited by a collaborator"})
+	assert.Equal(t, http.StatusOK, code)
+
+	// Public links work without authentication until they are revoked, and leave out
+	// the notes and reading progress of the owner
+	code, _ = do(http.MethodPatch, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID, "owner.jwt.token",
+		map[string]any{"notes": "Private **notes**"})
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = do(http.MethodPost, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID+"/read", "owner.jwt.token", nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, body = do(http.MethodPost, "/v1/shares/links", "owner.jwt.token", map[string]any{
+		"target_type": "bookmark",
+		"target":      fixture.FixtureBookmarkOneID,
+	})
+	assert.Equal(t, http.StatusOK, code)
+	token, _ := body["token"].(string)
+	linkID, _ := body["id"].(string)
+
+	code, body = do(http.MethodGet, "/v1/public/shares/"+token, "", nil)
+	assert.Equal(t, http.StatusOK, code)
+	if data := body["data"].([]any); assert.Len(t, data, 1) {
+		assert.Equal(t, "Edited by a collaborator", data[0].(map[string]any)["description"])
+		// The public link does not tell who owns the bookmark
+		assert.NotContains(t, data[0], "user_id")
+		assert.NotContains(t, data[0], "id")
+		assert.NotContains(t, data[0], "notes")
+		assert.NotContains(t, data[0], "read_at")
+	}
+
+	code, _ = do(http.MethodDelete, "/v1/shares/"+linkID, "owner.jwt.token", nil)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = do(http.MethodGet, "/v1/public/shares/"+token, "", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// Only the owner can revoke a share
+	code, _ = do(http.MethodDelete, "/v1/shares/"+shareID, "grantee.jwt.token", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = do(http.MethodDelete, "/v1/shares/"+shareID, "owner.jwt.token", nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = do(http.MethodGet, "/v1/bookmarks/"+fixture.FixtureBookmarkOneID, "grantee.jwt.token", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,82 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestStatsEndpoint validates that GET /v1/self/stats aggregates the bookmarks of the user
+// and counts the clicks on bookmark codes followed through the redirect endpoint.
+func TestStatsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultTestConfig()
+	// Disable the cache so each request sees the latest bookmarks
+	cfg.StatsCacheTTL = 0
+
+	testEngine := NewTestEngine(&TestEngineOpts{
+		T:       t,
+		Fixture: &fixture.BookmarkCommonTestDB{},
+		Cfg:     cfg,
+	})
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", fixture.FixtureUserOneID))
+	testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", testValidAuthToken)
+		rec := httptest.NewRecorder()
+		testEngine.Engine.ServeHTTP(rec, req)
+		return rec
+	}
+	getStats := func(path string) *model.BookmarkStats {
+		rec := do(http.MethodGet, path, nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var res struct {
+			Data *model.BookmarkStats `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res.Data
+	}
+
+	for _, url := range []string{"https://go.dev/doc", "https://www.go.dev/blog", "https://github.com/golang/go"} {
+		rec := do(http.MethodPost, "/v1/bookmarks", map[string]any{"description": "Go", "url": url})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// Following the code of a bookmark counts a click
+	for range 2 {
+		rec := do(http.MethodGet, "/v1/links/redirect/"+fixture.FixtureBookmarkOneCode, nil)
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, fixture.FixtureBookmarkURL, rec.Header().Get("Location"))
+	}
+	// The code of a trashed bookmark doesn't redirect
+	rec := do(http.MethodGet, "/v1/links/redirect/"+fixture.FixtureBookmarkTrashedCode, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	stats := getStats("/v1/self/stats?top=1")
+	assert.Equal(t, int64(4), stats.Total)
+	assert.Equal(t, []model.DomainCount{{Domain: "go.dev", Count: 2}}, stats.Domains)
+	assert.Empty(t, stats.Tags)
+	assert.Len(t, stats.Months, 2)
+	assert.Equal(t, []model.CodeClicks{{Code: fixture.FixtureBookmarkOneCode, URL: fixture.FixtureBookmarkURL, Clicks: 2}}, stats.TopClicked)
+	assert.False(t, stats.GeneratedAt.IsZero())
+
+	rec = do(http.MethodGet, "/v1/self/stats?top=0", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = do(http.MethodGet, "/v1/self/stats?top=abc", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,224 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/internal/model"
+	"github.com/HadesHo3820/ebvn-golang-course/internal/test/fixture"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testAdminAuthToken is the Authorization header of the admin in the admin endpoint tests.
+const testAdminAuthToken = "Bearer admin.jwt.token"
+
+// tokenTestClient sends requests to a test engine whose tokens have real lifetimes.
+type tokenTestClient struct {
+	t          *testing.T
+	testEngine *TestEngine
+}
+
+func newTokenTestClient(t *testing.T) *tokenTestClient {
+	cfg := defaultTestConfig()
+	cfg.AccessTokenTTL = 15 * time.Minute
+	cfg.RefreshTokenTTL = time.Hour
+	testEngine := NewTestEngine(&TestEngineOpts{T: t, Cfg: cfg})
+	testEngine.JwtGen.On("GenerateToken", mock.Anything).Return("valid.jwt.token", nil)
+
+	return &tokenTestClient{t: t, testEngine: testEngine}
+}
+
+// do sends a JSON request with the given headers and returns the status and decoded body.
+func (c *tokenTestClient) do(method, path string, body any, headers map[string]string) (int, map[string]any) {
+	var bodyBytes []byte
+	if body != nil {
+		bodyBytes, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(bodyBytes))
+	req.Header.Set(contentTypeHeader, contentTypeJSON)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	c.testEngine.Engine.ServeHTTP(rec, req)
+
+	var res map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &res)
+	return rec.Code, res
+}
+
+// signUp registers and logs in the default user, and returns its ID and refresh token.
+func (c *tokenTestClient) signUp() (string, string) {
+	status, res := c.do(http.MethodPost, "/v1/users/register", fixture.DefaultRegisterBody(), nil)
+	require.Equal(c.t, http.StatusOK, status)
+	data, _ := res["data"].(map[string]any)
+	userID, _ := data["id"].(string)
+	require.NotEmpty(c.t, userID)
+
+	status, res = c.do(http.MethodPost, "/v1/users/login", fixture.DefaultLoginBody(fixture.WithField("password", "Password1!")), nil)
+	require.Equal(c.t, http.StatusOK, status)
+	refreshToken, _ := res["refresh_token"].(string)
+	require.NotEmpty(c.t, refreshToken)
+
+	return userID, refreshToken
+}
+
+func (c *tokenTestClient) refresh(refreshToken string) (int, map[string]any) {
+	return c.do(http.MethodPost, "/v1/users/token/refresh", map[string]string{"refresh_token": refreshToken}, nil)
+}
+
+func (c *tokenTestClient) getSelfInfo() (int, map[string]any) {
+	return c.do(http.MethodGet, "/v1/self/info", nil, map[string]string{"Authorization": testValidAuthToken})
+}
+
+// TestUserEndpoint_RefreshToken validates the POST /v1/users/token/refresh endpoint:
+// a refresh token obtained at login is rotated on use, and replaying an already
+// used token revokes every token of the login, including the rotated one.
+func TestUserEndpoint_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	client := newTokenTestClient(t)
+	_, firstToken := client.signUp()
+
+	// Refreshing rotates the refresh token
+	status, res := client.refresh(firstToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "valid.jwt.token", res["data"])
+	assert.Equal(t, float64(900), res["expires_in"])
+	secondToken, _ := res["refresh_token"].(string)
+	require.NotEmpty(t, secondToken)
+	assert.NotEqual(t, firstToken, secondToken)
+
+	// Unknown refresh tokens are rejected
+	status, res = client.refresh("unknown-refresh-token")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "Invalid refresh token", res["message"])
+
+	// Replaying the first token is detected and revokes the family
+	status, _ = client.refresh(firstToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = client.refresh(secondToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+// TestUserEndpoint_Logout validates the POST /v1/users/logout endpoint:
+// the access token of the request and the given refresh token stop working.
+func TestUserEndpoint_Logout(t *testing.T) {
+	t.Parallel()
+
+	client := newTokenTestClient(t)
+	userID, refreshToken := client.signUp()
+
+	claims := fixture.DefaultJWTClaims(
+		fixture.WithClaim("sub", userID),
+		fixture.WithClaim("jti", "jti-logout"),
+		fixture.WithClaim("exp", float64(time.Now().Add(10*time.Minute).Unix())),
+	)
+	client.testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+
+	status, _ := client.getSelfInfo()
+	require.Equal(t, http.StatusOK, status)
+
+	status, res := client.do(http.MethodPost, "/v1/users/logout",
+		map[string]string{"refresh_token": refreshToken}, map[string]string{"Authorization": testValidAuthToken})
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Logged out successfully!", res["message"])
+
+	status, res = client.getSelfInfo()
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "Token has been revoked", res["error"])
+
+	status, _ = client.refresh(refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+// TestAdminEndpoint_RevokeUserTokens validates the POST /v1/admin/users/:id/tokens/revoke
+// endpoint: it requires the admin role, and every token issued to the user before stops working.
+func TestAdminEndpoint_RevokeUserTokens(t *testing.T) {
+	t.Parallel()
+
+	client := newTokenTestClient(t)
+	userID, refreshToken := client.signUp()
+
+	client.testEngine.JwtValidator.On("ValidateToken", "admin.jwt.token").Return(fixture.DefaultJWTClaims(
+		fixture.WithClaim("sub", fixture.FixtureUserOneID),
+		fixture.WithClaim("jti", "jti-admin"),
+		fixture.WithClaim("gen", float64(0)),
+		fixture.WithClaim("roles", []any{model.RoleUser, model.RoleAdmin}),
+	), nil)
+	client.testEngine.JwtValidator.On("ValidateToken", "valid.jwt.token").Return(fixture.DefaultJWTClaims(
+		fixture.WithClaim("sub", userID),
+		fixture.WithClaim("jti", "jti-user"),
+		fixture.WithClaim("gen", float64(0)),
+		fixture.WithClaim("roles", []any{model.RoleUser}),
+	), nil)
+	revokePath := "/v1/admin/users/" + userID + "/tokens/revoke"
+	adminHeaders := map[string]string{"Authorization": testAdminAuthToken}
+
+	status, _ := client.getSelfInfo()
+	require.Equal(t, http.StatusOK, status)
+
+	// The admin role is required
+	status, res := client.do(http.MethodPost, revokePath, nil, map[string]string{"Authorization": testValidAuthToken})
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "Insufficient permissions", res["error"])
+
+	status, _ = client.do(http.MethodPost, revokePath, nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// Unknown users are reported
+	status, _ = client.do(http.MethodPost, "/v1/admin/users/00000000-0000-4000-8000-000000000000/tokens/revoke", nil, adminHeaders)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, res = client.do(http.MethodPost, revokePath, nil, adminHeaders)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Tokens revoked", res["message"])
+
+	status, _ = client.getSelfInfo()
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = client.refresh(refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// Tokens issued after the revocation work again
+	status, res = client.do(http.MethodPost, "/v1/users/login", fixture.DefaultLoginBody(fixture.WithField("password", "Password1!")), nil)
+	require.Equal(t, http.StatusOK, status)
+	newRefreshToken, _ := res["refresh_token"].(string)
+	status, _ = client.refresh(newRefreshToken)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// TestAdminEndpoint_BootstrapAdmin validates that the admin configured at startup is created
+// and gets the admin role in the tokens issued at login.
+func TestAdminEndpoint_BootstrapAdmin(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultTestConfig()
+	cfg.BootstrapAdminUsername = "root"
+	cfg.BootstrapAdminEmail = "root@example.com"
+	cfg.BootstrapAdminPassword = "RootPassword1!"
+	testEngine := NewTestEngine(&TestEngineOpts{T: t, Cfg: cfg})
+	testEngine.JwtGen.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+		return assert.ObjectsAreEqual([]string{model.RoleUser, model.RoleAdmin}, claims["roles"])
+	})).Return("admin.jwt.token", nil)
+
+	body, _ := json.Marshal(map[string]string{"username": "root", "password": "RootPassword1!"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/login", bytes.NewReader(body))
+	req.Header.Set(contentTypeHeader, contentTypeJSON)
+	rec := httptest.NewRecorder()
+	testEngine.Engine.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "admin.jwt.token", res["data"])
+}
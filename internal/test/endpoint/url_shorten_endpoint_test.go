@@ -166,6 +166,20 @@ func TestGetUrlEndpoint(t *testing.T) {
 				assert.Equal(t, "https://preloaded-url.com", rec.Header().Get("Location"))
 			},
 		},
+		{
+			name: "success - redirects to bookmark URL",
+			code: fixture.FixtureBookmarkOneCode,
+			setupTestHTTP: func(apiEngine api.Engine, code string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, redirectURI+code, nil)
+				rec := httptest.NewRecorder()
+				apiEngine.ServeHTTP(rec, req)
+				return rec
+			},
+			expectedStatus: http.StatusFound,
+			validateBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, fixture.FixtureBookmarkURL, rec.Header().Get("Location"))
+			},
+		},
 		{
 			name:       "bad request - code not found",
 			code:       "notexist",
@@ -219,11 +233,12 @@ func TestGetUrlEndpoint(t *testing.T) {
 				tc.setupRedis(mockRedis)
 			}
 
-			// Create API engine
+			// Create API engine; codes unknown to Redis are looked up as bookmark codes
 			apiEngine := api.New(&api.EngineOpts{
 				Engine:      gin.New(),
 				Cfg:         &api.Config{},
 				RedisClient: mockRedis,
+				SqlDB:       fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{}),
 			})
 
 			// Execute request
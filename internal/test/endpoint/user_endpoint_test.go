@@ -16,6 +16,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // testValidUserDisplayName is the display name used for valid user test cases.
@@ -335,3 +336,42 @@ func TestUserEndpoint_UpdateSelfInfo(t *testing.T) {
 		})
 	}
 }
+
+// TestUserEndpoint_ChangePassword validates the PUT /v1/self/password endpoint:
+// the current password is checked, the new one replaces it for login, and every
+// token issued before the change is revoked while the returned ones work.
+func TestUserEndpoint_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	client := newTokenTestClient(t)
+	userID, oldRefreshToken := client.signUp()
+
+	claims := fixture.DefaultJWTClaims(fixture.WithClaim("sub", userID), fixture.WithClaim("gen", float64(0)))
+	client.testEngine.JwtValidator.On("ValidateToken", mock.Anything).Return(claims, nil)
+	authHeaders := map[string]string{"Authorization": testValidAuthToken}
+
+	status, res := client.do(http.MethodPut, "/v1/self/password",
+		map[string]string{"current_password": "WrongPassword1!", "new_password": "NewPassword1!"}, authHeaders)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Current password is incorrect", res["message"])
+
+	status, res = client.do(http.MethodPut, "/v1/self/password",
+		map[string]string{"current_password": "Password1!", "new_password": "NewPassword1!"}, authHeaders)
+	require.Equal(t, http.StatusOK, status)
+	newRefreshToken, _ := res["refresh_token"].(string)
+	require.NotEmpty(t, newRefreshToken)
+
+	// Tokens issued before the change are revoked
+	status, _ = client.getSelfInfo()
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = client.refresh(oldRefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = client.refresh(newRefreshToken)
+	assert.Equal(t, http.StatusOK, status)
+
+	// Only the new password logs in
+	status, _ = client.do(http.MethodPost, "/v1/users/login", fixture.DefaultLoginBody(fixture.WithField("password", "Password1!")), nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = client.do(http.MethodPost, "/v1/users/login", fixture.DefaultLoginBody(fixture.WithField("password", "NewPassword1!")), nil)
+	assert.Equal(t, http.StatusOK, status)
+}
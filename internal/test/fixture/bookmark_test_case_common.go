@@ -10,11 +10,15 @@ const (
 	FixtureBookmarkOneID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
 	// FixtureBookmarkTwoID is the ID for the second bookmark fixture.
 	FixtureBookmarkTwoID = "322ac10b-58cc-4372-a567-0e02b2c3d479"
+	// FixtureBookmarkTrashedID is the ID for a soft-deleted bookmark owned by the first user.
+	FixtureBookmarkTrashedID = "9d3ac10b-58cc-4372-a567-0e02b2c3d479"
 	// FixtureBookmarkOneCode is the unique code for the first bookmark fixture.
 	FixtureBookmarkOneCode = "abc12345"
 	// FixtureBookmarkTwoCode is the unique code for the second bookmark fixture.
 	FixtureBookmarkTwoCode = "def12345"
-	// FixtureBookmarkURL is the URL used for bookmark fixtures.
+	// FixtureBookmarkTrashedCode is the unique code for the soft-deleted bookmark fixture.
+	FixtureBookmarkTrashedCode = "ghi12345"
+	// FixtureBookmarkURL is the URL used for bookmark fixtures. It is already normalized.
 	FixtureBookmarkURL = "https://example.com/long-url"
 	// FixtureBookmarkDescription is the description used for bookmark fixtures.
 	FixtureBookmarkDescription = "My First Bookmark"
@@ -27,9 +31,9 @@ type BookmarkCommonTestDB struct {
 }
 
 // Migrate runs the necessary database migrations for the BookmarkCommonTestDB fixture.
-// It ensures that the Bookmark and User tables are created.
+// It ensures that the Bookmark, User, BookmarkTag, BookmarkRevision, Share, BookmarkSnapshot, UserQuota and PersonalAccessToken tables are created.
 func (f *BookmarkCommonTestDB) Migrate() error {
-	return f.db.AutoMigrate(&model.Bookmark{}, &model.User{})
+	return f.db.AutoMigrate(&model.Bookmark{}, &model.User{}, &model.BookmarkTag{}, &model.BookmarkRevision{}, &model.Share{}, &model.BookmarkSnapshot{}, &model.UserQuota{}, &model.PersonalAccessToken{})
 }
 
 // GenerateData seeds the test database.
@@ -76,11 +80,12 @@ func (f *BookmarkCommonTestDB) GenerateData() error {
 				CreatedAt: FixtureTimestamp,
 				UpdatedAt: FixtureTimestamp,
 			},
-			URL:         FixtureBookmarkURL,
-			Code:        FixtureBookmarkOneCode,
-			Description: FixtureBookmarkDescription,
-			UserID:      FixtureUserOneID,
-			User:        users[0],
+			URL:           FixtureBookmarkURL,
+			NormalizedURL: FixtureBookmarkURL,
+			Code:          FixtureBookmarkOneCode,
+			Description:   FixtureBookmarkDescription,
+			UserID:        FixtureUserOneID,
+			User:          users[0],
 		},
 		{
 			Base: model.Base{
@@ -88,11 +93,26 @@ func (f *BookmarkCommonTestDB) GenerateData() error {
 				CreatedAt: FixtureTimestamp,
 				UpdatedAt: FixtureTimestamp,
 			},
-			URL:         FixtureBookmarkURL,
-			Code:        FixtureBookmarkTwoCode,
-			Description: FixtureBookmarkDescription,
-			UserID:      FixtureUserTwoID,
-			User:        users[1],
+			URL:           FixtureBookmarkURL,
+			NormalizedURL: FixtureBookmarkURL,
+			Code:          FixtureBookmarkTwoCode,
+			Description:   FixtureBookmarkDescription,
+			UserID:        FixtureUserTwoID,
+			User:          users[1],
+		},
+		{
+			Base: model.Base{
+				ID:        FixtureBookmarkTrashedID,
+				CreatedAt: FixtureTimestamp,
+				UpdatedAt: FixtureTimestamp,
+				DeletedAt: gorm.DeletedAt{Time: FixtureTimestamp, Valid: true},
+			},
+			URL:           FixtureBookmarkURL,
+			NormalizedURL: FixtureBookmarkURL,
+			Code:          FixtureBookmarkTrashedCode,
+			Description:   FixtureBookmarkDescription,
+			UserID:        FixtureUserOneID,
+			User:          users[0],
 		},
 	}
 
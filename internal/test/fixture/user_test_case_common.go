@@ -19,11 +19,12 @@ type UserCommonTestDB struct {
 	base
 }
 
-// Migrate creates the User table schema in the test database.
-// It uses GORM's AutoMigrate to create the table based on the User model definition.
+// Migrate creates the User table schema in the test database, together with the
+// PersonalAccessToken table the user services revoke with the other tokens of a user.
+// It uses GORM's AutoMigrate to create the tables based on the model definitions.
 // Returns an error if the migration fails.
 func (f *UserCommonTestDB) Migrate() error {
-	return f.db.AutoMigrate(&model.User{})
+	return f.db.AutoMigrate(&model.User{}, &model.PersonalAccessToken{})
 }
 
 const (
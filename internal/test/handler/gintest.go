@@ -6,6 +6,7 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 
@@ -142,3 +143,38 @@ func (tc *TestContext) WithQueryParams(params map[string]string) *TestContext {
 	tc.Ctx.Request.URL.RawQuery = q.Encode()
 	return tc
 }
+
+// WithMultipartFile sets a multipart/form-data request body containing a single file
+// and optional plain form fields, as sent by an HTML file upload form.
+//
+// Example:
+//
+//	Usage: .WithMultipartFile("file", "bookmarks.html", content, map[string]string{
+//	    "duplicates": "skip",
+//	})
+//
+// Parameters:
+//   - fieldName: Form field name of the file (e.g., "file")
+//   - fileName: File name reported to the server
+//   - content: File content
+//   - fields: Additional form fields (optional)
+//
+// Returns the TestContext for method chaining.
+func (tc *TestContext) WithMultipartFile(fieldName, fileName string, content []byte, fields map[string]string) *TestContext {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(fieldName, fileName)
+	_, _ = part.Write(content)
+	for key, value := range fields {
+		_ = writer.WriteField(key, value)
+	}
+	_ = writer.Close()
+
+	tc.Ctx.Request = httptest.NewRequest(
+		tc.Ctx.Request.Method,
+		tc.Ctx.Request.URL.String(),
+		body,
+	)
+	tc.Ctx.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	return tc
+}
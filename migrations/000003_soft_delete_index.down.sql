@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookmarks_deleted_at;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
@@ -0,0 +1,12 @@
+-- =============================================================================
+-- Migration: 000003_soft_delete_index
+-- Description: Indexes deleted_at for soft deletion on users and bookmarks
+-- =============================================================================
+-- Every regular query filters on "deleted_at IS NULL", while the trash listing
+-- and the retention purge filter on "deleted_at IS NOT NULL". Both benefit from
+-- an index on the column.
+-- =============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE INDEX IF NOT EXISTS idx_bookmarks_deleted_at ON bookmarks (deleted_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bookmark_tags;
+
+ALTER TABLE bookmarks DROP COLUMN IF EXISTS folder;
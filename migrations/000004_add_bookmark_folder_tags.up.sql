@@ -0,0 +1,27 @@
+-- =============================================================================
+-- Migration: 000004_add_bookmark_folder_tags
+-- Description: Adds folders and tags to bookmarks
+-- =============================================================================
+-- Folders are stored as a slash-separated path on the bookmark itself
+-- (e.g. "Work/Projects"), so a bookmark lives in exactly one folder.
+-- Tags live in their own table, so a bookmark can carry any number of them.
+-- =============================================================================
+
+ALTER TABLE bookmarks ADD COLUMN folder varchar(512) not null default '';
+
+CREATE TABLE bookmark_tags
+(
+    -- Foreign key: References the tagged bookmark
+    bookmark_id varchar(36) not null,
+
+    -- The tag name (e.g. "golang")
+    name varchar(64) not null,
+
+    -- Constraints:
+    CONSTRAINT bookmark_tags_pkey PRIMARY KEY (bookmark_id, name), -- A bookmark carries each tag at most once
+    CONSTRAINT fk_bookmark_id FOREIGN KEY (bookmark_id)            -- Tags are removed together with their bookmark
+        REFERENCES bookmarks (id) ON DELETE CASCADE
+);
+
+-- Supports looking up bookmarks by tag
+CREATE INDEX idx_bookmark_tags_name ON bookmark_tags (name);
@@ -0,0 +1,5 @@
+ALTER TABLE bookmarks
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS favicon_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS title;
@@ -0,0 +1,13 @@
+-- =============================================================================
+-- Migration: 000005_add_bookmark_page_metadata
+-- Description: Adds page metadata columns filled in by the enrichment worker
+-- =============================================================================
+-- The worker fetches the bookmarked page in the background and stores what it
+-- finds in <head>. Empty strings mean "not fetched yet" or "not provided".
+-- =============================================================================
+
+ALTER TABLE bookmarks
+    ADD COLUMN title            varchar(255)  not null default '',
+    ADD COLUMN meta_description varchar(1024) not null default '',
+    ADD COLUMN favicon_url      varchar(2048) not null default '',
+    ADD COLUMN canonical_url    varchar(2048) not null default '';
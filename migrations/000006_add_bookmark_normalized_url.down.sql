@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookmarks_user_normalized_url;
+
+ALTER TABLE bookmarks DROP COLUMN IF EXISTS normalized_url;
@@ -0,0 +1,16 @@
+-- =============================================================================
+-- Migration: 000006_add_bookmark_normalized_url
+-- Description: Stores a normalized form of every bookmark URL for duplicate detection
+-- =============================================================================
+-- The application normalizes URLs (lowercase scheme and host, no default port,
+-- no fragment, no tracking parameters, sorted query, no trailing slash) before
+-- writing them. Existing rows are seeded with their raw URL; they are matched
+-- exactly until the URL is next updated.
+-- =============================================================================
+
+ALTER TABLE bookmarks ADD COLUMN normalized_url varchar(2048) not null default '';
+
+UPDATE bookmarks SET normalized_url = url WHERE url IS NOT NULL;
+
+-- Duplicate lookups always filter by owner first
+CREATE INDEX idx_bookmarks_user_normalized_url ON bookmarks (user_id, normalized_url);
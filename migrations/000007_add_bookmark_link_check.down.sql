@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_bookmarks_last_checked_at;
+
+ALTER TABLE bookmarks
+    DROP COLUMN IF EXISTS last_checked_at,
+    DROP COLUMN IF EXISTS check_error,
+    DROP COLUMN IF EXISTS final_url,
+    DROP COLUMN IF EXISTS status_code;
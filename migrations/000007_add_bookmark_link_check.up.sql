@@ -0,0 +1,17 @@
+-- =============================================================================
+-- Migration: 000007_add_bookmark_link_check
+-- Description: Stores the outcome of the periodic broken-link check
+-- =============================================================================
+-- status_code is 0 when the URL could not be reached (check_error says why).
+-- final_url is only set when the URL permanently redirects elsewhere, until
+-- the user accepts the redirect as the bookmark's new URL.
+-- =============================================================================
+
+ALTER TABLE bookmarks
+    ADD COLUMN status_code     integer       not null default 0,
+    ADD COLUMN final_url       varchar(2048) not null default '',
+    ADD COLUMN check_error     varchar(255)  not null default '',
+    ADD COLUMN last_checked_at TIMESTAMP WITH TIME ZONE;
+
+-- The checker picks the bookmarks checked least recently
+CREATE INDEX idx_bookmarks_last_checked_at ON bookmarks (last_checked_at);
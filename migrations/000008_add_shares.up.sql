@@ -0,0 +1,56 @@
+-- =============================================================================
+-- Migration: 000008_add_shares
+-- Description: Lets users share bookmarks, tags and folders
+-- =============================================================================
+-- A share either grants a single user (grantee_id) viewer or editor access,
+-- or is a public read-only link identified by an unguessable token.
+-- target holds a bookmark ID, a tag name or a folder path, depending on target_type;
+-- tag and folder shares cover bookmarks added to the collection later on.
+-- =============================================================================
+
+CREATE TABLE shares
+(
+    -- Primary key: UUID stored as string
+    id varchar(36) not null,
+
+    -- Foreign key: References the user whose bookmarks are shared
+    owner_id varchar(36) not null,
+
+    -- What is shared: 'bookmark', 'tag' or 'folder'
+    target_type varchar(16) not null,
+
+    -- The bookmark ID, tag name or folder path
+    target varchar(512) not null,
+
+    -- Foreign key: References the user the bookmarks are shared with (NULL for public links)
+    grantee_id varchar(36),
+
+    -- 'viewer' or 'editor'
+    permission varchar(16) not null,
+
+    -- Token of a public link (NULL for user shares)
+    token varchar(64),
+
+    -- Timestamps for auditing (created_at and updated_at auto-managed)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Soft deletion timestamp (NULL means not deleted)
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    -- Constraints:
+    CONSTRAINT shares_pkey PRIMARY KEY (id),
+    CONSTRAINT uni_shares_token UNIQUE (token),           -- Tokens identify public links
+    CONSTRAINT idx_shares_grantee_target                  -- A collection is shared with a user at most once
+        UNIQUE (owner_id, target_type, target, grantee_id),
+    CONSTRAINT fk_shares_owner_id FOREIGN KEY (owner_id)  -- Shares are removed together with their owner
+        REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_shares_grantee_id FOREIGN KEY (grantee_id) -- ... and with their grantee
+        REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Supports listing the shares a user created
+CREATE INDEX idx_shares_owner_id ON shares (owner_id);
+-- Supports the permission check on every read of a shared bookmark
+CREATE INDEX idx_shares_grantee_id ON shares (grantee_id);
+CREATE INDEX idx_shares_deleted_at ON shares (deleted_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_bookmarks_user_archived_pinned;
+
+ALTER TABLE bookmarks
+    DROP COLUMN IF EXISTS archived,
+    DROP COLUMN IF EXISTS pinned,
+    DROP COLUMN IF EXISTS is_favorite;
@@ -0,0 +1,15 @@
+-- =============================================================================
+-- Migration: 000009_add_bookmark_states
+-- Description: Adds the favorite, pinned and archived states of bookmarks
+-- =============================================================================
+-- Pinned bookmarks are listed first; archived bookmarks are hidden from the
+-- default list until they are explicitly asked for.
+-- =============================================================================
+
+ALTER TABLE bookmarks
+    ADD COLUMN is_favorite boolean not null default false,
+    ADD COLUMN pinned      boolean not null default false,
+    ADD COLUMN archived    boolean not null default false;
+
+-- Every listing filters on archived and sorts pinned bookmarks first
+CREATE INDEX idx_bookmarks_user_archived_pinned ON bookmarks (user_id, archived, pinned DESC, created_at DESC);
@@ -0,0 +1,32 @@
+-- =============================================================================
+-- Migration: 000010_add_bookmark_revisions
+-- Description: Keeps the history of bookmark edits
+-- =============================================================================
+-- Each edit of a bookmark's description or URL stores the replaced values,
+-- numbered per bookmark, in the same transaction as the edit itself.
+-- =============================================================================
+
+CREATE TABLE bookmark_revisions
+(
+    -- Foreign key: References the edited bookmark
+    bookmark_id varchar(36) not null,
+
+    -- Sequence number of the edit for this bookmark, starting at 1
+    revision integer not null,
+
+    -- The values the edit replaced
+    description varchar(255) not null default '',
+    url varchar(2048) not null,
+
+    -- The user who made the edit: the owner or an editor the bookmark was shared with.
+    -- Not a foreign key, so that the history survives the editor's account.
+    changed_by varchar(36) not null,
+
+    -- When the edit was made
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints:
+    CONSTRAINT bookmark_revisions_pkey PRIMARY KEY (bookmark_id, revision),
+    CONSTRAINT fk_bookmark_revisions_bookmark_id FOREIGN KEY (bookmark_id) -- History goes with its bookmark
+        REFERENCES bookmarks (id) ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_bookmarks_user_unread;
+
+ALTER TABLE bookmarks
+    DROP COLUMN IF EXISTS reading_time,
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS read_at;
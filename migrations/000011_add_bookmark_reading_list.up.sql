@@ -0,0 +1,15 @@
+-- =============================================================================
+-- Migration: 000011_add_bookmark_reading_list
+-- Description: Adds the read status, notes and reading time of bookmarks
+-- =============================================================================
+-- read_at is NULL while a bookmark is unread. reading_time is estimated by the
+-- enrichment worker from the length of the page, 0 when it is unknown.
+-- =============================================================================
+
+ALTER TABLE bookmarks
+    ADD COLUMN read_at      TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN notes        text    not null default '',
+    ADD COLUMN reading_time integer not null default 0;
+
+-- The reading list selects the unread bookmarks of a user
+CREATE INDEX idx_bookmarks_user_unread ON bookmarks (user_id, created_at DESC) WHERE read_at IS NULL;
@@ -0,0 +1,36 @@
+-- =============================================================================
+-- Migration: 000012_add_bookmark_snapshots
+-- Description: Tracks the offline snapshots of bookmarked pages
+-- =============================================================================
+-- The HTML of a snapshot is kept in the blob store; this table records its
+-- state and size, which the per-user storage quota is computed from.
+-- =============================================================================
+
+CREATE TABLE bookmark_snapshots
+(
+    -- The archived bookmark. Deliberately not a foreign key: rows of purged
+    -- bookmarks are kept until the cleanup job has deleted their blob.
+    bookmark_id varchar(36) not null,
+
+    -- The bookmark owner, whose quota the snapshot counts against
+    user_id varchar(36) not null,
+
+    -- State of the latest capture: pending, ready or failed
+    status varchar(16) not null,
+
+    -- Size in bytes and capture time of the stored copy, if any
+    size bigint not null default 0,
+    captured_at TIMESTAMP WITH TIME ZONE,
+
+    -- Why the latest capture failed
+    error varchar(255) not null default '',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints:
+    CONSTRAINT bookmark_snapshots_pkey PRIMARY KEY (bookmark_id)
+);
+
+-- Storage usage is summed per user
+CREATE INDEX idx_bookmark_snapshots_user_id ON bookmark_snapshots (user_id);
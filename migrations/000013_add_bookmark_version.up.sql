@@ -0,0 +1,10 @@
+-- =============================================================================
+-- Migration: 000013_add_bookmark_version
+-- Description: Adds the version of bookmarks used for optimistic concurrency
+-- =============================================================================
+-- version is incremented by every update of a bookmark and exposed as its ETag.
+-- Updates and deletes sent with If-Match only apply while the version is unchanged.
+-- =============================================================================
+
+ALTER TABLE bookmarks
+    ADD COLUMN version integer not null default 1;
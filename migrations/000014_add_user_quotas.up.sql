@@ -0,0 +1,29 @@
+-- =============================================================================
+-- Migration: 000014_add_user_quotas
+-- Description: Stores per-user overrides of the default quotas
+-- =============================================================================
+-- Each column overrides one of the limits configured for every user. NULL keeps
+-- the default, 0 lifts the limit.
+-- =============================================================================
+
+CREATE TABLE user_quotas
+(
+    user_id varchar(36) not null,
+
+    -- Maximum number of bookmarks outside the trash
+    max_bookmarks bigint,
+
+    -- Maximum number of short links created per UTC day
+    max_short_links_per_day bigint,
+
+    -- Maximum storage used by offline snapshots, in bytes
+    max_snapshot_bytes bigint,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints:
+    CONSTRAINT user_quotas_pkey PRIMARY KEY (user_id),
+    CONSTRAINT fk_user_quotas_user_id FOREIGN KEY (user_id)  -- Overrides are removed together with their user
+        REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_bookmarks_user_domain;
+
+ALTER TABLE bookmarks
+    DROP COLUMN IF EXISTS click_count,
+    DROP COLUMN IF EXISTS domain;
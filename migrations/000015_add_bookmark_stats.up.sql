@@ -0,0 +1,19 @@
+-- =============================================================================
+-- Migration: 000015_add_bookmark_stats
+-- Description: Adds the columns the bookmark statistics are aggregated from
+-- =============================================================================
+-- domain is the host name of the URL without "www.", kept next to the URL so
+-- that bookmarks can be grouped per domain in SQL.
+-- click_count counts how often the short code of a bookmark was followed.
+-- =============================================================================
+
+ALTER TABLE bookmarks
+    ADD COLUMN domain varchar(255) not null default '',
+    ADD COLUMN click_count bigint not null default 0;
+
+-- Derive the domain of existing bookmarks from their URL
+UPDATE bookmarks
+SET domain = coalesce(lower(substring(url from '^[a-zA-Z][a-zA-Z0-9+.-]*://(?:[^/?#@]*@)?(?:[wW]{3}\.)?([^/?#:]+)')), '');
+
+-- Supports the per-domain counts of a user
+CREATE INDEX idx_bookmarks_user_domain ON bookmarks (user_id, domain);
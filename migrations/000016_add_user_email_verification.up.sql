@@ -0,0 +1,13 @@
+-- =============================================================================
+-- Migration: 000016_add_user_email_verification
+-- Description: Tracks whether users confirmed their email address
+-- =============================================================================
+-- email_verified_at is set when the user follows the link emailed to the address.
+-- A new address chosen by the user is kept in pending_email until it is confirmed
+-- the same way; email keeps the previous address meanwhile. Existing users start
+-- unverified.
+-- =============================================================================
+
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN pending_email varchar(255);
@@ -0,0 +1,10 @@
+-- =============================================================================
+-- Migration: 000017_add_user_roles
+-- Description: Adds the roles of users used for access control
+-- =============================================================================
+-- roles is a comma-separated list of role names, embedded in the access tokens
+-- at login. Every user has the "user" role; "admin" opens the administration API.
+-- =============================================================================
+
+ALTER TABLE users
+    ADD COLUMN roles varchar(255) not null default 'user';
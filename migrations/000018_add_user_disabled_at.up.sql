@@ -0,0 +1,10 @@
+-- =============================================================================
+-- Migration: 000018_add_user_disabled_at
+-- Description: Lets administrators disable user accounts
+-- =============================================================================
+-- disabled_at is set while an administrator keeps the user from signing in.
+-- NULL means the account is enabled.
+-- =============================================================================
+
+ALTER TABLE users
+    ADD COLUMN disabled_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,49 @@
+-- =============================================================================
+-- Migration: 000019_add_personal_access_tokens
+-- Description: Lets users create API tokens for scripts and integrations
+-- =============================================================================
+-- A personal access token acts for its user on the routes allowed by its scopes,
+-- a comma-separated list such as 'bookmarks:read,links:write'.
+-- Only the SHA-256 of the token is stored; the token is shown once at creation.
+-- =============================================================================
+
+CREATE TABLE personal_access_tokens
+(
+    -- Primary key: UUID stored as string
+    id varchar(36) not null,
+
+    -- Foreign key: References the user the token acts for
+    user_id varchar(36) not null,
+
+    -- Label chosen by the user
+    name varchar(100) not null,
+
+    -- Hex-encoded SHA-256 of the token
+    token_hash varchar(64) not null,
+
+    -- Comma-separated scopes
+    scopes varchar(255) not null,
+
+    -- When the token stops working (NULL means it does not expire)
+    expires_at TIMESTAMP WITH TIME ZONE,
+
+    -- When the token last authenticated a request (NULL means never)
+    last_used_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps for auditing (created_at and updated_at auto-managed)
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Soft deletion timestamp (NULL means not deleted)
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    -- Constraints:
+    CONSTRAINT personal_access_tokens_pkey PRIMARY KEY (id),
+    CONSTRAINT uni_personal_access_tokens_token_hash UNIQUE (token_hash),  -- Every request looks tokens up by hash
+    CONSTRAINT fk_personal_access_tokens_user_id FOREIGN KEY (user_id)      -- Tokens are removed together with their user
+        REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Supports listing the tokens of a user
+CREATE INDEX idx_personal_access_tokens_user_id ON personal_access_tokens (user_id);
+CREATE INDEX idx_personal_access_tokens_deleted_at ON personal_access_tokens (deleted_at);
@@ -0,0 +1,12 @@
+-- =============================================================================
+-- Migration: 000020_add_user_oidc_subject
+-- Description: Links users to their account at the OpenID Connect provider
+-- =============================================================================
+-- oidc_subject is the "sub" claim of the ID tokens of the user, set the first
+-- time the user signs in through the provider. The provider never reassigns a
+-- subject, so it identifies the user even after the email address changed.
+-- NULL means the user never signed in through the provider.
+-- =============================================================================
+
+ALTER TABLE users
+    ADD COLUMN oidc_subject varchar(255) UNIQUE;
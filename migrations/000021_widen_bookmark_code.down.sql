@@ -0,0 +1,3 @@
+-- Fails while a bookmark has a code longer than 10 characters.
+ALTER TABLE bookmarks
+    ALTER COLUMN code TYPE varchar(10);
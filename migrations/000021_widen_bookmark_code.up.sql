@@ -0,0 +1,11 @@
+-- =============================================================================
+-- Migration: 000021_widen_bookmark_code
+-- Description: Makes room for longer bookmark codes
+-- =============================================================================
+-- Callers may choose codes of 4 to 32 characters, and the length of generated
+-- codes is configurable (BOOKMARK_CODE_LENGTH), so the column must hold codes
+-- longer than the 10 characters of the generated codes it was created for.
+-- =============================================================================
+
+ALTER TABLE bookmarks
+    ALTER COLUMN code TYPE varchar(32);
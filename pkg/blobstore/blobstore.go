@@ -0,0 +1,61 @@
+// Package blobstore stores opaque binary objects under string keys.
+//
+// Store is the extension point: services depend on the interface, and the driver
+// (currently only the local filesystem) is chosen when the application is wired up.
+// Keys are slash-separated paths such as "snapshots/<id>.html"; every segment is
+// restricted to letters, digits, '.', '_' and '-', so no driver has to worry about
+// path traversal or escaping.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under the key.
+	ErrNotFound = errors.New("blobstore: object not found")
+	// ErrInvalidKey is returned for keys that are empty or contain disallowed characters.
+	ErrInvalidKey = errors.New("blobstore: invalid key")
+)
+
+// Store reads and writes objects.
+//
+//go:generate mockery --name Store --filename store.go
+type Store interface {
+	// Put stores the content of r under key, replacing any existing object,
+	// and returns the number of bytes written. Readers never see a partially written object.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Open returns the object stored under key. The caller must close it.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// ValidateKey checks that key is a relative slash-separated path made of safe segments.
+func ValidateKey(key string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+		for _, r := range segment {
+			if !isKeyRune(r) {
+				return ErrInvalidKey
+			}
+		}
+	}
+	return nil
+}
+
+// isKeyRune reports whether r may appear in a key segment.
+func isKeyRune(r rune) bool {
+	return r >= 'a' && r <= 'z' ||
+		r >= 'A' && r <= 'Z' ||
+		r >= '0' && r <= '9' ||
+		r == '.' || r == '_' || r == '-'
+}
@@ -0,0 +1,100 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingReader returns some data and then an error, like a connection dropped mid-download.
+type failingReader struct {
+	sent bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, errors.New("connection reset")
+	}
+	r.sent = true
+	return copy(p, "partial"), nil
+}
+
+func TestLocalStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	root := t.TempDir()
+	store, err := NewLocalStore(filepath.Join(root, "blobs"))
+	assert.NoError(t, err)
+
+	// Put and read back
+	n, err := store.Put(ctx, "snapshots/one.html", strings.NewReader("<p>first</p>"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), n)
+
+	rc, err := store.Open(ctx, "snapshots/one.html")
+	if assert.NoError(t, err) {
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		assert.Equal(t, "<p>first</p>", string(content))
+	}
+
+	// A failed write leaves the previous object in place and no temporary file behind
+	_, err = store.Put(ctx, "snapshots/one.html", &failingReader{})
+	assert.Error(t, err)
+	content, _ := os.ReadFile(filepath.Join(root, "blobs", "snapshots", "one.html"))
+	assert.Equal(t, "<p>first</p>", string(content))
+	entries, _ := os.ReadDir(filepath.Join(root, "blobs", "snapshots"))
+	assert.Len(t, entries, 1)
+
+	// Delete is idempotent
+	assert.NoError(t, store.Delete(ctx, "snapshots/one.html"))
+	assert.NoError(t, store.Delete(ctx, "snapshots/one.html"))
+	_, err = store.Open(ctx, "snapshots/one.html")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalStore_Put_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = store.Put(ctx, "cancelled", strings.NewReader("data"))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{name: "simple key", input: "object"},
+		{name: "nested key", input: "snapshots/0b7d-42_a.html"},
+		{name: "empty key", input: "", expectedErr: ErrInvalidKey},
+		{name: "parent directory", input: "../etc/passwd", expectedErr: ErrInvalidKey},
+		{name: "absolute path", input: "/etc/passwd", expectedErr: ErrInvalidKey},
+		{name: "empty segment", input: "snapshots//one", expectedErr: ErrInvalidKey},
+		{name: "backslash", input: `snapshots\one`, expectedErr: ErrInvalidKey},
+		{name: "space", input: "my file", expectedErr: ErrInvalidKey},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.ErrorIs(t, ValidateKey(tc.input), tc.expectedErr)
+		})
+	}
+}
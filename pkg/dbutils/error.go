@@ -55,6 +55,10 @@ var (
 
 	// ErrNotFoundType indicates the requested record was not found in the database.
 	ErrNotFoundType = errors.New("not found type")
+
+	// ErrVersionMismatch indicates a conditional write was refused because the record
+	// no longer has the version the caller read, i.e. it was modified in the meantime.
+	ErrVersionMismatch = errors.New("version mismatch")
 )
 
 // filterDuplicationType checks if the error is a unique constraint violation.
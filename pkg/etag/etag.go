@@ -0,0 +1,78 @@
+// Package etag provides helpers for HTTP entity tags (RFC 9110, section 8.8.3)
+// and the conditional request headers that carry them.
+//
+// Single resources use a strong tag derived from their version number,
+// so that If-Match can be turned back into the version an update is conditional on.
+// Collections use a tag derived from a hash of the representation.
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Any is the wildcard accepted by If-Match and If-None-Match, matching any current representation.
+const Any = "*"
+
+// FromVersion returns the strong entity tag of a resource version.
+//
+// Example:
+//
+//	etag.FromVersion(3) // "\"3\""
+func FromVersion(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// FromContent returns a strong entity tag derived from the SHA-256 hash of a representation.
+func FromContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ParseVersion turns an If-Match header value into the version a write is conditional on.
+//
+// Returns:
+//   - int: The version carried by the tag, or 0 when the header is empty or the wildcard,
+//     meaning the write is unconditional
+//   - bool: false if the header cannot match any version: weak tags (which never match
+//     under the strong comparison If-Match requires), lists of several tags and malformed values
+func ParseVersion(ifMatch string) (int, bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == Any {
+		return 0, true
+	}
+
+	if len(ifMatch) < 2 || ifMatch[0] != '"' || ifMatch[len(ifMatch)-1] != '"' {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(ifMatch[1 : len(ifMatch)-1])
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// MatchNone reports whether an If-None-Match header value matches the current entity tag,
+// in which case a GET should be answered with 304 Not Modified.
+// The header may list several tags; they are compared with the weak comparison
+// If-None-Match requires, so a W/ prefix is ignored.
+func MatchNone(ifNoneMatch, current string) bool {
+	ifNoneMatch = strings.TrimSpace(ifNoneMatch)
+	if ifNoneMatch == "" {
+		return false
+	}
+	if ifNoneMatch == Any {
+		return true
+	}
+
+	current = strings.TrimPrefix(current, "W/")
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == current {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"1"`, FromVersion(1))
+	assert.Equal(t, `"42"`, FromVersion(42))
+}
+
+func TestFromContent(t *testing.T) {
+	t.Parallel()
+
+	first := FromContent([]byte(`{"data":[]}`))
+
+	assert.Equal(t, first, FromContent([]byte(`{"data":[]}`)))
+	assert.NotEqual(t, first, FromContent([]byte(`{"data":[{}]}`)))
+	assert.Len(t, first, 34)
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		input           string
+		expectedVersion int
+		expectedOK      bool
+	}{
+		{name: "empty header is unconditional", input: "", expectedVersion: 0, expectedOK: true},
+		{name: "wildcard is unconditional", input: " * ", expectedVersion: 0, expectedOK: true},
+		{name: "strong tag", input: `"3"`, expectedVersion: 3, expectedOK: true},
+		{name: "weak tag never matches", input: `W/"3"`, expectedOK: false},
+		{name: "unquoted tag", input: `3`, expectedOK: false},
+		{name: "several tags", input: `"3", "4"`, expectedOK: false},
+		{name: "not a version", input: `"abc"`, expectedOK: false},
+		{name: "zero version", input: `"0"`, expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, ok := ParseVersion(tc.input)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
+
+func TestMatchNone(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		header   string
+		current  string
+		expected bool
+	}{
+		{name: "empty header", header: "", current: `"1"`, expected: false},
+		{name: "wildcard", header: "*", current: `"1"`, expected: true},
+		{name: "same tag", header: `"1"`, current: `"1"`, expected: true},
+		{name: "different tag", header: `"2"`, current: `"1"`, expected: false},
+		{name: "weak tag matches", header: `W/"1"`, current: `"1"`, expected: true},
+		{name: "one of several tags", header: `"2", W/"1"`, current: `"1"`, expected: true},
+		{name: "none of several tags", header: `"2","3"`, current: `"1"`, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, MatchNone(tc.header, tc.current))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package linkcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// hostLimiter spaces out requests to the same host.
+// Each call to wait reserves the next free slot of the host, so concurrent callers
+// for one host are served one interval apart while other hosts are not slowed down.
+// Hosts whose next slot has passed are forgotten, so the limiter only keeps track of the
+// hosts requested within the last interval.
+type hostLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// newHostLimiter creates a limiter allowing one request per host every interval.
+// A non-positive interval disables limiting.
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{interval: interval, next: make(map[string]time.Time)}
+}
+
+// wait blocks until a request to host may be sent, or ctx is cancelled.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	if l.interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	// A slot in the past allows a request right away, the same as no slot at all
+	for h, next := range l.next {
+		if !next.After(now) {
+			delete(l.next, h)
+		}
+	}
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
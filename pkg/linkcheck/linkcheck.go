@@ -0,0 +1,195 @@
+// Package linkcheck verifies that URLs are still reachable.
+//
+// A Checker sends a HEAD request to each URL (falling back to GET for servers that do not
+// support HEAD), follows redirects itself to learn whether they are permanent, and spreads
+// its requests so that no host receives more than one request per configured interval.
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConcurrency  = 4
+	defaultMaxRedirects = 5
+	defaultUserAgent    = "bookmark-api/1.0 (+link checker)"
+)
+
+// ErrTooManyRedirects is reported when a URL redirects more often than allowed.
+var ErrTooManyRedirects = errors.New("linkcheck: too many redirects")
+
+// Result is the outcome of checking a single URL.
+//
+// Fields:
+//   - StatusCode: Status of the last response, 0 when no response was received
+//   - FinalURL: URL of the last response, after following redirects
+//   - Permanent: Whether at least one redirect was followed and all of them were permanent (301 or 308)
+//   - Err: Network or protocol error; StatusCode is 0 when set
+type Result struct {
+	StatusCode int
+	FinalURL   string
+	Permanent  bool
+	Err        error
+}
+
+// Options configures a Checker. Zero values fall back to sensible defaults.
+//
+// Fields:
+//   - Concurrency: Number of URLs checked at the same time by CheckAll (default 4)
+//   - HostInterval: Minimum time between two requests to the same host (default none)
+//   - MaxRedirects: Number of redirects followed at most (default 5)
+//   - UserAgent: User-Agent header sent with every request
+type Options struct {
+	Concurrency  int
+	HostInterval time.Duration
+	MaxRedirects int
+	UserAgent    string
+}
+
+// Checker checks URLs with bounded concurrency and per-host rate limits.
+type Checker struct {
+	client       *http.Client
+	concurrency  int
+	maxRedirects int
+	userAgent    string
+	limiter      *hostLimiter
+}
+
+// NewChecker creates a Checker sending its requests through client.
+// The client is injectable so that it can be hardened (see pkg/netguard) or pointed
+// at httptest servers; its redirect policy is ignored since the Checker follows redirects itself.
+func NewChecker(client *http.Client, opts Options) *Checker {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	if opts.MaxRedirects <= 0 {
+		opts.MaxRedirects = defaultMaxRedirects
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = defaultUserAgent
+	}
+
+	noFollow := *client
+	noFollow.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &Checker{
+		client:       &noFollow,
+		concurrency:  opts.Concurrency,
+		maxRedirects: opts.MaxRedirects,
+		userAgent:    opts.UserAgent,
+		limiter:      newHostLimiter(opts.HostInterval),
+	}
+}
+
+// CheckAll checks every URL and calls fn with the index of the URL and its result.
+// At most Concurrency URLs are checked at the same time; fn may be called concurrently.
+// CheckAll returns once every URL has been checked or ctx is cancelled.
+func (c *Checker) CheckAll(ctx context.Context, urls []string, fn func(i int, result Result)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(c.concurrency, len(urls)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i, c.Check(ctx, urls[i]))
+			}
+		}()
+	}
+
+feed:
+	for i := range urls {
+		select {
+		case <-ctx.Done():
+			break feed
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// Check checks a single URL, following up to MaxRedirects redirects.
+func (c *Checker) Check(ctx context.Context, rawURL string) Result {
+	current, err := url.Parse(rawURL)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	permanent := true
+	for redirects := 0; ; redirects++ {
+		// Checked on every hop: a redirect must not lead to another protocol
+		if current.Scheme != "http" && current.Scheme != "https" {
+			return Result{Err: fmt.Errorf("linkcheck: unsupported URL scheme %q", current.Scheme)}
+		}
+
+		status, location, err := c.request(ctx, current)
+		if err != nil {
+			return Result{Err: err}
+		}
+
+		if location == "" {
+			return Result{
+				StatusCode: status,
+				FinalURL:   current.String(),
+				Permanent:  permanent && redirects > 0,
+			}
+		}
+
+		if redirects == c.maxRedirects {
+			return Result{Err: ErrTooManyRedirects}
+		}
+		next, err := current.Parse(location)
+		if err != nil {
+			return Result{Err: err}
+		}
+		permanent = permanent && (status == http.StatusMovedPermanently || status == http.StatusPermanentRedirect)
+		current = next
+	}
+}
+
+// request sends a single HEAD request, retrying with GET when the server rejects HEAD.
+// It returns the status code and, for redirects, the Location header.
+func (c *Checker) request(ctx context.Context, target *url.URL) (int, string, error) {
+	status, location, err := c.do(ctx, http.MethodHead, target)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, location, err = c.do(ctx, http.MethodGet, target)
+	}
+	return status, location, err
+}
+
+// do sends one request, waiting for the host's rate limit first.
+func (c *Checker) do(ctx context.Context, method string, target *url.URL) (int, string, error) {
+	if err := c.limiter.wait(ctx, target.Host); err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	// Drain a little of the body so the connection can be reused, without downloading pages
+	_, _ = io.CopyN(io.Discard, resp.Body, 4<<10)
+	resp.Body.Close()
+
+	location := ""
+	if resp.StatusCode >= 300 && resp.StatusCode <= 399 {
+		location = resp.Header.Get("Location")
+	}
+	return resp.StatusCode, location, nil
+}
@@ -0,0 +1,189 @@
+package linkcheck
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved-again", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved-again", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+	checker := NewChecker(server.Client(), Options{MaxRedirects: 3})
+
+	testCases := []struct {
+		name          string
+		inputURL      string
+		expected      Result
+		expectedErr   error
+		expectsAnyErr bool
+	}{
+		{
+			name:     "reachable",
+			inputURL: server.URL + "/ok",
+			expected: Result{StatusCode: http.StatusOK, FinalURL: server.URL + "/ok"},
+		},
+		{
+			name:     "broken",
+			inputURL: server.URL + "/gone",
+			expected: Result{StatusCode: http.StatusGone, FinalURL: server.URL + "/gone"},
+		},
+		{
+			name:     "permanent redirects",
+			inputURL: server.URL + "/moved",
+			expected: Result{StatusCode: http.StatusOK, FinalURL: server.URL + "/ok", Permanent: true},
+		},
+		{
+			name:     "temporary redirect in the chain",
+			inputURL: server.URL + "/temporary",
+			expected: Result{StatusCode: http.StatusOK, FinalURL: server.URL + "/ok"},
+		},
+		{
+			name:     "falls back to GET",
+			inputURL: server.URL + "/get-only",
+			expected: Result{StatusCode: http.StatusOK, FinalURL: server.URL + "/get-only"},
+		},
+		{
+			name:        "redirect loop",
+			inputURL:    server.URL + "/loop",
+			expectedErr: ErrTooManyRedirects,
+		},
+		{
+			name:          "unsupported scheme",
+			inputURL:      "ftp://example.com/file",
+			expectsAnyErr: true,
+		},
+		{
+			name:          "unreachable",
+			inputURL:      "http://127.0.0.1:1/",
+			expectsAnyErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := checker.Check(context.Background(), tc.inputURL)
+
+			if tc.expectedErr != nil || tc.expectsAnyErr {
+				assert.Error(t, result.Err)
+				if tc.expectedErr != nil {
+					assert.ErrorIs(t, result.Err, tc.expectedErr)
+				}
+				assert.Zero(t, result.StatusCode)
+				return
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestChecker_CheckAll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		inFlight    atomic.Int32
+		maxInFlight atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	t.Cleanup(server.Close)
+
+	checker := NewChecker(server.Client(), Options{Concurrency: 2})
+	urls := make([]string, 6)
+	for i := range urls {
+		urls[i] = server.URL + "/page"
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	checker.CheckAll(context.Background(), urls, func(i int, result Result) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[i] = result.StatusCode
+	})
+
+	assert.Len(t, seen, len(urls))
+	for i := range urls {
+		assert.Equal(t, http.StatusOK, seen[i])
+	}
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+}
+
+func TestHostLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := newHostLimiter(50 * time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		assert.NoError(t, limiter.wait(ctx, "a.example"))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "third request waits two intervals")
+
+	// Another host is not slowed down
+	start = time.Now()
+	assert.NoError(t, limiter.wait(ctx, "b.example"))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// Waiting stops when the context is cancelled
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.NoError(t, limiter.wait(cancelled, "c.example"), "first request is immediate")
+	assert.ErrorIs(t, limiter.wait(cancelled, "c.example"), context.Canceled)
+
+	// Hosts whose slot has passed are forgotten
+	time.Sleep(150 * time.Millisecond)
+	assert.NoError(t, limiter.wait(ctx, "d.example"))
+	limiter.mu.Lock()
+	assert.Equal(t, []string{"d.example"}, slices.Collect(maps.Keys(limiter.next)))
+	limiter.mu.Unlock()
+}
@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// fileMailer is the Mailer driver writing each message to a file of a directory.
+type fileMailer struct {
+	from string
+	dir  string
+	seq  atomic.Int64
+}
+
+// NewFileMailer creates a Mailer that writes each message to a ".eml" file in dir,
+// which is created if it does not exist. Use ReadDir to read the messages back.
+func NewFileMailer(from, dir string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &fileMailer{from: from, dir: dir}, nil
+}
+
+// Send implements Mailer. File names start with the sending time and a sequence number,
+// so listing the directory returns the messages in the order they were sent.
+func (m *fileMailer) Send(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	name := fmt.Sprintf("%s-%06d.eml", now.UTC().Format("20060102T150405.000000000"), m.seq.Add(1))
+	return os.WriteFile(filepath.Join(m.dir, name), format(m.from, msg, now), 0o640)
+}
+
+// ReadDir reads back the messages written by the file driver to dir, oldest first.
+func ReadDir(dir string) ([]*Message, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	messages := make([]*Message, 0, len(names))
+	for _, name := range names {
+		msg, err := readFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(name), err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// readFile parses a message file written by the file driver.
+func readFile(name string) (*Message, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	parsed, err := mail.ReadMessage(f)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(parsed.Body)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:      parsed.Header.Get("To"),
+		Subject: subject,
+		Body:    strings.ReplaceAll(string(body), "\r\n", "\n"),
+	}, nil
+}
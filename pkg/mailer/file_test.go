@@ -0,0 +1,73 @@
+package mailer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMailer(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, err := New(Config{Driver: DriverFile, From: "no-reply@example.com", Dir: dir})
+	require.NoError(t, err)
+
+	sent := []*Message{
+		{To: "first@example.com", Subject: "Reset your password", Body: "Open this link:\nhttps://example.com/reset?token=abc\n"},
+		{To: "second@example.com", Subject: "Vérifiez votre adresse", Body: "Bonjour"},
+	}
+	for _, msg := range sent {
+		require.NoError(t, m.Send(context.Background(), msg))
+	}
+
+	got, err := ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, sent, got)
+}
+
+func TestFileMailer_CanceledContext(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, err := NewFileMailer("no-reply@example.com", dir)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, m.Send(ctx, &Message{To: "user@example.com"}), context.Canceled)
+
+	got, err := ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		cfg         Config
+		expectedErr error
+	}{
+		{name: "smtp driver", cfg: Config{Driver: DriverSMTP, SMTP: SMTPConfig{Host: "localhost", Port: 25}}},
+		{name: "file driver", cfg: Config{Driver: DriverFile, Dir: t.TempDir()}},
+		{name: "log driver", cfg: Config{Driver: DriverLog}},
+		{name: "unknown driver", cfg: Config{Driver: "carrier-pigeon"}, expectedErr: ErrUnknownDriver},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := New(tc.cfg)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				assert.NotNil(t, m)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// logMailer is the Mailer driver logging messages instead of sending them.
+type logMailer struct{}
+
+// NewLogMailer creates a Mailer that writes each message to the application log.
+// Messages may carry secrets such as reset links, so it is meant for development only.
+func NewLogMailer() Mailer {
+	return logMailer{}
+}
+
+// Send implements Mailer.
+func (logMailer) Send(_ context.Context, msg *Message) error {
+	log.Info().Str("to", msg.To).Str("subject", msg.Subject).Str("body", msg.Body).Msg("Mail not sent (log mailer)")
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package mailer sends plain-text emails.
+//
+// Mailer is the extension point: services depend on the interface, and the driver is
+// chosen when the application is wired up. The SMTP driver delivers mail for real, the
+// file driver writes each message to a directory (handy for tests and local development)
+// and the log driver only logs it.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Driver names accepted by New.
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+	DriverLog  = "log"
+)
+
+// ErrUnknownDriver is returned by New for an unsupported driver name.
+var ErrUnknownDriver = errors.New("mailer: unknown driver")
+
+// Message is a plain-text email.
+//
+// Fields:
+//   - To: Address of the single recipient
+//   - Subject: Subject line, may contain non-ASCII characters
+//   - Body: Plain-text body
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails.
+//
+//go:generate mockery --name Mailer --filename mailer.go
+type Mailer interface {
+	// Send delivers msg, or returns an error if it could not be handed over to the driver.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Config selects and configures a driver.
+//
+// Fields:
+//   - Driver: One of DriverSMTP, DriverFile or DriverLog
+//   - From: Sender address of every message
+//   - Dir: Directory the file driver writes messages to
+//   - SMTP: Server settings of the SMTP driver
+type Config struct {
+	Driver string
+	From   string
+	Dir    string
+	SMTP   SMTPConfig
+}
+
+// New creates the Mailer of the configured driver.
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg.From, cfg.SMTP), nil
+	case DriverFile:
+		return NewFileMailer(cfg.From, cfg.Dir)
+	case DriverLog:
+		return NewLogMailer(), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, cfg.Driver)
+}
+
+// format renders msg as an RFC 5322 message with CRLF line endings,
+// as written by the file driver and sent by the SMTP driver.
+func format(from string, msg *Message, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return b.Bytes()
+}
@@ -0,0 +1,86 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPConfig holds the settings of the SMTP driver.
+//
+// Fields:
+//   - Host: Host name of the SMTP server
+//   - Port: Port of the SMTP server, usually 587 (submission with STARTTLS)
+//   - Username: User to authenticate as, empty to send without authentication
+//   - Password: Password of the user
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+// smtpMailer is the Mailer driver delivering messages to an SMTP server.
+type smtpMailer struct {
+	from string
+	cfg  SMTPConfig
+	now  func() time.Time
+}
+
+// NewSMTPMailer creates a Mailer sending each message through the SMTP server of cfg.
+// The connection is upgraded with STARTTLS whenever the server offers it; credentials
+// are only sent over an encrypted connection or to localhost (see smtp.PlainAuth).
+func NewSMTPMailer(from string, cfg SMTPConfig) Mailer {
+	return &smtpMailer{from: from, cfg: cfg, now: time.Now}
+}
+
+// Send implements Mailer. It opens one connection per message; the deadline
+// of ctx, if any, bounds the whole exchange with the server.
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(format(m.from, msg, m.now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
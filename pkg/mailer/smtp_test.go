@@ -0,0 +1,118 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single SMTP session and records what it received.
+// It offers neither STARTTLS nor AUTH, like a local development relay.
+type fakeSMTPServer struct {
+	addr *net.TCPAddr
+	done chan struct{}
+
+	from string
+	rcpt string
+	data string
+}
+
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	s := &fakeSMTPServer{addr: ln.Addr().(*net.TCPAddr), done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.serve(textproto.NewConn(conn))
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(c *textproto.Conn) {
+	_ = c.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = c.PrintfLine("250-localhost")
+			_ = c.PrintfLine("250 8BITMIME")
+		case "MAIL":
+			s.from = arg
+			_ = c.PrintfLine("250 OK")
+		case "RCPT":
+			s.rcpt = arg
+			_ = c.PrintfLine("250 OK")
+		case "DATA":
+			_ = c.PrintfLine("354 Go ahead")
+			data, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			_ = c.PrintfLine("250 OK")
+		case "QUIT":
+			_ = c.PrintfLine("221 Bye")
+			return
+		default:
+			_ = c.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	t.Parallel()
+
+	server := startFakeSMTPServer(t)
+	m := NewSMTPMailer("no-reply@example.com", SMTPConfig{Host: "127.0.0.1", Port: server.addr.Port}).(*smtpMailer)
+	m.now = func() time.Time { return time.Date(2025, 3, 10, 15, 4, 5, 0, time.UTC) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := m.Send(ctx, &Message{To: "user@example.com", Subject: "Reset your password", Body: "line 1\nline 2"})
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Equal(t, "FROM:<no-reply@example.com> BODY=8BITMIME", server.from)
+	assert.Equal(t, "TO:<user@example.com>", server.rcpt)
+
+	msg, err := textproto.NewReader(bufio.NewReader(strings.NewReader(server.data))).ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", msg.Get("To"))
+	assert.Equal(t, "Reset your password", msg.Get("Subject"))
+	assert.Equal(t, "Mon, 10 Mar 2025 15:04:05 +0000", msg.Get("Date"))
+	assert.True(t, strings.HasSuffix(server.data, "\nline 1\nline 2\n"), server.data)
+}
+
+func TestSMTPMailer_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	m := NewSMTPMailer("no-reply@example.com", SMTPConfig{Host: "127.0.0.1", Port: port})
+	err = m.Send(context.Background(), &Message{To: "user@example.com"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), strconv.Itoa(port))
+}
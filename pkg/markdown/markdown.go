@@ -0,0 +1,55 @@
+// Package markdown renders user-written Markdown to HTML that is safe to embed in a page.
+//
+// The input is untrusted, so the renderer never lets markup through from the source:
+// raw HTML is dropped, links are only rendered for trusted protocols and open in a new
+// tab without leaking the referrer, and images are only kept when they load over http(s).
+package markdown
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// htmlFlags configures the HTML renderer for untrusted input.
+const htmlFlags = blackfriday.SkipHTML |
+	blackfriday.Safelink |
+	blackfriday.NofollowLinks |
+	blackfriday.NoreferrerLinks |
+	blackfriday.NoopenerLinks |
+	blackfriday.HrefTargetBlank
+
+// safeRenderer is the blackfriday HTML renderer with image sources restricted to http(s).
+// Safelink only covers links, so images need their own check.
+type safeRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+// RenderNode implements blackfriday.Renderer.
+func (r *safeRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	// The image is skipped on entering and on leaving, otherwise its closing tag would still be written
+	if node.Type == blackfriday.Image && !isHTTPURL(node.LinkData.Destination) {
+		return blackfriday.SkipChildren
+	}
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
+// ToHTML renders the Markdown source to sanitized HTML.
+// Empty or blank input renders to an empty string.
+func ToHTML(src string) string {
+	renderer := &safeRenderer{
+		HTMLRenderer: blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{Flags: htmlFlags}),
+	}
+	out := blackfriday.Run([]byte(src),
+		blackfriday.WithRenderer(renderer),
+		blackfriday.WithExtensions(blackfriday.CommonExtensions),
+	)
+	return string(bytes.TrimSpace(out))
+}
+
+// isHTTPURL reports whether dest is an absolute http or https URL.
+func isHTTPURL(dest []byte) bool {
+	lower := bytes.ToLower(dest)
+	return bytes.HasPrefix(lower, []byte("http://")) || bytes.HasPrefix(lower, []byte("https://"))
+}
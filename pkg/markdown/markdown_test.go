@@ -0,0 +1,65 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty",
+			input:    "  \n",
+			expected: "",
+		},
+		{
+			name:     "formatting",
+			input:    "# Notes\n\nRead **twice**, skip `code`.",
+			expected: "<h1>Notes</h1>\n\n<p>Read <strong>twice</strong>, skip <code>code</code>.</p>",
+		},
+		{
+			name:     "raw HTML is dropped",
+			input:    "Hello <script>alert(1)</script>world",
+			expected: "<p>Hello alert(1)world</p>",
+		},
+		{
+			name:     "HTML block is dropped",
+			input:    "<div onclick=\"alert(1)\">\nclick\n</div>\n\nafter",
+			expected: "<p>after</p>",
+		},
+		{
+			name:     "safe link",
+			input:    "[Go](https://go.dev)",
+			expected: `<p><a href="https://go.dev" target="_blank" rel="nofollow noreferrer noopener">Go</a></p>`,
+		},
+		{
+			name:     "javascript link is rendered as text",
+			input:    "[click](javascript:void)",
+			expected: "<p><tt>click</tt></p>",
+		},
+		{
+			name:     "http image is kept",
+			input:    "![logo](https://go.dev/logo.png)",
+			expected: `<p><img src="https://go.dev/logo.png" alt="logo" /></p>`,
+		},
+		{
+			name:     "data image is dropped",
+			input:    "![x](data:image/svg+xml;base64,PHN2Zz4=)",
+			expected: "<p></p>",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, ToHTML(tc.input))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package mergepatch provides helpers for decoding RFC 7396 JSON Merge Patch documents.
+//
+// A merge patch distinguishes three states for every member of the target document:
+//   - absent: the member is not mentioned and must be left unchanged
+//   - null: the member is explicitly set to null and must be removed (reset)
+//   - value: the member is set to a new value
+//
+// Plain Go fields cannot tell "absent" from "zero value", so request structs use
+// Field[T] for every patchable member instead.
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Field holds a single member of a JSON Merge Patch document.
+//
+// Example:
+//
+//	type patchInput struct {
+//	    Description mergepatch.Field[string] `json:"description"`
+//	}
+//
+//	// {}                      -> Present=false
+//	// {"description": null}   -> Present=true, Null=true
+//	// {"description": "text"} -> Present=true, Value="text"
+type Field[T any] struct {
+	// Value is the decoded member value. It is the zero value when the member is absent or null.
+	Value T
+	// Present reports whether the member appeared in the patch document.
+	Present bool
+	// Null reports whether the member was explicitly set to null.
+	Null bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// encoding/json only calls it when the member exists in the document,
+// so reaching this method always means the member is present.
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Present = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		var zero T
+		f.Value = zero
+		return nil
+	}
+
+	f.Null = false
+	return json.Unmarshal(data, &f.Value)
+}
+
+// IsSet reports whether the member carries a non-null value.
+func (f Field[T]) IsSet() bool {
+	return f.Present && !f.Null
+}
+
+// Ptr converts the field into the pointer form used by the service layer:
+//   - nil when the member is absent (leave unchanged)
+//   - a pointer to the zero value when the member is null (reset)
+//   - a pointer to the value otherwise
+func (f Field[T]) Ptr() *T {
+	if !f.Present {
+		return nil
+	}
+	v := f.Value
+	return &v
+}
+
+// ValidationValue returns the value that validation rules should be applied to.
+// Absent and null members yield nil so that "omitempty" rules skip them.
+func (f Field[T]) ValidationValue() any {
+	if !f.IsSet() {
+		return nil
+	}
+	return f.Value
+}
+
+// validationValuer is implemented by every Field instantiation.
+type validationValuer interface {
+	ValidationValue() any
+}
+
+// ValidatorTypeFunc adapts Field values for go-playground/validator.
+// Register it with validator.RegisterCustomTypeFunc so that validation tags
+// declared on a Field are evaluated against the wrapped value.
+//
+// Example:
+//
+//	validate.RegisterCustomTypeFunc(mergepatch.ValidatorTypeFunc, mergepatch.Field[string]{})
+func ValidatorTypeFunc(field reflect.Value) any {
+	if v, ok := field.Interface().(validationValuer); ok {
+		return v.ValidationValue()
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPatch struct {
+	Description Field[string] `json:"description" validate:"omitempty,lte=5"`
+	Count       Field[int]    `json:"count"`
+}
+
+func TestField_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		input         string
+		expectedField Field[string]
+		expectedErr   bool
+	}{
+		{
+			name:          "absent member",
+			input:         `{}`,
+			expectedField: Field[string]{},
+		},
+		{
+			name:          "null member",
+			input:         `{"description": null}`,
+			expectedField: Field[string]{Present: true, Null: true},
+		},
+		{
+			name:          "value member",
+			input:         `{"description": "text"}`,
+			expectedField: Field[string]{Present: true, Value: "text"},
+		},
+		{
+			name:          "empty string member",
+			input:         `{"description": ""}`,
+			expectedField: Field[string]{Present: true, Value: ""},
+		},
+		{
+			name:        "wrong type",
+			input:       `{"description": 10}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var patch testPatch
+			err := json.Unmarshal([]byte(tc.input), &patch)
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedField, patch.Description)
+			assert.False(t, patch.Count.Present)
+		})
+	}
+}
+
+func TestField_Ptr(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		field    Field[string]
+		expected *string
+	}{
+		{
+			name:     "absent returns nil",
+			field:    Field[string]{},
+			expected: nil,
+		},
+		{
+			name:     "null returns zero value",
+			field:    Field[string]{Present: true, Null: true},
+			expected: new(string),
+		},
+		{
+			name:     "value returns value",
+			field:    Field[string]{Present: true, Value: "text"},
+			expected: func() *string { s := "text"; return &s }(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.field.Ptr())
+		})
+	}
+}
+
+func TestValidatorTypeFunc(t *testing.T) {
+	t.Parallel()
+
+	validate := validator.New()
+	validate.RegisterCustomTypeFunc(ValidatorTypeFunc, Field[string]{})
+
+	testCases := []struct {
+		name        string
+		input       testPatch
+		expectedErr bool
+	}{
+		{
+			name:  "absent member skips validation",
+			input: testPatch{},
+		},
+		{
+			name:  "null member skips validation",
+			input: testPatch{Description: Field[string]{Present: true, Null: true}},
+		},
+		{
+			name:  "valid value",
+			input: testPatch{Description: Field[string]{Present: true, Value: "abc"}},
+		},
+		{
+			name:        "invalid value",
+			input:       testPatch{Description: Field[string]{Present: true, Value: "too long"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validate.Struct(tc.input)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("non field value returns nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, ValidatorTypeFunc(reflect.ValueOf("plain")))
+	})
+}
@@ -0,0 +1,81 @@
+package netguard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		addr     string
+		expected bool
+	}{
+		{addr: "93.184.216.34", expected: true},
+		{addr: "2606:4700::1111", expected: true},
+		{addr: "127.0.0.1", expected: false},
+		{addr: "10.1.2.3", expected: false},
+		{addr: "172.16.0.1", expected: false},
+		{addr: "192.168.1.1", expected: false},
+		{addr: "169.254.169.254", expected: false},
+		{addr: "100.64.0.1", expected: false},
+		{addr: "0.0.0.0", expected: false},
+		{addr: "::1", expected: false},
+		{addr: "fd00::1", expected: false},
+		{addr: "fe80::1", expected: false},
+		{addr: "::ffff:127.0.0.1", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, IsPublicAddr(netip.MustParseAddr(tc.addr)))
+		})
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name         string
+		allowPrivate bool
+		expectedErr  error
+	}{
+		{
+			name:        "loopback is blocked",
+			expectedErr: ErrBlockedAddress,
+		},
+		{
+			name:         "loopback is allowed when the guard is disabled",
+			allowPrivate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &http.Client{Transport: NewTransport(time.Second, tc.allowPrivate)}
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			resp, err := client.Do(req)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Package netscape reads and writes the Netscape bookmark file format.
+//
+// Every major browser exports bookmarks as this loosely specified HTML dialect:
+//
+//	<!DOCTYPE NETSCAPE-Bookmark-file-1>
+//	<DL><p>
+//	    <DT><H3>Folder</H3>
+//	    <DL><p>
+//	        <DT><A HREF="https://example.com" ADD_DATE="1700000000" TAGS="a,b">Title</A>
+//	        <DD>Optional description
+//	    </DL><p>
+//	</DL><p>
+//
+// Folders are nested <DL> lists introduced by an <H3> heading, and bookmarks are <A> links.
+// Tags are not part of the original format, but Firefox, Pinboard and others use a
+// comma-separated TAGS attribute, which this package reads and writes as well.
+package netscape
+
+import "time"
+
+// Bookmark is a single link read from or written to a Netscape bookmark file.
+type Bookmark struct {
+	// URL is the HREF of the link.
+	URL string
+	// Title is the link text.
+	Title string
+	// Description is the text of the <DD> element following the link, if any.
+	Description string
+	// Folder is the path of folder names from the root to the link, e.g. ["Bookmarks bar", "Work"].
+	Folder []string
+	// Tags are read from the TAGS attribute.
+	Tags []string
+	// AddDate is read from the ADD_DATE attribute (Unix seconds). Zero if absent.
+	AddDate time.Time
+}
@@ -0,0 +1,145 @@
+package netscape
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chromeExport is a trimmed down bookmark export as produced by Chrome and Firefox.
+const chromeExport = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file. -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1700000000" PERSONAL_TOOLBAR_FOLDER="true">Bookmarks bar</H3>
+    <DL><p>
+        <DT><A HREF="https://go.dev/" ADD_DATE="1700000100" ICON="data:image/png;base64,AAA">The Go Programming Language</A>
+        <DT><H3>Work &amp; Projects</H3>
+        <DD>Folder description
+        <DL><p>
+            <DT><A HREF="https://example.com/a?x=1&amp;y=2" TAGS="work, docs,,">Example A</A>
+            <DD>Notes about A
+        </DL><p>
+        <DT><A HREF="https://example.com/b">Example B</A>
+    </DL><p>
+    <DT><A HREF="https://example.com/root">Root link</A>
+    <DT><A>No href</A>
+</DL><p>
+`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected []Bookmark
+	}{
+		{
+			name:  "browser export",
+			input: chromeExport,
+			expected: []Bookmark{
+				{
+					URL:     "https://go.dev/",
+					Title:   "The Go Programming Language",
+					Folder:  []string{"Bookmarks bar"},
+					AddDate: time.Unix(1700000100, 0).UTC(),
+				},
+				{
+					URL:         "https://example.com/a?x=1&y=2",
+					Title:       "Example A",
+					Description: "Notes about A",
+					Folder:      []string{"Bookmarks bar", "Work & Projects"},
+					Tags:        []string{"work", "docs"},
+				},
+				{
+					URL:    "https://example.com/b",
+					Title:  "Example B",
+					Folder: []string{"Bookmarks bar"},
+				},
+				{
+					URL:   "https://example.com/root",
+					Title: "Root link",
+				},
+			},
+		},
+		{
+			name:     "empty document",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:  "unclosed lists",
+			input: `<DL><DT><H3>A</H3><DL><DT><A HREF="https://example.com">x`,
+			expected: []Bookmark{
+				{URL: "https://example.com", Title: "x", Folder: []string{"A"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(strings.NewReader(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	input := []Bookmark{
+		{URL: "https://example.com/root", Title: "Root"},
+		{URL: "https://example.com/a", Title: "A <1>", Folder: []string{"Work"}, Tags: []string{"x", "y"}},
+		{URL: "https://example.com/b", Title: "B", Folder: []string{"Work", "Deep"}, Description: "desc & more"},
+		{URL: "https://example.com/c", Title: "C", Folder: []string{"Other"}, AddDate: time.Unix(1700000000, 0).UTC()},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, b := range input {
+		assert.NoError(t, w.Write(b))
+	}
+	assert.NoError(t, w.Close())
+
+	assert.True(t, strings.HasPrefix(buf.String(), "<!DOCTYPE NETSCAPE-Bookmark-file-1>"))
+	assert.Equal(t, strings.Count(buf.String(), "<DL>"), strings.Count(buf.String(), "</DL>"))
+
+	got, err := Parse(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, input, got)
+}
+
+func TestWriter_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	assert.NoError(t, NewWriter(&buf).Close())
+
+	got, err := Parse(&buf)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWriter_Error(t *testing.T) {
+	t.Parallel()
+
+	w := NewWriter(failingWriter{})
+	// Small writes are buffered, so the error surfaces on Close at the latest
+	_ = w.Write(Bookmark{URL: "https://example.com", Title: strings.Repeat("x", 8192)})
+	assert.EqualError(t, w.Close(), "disk full")
+}
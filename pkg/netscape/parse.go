@@ -0,0 +1,170 @@
+package netscape
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Parse reads a Netscape bookmark file and returns its links in document order.
+//
+// The parser is lenient, like browsers are: unknown elements are ignored and
+// unclosed lists are closed at the end of the document. Links without an HREF are skipped.
+func Parse(r io.Reader) ([]Bookmark, error) {
+	z := html.NewTokenizer(r)
+
+	var (
+		bookmarks []Bookmark
+		// folder is the current folder path
+		folder []string
+		// pendingFolder is the name of the last <H3>, which the next <DL> opens
+		pendingFolder *string
+		// opened records for each open <DL> whether it pushed a folder name
+		opened []bool
+		// current is the link whose title or description is being read
+		current *Bookmark
+		// text collects character data for the element being read
+		text strings.Builder
+		// state tells which element the collected text belongs to
+		state parseState
+	)
+
+	flush := func() {
+		switch state {
+		case inTitle:
+			current.Title = strings.TrimSpace(text.String())
+		case inDescription:
+			current.Description = strings.TrimSpace(text.String())
+		case inHeading:
+			name := strings.TrimSpace(text.String())
+			pendingFolder = &name
+		}
+		if current != nil {
+			bookmarks = append(bookmarks, *current)
+			current = nil
+		}
+		text.Reset()
+		state = idle
+	}
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				flush()
+				return bookmarks, nil
+			}
+			return nil, z.Err()
+
+		case html.TextToken:
+			if state != idle {
+				text.Write(z.Text())
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.A:
+				flush()
+				b := Bookmark{Folder: append([]string(nil), folder...)}
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = z.TagAttr()
+					switch string(key) {
+					case "href":
+						b.URL = strings.TrimSpace(string(val))
+					case "add_date":
+						b.AddDate = parseUnix(string(val))
+					case "tags":
+						b.Tags = splitTags(string(val))
+					}
+				}
+				if b.URL != "" {
+					current = &b
+					state = inTitle
+				}
+			case atom.Dd:
+				if current != nil {
+					text.Reset()
+					state = inDescription
+				} else {
+					flush()
+				}
+			case atom.H3, atom.H1:
+				flush()
+				state = inHeading
+			case atom.Dl:
+				flush()
+				if pendingFolder != nil {
+					folder = append(folder, *pendingFolder)
+					pendingFolder = nil
+					opened = append(opened, true)
+				} else {
+					opened = append(opened, false)
+				}
+			case atom.Dt:
+				flush()
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.A:
+				if state == inTitle {
+					current.Title = strings.TrimSpace(text.String())
+					text.Reset()
+					state = idle
+				}
+			case atom.H3:
+				flush()
+			case atom.H1:
+				// The document title is not a folder
+				text.Reset()
+				state = idle
+			case atom.Dl:
+				flush()
+				if n := len(opened); n > 0 {
+					if opened[n-1] && len(folder) > 0 {
+						folder = folder[:len(folder)-1]
+					}
+					opened = opened[:n-1]
+				}
+			}
+		}
+	}
+}
+
+// parseState tells which element the tokenizer is collecting text for.
+type parseState int
+
+const (
+	idle parseState = iota
+	inTitle
+	inDescription
+	inHeading
+)
+
+// parseUnix converts an ADD_DATE attribute to a time, returning the zero time when invalid.
+func parseUnix(s string) time.Time {
+	sec, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
+
+// splitTags splits a comma-separated TAGS attribute, dropping empty entries.
+func splitTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,116 @@
+package netscape
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+const header = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+`
+
+// Writer streams bookmarks to a Netscape bookmark file.
+//
+// Bookmarks are written as they come, so callers should pass them grouped by folder;
+// a folder that appears again after another one is written as a second folder
+// with the same name, which browsers merge on import.
+//
+// Example:
+//
+//	w := netscape.NewWriter(out)
+//	for _, b := range bookmarks {
+//	    if err := w.Write(b); err != nil {
+//	        return err
+//	    }
+//	}
+//	return w.Close()
+type Writer struct {
+	w       *bufio.Writer
+	started bool
+	// folder is the folder path currently open in the output
+	folder []string
+	// err is the first write error, returned by every later call
+	err error
+}
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Write writes a single bookmark, opening and closing folders as needed.
+func (w *Writer) Write(b Bookmark) error {
+	w.start()
+
+	// Close the folders that are not shared with the new path, then open the new ones
+	common := 0
+	for common < len(w.folder) && common < len(b.Folder) && w.folder[common] == b.Folder[common] {
+		common++
+	}
+	for len(w.folder) > common {
+		w.folder = w.folder[:len(w.folder)-1]
+		w.line(len(w.folder)+1, "</DL><p>")
+	}
+	for _, name := range b.Folder[common:] {
+		w.line(len(w.folder)+1, "<DT><H3>"+html.EscapeString(name)+"</H3>")
+		w.line(len(w.folder)+1, "<DL><p>")
+		w.folder = append(w.folder, name)
+	}
+
+	var attrs strings.Builder
+	fmt.Fprintf(&attrs, ` HREF="%s"`, html.EscapeString(b.URL))
+	if !b.AddDate.IsZero() {
+		fmt.Fprintf(&attrs, ` ADD_DATE="%d"`, b.AddDate.Unix())
+	}
+	if len(b.Tags) > 0 {
+		fmt.Fprintf(&attrs, ` TAGS="%s"`, html.EscapeString(strings.Join(b.Tags, ",")))
+	}
+
+	depth := len(w.folder) + 1
+	w.line(depth, "<DT><A"+attrs.String()+">"+html.EscapeString(b.Title)+"</A>")
+	if b.Description != "" {
+		w.line(depth, "<DD>"+html.EscapeString(b.Description))
+	}
+
+	return w.err
+}
+
+// Close closes all open folders and the root list, then flushes the output.
+// The underlying io.Writer is not closed.
+func (w *Writer) Close() error {
+	w.start()
+	for len(w.folder) > 0 {
+		w.folder = w.folder[:len(w.folder)-1]
+		w.line(len(w.folder)+1, "</DL><p>")
+	}
+	w.line(0, "</DL><p>")
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+// start writes the file header once.
+func (w *Writer) start() {
+	if !w.started {
+		w.started = true
+		w.line(0, strings.TrimSuffix(header, "\n"))
+	}
+}
+
+// line writes s indented by depth levels, recording the first write error.
+func (w *Writer) line(depth int, s string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.w.WriteString(strings.Repeat("    ", depth) + s + "\n")
+}
@@ -0,0 +1,335 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+
+	// maxResponseSize bounds the documents read from the provider.
+	maxResponseSize = 1 << 20
+
+	// keyRefreshInterval is how long after a JWKS fetch that did not find a key ID the JWKS
+	// is not fetched again. Providers publish new keys ahead of rotating them, so this only
+	// guards against tokens with made-up key IDs hammering the provider.
+	keyRefreshInterval = time.Minute
+
+	// clockSkew is the leeway granted when checking the expiry of ID tokens.
+	clockSkew = 30 * time.Second
+)
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// providerMetadata is the part of the discovery document of the provider used by the client.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// idTokenClaims are the claims of an ID token read by the client.
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// httpProvider is the Provider implementation backed by net/http.
+// The discovery document is fetched on first use and kept, while the signing keys
+// are cached and fetched again when a token is signed by an unknown key.
+//
+// The documents are fetched without holding mu, which only guards the cache, so that a slow
+// provider does not hold up the logins served from the cache; concurrent fetches of the same
+// document are merged by fetches.
+type httpProvider struct {
+	cfg     Config
+	client  *http.Client
+	fetches singleflight.Group
+
+	mu           sync.Mutex
+	metadata     *providerMetadata
+	keys         map[string]*rsa.PublicKey
+	keysMissedAt time.Time
+}
+
+// NewProvider creates the client of the identity provider described by cfg.
+// It does not contact the provider; discovery happens on first use, so the
+// application starts even while the provider is unreachable.
+func NewProvider(cfg Config) Provider {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = defaultScopes
+	}
+
+	return &httpProvider{cfg: cfg, client: client}
+}
+
+// AuthCodeURL builds the authorization request of the login, with the S256 code challenge.
+func (p *httpProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Exchange redeems the code at the token endpoint and validates the returned ID token.
+func (p *httpProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// RFC 6749 section 2.3.1: the credentials are form-encoded before Basic authentication
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	var res struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &res)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d: %s %s", ErrTokenExchange, status, res.Error, res.ErrorDescription)
+	}
+	if res.IDToken == "" {
+		return nil, fmt.Errorf("%w: missing from token response", ErrInvalidIDToken)
+	}
+
+	return p.verify(ctx, metadata, res.IDToken, nonce)
+}
+
+// verify checks the signature and the claims of an ID token and returns the identity it asserts.
+func (p *httpProvider) verify(ctx context.Context, metadata *providerMetadata, rawIDToken, nonce string) (*Identity, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.signingKey(ctx, metadata, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	// A token issued to several clients must name this client as the one it was meant for
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: authorized party %q", ErrInvalidIDToken, claims.AuthorizedParty)
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+
+	return &Identity{
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified,
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// discover returns the configuration of the provider, fetching it on first use.
+func (p *httpProvider) discover(ctx context.Context) (*providerMetadata, error) {
+	p.mu.Lock()
+	metadata := p.metadata
+	p.mu.Unlock()
+	if metadata != nil {
+		return metadata, nil
+	}
+
+	v, err, _ := p.fetches.Do("discovery", func() (any, error) {
+		metadata, err := p.fetchMetadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		p.metadata = metadata
+		p.mu.Unlock()
+		return metadata, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*providerMetadata), nil
+}
+
+// fetchMetadata downloads the discovery document of the provider.
+// The issuer it announces must be exactly the configured one.
+func (p *httpProvider) fetchMetadata(ctx context.Context) (*providerMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.cfg.IssuerURL, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	metadata := &providerMetadata{}
+	status, err := p.doJSON(req, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrDiscovery, status)
+	}
+	if metadata.Issuer != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("%w: issuer %q does not match %q", ErrDiscovery, metadata.Issuer, p.cfg.IssuerURL)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: missing endpoints", ErrDiscovery)
+	}
+	return metadata, nil
+}
+
+// signingKey returns the public key with the given ID, fetching the JWKS of the provider
+// when the key is not cached yet.
+func (p *httpProvider) signingKey(ctx context.Context, metadata *providerMetadata, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	key, ok := p.lookupKey(kid)
+	missedRecently := time.Since(p.keysMissedAt) < keyRefreshInterval
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if missedRecently {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	_, err, _ := p.fetches.Do("jwks", func() (any, error) {
+		keys, err := p.fetchKeys(ctx, metadata.JWKSURI)
+		if err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		p.keys = keys
+		p.mu.Unlock()
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	p.keysMissedAt = time.Now()
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookupKey finds a cached key. Tokens without key ID are accepted when the provider has a single key.
+// The caller must hold mu.
+func (p *httpProvider) lookupKey(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// fetchKeys downloads the JWKS of the provider and returns its RSA signing keys by key ID.
+func (p *httpProvider) fetchKeys(ctx context.Context, jwksURI string) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set jsonWebKeySet
+	status, err := p.doJSON(req, &set)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: status %d", status)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		// Only RS256 signing keys are used; encryption keys and other algorithms are skipped
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") ||
+			(jwk.Algorithm != "" && jwk.Algorithm != jwt.SigningMethodRS256.Alg()) {
+			continue
+		}
+		key, err := jwk.rsaPublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys, nil
+}
+
+// doJSON sends a request and decodes its JSON response, whatever its status.
+func (p *httpProvider) doJSON(req *http.Request, v any) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("decoding %s: %w", req.URL.Path, err)
+	}
+	return resp.StatusCode, nil
+}
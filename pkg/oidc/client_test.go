@@ -0,0 +1,282 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRedirectURL  = "http://app.test/v1/auth/oidc/callback"
+	testState        = "test-state"
+	testNonce        = "test-nonce"
+	testCodeVerifier = "test-code-verifier-test-code-verifier-test-code-verifier"
+)
+
+var testIdentity = oidc.Identity{
+	Subject:           "subject-1",
+	Email:             "jane@example.com",
+	EmailVerified:     true,
+	Name:              "Jane Doe",
+	PreferredUsername: "jane",
+}
+
+func newTestProvider(server *oidctest.Server) oidc.Provider {
+	return oidc.NewProvider(oidc.Config{
+		IssuerURL:    server.URL,
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  testRedirectURL,
+		HTTPClient:   server.Client(),
+	})
+}
+
+// authorize runs the browser part of the flow and returns the authorization code.
+func authorize(t *testing.T, server *oidctest.Server, provider oidc.Provider) string {
+	t.Helper()
+
+	authURL, err := provider.AuthCodeURL(context.Background(), testState, testNonce, oidc.CodeChallenge(testCodeVerifier))
+	require.NoError(t, err)
+
+	callback, err := server.Authorize(authURL, testIdentity)
+	require.NoError(t, err)
+	assert.Equal(t, testState, callback.Query().Get("state"))
+	return callback.Query().Get("code")
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		modifyIDToken func(claims jwt.MapClaims)
+		codeVerifier  string
+		nonce         string
+		expectedErr   error
+	}{
+		{
+			name:         "success",
+			codeVerifier: testCodeVerifier,
+			nonce:        testNonce,
+		},
+		{
+			name:         "error - wrong code verifier",
+			codeVerifier: "another-code-verifier-another-code-verifier-another",
+			nonce:        testNonce,
+			expectedErr:  oidc.ErrTokenExchange,
+		},
+		{
+			name:         "error - nonce mismatch",
+			codeVerifier: testCodeVerifier,
+			nonce:        "another-nonce",
+			expectedErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "error - wrong audience",
+			modifyIDToken: func(claims jwt.MapClaims) {
+				claims["aud"] = "another-client"
+			},
+			codeVerifier: testCodeVerifier,
+			nonce:        testNonce,
+			expectedErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "error - other authorized party",
+			modifyIDToken: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{oidctest.ClientID, "another-client"}
+				claims["azp"] = "another-client"
+			},
+			codeVerifier: testCodeVerifier,
+			nonce:        testNonce,
+			expectedErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "error - wrong issuer",
+			modifyIDToken: func(claims jwt.MapClaims) {
+				claims["iss"] = "https://evil.example.com"
+			},
+			codeVerifier: testCodeVerifier,
+			nonce:        testNonce,
+			expectedErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "error - expired",
+			modifyIDToken: func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			},
+			codeVerifier: testCodeVerifier,
+			nonce:        testNonce,
+			expectedErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "error - missing subject",
+			modifyIDToken: func(claims jwt.MapClaims) {
+				delete(claims, "sub")
+			},
+			codeVerifier: testCodeVerifier,
+			nonce:        testNonce,
+			expectedErr:  oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := oidctest.NewServer(t)
+			server.ModifyIDToken = tc.modifyIDToken
+			provider := newTestProvider(server)
+			code := authorize(t, server, provider)
+
+			identity, err := provider.Exchange(context.Background(), code, tc.codeVerifier, tc.nonce)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, identity)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testIdentity, *identity)
+		})
+	}
+}
+
+func TestProvider_Exchange_CodeUsedTwice(t *testing.T) {
+	t.Parallel()
+
+	server := oidctest.NewServer(t)
+	provider := newTestProvider(server)
+	code := authorize(t, server, provider)
+
+	_, err := provider.Exchange(context.Background(), code, testCodeVerifier, testNonce)
+	require.NoError(t, err)
+
+	_, err = provider.Exchange(context.Background(), code, testCodeVerifier, testNonce)
+	assert.ErrorIs(t, err, oidc.ErrTokenExchange)
+}
+
+func TestProvider_Exchange_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	server := oidctest.NewServer(t)
+	provider := newTestProvider(server)
+
+	_, err := provider.Exchange(context.Background(), authorize(t, server, provider), testCodeVerifier, testNonce)
+	require.NoError(t, err)
+
+	// Tokens signed by the new key are accepted once the JWKS has been fetched again
+	require.NoError(t, server.RotateKey())
+	identity, err := provider.Exchange(context.Background(), authorize(t, server, provider), testCodeVerifier, testNonce)
+	require.NoError(t, err)
+	assert.Equal(t, testIdentity.Subject, identity.Subject)
+}
+
+func TestProvider_Discovery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "error - issuer mismatch",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"issuer":"https://evil.example.com","authorization_endpoint":"https://evil.example.com/authorize","token_endpoint":"https://evil.example.com/token","jwks_uri":"https://evil.example.com/jwks"}`))
+			},
+		},
+		{
+			name: "error - not found",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			provider := oidc.NewProvider(oidc.Config{IssuerURL: server.URL, ClientID: oidctest.ClientID})
+
+			authURL, err := provider.AuthCodeURL(context.Background(), testState, testNonce, oidc.CodeChallenge(testCodeVerifier))
+
+			assert.ErrorIs(t, err, oidc.ErrDiscovery)
+			assert.Empty(t, authURL)
+		})
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	t.Parallel()
+
+	// Example of RFC 7636 appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", oidc.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+// holdJWKSTransport holds the requests for the signing keys until released, as a slow provider would.
+type holdJWKSTransport struct {
+	base    http.RoundTripper
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h *holdJWKSTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/jwks" {
+		close(h.started)
+		<-h.release
+	}
+	return h.base.RoundTrip(req)
+}
+
+func TestProvider_SlowKeyFetch(t *testing.T) {
+	t.Parallel()
+
+	server := oidctest.NewServer(t)
+	transport := &holdJWKSTransport{
+		base:    server.Client().Transport,
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	provider := oidc.NewProvider(oidc.Config{
+		IssuerURL:    server.URL,
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  testRedirectURL,
+		HTTPClient:   &http.Client{Transport: transport},
+	})
+	code := authorize(t, server, provider)
+
+	exchanged := make(chan error, 1)
+	go func() {
+		_, err := provider.Exchange(context.Background(), code, testCodeVerifier, testNonce)
+		exchanged <- err
+	}()
+	<-transport.started
+
+	// Another login must not wait for the pending key fetch.
+	started := make(chan error, 1)
+	go func() {
+		_, err := provider.AuthCodeURL(context.Background(), testState, testNonce, oidc.CodeChallenge(testCodeVerifier))
+		started <- err
+	}()
+	select {
+	case err := <-started:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("AuthCodeURL blocked by the key fetch")
+	}
+
+	close(transport.release)
+	assert.NoError(t, <-exchanged)
+}
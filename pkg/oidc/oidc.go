@@ -0,0 +1,79 @@
+// Package oidc signs users in through an OpenID Connect identity provider with the
+// authorization code flow and PKCE (RFC 7636).
+//
+// The endpoints of the provider are discovered from its issuer URL. ID tokens are only
+// trusted after their RS256 signature was checked against the keys the provider publishes
+// in its JWKS, and their issuer, audience, expiry and nonce were validated.
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrDiscovery is returned when the configuration of the provider cannot be retrieved.
+	ErrDiscovery = errors.New("oidc: provider discovery failed")
+	// ErrTokenExchange is returned when the provider refuses to exchange an authorization code,
+	// for instance because it expired, was already used or the code verifier does not match.
+	ErrTokenExchange = errors.New("oidc: token exchange failed")
+	// ErrInvalidIDToken is returned when the ID token of the provider fails validation.
+	ErrInvalidIDToken = errors.New("oidc: invalid ID token")
+)
+
+// Config configures the client of an identity provider.
+//
+// Fields:
+//   - IssuerURL: The issuer of the provider; its configuration is read from IssuerURL/.well-known/openid-configuration
+//   - ClientID: The ID of the application registered at the provider
+//   - ClientSecret: The secret of the application, sent with HTTP Basic authentication
+//   - RedirectURL: The callback URL the provider redirects users to after signing in
+//   - Scopes: The scopes requested (default "openid", "email" and "profile")
+//   - HTTPClient: The client used to call the provider (default a client with a 10s timeout)
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+}
+
+// Identity is the user the provider signed in, as asserted by a validated ID token.
+// Claims the provider does not release are left empty.
+//
+// Fields:
+//   - Subject: The identifier of the user at the provider, stable and never reassigned
+//   - Email: The email address of the user
+//   - EmailVerified: Whether the provider verified the email address
+//   - Name: The full name of the user
+//   - PreferredUsername: The username the user goes by at the provider
+type Identity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// Provider is the client of an OpenID Connect identity provider.
+//
+//go:generate mockery --name Provider --filename provider.go
+type Provider interface {
+	// AuthCodeURL returns the URL of the provider to send users to, carrying the state
+	// and nonce of the login and the S256 challenge of its code verifier.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems an authorization code with its code verifier and returns the identity
+	// asserted by the ID token, which must carry the nonce of the login.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error)
+}
+
+// CodeChallenge returns the S256 code challenge of a PKCE code verifier:
+// the unpadded base64url encoding of its SHA-256.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,243 @@
+// Package oidctest provides a fake OpenID Connect provider for tests, in the manner of net/http/httptest.
+//
+// The provider serves discovery, JWKS and token endpoints on a local httptest server and signs
+// ID tokens with a generated RSA key. Instead of a login page, tests call Authorize with the
+// authorization URL built by the client and the identity the user signs in as.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/oidc"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// ClientID is the only client registered at the provider.
+	ClientID = "test-client"
+	// ClientSecret is the secret of ClientID.
+	ClientSecret = "test-secret"
+
+	idTokenTTL = 5 * time.Minute
+)
+
+// Server is a fake OpenID Connect provider.
+//
+// ModifyIDToken, when set, is called with the claims of every ID token before it is signed,
+// so that tests can issue tokens failing validation.
+type Server struct {
+	*httptest.Server
+	ModifyIDToken func(claims jwt.MapClaims)
+
+	mu     sync.Mutex
+	key    *rsa.PrivateKey
+	keyID  string
+	keys   int
+	grants map[string]*grant
+}
+
+// grant is an authorization code waiting to be redeemed.
+type grant struct {
+	identity      oidc.Identity
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+}
+
+// NewServer starts a fake provider, closed when the test ends.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := &Server{grants: map[string]*grant{}}
+	if err := s.RotateKey(); err != nil {
+		t.Fatalf("oidctest: generating key: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("GET /jwks", s.handleJWKS)
+	mux.HandleFunc("POST /token", s.handleToken)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// RotateKey replaces the signing key; the JWKS only publishes the new key from then on.
+func (s *Server) RotateKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys++
+	s.key = key
+	s.keyID = fmt.Sprintf("key-%d", s.keys)
+	return nil
+}
+
+// Authorize plays the user signing in at the provider as identity. It validates the
+// authorization request in authURL and returns the callback URL the provider redirects
+// the user to, carrying the authorization code and the state of the request.
+func (s *Server) Authorize(authURL string, identity oidc.Identity) (*url.URL, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	switch {
+	case q.Get("response_type") != "code":
+		return nil, errors.New("oidctest: response_type must be code")
+	case q.Get("client_id") != ClientID:
+		return nil, errors.New("oidctest: unknown client")
+	case q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "":
+		return nil, errors.New("oidctest: S256 code challenge required")
+	case q.Get("redirect_uri") == "":
+		return nil, errors.New("oidctest: redirect_uri required")
+	}
+
+	code := rand.Text()
+	s.mu.Lock()
+	s.grants[code] = &grant{
+		identity:      identity,
+		redirectURI:   q.Get("redirect_uri"),
+		codeChallenge: q.Get("code_challenge"),
+		nonce:         q.Get("nonce"),
+	}
+	s.mu.Unlock()
+
+	callback, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		return nil, err
+	}
+	cq := callback.Query()
+	cq.Set("code", code)
+	cq.Set("state", q.Get("state"))
+	callback.RawQuery = cq.Encode()
+	return callback, nil
+}
+
+// handleDiscovery serves the provider configuration.
+func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// handleJWKS publishes the current signing key.
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	key := map[string]string{
+		"kty": "RSA",
+		"kid": s.keyID,
+		"use": "sig",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"keys": []any{key}})
+}
+
+// handleToken redeems an authorization code once, checking the client credentials,
+// the redirect URI and the PKCE code verifier.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+	if clientID != ClientID || clientSecret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	s.mu.Lock()
+	code := r.PostFormValue("code")
+	g := s.grants[code]
+	delete(s.grants, code)
+	s.mu.Unlock()
+
+	if g == nil || g.redirectURI != r.PostFormValue("redirect_uri") ||
+		oidc.CodeChallenge(r.PostFormValue("code_verifier")) != g.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken, err := s.signIDToken(g)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+// signIDToken issues the ID token of a grant with the current key.
+func (s *Server) signIDToken(g *grant) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   s.URL,
+		"sub":   g.identity.Subject,
+		"aud":   ClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(idTokenTTL).Unix(),
+		"nonce": g.nonce,
+	}
+	if g.identity.Email != "" {
+		claims["email"] = g.identity.Email
+		claims["email_verified"] = g.identity.EmailVerified
+	}
+	if g.identity.Name != "" {
+		claims["name"] = g.identity.Name
+	}
+	if g.identity.PreferredUsername != "" {
+		claims["preferred_username"] = g.identity.PreferredUsername
+	}
+	if s.ModifyIDToken != nil {
+		s.ModifyIDToken(claims)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.keyID
+	return token.SignedString(s.key)
+}
+
+// writeJSON writes v as the JSON body of a response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,128 @@
+package pagemeta
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netguard"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultMaxBodySize = 1 << 20
+	defaultUserAgent   = "bookmark-api/1.0 (+link preview)"
+	maxRedirects       = 5
+)
+
+// Options configures the HTTP fetcher. Zero values fall back to sensible defaults.
+//
+// Fields:
+//   - Timeout: Upper bound for the whole request, redirects and body included (default 10s)
+//   - MaxBodySize: Number of body bytes read at most; the rest of the page is ignored (default 1 MiB)
+//   - UserAgent: User-Agent header sent with every request
+//   - AllowPrivateNetworks: Disables the address guard. Only meant for tests against httptest servers.
+type Options struct {
+	Timeout              time.Duration
+	MaxBodySize          int64
+	UserAgent            string
+	AllowPrivateNetworks bool
+}
+
+// httpFetcher is the Fetcher implementation backed by net/http.
+type httpFetcher struct {
+	client      *http.Client
+	maxBodySize int64
+	userAgent   string
+}
+
+// NewFetcher creates a Fetcher that downloads pages over HTTP.
+func NewFetcher(opts Options) Fetcher {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = defaultMaxBodySize
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = defaultUserAgent
+	}
+
+	return &httpFetcher{
+		client: &http.Client{
+			Transport:     netguard.NewTransport(opts.Timeout, opts.AllowPrivateNetworks),
+			Timeout:       opts.Timeout,
+			CheckRedirect: checkRedirect,
+		},
+		maxBodySize: opts.MaxBodySize,
+		userAgent:   opts.UserAgent,
+	}
+}
+
+// checkRedirect limits the redirect chain and keeps it on http(s).
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("pagemeta: stopped after %d redirects", maxRedirects)
+	}
+	return checkScheme(req.URL)
+}
+
+// checkScheme rejects every scheme but http and https.
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+	return nil
+}
+
+// Fetch downloads pageURL and extracts its metadata.
+// Relative links in the page are resolved against the final URL, after redirects.
+func (f *httpFetcher) Fetch(ctx context.Context, pageURL string) (*Metadata, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheme(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("pagemeta: unexpected status %d", resp.StatusCode)
+	}
+	if !isHTML(resp.Header.Get("Content-Type")) {
+		return nil, ErrNotHTML
+	}
+
+	// Metadata lives in <head>, so a page cut at the size limit still yields it
+	return Parse(io.LimitReader(resp.Body, f.maxBodySize), resp.Request.URL)
+}
+
+// isHTML reports whether a Content-Type header denotes an HTML document.
+// A missing header is accepted, as many servers omit it for HTML.
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
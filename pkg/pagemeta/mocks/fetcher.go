@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pagemeta "github.com/HadesHo3820/ebvn-golang-course/pkg/pagemeta"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Fetcher is an autogenerated mock type for the Fetcher type
+type Fetcher struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx, pageURL
+func (_m *Fetcher) Fetch(ctx context.Context, pageURL string) (*pagemeta.Metadata, error) {
+	ret := _m.Called(ctx, pageURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fetch")
+	}
+
+	var r0 *pagemeta.Metadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*pagemeta.Metadata, error)); ok {
+		return rf(ctx, pageURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *pagemeta.Metadata); ok {
+		r0 = rf(ctx, pageURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagemeta.Metadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pageURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFetcher creates a new instance of Fetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFetcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Fetcher {
+	mock := &Fetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,47 @@
+// Package pagemeta fetches web pages and extracts the metadata shown in link previews:
+// title, description, favicon and canonical URL, as well as the length of the page text.
+//
+// Pages are fetched from user-provided URLs, so the HTTP fetcher is hardened against
+// server-side request forgery: it only speaks http(s), refuses to connect to private,
+// loopback and link-local addresses, and bounds both the request time and the body size.
+package pagemeta
+
+import (
+	"context"
+	"errors"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netguard"
+)
+
+var (
+	// ErrUnsupportedScheme is returned for URLs that are not http or https.
+	ErrUnsupportedScheme = errors.New("pagemeta: unsupported URL scheme")
+	// ErrBlockedAddress is returned when the target resolves to a non-public address.
+	ErrBlockedAddress = netguard.ErrBlockedAddress
+	// ErrNotHTML is returned when the response is not an HTML document.
+	ErrNotHTML = errors.New("pagemeta: response is not HTML")
+)
+
+// Metadata is the information extracted from a page.
+// Fields that the page does not provide are left empty.
+//
+// Fields:
+//   - Title: The <title> of the page, or its og:title
+//   - Description: The meta description of the page, or its og:description
+//   - FaviconURL: Absolute URL of the page icon (defaults to /favicon.ico)
+//   - CanonicalURL: Absolute URL from <link rel="canonical">
+//   - WordCount: Number of words in the text of the <body>, 0 if the page has none
+type Metadata struct {
+	Title        string
+	Description  string
+	FaviconURL   string
+	CanonicalURL string
+	WordCount    int
+}
+
+// Fetcher retrieves the metadata of a web page.
+//
+//go:generate mockery --name Fetcher --filename fetcher.go
+type Fetcher interface {
+	Fetch(ctx context.Context, pageURL string) (*Metadata, error)
+}
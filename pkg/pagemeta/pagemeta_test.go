@@ -0,0 +1,244 @@
+package pagemeta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>
+    The Go   Programming Language
+  </title>
+  <meta name="description" content="Go is an open source programming language.">
+  <meta property="og:title" content="Go">
+  <link rel="shortcut icon" href="/images/favicon.png">
+  <link rel="canonical" href="https://go.dev/">
+</head>
+<body>
+  <title>Not the title</title>
+  <link rel="canonical" href="https://example.com/not-canonical">
+</body>
+</html>`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	base, _ := url.Parse("https://go.dev/doc/")
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected *Metadata
+	}{
+		{
+			name:  "standard head",
+			input: testPage,
+			expected: &Metadata{
+				Title:        "The Go Programming Language",
+				Description:  "Go is an open source programming language.",
+				FaviconURL:   "https://go.dev/images/favicon.png",
+				CanonicalURL: "https://go.dev/",
+			},
+		},
+		{
+			name: "open graph fallback and default favicon",
+			input: `<html><head>
+				<meta property="og:title" content="OG Title">
+				<meta property="og:description" content="OG Description">
+			</head></html>`,
+			expected: &Metadata{
+				Title:       "OG Title",
+				Description: "OG Description",
+				FaviconURL:  "https://go.dev/favicon.ico",
+			},
+		},
+		{
+			name: "base href and relative canonical",
+			input: `<head>
+				<base href="https://cdn.example.com/site/">
+				<link rel="icon" href="icon.svg">
+				<link rel="canonical" href="page">
+			</head>`,
+			expected: &Metadata{
+				FaviconURL:   "https://cdn.example.com/site/icon.svg",
+				CanonicalURL: "https://cdn.example.com/site/page",
+			},
+		},
+		{
+			name:  "non http links are dropped",
+			input: `<head><link rel="icon" href="data:image/png;base64,AAA"><link rel="canonical" href="javascript:alert(1)"></head>`,
+			expected: &Metadata{
+				FaviconURL: "",
+			},
+		},
+		{
+			name: "body words are counted outside of scripts and styles",
+			input: `<head><title>Words</title></head>
+			<body>
+				<h1>Three  words here</h1>
+				<script>var notCounted = 1;</script>
+				<style>p { color: red; }</style>
+				<p>and <b>four</b> more.</p>
+				<svg><title>icon</title><text>hidden</text></svg>
+				<meta name="description" content="ignored in body">
+			</body>`,
+			expected: &Metadata{
+				Title:      "Words",
+				FaviconURL: "https://go.dev/favicon.ico",
+				WordCount:  6,
+			},
+		},
+		{
+			name:     "empty document",
+			input:    ``,
+			expected: &Metadata{FaviconURL: "https://go.dev/favicon.ico"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			meta, err := Parse(strings.NewReader(tc.input), base)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, meta)
+		})
+	}
+}
+
+func TestHTTPFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(testPage))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<head><title>Large</title>" + strings.Repeat("<!-- padding -->", 1000) + "<meta name=\"description\" content=\"unreachable\">"))
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	testFetcher := NewFetcher(Options{
+		Timeout:              500 * time.Millisecond,
+		MaxBodySize:          256,
+		AllowPrivateNetworks: true,
+	})
+
+	testCases := []struct {
+		name          string
+		fetcher       Fetcher
+		inputURL      string
+		expected      *Metadata
+		expectedErr   error
+		expectsAnyErr bool
+	}{
+		{
+			name:     "success",
+			fetcher:  NewFetcher(Options{AllowPrivateNetworks: true}),
+			inputURL: server.URL + "/page",
+			expected: &Metadata{
+				Title:        "The Go Programming Language",
+				Description:  "Go is an open source programming language.",
+				FaviconURL:   server.URL + "/images/favicon.png",
+				CanonicalURL: "https://go.dev/",
+			},
+		},
+		{
+			name:     "success - follows redirects and resolves against the final URL",
+			fetcher:  NewFetcher(Options{AllowPrivateNetworks: true}),
+			inputURL: server.URL + "/redirect",
+			expected: &Metadata{
+				Title:        "The Go Programming Language",
+				Description:  "Go is an open source programming language.",
+				FaviconURL:   server.URL + "/images/favicon.png",
+				CanonicalURL: "https://go.dev/",
+			},
+		},
+		{
+			name:     "success - body is cut at the size limit",
+			fetcher:  testFetcher,
+			inputURL: server.URL + "/large",
+			expected: &Metadata{
+				Title:      "Large",
+				FaviconURL: server.URL + "/favicon.ico",
+			},
+		},
+		{
+			name:        "error - not HTML",
+			fetcher:     testFetcher,
+			inputURL:    server.URL + "/image",
+			expectedErr: ErrNotHTML,
+		},
+		{
+			name:          "error - unexpected status",
+			fetcher:       testFetcher,
+			inputURL:      server.URL + "/missing",
+			expectsAnyErr: true,
+		},
+		{
+			name:          "error - timeout",
+			fetcher:       testFetcher,
+			inputURL:      server.URL + "/slow",
+			expectsAnyErr: true,
+		},
+		{
+			name:        "error - unsupported scheme",
+			fetcher:     testFetcher,
+			inputURL:    "file:///etc/passwd",
+			expectedErr: ErrUnsupportedScheme,
+		},
+		{
+			name:        "error - loopback address blocked",
+			fetcher:     NewFetcher(Options{}),
+			inputURL:    server.URL + "/page",
+			expectedErr: ErrBlockedAddress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			meta, err := tc.fetcher.Fetch(context.Background(), tc.inputURL)
+
+			if tc.expectedErr != nil || tc.expectsAnyErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.ErrorIs(t, err, tc.expectedErr)
+				}
+				assert.Nil(t, meta)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, meta)
+		})
+	}
+}
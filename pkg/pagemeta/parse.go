@@ -0,0 +1,171 @@
+package pagemeta
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Parse extracts the metadata from the <head> of an HTML document and counts the words of its <body>.
+// Relative URLs are resolved against base (or the document's <base href>). Metadata elements
+// are only honored before the first <body> tag; after it, only text outside of scripts,
+// styles and similar non-prose elements is looked at.
+//
+// Title and description prefer the standard elements and fall back to their
+// Open Graph counterparts. When the page declares no icon, the favicon defaults
+// to /favicon.ico on the page's host.
+func Parse(r io.Reader, base *url.URL) (*Metadata, error) {
+	z := html.NewTokenizer(r)
+
+	var (
+		meta          Metadata
+		ogTitle       string
+		ogDescription string
+		favicon       string
+		canonical     string
+		inTitle       bool
+		title         strings.Builder
+		inBody        bool
+		skipDepth     int
+	)
+
+loop:
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				break loop
+			}
+			return nil, z.Err()
+
+		case html.TextToken:
+			switch {
+			case inBody && skipDepth == 0:
+				meta.WordCount += len(strings.Fields(string(z.Text())))
+			case inTitle:
+				title.Write(z.Text())
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := atom.Lookup(name)
+			switch {
+			case inBody:
+				if nonProse[tag] && skipDepth > 0 {
+					skipDepth--
+				}
+			case tag == atom.Title:
+				inTitle = false
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := atom.Lookup(name)
+
+			if inBody {
+				if nonProse[tag] && tt == html.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+
+			attrs := readAttrs(z, hasAttr)
+			switch tag {
+			case atom.Body:
+				inBody = true
+				inTitle = false
+			case atom.Title:
+				// Only the first <title> counts; <svg> may carry its own
+				inTitle = title.Len() == 0 && tt == html.StartTagToken
+			case atom.Base:
+				if href, ok := attrs["href"]; ok && base != nil {
+					if u, err := base.Parse(href); err == nil {
+						base = u
+					}
+				}
+			case atom.Meta:
+				content := attrs["content"]
+				switch {
+				case strings.EqualFold(attrs["name"], "description") && meta.Description == "":
+					meta.Description = content
+				case strings.EqualFold(attrs["property"], "og:title") && ogTitle == "":
+					ogTitle = content
+				case strings.EqualFold(attrs["property"], "og:description") && ogDescription == "":
+					ogDescription = content
+				}
+			case atom.Link:
+				rels := strings.Fields(strings.ToLower(attrs["rel"]))
+				for _, rel := range rels {
+					switch {
+					case rel == "icon" && favicon == "":
+						favicon = attrs["href"]
+					case rel == "canonical" && canonical == "":
+						canonical = attrs["href"]
+					}
+				}
+			}
+		}
+	}
+
+	meta.Title = cleanText(title.String())
+	if meta.Title == "" {
+		meta.Title = cleanText(ogTitle)
+	}
+	meta.Description = cleanText(meta.Description)
+	if meta.Description == "" {
+		meta.Description = cleanText(ogDescription)
+	}
+	if favicon == "" {
+		favicon = "/favicon.ico"
+	}
+	meta.FaviconURL = resolve(base, favicon)
+	meta.CanonicalURL = resolve(base, canonical)
+
+	return &meta, nil
+}
+
+// nonProse lists the elements whose text is not read by visitors of the page.
+var nonProse = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Title:    true,
+	atom.Textarea: true,
+	atom.Svg:      true,
+}
+
+// readAttrs collects the attributes of the current tag, keyed by lowercase name.
+func readAttrs(z *html.Tokenizer, hasAttr bool) map[string]string {
+	attrs := make(map[string]string)
+	for hasAttr {
+		var key, val []byte
+		key, val, hasAttr = z.TagAttr()
+		if _, ok := attrs[string(key)]; !ok {
+			attrs[string(key)] = string(val)
+		}
+	}
+	return attrs
+}
+
+// cleanText collapses runs of whitespace and drops invalid UTF-8.
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(strings.ToValidUTF8(s, "")), " ")
+}
+
+// resolve turns ref into an absolute http(s) URL, or returns "" if that is not possible.
+func resolve(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || base == nil {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || checkScheme(u) != nil {
+		return ""
+	}
+	return u.String()
+}
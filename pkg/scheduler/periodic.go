@@ -0,0 +1,64 @@
+// Package scheduler runs background tasks alongside the HTTP server.
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Task is a unit of background work. A returned error is logged and does not stop the schedule.
+type Task func(ctx context.Context) error
+
+// Job is a background job that runs until its context is cancelled.
+type Job interface {
+	// Start runs the job and blocks until ctx is cancelled.
+	Start(ctx context.Context)
+}
+
+// periodic runs a Task on a fixed interval.
+type periodic struct {
+	name     string
+	interval time.Duration
+	task     Task
+}
+
+// NewPeriodic creates a Job that runs task once at start and then every interval.
+// A non-positive interval disables the job: Start returns immediately.
+//
+// Parameters:
+//   - name: Job name used in log entries
+//   - interval: Time between two runs
+//   - task: The work to perform on every run
+func NewPeriodic(name string, interval time.Duration, task Task) Job {
+	return &periodic{name: name, interval: interval, task: task}
+}
+
+// Start implements Job.
+func (p *periodic) Start(ctx context.Context) {
+	if p.interval <= 0 {
+		log.Info().Str("job", p.name).Msg("Periodic job disabled")
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run executes the task once, logging its failure.
+func (p *periodic) run(ctx context.Context) {
+	if err := p.task(ctx); err != nil {
+		log.Error().Err(err).Str("job", p.name).Msg("Periodic job failed")
+	}
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodic_Start(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		interval    time.Duration
+		taskErr     error
+		minExpected int32
+		maxExpected int32
+	}{
+		{
+			name:        "runs repeatedly until cancelled",
+			interval:    5 * time.Millisecond,
+			minExpected: 2,
+			maxExpected: 1000,
+		},
+		{
+			name:        "keeps running after task error",
+			interval:    5 * time.Millisecond,
+			taskErr:     errors.New("task error"),
+			minExpected: 2,
+			maxExpected: 1000,
+		},
+		{
+			name:        "non-positive interval disables job",
+			interval:    0,
+			minExpected: 0,
+			maxExpected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var runs atomic.Int32
+			job := NewPeriodic("test", tc.interval, func(ctx context.Context) error {
+				runs.Add(1)
+				return tc.taskErr
+			})
+
+			ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+			defer cancel()
+
+			done := make(chan struct{})
+			go func() {
+				job.Start(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("job did not stop after context cancellation")
+			}
+
+			assert.GreaterOrEqual(t, runs.Load(), tc.minExpected)
+			assert.LessOrEqual(t, runs.Load(), tc.maxExpected)
+		})
+	}
+}
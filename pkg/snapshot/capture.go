@@ -0,0 +1,186 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netguard"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	defaultTimeout           = 30 * time.Second
+	defaultMaxPageSize       = 5 << 20
+	defaultMaxStylesheetSize = 1 << 20
+	defaultMaxStylesheets    = 20
+	defaultUserAgent         = "bookmark-api/1.0 (+offline snapshot)"
+	maxRedirects             = 5
+)
+
+// Options configures the HTTP capturer. Zero values fall back to sensible defaults.
+//
+// Fields:
+//   - Timeout: Upper bound for the whole capture, stylesheets included (default 30s)
+//   - MaxPageSize: Largest page accepted, in bytes; larger pages fail with ErrTooLarge (default 5 MiB)
+//   - MaxStylesheetSize: Largest stylesheet inlined, in bytes; larger ones are left linked (default 1 MiB)
+//   - MaxStylesheets: Number of stylesheets inlined at most; the others are left linked (default 20)
+//   - UserAgent: User-Agent header sent with every request
+//   - AllowPrivateNetworks: Disables the address guard. Only meant for tests against httptest servers.
+type Options struct {
+	Timeout              time.Duration
+	MaxPageSize          int64
+	MaxStylesheetSize    int64
+	MaxStylesheets       int
+	UserAgent            string
+	AllowPrivateNetworks bool
+}
+
+// httpCapturer is the Capturer implementation backed by net/http.
+type httpCapturer struct {
+	client *http.Client
+	opts   Options
+}
+
+// NewCapturer creates a Capturer that downloads pages and their stylesheets over HTTP.
+func NewCapturer(opts Options) Capturer {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxPageSize <= 0 {
+		opts.MaxPageSize = defaultMaxPageSize
+	}
+	if opts.MaxStylesheetSize <= 0 {
+		opts.MaxStylesheetSize = defaultMaxStylesheetSize
+	}
+	if opts.MaxStylesheets <= 0 {
+		opts.MaxStylesheets = defaultMaxStylesheets
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = defaultUserAgent
+	}
+
+	return &httpCapturer{
+		client: &http.Client{
+			Transport:     netguard.NewTransport(opts.Timeout, opts.AllowPrivateNetworks),
+			CheckRedirect: checkRedirect,
+		},
+		opts: opts,
+	}
+}
+
+// checkRedirect limits the redirect chain and keeps it on http(s).
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("snapshot: stopped after %d redirects", maxRedirects)
+	}
+	return checkScheme(req.URL)
+}
+
+// checkScheme rejects every scheme but http and https.
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+	return nil
+}
+
+// Capture implements Capturer.
+func (c *httpCapturer) Capture(ctx context.Context, pageURL string) ([]byte, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheme(u); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	body, contentType, finalURL, err := c.get(ctx, u, "text/html,application/xhtml+xml", c.opts.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	if !isHTML(contentType) {
+		return nil, ErrNotHTML
+	}
+
+	// The document is parsed and rendered as UTF-8 whatever its declared encoding
+	utf8Body, err := charset.NewReader(bytes.NewReader(body), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return rewrite(ctx, utf8Body, finalURL, c.stylesheetFetcher())
+}
+
+// stylesheetFetcher returns the function used by rewrite to download stylesheets.
+// It stops returning stylesheets once MaxStylesheets have been fetched.
+func (c *httpCapturer) stylesheetFetcher() fetchFunc {
+	fetched := 0
+	return func(ctx context.Context, u *url.URL) (string, error) {
+		if fetched >= c.opts.MaxStylesheets {
+			return "", fmt.Errorf("snapshot: more than %d stylesheets", c.opts.MaxStylesheets)
+		}
+		fetched++
+
+		body, contentType, _, err := c.get(ctx, u, "text/css", c.opts.MaxStylesheetSize)
+		if err != nil {
+			return "", err
+		}
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if contentType != "" && mediaType != "text/css" {
+			return "", fmt.Errorf("snapshot: stylesheet has content type %q", contentType)
+		}
+		return string(body), nil
+	}
+}
+
+// get downloads u and returns its body, Content-Type and final URL after redirects.
+// Bodies larger than limit fail with ErrTooLarge.
+func (c *httpCapturer) get(ctx context.Context, u *url.URL, accept string, limit int64) ([]byte, string, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", nil, fmt.Errorf("snapshot: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, "", nil, ErrTooLarge
+	}
+	return body, resp.Header.Get("Content-Type"), resp.Request.URL, nil
+}
+
+// isHTML reports whether a Content-Type header denotes an HTML document.
+// A missing header is accepted, as many servers omit it for HTML.
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
@@ -0,0 +1,237 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// fetchFunc downloads the stylesheet at u.
+type fetchFunc func(ctx context.Context, u *url.URL) (string, error)
+
+// removedElements lists the elements dropped from snapshots: they run code, embed other
+// documents, or (for <base>) are replaced by one pointing at the original page.
+var removedElements = map[string]bool{
+	"script":   true,
+	"iframe":   true,
+	"frame":    true,
+	"frameset": true,
+	"object":   true,
+	"embed":    true,
+	"applet":   true,
+	"portal":   true,
+	"base":     true,
+}
+
+// unsafeURLPrefixes lists URL schemes that execute code when followed or loaded.
+var unsafeURLPrefixes = []string{"javascript:", "vbscript:", "data:text/html"}
+
+var (
+	// cssURL matches url() references in a stylesheet, quoted or not.
+	cssURL = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^)'"\s]*))\s*\)`)
+	// styleEnd matches a closing style tag, which would end an inlined stylesheet early.
+	styleEnd = regexp.MustCompile(`(?i)</style`)
+)
+
+// rewrite parses the UTF-8 document read from r, removes everything that could run code,
+// inlines its stylesheets using fetch and renders the result.
+// pageURL is where the document was downloaded from; relative URLs are resolved against it.
+func rewrite(ctx context.Context, r io.Reader, pageURL *url.URL, fetch fetchFunc) ([]byte, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	base := documentBase(doc, pageURL)
+
+	var stylesheets []*html.Node
+	sanitize(doc, &stylesheets)
+
+	for _, link := range stylesheets {
+		href, err := base.Parse(attr(link, "href"))
+		if err != nil || checkScheme(href) != nil {
+			link.Parent.RemoveChild(link)
+			continue
+		}
+		css, err := fetch(ctx, href)
+		if err != nil {
+			// Left linked, the stylesheet still loads while the original site is up
+			continue
+		}
+		link.Parent.InsertBefore(styleElement(absolutizeCSS(css, href), attr(link, "media")), link)
+		link.Parent.RemoveChild(link)
+	}
+
+	if head := findElement(doc, atom.Head); head != nil {
+		head.InsertBefore(&html.Node{
+			Type:     html.ElementNode,
+			Data:     "base",
+			DataAtom: atom.Base,
+			Attr:     []html.Attribute{{Key: "href", Val: base.String()}},
+		}, head.FirstChild)
+		head.InsertBefore(&html.Node{
+			Type:     html.ElementNode,
+			Data:     "meta",
+			DataAtom: atom.Meta,
+			Attr:     []html.Attribute{{Key: "charset", Val: "utf-8"}},
+		}, head.FirstChild)
+	}
+
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// documentBase returns the URL relative references of the document resolve against:
+// its own <base href> if it declares a usable one, otherwise the page URL.
+func documentBase(doc *html.Node, pageURL *url.URL) *url.URL {
+	if b := findElement(doc, atom.Base); b != nil {
+		if u, err := pageURL.Parse(attr(b, "href")); err == nil && checkScheme(u) == nil {
+			return u
+		}
+	}
+	return pageURL
+}
+
+// sanitize removes the elements and attributes of n's subtree that could run code,
+// and collects the <link rel="stylesheet"> elements into stylesheets.
+func sanitize(n *html.Node, stylesheets *[]*html.Node) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type == html.ElementNode {
+			if isRemoved(child) {
+				n.RemoveChild(child)
+				child = next
+				continue
+			}
+			child.Attr = safeAttrs(child.Attr)
+			if child.DataAtom == atom.Link && hasToken(attr(child, "rel"), "stylesheet") {
+				*stylesheets = append(*stylesheets, child)
+			}
+		}
+		sanitize(child, stylesheets)
+		child = next
+	}
+}
+
+// isRemoved reports whether the element is dropped from snapshots, including
+// <meta> elements that redirect or declare the original encoding.
+func isRemoved(n *html.Node) bool {
+	if removedElements[strings.ToLower(n.Data)] {
+		return true
+	}
+	if n.DataAtom == atom.Meta {
+		httpEquiv := strings.ToLower(attr(n, "http-equiv"))
+		return httpEquiv == "refresh" || httpEquiv == "content-type" || hasAttr(n, "charset")
+	}
+	return false
+}
+
+// safeAttrs drops event handler attributes and attributes holding URLs with an unsafe scheme.
+func safeAttrs(attrs []html.Attribute) []html.Attribute {
+	kept := attrs[:0]
+	for _, a := range attrs {
+		key := strings.ToLower(a.Key)
+		if strings.HasPrefix(key, "on") || isUnsafeURL(a.Val) {
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
+
+// isUnsafeURL reports whether value is a URL that executes code.
+func isUnsafeURL(value string) bool {
+	// Browsers ignore whitespace and control characters inside the scheme
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(value))
+	for _, prefix := range unsafeURLPrefixes {
+		if strings.HasPrefix(cleaned, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// absolutizeCSS rewrites the relative url() references of a stylesheet downloaded from
+// base to absolute URLs, and escapes closing style tags so that the stylesheet can be inlined.
+func absolutizeCSS(css string, base *url.URL) string {
+	css = cssURL.ReplaceAllStringFunc(css, func(match string) string {
+		groups := cssURL.FindStringSubmatch(match)
+		ref := groups[1] + groups[2] + groups[3]
+		if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(strings.ToLower(ref), "data:") {
+			return match
+		}
+		u, err := base.Parse(ref)
+		if err != nil {
+			return match
+		}
+		return `url("` + strings.ReplaceAll(u.String(), `"`, "%22") + `")`
+	})
+	return styleEnd.ReplaceAllString(css, `<\/style`)
+}
+
+// styleElement creates a <style> element holding css, restricted to media if not empty.
+func styleElement(css, media string) *html.Node {
+	style := &html.Node{Type: html.ElementNode, Data: "style", DataAtom: atom.Style}
+	if media != "" {
+		style.Attr = []html.Attribute{{Key: "media", Val: media}}
+	}
+	style.AppendChild(&html.Node{Type: html.TextNode, Data: css})
+	return style
+}
+
+// findElement returns the first element of n's subtree with the given tag, or nil.
+func findElement(n *html.Node, tag atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == tag {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// attr returns the value of the attribute key of n, or "" if it has none.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasAttr reports whether n has the attribute key.
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasToken reports whether the space-separated list contains token, ignoring case.
+func hasToken(list, token string) bool {
+	for _, field := range strings.Fields(list) {
+		if strings.EqualFold(field, token) {
+			return true
+		}
+	}
+	return false
+}
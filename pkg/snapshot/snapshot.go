@@ -0,0 +1,36 @@
+// Package snapshot captures web pages as self-contained HTML documents for offline reading.
+//
+// A snapshot is the page's HTML with its external stylesheets inlined. Everything that
+// could run code is removed (scripts, frames, plugins, event handler attributes and
+// javascript: URLs), and a <base> element pins relative URLs to the original page, so
+// images and fonts keep loading from the origin while the layout survives the page going away.
+//
+// Like pagemeta, the fetcher only speaks http(s), refuses to connect to non-public
+// addresses and bounds both the time spent and the number of bytes read.
+package snapshot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/HadesHo3820/ebvn-golang-course/pkg/netguard"
+)
+
+var (
+	// ErrUnsupportedScheme is returned for URLs that are not http or https.
+	ErrUnsupportedScheme = errors.New("snapshot: unsupported URL scheme")
+	// ErrBlockedAddress is returned when the target resolves to a non-public address.
+	ErrBlockedAddress = netguard.ErrBlockedAddress
+	// ErrNotHTML is returned when the page is not an HTML document.
+	ErrNotHTML = errors.New("snapshot: response is not HTML")
+	// ErrTooLarge is returned when the page exceeds the configured size limit.
+	ErrTooLarge = errors.New("snapshot: page is too large")
+)
+
+// Capturer produces the snapshot of a web page.
+//
+//go:generate mockery --name Capturer --filename capturer.go
+type Capturer interface {
+	// Capture downloads pageURL and returns the snapshot as UTF-8 encoded HTML.
+	Capture(ctx context.Context, pageURL string) ([]byte, error)
+}
@@ -0,0 +1,229 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewrite(t *testing.T) {
+	t.Parallel()
+
+	pageURL, _ := url.Parse("https://example.com/articles/go.html")
+
+	stylesheets := map[string]string{
+		"https://example.com/css/site.css": `body { background: url(../img/bg.png) } .icon { background: url("data:image/png;base64,AAA") }`,
+		"https://example.com/css/evil.css": `p::after { content: "</style><script>alert(1)</script>" }`,
+	}
+	fetch := func(_ context.Context, u *url.URL) (string, error) {
+		css, ok := stylesheets[u.String()]
+		if !ok {
+			return "", errors.New("not found")
+		}
+		return css, nil
+	}
+
+	testCases := []struct {
+		name        string
+		input       string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "inlines stylesheets and absolutizes their URLs",
+			input: `<html><head><link rel="stylesheet" href="/css/site.css" media="screen">` +
+				`<link rel="stylesheet" href="/css/missing.css"></head><body><p>Hello</p></body></html>`,
+			contains: []string{
+				`<style media="screen">body { background: url("https://example.com/img/bg.png") }`,
+				`url("data:image/png;base64,AAA")`,
+				`<link rel="stylesheet" href="/css/missing.css"/>`,
+				`<p>Hello</p>`,
+			},
+			notContains: []string{`href="/css/site.css"`},
+		},
+		{
+			name:        "escapes closing style tags in inlined stylesheets",
+			input:       `<head><link rel="stylesheet" href="/css/evil.css"></head>`,
+			contains:    []string{`content: "<\/style><script>alert(1)</script>"`},
+			notContains: []string{`</style><script>`},
+		},
+		{
+			name: "removes active content",
+			input: `<head><script>alert(1)</script><meta http-equiv="refresh" content="0;url=https://evil.example">` +
+				`</head><body onload="alert(1)"><iframe src="https://ads.example"></iframe><object data="x.swf"></object>` +
+				`<a href="javascript:alert(1)" title="link">Link</a><a href=" JaVa&#09;Script:alert(1)">Tricky</a>` +
+				`<svg><script>alert(1)</script></svg><img src="/img/photo.jpg" onerror="alert(1)"></body>`,
+			contains: []string{
+				`<body>`,
+				`<a title="link">Link</a>`,
+				`<a>Tricky</a>`,
+				`<img src="/img/photo.jpg"/>`,
+			},
+			notContains: []string{"<script", "<iframe", "<object", "refresh", "onload", "onerror", "alert(1)"},
+		},
+		{
+			name:        "pins the base and declares utf-8",
+			input:       `<head><meta charset="iso-8859-1"><title>Go</title></head>`,
+			contains:    []string{`<head><meta charset="utf-8"/><base href="https://example.com/articles/go.html"/><title>Go</title></head>`},
+			notContains: []string{"iso-8859-1"},
+		},
+		{
+			name:        "keeps the document base",
+			input:       `<head><base href="https://cdn.example.com/site/"><link rel="stylesheet" href="missing.css"></head>`,
+			contains:    []string{`<base href="https://cdn.example.com/site/"/>`},
+			notContains: []string{`<base href="https://example.com/articles/go.html"/>`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := rewrite(context.Background(), strings.NewReader(tc.input), pageURL, fetch)
+
+			assert.NoError(t, err)
+			for _, s := range tc.contains {
+				assert.Contains(t, string(out), s)
+			}
+			for _, s := range tc.notContains {
+				assert.NotContains(t, string(out), s)
+			}
+		})
+	}
+}
+
+func TestHTTPCapturer_Capture(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><link rel="stylesheet" href="/style.css"></head><body>Hello</body></html>`))
+	})
+	mux.HandleFunc("/latin1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		_, _ = w.Write([]byte("<p>Caf\xe9</p>"))
+	})
+	mux.HandleFunc("/style.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = w.Write([]byte("body { color: red }"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(strings.Repeat("<p>padding</p>", 100)))
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	testCapturer := NewCapturer(Options{
+		Timeout:              500 * time.Millisecond,
+		MaxPageSize:          512,
+		AllowPrivateNetworks: true,
+	})
+
+	testCases := []struct {
+		name          string
+		capturer      Capturer
+		inputURL      string
+		expected      []string
+		expectedErr   error
+		expectsAnyErr bool
+	}{
+		{
+			name:     "success",
+			capturer: testCapturer,
+			inputURL: server.URL + "/page",
+			expected: []string{`<base href="` + server.URL + `/page"/>`, "<style>body { color: red }</style>", "Hello"},
+		},
+		{
+			name:     "success - follows redirects and pins the final URL",
+			capturer: testCapturer,
+			inputURL: server.URL + "/redirect",
+			expected: []string{`<base href="` + server.URL + `/page"/>`},
+		},
+		{
+			name:     "success - converts the page to utf-8",
+			capturer: testCapturer,
+			inputURL: server.URL + "/latin1",
+			expected: []string{`<meta charset="utf-8"/>`, "<p>Café</p>"},
+		},
+		{
+			name:        "error - too large",
+			capturer:    testCapturer,
+			inputURL:    server.URL + "/large",
+			expectedErr: ErrTooLarge,
+		},
+		{
+			name:        "error - not HTML",
+			capturer:    testCapturer,
+			inputURL:    server.URL + "/image",
+			expectedErr: ErrNotHTML,
+		},
+		{
+			name:          "error - unexpected status",
+			capturer:      testCapturer,
+			inputURL:      server.URL + "/missing",
+			expectsAnyErr: true,
+		},
+		{
+			name:          "error - timeout",
+			capturer:      testCapturer,
+			inputURL:      server.URL + "/slow",
+			expectsAnyErr: true,
+		},
+		{
+			name:        "error - unsupported scheme",
+			capturer:    testCapturer,
+			inputURL:    "file:///etc/passwd",
+			expectedErr: ErrUnsupportedScheme,
+		},
+		{
+			name:        "error - loopback address blocked",
+			capturer:    NewCapturer(Options{}),
+			inputURL:    server.URL + "/page",
+			expectedErr: ErrBlockedAddress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := tc.capturer.Capture(context.Background(), tc.inputURL)
+
+			if tc.expectedErr != nil || tc.expectsAnyErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.ErrorIs(t, err, tc.expectedErr)
+				}
+				assert.Nil(t, out)
+				return
+			}
+
+			assert.NoError(t, err)
+			for _, s := range tc.expected {
+				assert.Contains(t, string(out), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Package urlnorm normalizes URLs so that different spellings of the same page compare equal.
+//
+// The normalization is deliberately conservative: it only removes differences that
+// never change which page is served, plus well-known tracking parameters.
+//
+//	HTTPS://Example.COM:443/page/?utm_source=x&b=2&a=1#top -> https://example.com/page?a=1&b=2
+package urlnorm
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams lists query parameters that only carry analytics information.
+// Parameters starting with "utm_" are matched by prefix.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"yclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"_ga":     true,
+	"_gl":     true,
+}
+
+// defaultPorts maps each scheme to the port that can be omitted.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Normalize returns the canonical spelling of rawURL:
+//   - the scheme and host are lowercased
+//   - the default port of the scheme is dropped
+//   - the fragment is dropped
+//   - tracking parameters (utm_*, fbclid, gclid, ...) are dropped and the remaining ones are sorted
+//   - a trailing slash is dropped from the path, and an empty path becomes "/"
+//
+// Input that cannot be parsed as an absolute URL is returned trimmed but otherwise unchanged,
+// so Normalize can be applied to any stored value.
+func Normalize(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = normalizeHost(u.Scheme, u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	if u.Path == "" {
+		u.Path = "/"
+	} else if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		if u.Path == "" {
+			u.Path = "/"
+		}
+	}
+	// Re-derive the escaped path from the cleaned one
+	u.RawPath = ""
+
+	u.RawQuery = normalizeQuery(u.Query())
+	u.ForceQuery = false
+
+	return u.String()
+}
+
+// normalizeHost lowercases the host and strips the default port of the scheme.
+func normalizeHost(scheme, host string) string {
+	host = strings.ToLower(host)
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return host
+	}
+	if port == defaultPorts[scheme] {
+		if strings.Contains(hostname, ":") {
+			return "[" + hostname + "]"
+		}
+		return hostname
+	}
+	return host
+}
+
+// normalizeQuery removes tracking parameters and encodes the rest sorted by key.
+func normalizeQuery(values url.Values) string {
+	for key := range values {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			values.Del(key)
+		}
+	}
+	return values.Encode()
+}
+
+// Domain returns the lowercased host name of rawURL without port and without a leading "www.",
+// so that "https://WWW.Example.com:8080/a" and "http://example.com/b" share the domain "example.com".
+// Input that cannot be parsed as an absolute URL has no domain and yields "".
+func Domain(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}